@@ -2,13 +2,15 @@ package main
 
 import (
 	"Dp218GO/configs"
+	"Dp218GO/internal/app"
 	"Dp218GO/protos"
 	"Dp218GO/repositories/postgres"
 	"Dp218GO/routing"
 	"Dp218GO/routing/grpcserver"
 	"Dp218GO/routing/httpserver"
 	"Dp218GO/services"
-	"fmt"
+	"context"
+	"flag"
 	"github.com/golang-migrate/migrate/v4"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
@@ -16,8 +18,7 @@ import (
 	"net"
 	"net/http"
 	"os"
-	"os/signal"
-	"syscall"
+	"time"
 
 	_ "github.com/golang-migrate/migrate/v4/database/postgres"
 	_ "github.com/golang-migrate/migrate/v4/source/file"
@@ -25,21 +26,27 @@ import (
 )
 
 func main() {
+	configFile := flag.String("config", "", "path to KEY=VALUE configuration file, environment overrides it")
+	flag.Parse()
 
-	var connectionString = fmt.Sprintf("postgres://%s:%s@%s:%s/%s",
-		configs.POSTGRES_USER,
-		configs.POSTGRES_PASSWORD,
-		configs.PG_HOST,
-		configs.PG_PORT,
-		configs.POSTGRES_DB)
+	cfg, err := configs.Load(*configFile)
+	if err != nil {
+		log.Fatalf("app - Run - %v", err)
+	}
+
+	var connectionString = cfg.Postgres.ConnectionString()
 
 	db, err := postgres.NewConnection(connectionString)
 	if err != nil {
 		log.Fatalf("app - Run - postgres.New: %v", err)
 	}
-	defer db.CloseDB()
+	runner := app.NewRunner()
+	runner.AddCloser("postgres", func() error {
+		db.CloseDB()
+		return nil
+	})
 
-	err = doMigrate(connectionString)
+	err = doMigrate(connectionString, cfg.Migrations)
 	if err != nil {
 		log.Printf("app - Run - Migration issues: %v\n", err)
 	}
@@ -49,19 +56,42 @@ func main() {
 
 	var accRepoDB = postgres.NewAccountRepoDB(userRoleRepoDB, db)
 	var clock = services.NewClock()
-	var accService = services.NewAccountService(accRepoDB, accRepoDB, accRepoDB, clock)
+	var accService = services.NewAccountService(accRepoDB, accRepoDB, accRepoDB, clock,
+		cfg.Accounting.PlatformAccountNumber, cfg.Accounting.TripDepositCents)
+	if _, err = accService.EnsurePlatformAccount(context.Background(), cfg.Accounting.PlatformAccountOwnerID); err != nil {
+		log.Fatalf("app - Run - EnsurePlatformAccount: %v", err)
+	}
 	var stationRepoDB = postgres.NewStationRepoDB(db)
-	var stationService = services.NewStationService(stationRepoDB)
+	var stationService = services.NewStationService(stationRepoDB, clock)
+	scheduleCtx, stopSchedule := context.WithCancel(context.Background())
+	go stationService.ApplyScheduledEvery(scheduleCtx, time.Minute)
+	runner.AddCloser("station status schedule", func() error {
+		stopSchedule()
+		return nil
+	})
+	var zoneRepoDB = postgres.NewZoneRepoDB(db)
+	var zoneService = services.NewZoneService(zoneRepoDB)
+	var placeService = services.NewPlaceService(stationService, postgres.NewLocationRepoDB(db), db)
+
+	var reservationRepoDB = postgres.NewReservationRepoDB(db)
+	var reservationService = services.NewReservationService(reservationRepoDB, clock, cfg.Trips.ReservationHold)
+	expiryCtx, stopExpiry := context.WithCancel(context.Background())
+	go reservationService.ExpireEvery(expiryCtx, time.Minute)
+	runner.AddCloser("reservation expiry", func() error {
+		stopExpiry()
+		return nil
+	})
 
 	var scooterRepo = postgres.NewScooterRepoDB(db)
-	var grpcScooterService = services.NewGrpcScooterService(scooterRepo, stationService)
+	var grpcScooterService = services.NewGrpcScooterService(scooterRepo, stationService, reservationService,
+		zoneService, net.JoinHostPort("", cfg.GRPC.Port), cfg.Trips.ScooterTick)
 	var scooterService = services.NewScooterService(scooterRepo)
 
 	var supplierRepoDB = postgres.NewSupplierRepoDB(db)
 	var supplierService = services.NewSupplierService(supplierRepoDB)
 
-	problemGRPCServer := net.JoinHostPort(configs.PROBLEMS_SERVICE, configs.PROBLEMS_GRPC_PORT)
-	problemCred, err := credentials.NewClientTLSFromFile(configs.PROBLEMS_CERTIFICATE, "")
+	problemGRPCServer := net.JoinHostPort(cfg.Problems.Service, cfg.Problems.Port)
+	problemCred, err := credentials.NewClientTLSFromFile(cfg.Problems.Certificate, "")
 	if err != nil {
 		log.Panicf("%s: unable to get TLS certificate - %v", problemGRPCServer, err)
 	}
@@ -69,11 +99,12 @@ func main() {
 	if err != nil {
 		log.Panicf("%s: unable to set grpc connection - %v", problemGRPCServer, err)
 	}
-	defer problemConnection.Close()
+	runner.AddCloser("problem service connection", problemConnection.Close)
 	var problemService = services.NewProblemService(problemConnection, userService)
 
 	var orderRepoDB = postgres.NewOrderRepoDB(db)
-	var orderService = services.NewOrderService(orderRepoDB)
+	var priceRepoDB = postgres.NewPriceRepoDB(db)
+	var orderService = services.NewOrderService(orderRepoDB, services.NewPricingService(priceRepoDB), accService, db)
 
 	var scootersInitRepoDb = postgres.NewScooterInitRepoDB(db)
 	var scootersInitService = services.NewScooterInitService(scootersInitRepoDb)
@@ -81,13 +112,46 @@ func main() {
 	var supMicroRepoDb = postgres.NewSupMicroRepoDB(db)
 	var supMicroService = services.NewSupMicroService(supMicroRepoDb)
 
-	sessStore := sessions.NewCookieStore([]byte(configs.SESSION_SECRET))
-	authService := services.NewAuthService(userRoleRepoDB, sessStore)
+	sessStore := sessions.NewCookieStore([]byte(cfg.Auth.SessionSecret))
+	var loginAuditRepoDB = postgres.NewLoginAuditRepoDB(db)
+	var loginAuditService = services.NewLoginAuditService(loginAuditRepoDB, clock)
+	var lockoutRepoDB = postgres.NewLockoutRepoDB(db)
+	var lockoutService = services.NewLockoutService(lockoutRepoDB, clock, services.LockoutPolicy{
+		MaxAttempts:   cfg.Lockout.MaxAttempts,
+		Lockout:       cfg.Lockout.Lockout,
+		Backoff:       cfg.Lockout.Backoff,
+		IPMaxAttempts: cfg.Lockout.IPMaxAttempts,
+		IPWindow:      cfg.Lockout.IPWindow,
+	})
+	authService := services.NewAuthService(userRoleRepoDB, sessStore, loginAuditService, lockoutService)
+	var tokenRepoDB = postgres.NewTokenRepoDB(db)
+	var tokenService = services.NewTokenService(tokenRepoDB, userRoleRepoDB, clock, cfg.Auth.AccessTokenTTL,
+		cfg.Auth.RefreshTokenTTL)
+
+	var mailer services.Mailer = services.NewLogMailer(os.Stdout, cfg.Mail.From)
+	if cfg.Mail.SMTPHost != "" {
+		mailer = services.NewSMTPMailer(cfg.Mail.SMTPHost, cfg.Mail.SMTPPort, cfg.Mail.SMTPUser,
+			cfg.Mail.SMTPPassword, cfg.Mail.From)
+	} else if cfg.Mail.LogPath != "" {
+		mailFile, err := os.OpenFile(cfg.Mail.LogPath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+		if err != nil {
+			log.Fatalf("app - Run - mail log: %v", err)
+		}
+		runner.AddCloser("mail log", mailFile.Close)
+		mailer = services.NewLogMailer(mailFile, cfg.Mail.From)
+	}
+	var activationService = services.NewActivationService(userRoleRepoDB, mailer, clock, cfg.Auth.SessionSecret,
+		cfg.Auth.ActivationTTL, cfg.Auth.AppURL)
+	var passwordResetRepoDB = postgres.NewPasswordResetRepoDB(db)
+	var contactRepoDB = postgres.NewContactRepoDB(db)
+	var contactService = services.NewContactService(contactRepoDB)
+	var passwordService = services.NewPasswordService(userRoleRepoDB, passwordResetRepoDB, tokenRepoDB, contactRepoDB,
+		mailer, clock, cfg.Auth.PasswordResetTTL, cfg.Auth.AppURL)
 
 	custService := services.NewCustomerService(stationRepoDB)
 
-	supplierMicroGRPCServer := net.JoinHostPort(configs.SUPPLIER_MICRO_SERVICE, configs.SUPPLIER_MICRO_GRPC_PORT)
-	supplierMicroCred, err := credentials.NewClientTLSFromFile(configs.SUPPLIER_MICRO_CERTIFICATE, "")
+	supplierMicroGRPCServer := net.JoinHostPort(cfg.SupplierMicro.Service, cfg.SupplierMicro.Port)
+	supplierMicroCred, err := credentials.NewClientTLSFromFile(cfg.SupplierMicro.Certificate, "")
 	if err != nil {
 		log.Panicf("%s: unable to get TLS certificate - %v", supplierMicroGRPCServer, err)
 	}
@@ -95,59 +159,75 @@ func main() {
 	if err != nil {
 		log.Panicf("%s: unable to set grpc connection - %v", supplierMicroGRPCServer, err)
 	}
-	defer supplierMicroConnection.Close()
+	runner.AddCloser("supplier service connection", supplierMicroConnection.Close)
 	var supplierMicroService = services.NewSupplierMicroService(supplierMicroConnection)
 
-	handler := routing.NewRouter()
+	handler := routing.NewRouter(cfg.HTTP.TemplatesPath, cfg.HTTP.RequestTimeout)
 	routing.AddAuthHandler(handler, authService)
+	routing.AddTokenHandler(handler, tokenService)
+	routing.AddActivationHandler(handler, activationService)
+	routing.AddPasswordHandler(handler, passwordService)
+	routing.AddSessionHandler(handler, loginAuditService)
+	routing.AddContactHandler(handler, contactService)
 	routing.AddCustomerHandler(handler, custService)
 	routing.AddUserHandler(handler, userService)
 	routing.AddStationHandler(handler, stationService)
+	routing.AddZoneHandler(handler, zoneService)
+	routing.AddPlaceHandler(handler, placeService)
 	routing.AddAccountHandler(handler, accService)
 	routing.AddScooterHandler(handler, scooterService)
 	routing.AddProblemHandler(handler, problemService)
 	routing.AddSupplierMicroHandler(handler, supplierMicroService)
 	routing.AddGrpcScooterHandler(handler, grpcScooterService)
 	routing.AddOrderHandler(handler, orderService)
+	routing.AddReservationHandler(handler, reservationService)
 	routing.AddSupplierHandler(handler, supplierService)
 	routing.AddScooterInitHandler(handler, scootersInitService)
 	routing.AddSupMicroHandler(handler, supMicroService)
-	httpServer := httpserver.New(handler, httpserver.Port(configs.HTTP_PORT))
-	handler.HandleFunc("/scooter", httpServer.ScooterHandler)
-
-	//utils.CheckKafka() //TODO: delete after checking
-
-	grpcServer := grpcserver.NewGrpcServer()
+	httpServer := httpserver.New(handler, httpserver.Port(cfg.HTTP.Port),
+		httpserver.ReadTimeout(cfg.HTTP.ReadTimeout), httpserver.WriteTimeout(cfg.HTTP.WriteTimeout),
+		httpserver.IdleTimeout(cfg.HTTP.IdleTimeout), httpserver.ShutdownTimeout(cfg.HTTP.ShutdownTimeout),
+		httpserver.Trips(func(ctx context.Context, tripID uint64) (httpserver.Trip, error) {
+			order, err := orderService.GetOrderByID(ctx, int(tripID))
+			return httpserver.Trip{UserID: order.UserID, ScooterID: uint64(order.ScooterID),
+				Active: order.StatusEndID == 0}, err
+		}, func(ctx context.Context, scooterID uint64) (httpserver.Trip, error) {
+			order, ok, err := orderService.GetActiveOrderByScooterID(ctx, int(scooterID))
+			return httpserver.Trip{UserID: order.UserID, ScooterID: scooterID, Active: ok}, err
+		}, func(r *http.Request) (int, bool) {
+			user := routing.GetUserFromContext(r)
+			if user == nil {
+				return 0, false
+			}
+			return user.ID, true
+		}),
+		httpserver.Warnings(grpcScooterService))
+	handler.Handle("/scooter", routing.FilterAuthOrToken(authService, tokenService)(
+		http.HandlerFunc(httpServer.ScooterHandler)))
+
+	//utils.CheckKafka(cfg.Kafka.Broker) //TODO: delete after checking
+
+	grpcServer := grpcserver.NewGrpcServer(grpcserver.Port(cfg.GRPC.Port))
 	protos.RegisterScooterServiceServer(grpcServer, httpServer)
-	http.ListenAndServe(":8080", handler)
-
-	interrupt := make(chan os.Signal, 1)
-	signal.Notify(interrupt, os.Interrupt, syscall.SIGTERM)
 
-	select {
-	case s := <-interrupt:
-		log.Println("app - Run - signal: " + s.String())
-	case err = <-httpServer.Notify():
-		log.Fatalf("app - Run - httpServer.Notify: %v", err)
-	}
-
-	err = httpServer.Shutdown()
-	if err != nil {
-		log.Fatalf("app - Run - httpServer.Shutdown: %v", err)
+	runner.AddServer("grpc server", grpcServer)
+	runner.AddServer("http server", httpServer)
+	if err = runner.Run(context.Background()); err != nil {
+		log.Fatalf("app - Run - %v", err)
 	}
 }
 
-func doMigrate(connStr string) error {
-	migr, err := migrate.New("file://"+configs.MIGRATIONS_PATH, connStr+"?sslmode=disable")
+func doMigrate(connStr string, cfg configs.Migrations) error {
+	migr, err := migrate.New("file://"+cfg.Path, connStr+"?sslmode=disable")
 	if err != nil {
 		return err
 	}
 
-	if configs.MIGRATE_VERSION_FORCE > 0 {
-		migr.Force(configs.MIGRATE_VERSION_FORCE)
+	if cfg.VersionForce > 0 {
+		migr.Force(cfg.VersionForce)
 	}
 
-	if configs.MIGRATE_DOWN {
+	if cfg.Down {
 		migr.Down()
 	}
 
@@ -1,31 +1,220 @@
 package configs
 
 import (
+	"fmt"
 	"os"
-	"strconv"
+	"path/filepath"
+	"shared.micro/envconfig"
+	"time"
 )
 
-var PG_HOST = os.Getenv("PG_HOST")
-var PG_PORT = os.Getenv("PG_PORT")
-var POSTGRES_DB = os.Getenv("POSTGRES_DB")
-var POSTGRES_USER = os.Getenv("POSTGRES_USER")
-var POSTGRES_PASSWORD = os.Getenv("POSTGRES_PASSWORD")
-var HTTP_PORT = os.Getenv("HTTP_PORT")
-var MIGRATE_DOWN, _ = strconv.ParseBool(os.Getenv("MIGRATE_DOWN"))
-var MIGRATIONS_PATH = os.Getenv("MIGRATIONS_PATH")
-var TEMPLATES_PATH = os.Getenv("TEMPLATES_PATH")
-var MIGRATE_VERSION_FORCE, _ = strconv.Atoi(os.Getenv("MIGRATE_VERSION_FORCE"))
-var KAFKA_BROKER = os.Getenv("KAFKA_BROKER")
-var SESSION_SECRET = os.Getenv("SESSION_SECRET")
-
-var CERT_PATH = os.Getenv("CERT_PATH")
-
-var PROBLEMS_GRPC_PORT = os.Getenv("PROBLEMS_GRPC_PORT")
-var PROBLEMS_SERVICE = os.Getenv("PROBLEMS_SERVICE")
-var PROBLEMS_CERT_NAME = os.Getenv("PROBLEMS_CERT_NAME")
-var PROBLEMS_CERTIFICATE = CERT_PATH + PROBLEMS_CERT_NAME
-
-var SUPPLIER_MICRO_GRPC_PORT = os.Getenv("SUPPLIER_MICRO_GRPC_PORT")
-var SUPPLIER_MICRO_SERVICE = os.Getenv("SUPPLIER_MICRO_SERVICE")
-var SUPPLIER_MICRO_CERT_NAME = os.Getenv("SUPPLIER_MICRO_CERT_NAME")
-var SUPPLIER_MICRO_CERTIFICATE = CERT_PATH + SUPPLIER_MICRO_CERT_NAME
+// FileEnv - environment variable with the path of an optional KEY=VALUE configuration file
+const FileEnv = "CONFIG_FILE"
+
+// Config - configuration of the application, values come from the environment and an optional file
+type Config struct {
+	Postgres      Postgres
+	Migrations    Migrations
+	HTTP          HTTP
+	GRPC          GRPC
+	Auth          Auth
+	Lockout       Lockout
+	Mail          Mail
+	Trips         Trips
+	Accounting    Accounting
+	Kafka         Kafka
+	Problems      Remote
+	SupplierMicro Remote
+}
+
+// Postgres - database connection settings
+type Postgres struct {
+	Host     string
+	Port     string
+	DB       string
+	User     string
+	Password string
+}
+
+// Migrations - database migration settings
+type Migrations struct {
+	Path         string
+	Down         bool
+	VersionForce int
+}
+
+// HTTP - settings of the http server. WriteTimeout limits the whole connection and is 0 by default,
+// because the event streams and the trip requests last long; the other requests are limited by RequestTimeout
+type HTTP struct {
+	Port            string
+	TemplatesPath   string
+	ReadTimeout     time.Duration
+	WriteTimeout    time.Duration
+	IdleTimeout     time.Duration
+	RequestTimeout  time.Duration
+	ShutdownTimeout time.Duration
+}
+
+// GRPC - settings of the application's gRPC server which receives scooter positions
+type GRPC struct {
+	Port string
+}
+
+// Auth - settings of sessions, tokens and account emails
+type Auth struct {
+	SessionSecret    string
+	AccessTokenTTL   time.Duration
+	RefreshTokenTTL  time.Duration
+	ActivationTTL    time.Duration
+	PasswordResetTTL time.Duration
+	AppURL           string
+}
+
+// Lockout - sign in throttling settings
+type Lockout struct {
+	MaxAttempts   int
+	Lockout       time.Duration
+	Backoff       time.Duration
+	IPMaxAttempts int
+	IPWindow      time.Duration
+}
+
+// Mail - outgoing mail settings, mails are logged when SMTP host is empty
+type Mail struct {
+	SMTPHost     string
+	SMTPPort     string
+	SMTPUser     string
+	SMTPPassword string
+	From         string
+	LogPath      string
+}
+
+// Trips - settings of scooter trips and reservations
+type Trips struct {
+	ScooterTick     time.Duration
+	ReservationHold time.Duration
+}
+
+// Accounting - settings of trip payments
+type Accounting struct {
+	PlatformAccountNumber  string
+	PlatformAccountOwnerID int
+	TripDepositCents       int
+}
+
+// Kafka - message broker settings
+type Kafka struct {
+	Broker string
+}
+
+// Remote - connection settings of a TLS secured gRPC microservice
+type Remote struct {
+	Service     string
+	Port        string
+	Certificate string
+}
+
+// ConnectionString - postgres connection url of the database
+func (p Postgres) ConnectionString() string {
+	return fmt.Sprintf("postgres://%s:%s@%s:%s/%s", p.User, p.Password, p.Host, p.Port, p.DB)
+}
+
+// Load - read configuration from the environment and the file at path (if not empty),
+// environment values override the file; fails with all missing or malformed values listed
+func Load(path string) (*Config, error) {
+	if path == "" {
+		path = os.Getenv(FileEnv)
+	}
+	l, err := envconfig.New(path)
+	if err != nil {
+		return nil, err
+	}
+
+	certPath := l.Required("CERT_PATH")
+	cfg := &Config{
+		Postgres: Postgres{
+			Host:     l.Required("PG_HOST"),
+			Port:     l.Str("PG_PORT", "5432"),
+			DB:       l.Required("POSTGRES_DB"),
+			User:     l.Required("POSTGRES_USER"),
+			Password: l.Required("POSTGRES_PASSWORD"),
+		},
+		Migrations: Migrations{
+			Path:         l.Required("MIGRATIONS_PATH"),
+			Down:         l.Bool("MIGRATE_DOWN", false),
+			VersionForce: l.Int("MIGRATE_VERSION_FORCE", 0),
+		},
+		HTTP: HTTP{
+			Port:            l.Str("HTTP_PORT", "8080"),
+			TemplatesPath:   l.Required("TEMPLATES_PATH"),
+			ReadTimeout:     l.Duration("HTTP_READ_TIMEOUT", 5*time.Second),
+			WriteTimeout:    l.Timeout("HTTP_WRITE_TIMEOUT", 0),
+			IdleTimeout:     l.Duration("HTTP_IDLE_TIMEOUT", 30*time.Second),
+			RequestTimeout:  l.Timeout("HTTP_REQUEST_TIMEOUT", 30*time.Second),
+			ShutdownTimeout: l.Duration("HTTP_SHUTDOWN_TIMEOUT", 15*time.Second),
+		},
+		GRPC: GRPC{
+			Port: l.Str("APP_GRPC_PORT", "8000"),
+		},
+		Auth: Auth{
+			SessionSecret:    l.Required("SESSION_SECRET"),
+			AccessTokenTTL:   l.Duration("ACCESS_TOKEN_TTL", 15*time.Minute),
+			RefreshTokenTTL:  l.Duration("REFRESH_TOKEN_TTL", 30*24*time.Hour),
+			ActivationTTL:    l.Duration("ACTIVATION_TTL", 24*time.Hour),
+			PasswordResetTTL: l.Duration("PASSWORD_RESET_TTL", time.Hour),
+			AppURL:           l.Str("APP_URL", "http://localhost:8080"),
+		},
+		Lockout: Lockout{
+			MaxAttempts:   l.Int("LOGIN_MAX_ATTEMPTS", 5),
+			Lockout:       l.Duration("LOGIN_LOCKOUT", 15*time.Minute),
+			Backoff:       l.Duration("LOGIN_BACKOFF", time.Second),
+			IPMaxAttempts: l.Int("LOGIN_IP_MAX_ATTEMPTS", 20),
+			IPWindow:      l.Duration("LOGIN_IP_WINDOW", 15*time.Minute),
+		},
+		Mail: Mail{
+			SMTPHost:     l.Str("SMTP_HOST", ""),
+			SMTPPort:     l.Str("SMTP_PORT", "587"),
+			SMTPUser:     l.Str("SMTP_USER", ""),
+			SMTPPassword: l.Str("SMTP_PASSWORD", ""),
+			From:         l.Str("MAIL_FROM", "noreply@scooters.local"),
+			LogPath:      l.Str("MAIL_LOG_PATH", ""),
+		},
+		Trips: Trips{
+			ScooterTick:     l.Duration("SCOOTER_TICK", 450*time.Millisecond),
+			ReservationHold: l.Duration("RESERVATION_HOLD", 10*time.Minute),
+		},
+		Accounting: Accounting{
+			PlatformAccountNumber:  l.Required("PLATFORM_ACCOUNT_NUMBER"),
+			PlatformAccountOwnerID: l.Int("PLATFORM_ACCOUNT_OWNER_ID", 0),
+			TripDepositCents:       l.Int("TRIP_DEPOSIT_CENTS", 5000),
+		},
+		Kafka: Kafka{
+			Broker: l.Str("KAFKA_BROKER", "kafka:9092"),
+		},
+		Problems: Remote{
+			Service:     l.Required("PROBLEMS_SERVICE"),
+			Port:        l.Str("PROBLEMS_GRPC_PORT", "3333"),
+			Certificate: filepath.Join(certPath, l.Str("PROBLEMS_CERT_NAME", "problserv.crt")),
+		},
+		SupplierMicro: Remote{
+			Service:     l.Required("SUPPLIER_MICRO_SERVICE"),
+			Port:        l.Str("SUPPLIER_MICRO_GRPC_PORT", "4444"),
+			Certificate: filepath.Join(certPath, l.Str("SUPPLIER_MICRO_CERT_NAME", "supserv.crt")),
+		},
+	}
+
+	if cfg.Lockout.MaxAttempts < 0 || cfg.Lockout.IPMaxAttempts < 0 {
+		l.Invalid("LOGIN_MAX_ATTEMPTS and LOGIN_IP_MAX_ATTEMPTS must not be negative")
+	}
+	if cfg.Accounting.PlatformAccountOwnerID <= 0 {
+		l.Invalid("PLATFORM_ACCOUNT_OWNER_ID must be the id of an existing user")
+	}
+	if cfg.Accounting.TripDepositCents < 0 {
+		l.Invalid("TRIP_DEPOSIT_CENTS must not be negative")
+	}
+
+	if err = l.Err(); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
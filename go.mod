@@ -12,6 +12,8 @@ replace order.micro => ./microservice/OrderService
 
 replace scooter_server.micro => ./microservice/ScooterServer
 
+replace shared.micro => ./microservice/Shared
+
 require (
 	github.com/gorilla/sessions v1.2.1
 	golang.org/x/crypto v0.0.0-20211209193657-4570a0811e8b
@@ -31,6 +33,7 @@ require (
 	google.golang.org/grpc v1.43.0
 	google.golang.org/protobuf v1.27.1
 	problem.micro v0.0.0-00010101000000-000000000000
+	shared.micro v0.0.0-00010101000000-000000000000
 	supplier.micro v0.0.0-00010101000000-000000000000
 )
 
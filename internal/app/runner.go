@@ -0,0 +1,96 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+// Server is the server the application runs: it is started in background, reports the error
+// of serving to Notify channel and stops gracefully on Shutdown
+type Server interface {
+	Start()
+	Notify() <-chan error
+	Shutdown() error
+}
+
+// namedServer - server with the name used in log messages
+type namedServer struct {
+	name   string
+	server Server
+}
+
+// closer - resource which is released after the servers are stopped
+type closer struct {
+	name  string
+	close func() error
+}
+
+// Runner starts the servers of the application and waits for SIGINT/SIGTERM or the failure of any server.
+// Then the servers are shut down in reverse order and the resources are closed in reverse order of adding,
+// so the database connection added first is closed last
+type Runner struct {
+	servers []namedServer
+	closers []closer
+}
+
+// NewRunner returns new Runner without servers & resources
+func NewRunner() *Runner {
+	return &Runner{}
+}
+
+// AddServer adds the server which is started by Run
+func (r *Runner) AddServer(name string, server Server) {
+	r.servers = append(r.servers, namedServer{name: name, server: server})
+}
+
+// AddCloser adds the resource (connection, background job...) which is released when the servers are stopped
+func (r *Runner) AddCloser(name string, close func() error) {
+	r.closers = append(r.closers, closer{name: name, close: close})
+}
+
+// Run starts the servers and blocks until the signal is received, ctx is done or any server fails.
+// Returns the error of failed server, the errors of shutdown are only logged
+func (r *Runner) Run(ctx context.Context) error {
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	failed := make(chan error, len(r.servers))
+	for _, s := range r.servers {
+		s.server.Start()
+		go func(s namedServer) {
+			if err, ok := <-s.server.Notify(); ok && err != nil {
+				failed <- fmt.Errorf("%s: %w", s.name, err)
+			}
+		}(s)
+	}
+
+	var runErr error
+	select {
+	case <-ctx.Done():
+		log.Println("app - Run - shutting down")
+	case runErr = <-failed:
+		log.Printf("app - Run - server failed: %v", runErr)
+	}
+
+	r.shutdown()
+	return runErr
+}
+
+// shutdown stops the servers and then releases the resources
+func (r *Runner) shutdown() {
+	for i := len(r.servers) - 1; i >= 0; i-- {
+		if err := r.servers[i].server.Shutdown(); err != nil {
+			log.Printf("app - Run - %s shutdown: %v", r.servers[i].name, err)
+		}
+	}
+
+	for i := len(r.closers) - 1; i >= 0; i-- {
+		if err := r.closers[i].close(); err != nil {
+			log.Printf("app - Run - %s close: %v", r.closers[i].name, err)
+		}
+	}
+}
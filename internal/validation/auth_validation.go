@@ -20,7 +20,7 @@ func (su SignUpUserRequest) Validate() error {
 		validation.Field(&su.LoginEmail, validation.Required, is.EmailFormat, validation.Length(3, 100)),
 		validation.Field(&su.UserName, validation.Required, validation.Length(3, 100)),
 		validation.Field(&su.UserSurname, validation.Required, validation.Length(3, 100)),
-		validation.Field(&su.Password, validation.Required, validation.Length(3, 10)),
+		validation.Field(&su.Password, PasswordPolicy...),
 	)
 }
 
@@ -35,6 +35,6 @@ func (su SignInUserRequest) Validate() error {
 
 	return validation.ValidateStruct(&su,
 		validation.Field(&su.LoginEmail, validation.Required, is.EmailFormat, validation.Length(3, 100)),
-		validation.Field(&su.Password, validation.Required, validation.Length(3, 10)),
+		validation.Field(&su.Password, validation.Required, validation.Length(0, MaxPasswordLength)),
 	)
 }
@@ -0,0 +1,42 @@
+package validation
+
+import (
+	"Dp218GO/models"
+	"regexp"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+	"github.com/go-ozzo/ozzo-validation/v4/is"
+)
+
+var (
+	phoneRegexp    = regexp.MustCompile(`^\+[1-9][0-9]{9,14}$`)
+	telegramRegexp = regexp.MustCompile(`^@[A-Za-z][A-Za-z0-9_]{4,31}$`)
+)
+
+// contactRules are additional rules of contact value by the code of contact type,
+// values of other types are only checked for length
+var contactRules = map[string][]validation.Rule{
+	models.ContactTypePhone: {
+		validation.Match(phoneRegexp).Error("must be phone number in international format, e.g. +380501234567"),
+	},
+	models.ContactTypeEmail: {
+		is.EmailFormat,
+	},
+	models.ContactTypeTelegram: {
+		validation.Match(telegramRegexp).Error("must be telegram username, e.g. @scooter_rider"),
+	},
+}
+
+// ContactRequest takes code of contact type and value of user contact
+type ContactRequest struct {
+	TypeCode    string
+	ContactInfo string
+}
+
+// Validate validates contact request data by the rules of its type
+func (cr ContactRequest) Validate() error {
+	rules := append([]validation.Rule{validation.Required, validation.Length(1, 200)}, contactRules[cr.TypeCode]...)
+	return validation.ValidateStruct(&cr,
+		validation.Field(&cr.ContactInfo, rules...),
+	)
+}
@@ -5,10 +5,12 @@ import (
 	"github.com/go-ozzo/ozzo-validation/v4/is"
 )
 
-// LocationRequest represents coordinates
+// LocationRequest represents coordinates with optional search radius (meters) and number of results
 type LocationRequest struct {
 	Latitude  string
 	Longitude string
+	Radius    string
+	Limit     string
 }
 
 // Validate validates locationrequest
@@ -16,5 +18,7 @@ func (cs LocationRequest) Validate() error {
 	return validation.ValidateStruct(&cs,
 		validation.Field(&cs.Latitude, validation.Required, is.Float),
 		validation.Field(&cs.Longitude, validation.Required, is.Float),
+		validation.Field(&cs.Radius, is.Float),
+		validation.Field(&cs.Limit, is.Int),
 	)
 }
@@ -0,0 +1,86 @@
+package validation
+
+import (
+	"errors"
+	"unicode"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+	"github.com/go-ozzo/ozzo-validation/v4/is"
+)
+
+// password policy in bytes, bcrypt ignores the bytes after 72nd one so longer passwords are not allowed
+const (
+	MinPasswordLength = 8
+	MaxPasswordLength = 72
+)
+
+// ErrPasswordComplexity is returned if password doesn't contain lower & upper case letters and digits
+var ErrPasswordComplexity = errors.New("must contain lower and upper case letters and digits")
+
+// PasswordPolicy is list of rules for new passwords
+var PasswordPolicy = []validation.Rule{
+	validation.Required,
+	validation.Length(MinPasswordLength, MaxPasswordLength),
+	validation.By(passwordComplexity),
+}
+
+func passwordComplexity(value interface{}) error {
+	password, _ := value.(string)
+
+	var lower, upper, digit bool
+	for _, r := range password {
+		switch {
+		case unicode.IsLower(r):
+			lower = true
+		case unicode.IsUpper(r):
+			upper = true
+		case unicode.IsDigit(r):
+			digit = true
+		}
+	}
+
+	if !(lower && upper && digit) {
+		return ErrPasswordComplexity
+	}
+	return nil
+}
+
+// ForgotPasswordRequest takes email of user who requests password reset
+type ForgotPasswordRequest struct {
+	LoginEmail string
+}
+
+// Validate validates forgotpassword request data
+func (fp ForgotPasswordRequest) Validate() error {
+	return validation.ValidateStruct(&fp,
+		validation.Field(&fp.LoginEmail, validation.Required, is.EmailFormat, validation.Length(3, 100)),
+	)
+}
+
+// ResetPasswordRequest takes token from reset link and new password
+type ResetPasswordRequest struct {
+	Token    string
+	Password string
+}
+
+// Validate validates resetpassword request data
+func (rp ResetPasswordRequest) Validate() error {
+	return validation.ValidateStruct(&rp,
+		validation.Field(&rp.Token, validation.Required),
+		validation.Field(&rp.Password, PasswordPolicy...),
+	)
+}
+
+// ChangePasswordRequest takes current and new password of authenticated user
+type ChangePasswordRequest struct {
+	OldPassword string
+	NewPassword string
+}
+
+// Validate validates changepassword request data
+func (cp ChangePasswordRequest) Validate() error {
+	return validation.ValidateStruct(&cp,
+		validation.Field(&cp.OldPassword, validation.Required, validation.Length(0, MaxPasswordLength)),
+		validation.Field(&cp.NewPassword, PasswordPolicy...),
+	)
+}
@@ -6,34 +6,34 @@ import (
 	"OrderService/repository"
 	"OrderService/service"
 	"database/sql"
-	"fmt"
+	"flag"
 	_ "github.com/lib/pq"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/reflection"
 	"log"
 	"net"
-	"os"
 )
 
 func main() {
 	log.Println("Starting order microservice")
-	connectionString := fmt.Sprintf("host=%s port=%s user=%s password=%s dbname=%s sslmode=disable",
-		config.PG_HOST,
-		config.PG_PORT,
-		config.POSTGRES_USER,
-		config.POSTGRES_PASSWORD,
-		config.POSTGRES_DB)
-
-	db, err := sql.Open("postgres", connectionString)
+	configFile := flag.String("config", "", "path to KEY=VALUE configuration file, environment overrides it")
+	flag.Parse()
+
+	cfg, err := config.Load(*configFile)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	db, err := sql.Open("postgres", cfg.Postgres.ConnectionString())
 	if err != nil {
 		log.Panicf("%s: failed to open db connection - %v", "order_micro", err)
 	}
 	defer db.Close()
 
-	orderRepo := repository.NewOrderRepo(db)
-	service := service.NewOrderService(orderRepo)
+	orderRepo := repository.NewOrderRepo(db, cfg.PlatformAccountNumber)
+	service := service.NewOrderService(orderRepo, cfg.TripDepositCents)
 
-	listener, err := net.Listen("tcp", net.JoinHostPort("", os.Getenv("ORDER_GRPC_PORT")))
+	listener, err := net.Listen("tcp", net.JoinHostPort("", cfg.GRPCPort))
 	if err != nil {
 		log.Panicf("%s: failed to listen on port - %v", "order_micro", err)
 	}
@@ -1,15 +1,68 @@
 package config
 
 import (
+	"fmt"
 	"os"
+	"shared.micro/envconfig"
 )
 
-var PG_HOST = os.Getenv("PG_HOST")
-var PG_PORT = os.Getenv("PG_PORT")
-var POSTGRES_DB = os.Getenv("POSTGRES_DB")
-var POSTGRES_USER = os.Getenv("POSTGRES_USER")
-var POSTGRES_PASSWORD = os.Getenv("POSTGRES_PASSWORD")
-var TEMPLATES_PATH = os.Getenv("TEMPLATES_PATH")
-var GRPC_PORT = os.Getenv("GRPC_PORT")
-var ORDER_GRPC_PORT = os.Getenv("ORDER_GRPC_PORT")
-var MONO_TEMPLATES_PATH = os.Getenv("MONO_TEMPLATES_PATH")
+//FileEnv is the environment variable with the path of an optional KEY=VALUE configuration file.
+const FileEnv = "CONFIG_FILE"
+
+//Config is the configuration of the order service.
+type Config struct {
+	Postgres Postgres
+	//GRPCPort is the port of the order gRPC server.
+	GRPCPort string
+	//TripDepositCents is the money which must be on the user's account to start a trip.
+	TripDepositCents int
+	//PlatformAccountNumber is the number of the account which gets the trip commissions, the same as in the monolith.
+	PlatformAccountNumber string
+}
+
+//Postgres is the database connection settings.
+type Postgres struct {
+	Host     string
+	Port     string
+	DB       string
+	User     string
+	Password string
+}
+
+//ConnectionString returns the postgres connection string of the database.
+func (p Postgres) ConnectionString() string {
+	return fmt.Sprintf("host=%s port=%s user=%s password=%s dbname=%s sslmode=disable",
+		p.Host, p.Port, p.User, p.Password, p.DB)
+}
+
+//Load reads the configuration from the environment and the file at path (if not empty),
+//the environment overrides the file. All missing or malformed values are reported in one error.
+func Load(path string) (*Config, error) {
+	if path == "" {
+		path = os.Getenv(FileEnv)
+	}
+	l, err := envconfig.New(path)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := &Config{
+		Postgres: Postgres{
+			Host:     l.Required("PG_HOST"),
+			Port:     l.Str("PG_PORT", "5432"),
+			DB:       l.Required("POSTGRES_DB"),
+			User:     l.Required("POSTGRES_USER"),
+			Password: l.Required("POSTGRES_PASSWORD"),
+		},
+		GRPCPort:              l.Str("ORDER_GRPC_PORT", "9999"),
+		TripDepositCents:      l.Int("TRIP_DEPOSIT_CENTS", 5000),
+		PlatformAccountNumber: l.Required("PLATFORM_ACCOUNT_NUMBER"),
+	}
+	if cfg.TripDepositCents < 0 {
+		l.Invalid("TRIP_DEPOSIT_CENTS must not be negative")
+	}
+	if err = l.Err(); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
@@ -0,0 +1,172 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: orderRepository.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	proto "OrderService/proto"
+	repository "OrderService/repository"
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockOrderRepository is a mock of OrderRepository interface.
+type MockOrderRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockOrderRepositoryMockRecorder
+}
+
+// MockOrderRepositoryMockRecorder is the mock recorder for MockOrderRepository.
+type MockOrderRepositoryMockRecorder struct {
+	mock *MockOrderRepository
+}
+
+// NewMockOrderRepository creates a new mock instance.
+func NewMockOrderRepository(ctrl *gomock.Controller) *MockOrderRepository {
+	mock := &MockOrderRepository{ctrl: ctrl}
+	mock.recorder = &MockOrderRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOrderRepository) EXPECT() *MockOrderRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateOrder mocks base method.
+func (m *MockOrderRepository) CreateOrder(ctx context.Context, info *proto.TripInfo) (*proto.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateOrder", ctx, info)
+	ret0, _ := ret[0].(*proto.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateOrder indicates an expected call of CreateOrder.
+func (mr *MockOrderRepositoryMockRecorder) CreateOrder(ctx, info interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOrder", reflect.TypeOf((*MockOrderRepository)(nil).CreateOrder), ctx, info)
+}
+
+// FinishTrip mocks base method.
+func (m *MockOrderRepository) FinishTrip(ctx context.Context, order *proto.Order, end *repository.TripPoint, price *repository.OrderPrice) (*proto.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FinishTrip", ctx, order, end, price)
+	ret0, _ := ret[0].(*proto.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FinishTrip indicates an expected call of FinishTrip.
+func (mr *MockOrderRepositoryMockRecorder) FinishTrip(ctx, order, end, price interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FinishTrip", reflect.TypeOf((*MockOrderRepository)(nil).FinishTrip), ctx, order, end, price)
+}
+
+// GetAccountBalance mocks base method.
+func (m *MockOrderRepository) GetAccountBalance(ctx context.Context, userID uint64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAccountBalance", ctx, userID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAccountBalance indicates an expected call of GetAccountBalance.
+func (mr *MockOrderRepositoryMockRecorder) GetAccountBalance(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccountBalance", reflect.TypeOf((*MockOrderRepository)(nil).GetAccountBalance), ctx, userID)
+}
+
+// GetActiveTrip mocks base method.
+func (m *MockOrderRepository) GetActiveTrip(ctx context.Context, userID uint64) (*proto.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetActiveTrip", ctx, userID)
+	ret0, _ := ret[0].(*proto.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetActiveTrip indicates an expected call of GetActiveTrip.
+func (mr *MockOrderRepositoryMockRecorder) GetActiveTrip(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActiveTrip", reflect.TypeOf((*MockOrderRepository)(nil).GetActiveTrip), ctx, userID)
+}
+
+// GetActiveTripByID mocks base method.
+func (m *MockOrderRepository) GetActiveTripByID(ctx context.Context, orderID uint64) (*proto.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetActiveTripByID", ctx, orderID)
+	ret0, _ := ret[0].(*proto.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetActiveTripByID indicates an expected call of GetActiveTripByID.
+func (mr *MockOrderRepositoryMockRecorder) GetActiveTripByID(ctx, orderID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActiveTripByID", reflect.TypeOf((*MockOrderRepository)(nil).GetActiveTripByID), ctx, orderID)
+}
+
+// GetScooterPosition mocks base method.
+func (m *MockOrderRepository) GetScooterPosition(ctx context.Context, scooterID uint64) (*repository.TripPoint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetScooterPosition", ctx, scooterID)
+	ret0, _ := ret[0].(*repository.TripPoint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetScooterPosition indicates an expected call of GetScooterPosition.
+func (mr *MockOrderRepositoryMockRecorder) GetScooterPosition(ctx, scooterID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetScooterPosition", reflect.TypeOf((*MockOrderRepository)(nil).GetScooterPosition), ctx, scooterID)
+}
+
+// GetScooterTariff mocks base method.
+func (m *MockOrderRepository) GetScooterTariff(ctx context.Context, scooterID uint64) (*repository.Tariff, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetScooterTariff", ctx, scooterID)
+	ret0, _ := ret[0].(*repository.Tariff)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetScooterTariff indicates an expected call of GetScooterTariff.
+func (mr *MockOrderRepositoryMockRecorder) GetScooterTariff(ctx, scooterID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetScooterTariff", reflect.TypeOf((*MockOrderRepository)(nil).GetScooterTariff), ctx, scooterID)
+}
+
+// GetTripPoint mocks base method.
+func (m *MockOrderRepository) GetTripPoint(ctx context.Context, statusID uint64) (*repository.TripPoint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTripPoint", ctx, statusID)
+	ret0, _ := ret[0].(*repository.TripPoint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTripPoint indicates an expected call of GetTripPoint.
+func (mr *MockOrderRepositoryMockRecorder) GetTripPoint(ctx, statusID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTripPoint", reflect.TypeOf((*MockOrderRepository)(nil).GetTripPoint), ctx, statusID)
+}
+
+// StartTrip mocks base method.
+func (m *MockOrderRepository) StartTrip(ctx context.Context, userID, scooterID uint64) (*proto.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StartTrip", ctx, userID, scooterID)
+	ret0, _ := ret[0].(*proto.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StartTrip indicates an expected call of StartTrip.
+func (mr *MockOrderRepositoryMockRecorder) StartTrip(ctx, userID, scooterID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartTrip", reflect.TypeOf((*MockOrderRepository)(nil).StartTrip), ctx, userID, scooterID)
+}
@@ -1,22 +1,87 @@
+//go:generate mockgen -source=orderRepository.go -destination=../repository/mock/mock_orderRepository.go -package=mock
 package repository
 
 import (
 	"OrderService/proto"
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
+	"github.com/lib/pq"
+	"math"
+	"shared.micro/pricing"
+	"time"
+)
+
+var (
+	ErrScooterNotAvailable = errors.New("scooter is not available for rent")
+	ErrActiveTripExists    = errors.New("user already has an active trip")
+	ErrNoActiveTrip        = errors.New("there is no active trip")
+	ErrNoAccount           = errors.New("user has no account")
+	ErrNotEnoughMoney      = errors.New("not enough money on the account to start the trip")
+	ErrOrderAlreadySettled = errors.New("order is already settled")
+)
+
+const (
+	uniqueViolation = "23505"
+	//orderSettlementIndex is the unique index which lets the order have only one transaction of each payment type.
+	orderSettlementIndex = "account_transactions_order_payment_type"
 )
 
 type OrderRepository interface {
 	CreateOrder(ctx context.Context, info *proto.TripInfo) (*proto.Order, error)
+	StartTrip(ctx context.Context, userID, scooterID uint64) (*proto.Order, error)
+	FinishTrip(ctx context.Context, order *proto.Order, end *TripPoint, price *OrderPrice) (*proto.Order, error)
+	GetActiveTrip(ctx context.Context, userID uint64) (*proto.Order, error)
+	GetActiveTripByID(ctx context.Context, orderID uint64) (*proto.Order, error)
+	GetTripPoint(ctx context.Context, statusID uint64) (*TripPoint, error)
+	GetScooterPosition(ctx context.Context, scooterID uint64) (*TripPoint, error)
+	GetScooterTariff(ctx context.Context, scooterID uint64) (*Tariff, error)
+	GetAccountBalance(ctx context.Context, userID uint64) (int64, error)
+}
+
+//TripPoint is a scooter position at the start or at the end of the trip.
+type TripPoint struct {
+	StatusID  uint64
+	StationID uint64
+	Latitude  float64
+	Longitude float64
+	DateTime  time.Time
+}
+
+//Tariff is the price which the scooter's owner set for the scooter's model and the owner's latest commission.
+type Tariff struct {
+	SupplierID    uint64
+	PaymentTypeID uint64
+	pricing.Tariff
+}
+
+//OrderPrice is the itemised price of the trip which is stored with the order.
+//Transactions are the money transactions which pay for the trip, they are stored with the order too.
+type OrderPrice struct {
+	SupplierID    uint64
+	PaymentTypeID uint64
+	pricing.Breakdown
+	Transactions []Transaction
+}
+
+//Transaction is the money transfer between the main accounts (the first ones) of two users.
+//The transaction without the receiver goes to the platform account.
+type Transaction struct {
+	PaymentTypeID uint64
+	FromUserID    uint64
+	ToUserID      uint64
+	AmountCents   int
 }
 
 type OrderRepo struct {
 	db *sql.DB
+	//platformAccountNumber is the number of the account which gets the trip commissions.
+	platformAccountNumber string
 }
 
-func NewOrderRepo(db *sql.DB) *OrderRepo {
-	return &OrderRepo{db: db}
+func NewOrderRepo(db *sql.DB, platformAccountNumber string) *OrderRepo {
+	return &OrderRepo{db: db, platformAccountNumber: platformAccountNumber}
 }
 
 func (or *OrderRepo) CreateOrder(ctx context.Context, info *proto.TripInfo) (*proto.Order, error) {
@@ -27,7 +92,7 @@ func (or *OrderRepo) CreateOrder(ctx context.Context, info *proto.TripInfo) (*pr
 	order.StatusStartID = info.StatusStartID
 	order.StatusEndID = info.StatusEndID
 
-	querySQL := `INSERT INTO orders(user_id, scooter_id, status_start_id, status_end_id) 
+	querySQL := `INSERT INTO orders(user_id, scooter_id, status_start_id, status_end_id)
 					VALUES ($1, $2, $3, $4) RETURNING id`
 	err := or.db.QueryRowContext(ctx, querySQL, order.UserID, order.ScooterID, order.StatusStartID, order.StatusEndID).Scan(&order.Id)
 	if err != nil {
@@ -37,3 +102,311 @@ func (or *OrderRepo) CreateOrder(ctx context.Context, info *proto.TripInfo) (*pr
 	fmt.Println("Order created on Order_service")
 	return order, nil
 }
+
+//StartTrip reserves the scooter, records its start status in rent and opens the order without the end status.
+//Everything is done in one transaction, so the scooter can't be taken by two users at once.
+//The scooter which the user reserved before is taken even though it isn't available for others.
+func (or *OrderRepo) StartTrip(ctx context.Context, userID, scooterID uint64) (*proto.Order, error) {
+	tx, err := or.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	var activeID uint64
+	querySQL := `SELECT id FROM orders WHERE user_id = $1 AND status_end_id IS NULL`
+	err = tx.QueryRowContext(ctx, querySQL, userID).Scan(&activeID)
+	if err == nil {
+		return nil, ErrActiveTripExists
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return nil, err
+	}
+
+	reserved, err := useReservation(ctx, tx, userID, scooterID)
+	if err != nil {
+		return nil, err
+	}
+
+	var start TripPoint
+	var stationID sql.NullInt64
+	querySQL = `UPDATE scooter_statuses SET can_be_rent = false, reserved = false
+					WHERE scooter_id = $1 AND (can_be_rent OR $2)
+					RETURNING station_id, latitude, longitude`
+	err = tx.QueryRowContext(ctx, querySQL, scooterID, reserved).Scan(&stationID, &start.Latitude, &start.Longitude)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrScooterNotAvailable
+	}
+	if err != nil {
+		return nil, err
+	}
+	start.StationID = uint64(stationID.Int64)
+
+	start.StatusID, err = insertStatusInRent(ctx, tx, &start)
+	if err != nil {
+		return nil, err
+	}
+
+	order := &proto.Order{UserID: userID, ScooterID: scooterID, StatusStartID: start.StatusID}
+	querySQL = `INSERT INTO orders(user_id, scooter_id, status_start_id, distance, amount_cents)
+					VALUES ($1, $2, $3, 0, 0) RETURNING id`
+	err = tx.QueryRowContext(ctx, querySQL, order.UserID, order.ScooterID, order.StatusStartID).Scan(&order.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	return order, tx.Commit()
+}
+
+//FinishTrip records the end status of the trip, closes the order with the given distance and amount
+//and makes the scooter available for the next rent if its battery is above the low level of its model.
+//The itemised price is stored with the order and the order is settled by the price transactions,
+//the cancelled trip has no price. The order which is already settled gets ErrOrderAlreadySettled.
+func (or *OrderRepo) FinishTrip(ctx context.Context, order *proto.Order, end *TripPoint,
+	price *OrderPrice) (*proto.Order, error) {
+	tx, err := or.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	end.StatusID, err = insertStatusInRent(ctx, tx, end)
+	if err != nil {
+		return nil, err
+	}
+
+	querySQL := `UPDATE orders SET status_end_id = $1, distance = $2, amount_cents = $3
+					WHERE id = $4 AND status_end_id IS NULL`
+	result, err := tx.ExecContext(ctx, querySQL, end.StatusID, order.Distance, order.AmountCents, order.Id)
+	if err != nil {
+		return nil, err
+	}
+	if affected, err := result.RowsAffected(); err != nil || affected == 0 {
+		return nil, ErrNoActiveTrip
+	}
+
+	if price != nil {
+		err = insertOrderPrice(ctx, tx, order.Id, price)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, transaction := range price.Transactions {
+			err = or.insertTransaction(ctx, tx, order.Id, transaction)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	querySQL = `UPDATE scooter_statuses AS ss SET can_be_rent = ss.battery_remain > sm.low_battery_level,
+					station_id = NULLIF($1, 0)
+					FROM scooters AS s
+					JOIN scooter_models AS sm ON s.model_id = sm.id
+					WHERE ss.scooter_id = s.id AND ss.scooter_id = $2`
+	_, err = tx.ExecContext(ctx, querySQL, end.StationID, order.ScooterID)
+	if err != nil {
+		return nil, err
+	}
+
+	order.StatusEndID = end.StatusID
+	return order, tx.Commit()
+}
+
+//GetActiveTrip returns the order of the user which has no end status yet.
+func (or *OrderRepo) GetActiveTrip(ctx context.Context, userID uint64) (*proto.Order, error) {
+	querySQL := `SELECT id, user_id, scooter_id, status_start_id, distance, amount_cents
+					FROM orders WHERE user_id = $1 AND status_end_id IS NULL`
+	return or.scanActiveTrip(or.db.QueryRowContext(ctx, querySQL, userID))
+}
+
+//GetActiveTripByID returns the order by its ID if the trip is not finished yet.
+func (or *OrderRepo) GetActiveTripByID(ctx context.Context, orderID uint64) (*proto.Order, error) {
+	querySQL := `SELECT id, user_id, scooter_id, status_start_id, distance, amount_cents
+					FROM orders WHERE id = $1 AND status_end_id IS NULL`
+	return or.scanActiveTrip(or.db.QueryRowContext(ctx, querySQL, orderID))
+}
+
+func (or *OrderRepo) scanActiveTrip(row *sql.Row) (*proto.Order, error) {
+	order := &proto.Order{}
+	err := row.Scan(&order.Id, &order.UserID, &order.ScooterID, &order.StatusStartID, &order.Distance, &order.AmountCents)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrNoActiveTrip
+	}
+	if err != nil {
+		return nil, err
+	}
+	return order, nil
+}
+
+//GetTripPoint returns the scooter status in rent by its ID.
+func (or *OrderRepo) GetTripPoint(ctx context.Context, statusID uint64) (*TripPoint, error) {
+	point := &TripPoint{StatusID: statusID}
+	var stationID sql.NullInt64
+
+	querySQL := `SELECT station_id, latitude, longitude, date_time
+					FROM scooter_statuses_in_rent WHERE id = $1`
+	err := or.db.QueryRowContext(ctx, querySQL, statusID).Scan(&stationID, &point.Latitude, &point.Longitude,
+		&point.DateTime)
+	if err != nil {
+		return nil, err
+	}
+	point.StationID = uint64(stationID.Int64)
+
+	return point, nil
+}
+
+//GetScooterPosition returns the current scooter position from the scooter_statuses table.
+func (or *OrderRepo) GetScooterPosition(ctx context.Context, scooterID uint64) (*TripPoint, error) {
+	point := &TripPoint{}
+	var stationID sql.NullInt64
+
+	querySQL := `SELECT station_id, latitude, longitude FROM scooter_statuses WHERE scooter_id = $1`
+	err := or.db.QueryRowContext(ctx, querySQL, scooterID).Scan(&stationID, &point.Latitude, &point.Longitude)
+	if err != nil {
+		return nil, err
+	}
+	point.StationID = uint64(stationID.Int64)
+
+	return point, nil
+}
+
+//GetScooterTariff returns the prices which the scooter's owner set for the scooter's model and the owner's
+//latest commission percent. The prices are converted to cents.
+func (or *OrderRepo) GetScooterTariff(ctx context.Context, scooterID uint64) (*Tariff, error) {
+	tariff := &Tariff{}
+	var pricePerMinute, pricePerKm, unlockFee, minimumCharge float64
+
+	querySQL := `SELECT s.owner_id, sm.payment_type_id, sp.price, sp.price_per_km, sp.unlock_fee, sp.minimum_charge,
+					COALESCE(sc.commission_percent, 0)
+					FROM scooters AS s
+					JOIN scooter_models AS sm ON s.model_id = sm.id
+					JOIN supplier_prices AS sp ON sp.payment_type_id = sm.payment_type_id AND sp.user_id = s.owner_id
+					LEFT JOIN LATERAL (
+						SELECT commission_percent FROM supplier_commissions
+						WHERE user_id = s.owner_id ORDER BY id DESC LIMIT 1
+					) AS sc ON true
+					WHERE s.id = $1`
+	err := or.db.QueryRowContext(ctx, querySQL, scooterID).Scan(&tariff.SupplierID, &tariff.PaymentTypeID,
+		&pricePerMinute, &pricePerKm, &unlockFee, &minimumCharge, &tariff.CommissionPercent)
+	if err != nil {
+		return nil, err
+	}
+
+	tariff.PricePerMinute = centsFromPrice(pricePerMinute)
+	tariff.PricePerKm = centsFromPrice(pricePerKm)
+	tariff.UnlockFee = centsFromPrice(unlockFee)
+	tariff.MinimumCharge = centsFromPrice(minimumCharge)
+
+	return tariff, nil
+}
+
+//GetAccountBalance returns the money (in cents) on the user's main account, which is the first one like in
+//the monolith. The transactions planned for the future are not counted.
+func (or *OrderRepo) GetAccountBalance(ctx context.Context, userID uint64) (int64, error) {
+	var balance int64
+
+	querySQL := `SELECT COALESCE(SUM(CASE WHEN t.account_to_id = a.id THEN t.amount_cents ELSE 0 END
+					- CASE WHEN t.account_from_id = a.id THEN t.amount_cents ELSE 0 END), 0)
+					FROM (SELECT id FROM accounts WHERE owner_id = $1 ORDER BY id LIMIT 1) AS a
+					LEFT JOIN account_transactions AS t
+						ON (t.account_to_id = a.id OR t.account_from_id = a.id) AND t.date_time <= now()
+					GROUP BY a.id`
+	err := or.db.QueryRowContext(ctx, querySQL, userID).Scan(&balance)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, ErrNoAccount
+	}
+
+	return balance, err
+}
+
+//useReservation closes the user's reservation of the scooter because the trip starts. The expired reservation
+//of the scooter is released first, so it neither blocks other users nor can be used.
+func useReservation(ctx context.Context, tx *sql.Tx, userID, scooterID uint64) (bool, error) {
+	querySQL := `WITH released AS (
+						UPDATE scooter_reservations SET released_at = expires_at
+						WHERE scooter_id = $1 AND released_at IS NULL AND expires_at <= now()
+						RETURNING scooter_id
+					)
+					UPDATE scooter_statuses AS ss SET can_be_rent = ss.battery_remain > sm.low_battery_level,
+						reserved = false
+					FROM released
+					JOIN scooters AS s ON s.id = released.scooter_id
+					JOIN scooter_models AS sm ON s.model_id = sm.id
+					WHERE ss.scooter_id = released.scooter_id AND ss.reserved`
+	_, err := tx.ExecContext(ctx, querySQL, scooterID)
+	if err != nil {
+		return false, err
+	}
+
+	var reservationID uint64
+	querySQL = `UPDATE scooter_reservations SET released_at = now(), used = true
+					WHERE user_id = $1 AND scooter_id = $2 AND released_at IS NULL
+					RETURNING id`
+	err = tx.QueryRowContext(ctx, querySQL, userID, scooterID).Scan(&reservationID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func insertStatusInRent(ctx context.Context, tx *sql.Tx, point *TripPoint) (uint64, error) {
+	var statusID uint64
+
+	querySQL := `INSERT INTO scooter_statuses_in_rent(station_id, date_time, latitude, longitude)
+					VALUES(NULLIF($1, 0), now(), $2, $3) RETURNING id, date_time`
+	err := tx.QueryRowContext(ctx, querySQL, point.StationID, point.Latitude, point.Longitude).
+		Scan(&statusID, &point.DateTime)
+	if err != nil {
+		return 0, err
+	}
+
+	return statusID, nil
+}
+
+//insertOrderPrice stores the itemised price of the order in the same table as the monolith does.
+func insertOrderPrice(ctx context.Context, tx *sql.Tx, orderID uint64, price *OrderPrice) error {
+	querySQL := `INSERT INTO order_prices(order_id, supplier_id, payment_type_id, minutes, kilometers, time_cents,
+					distance_cents, unlock_fee_cents, minimum_charge_cents, penalty_cents, total_cents, commission_cents,
+					supplier_cents)
+					VALUES($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)`
+	_, err := tx.ExecContext(ctx, querySQL, orderID, price.SupplierID, price.PaymentTypeID, price.Minutes,
+		price.Kilometers, price.TimeCents, price.DistanceCents, price.UnlockFeeCents, price.MinimumChargeCents,
+		price.PenaltyCents, price.TotalCents, price.CommissionCents, price.SupplierCents)
+	return err
+}
+
+//insertTransaction stores the money transaction of the order in the same table as the monolith does.
+//If one of the users has no account, ErrNoAccount is returned.
+func (or *OrderRepo) insertTransaction(ctx context.Context, tx *sql.Tx, orderID uint64,
+	transaction Transaction) error {
+	querySQL := `INSERT INTO account_transactions(date_time, payment_type_id, account_from_id, account_to_id,
+					order_id, amount_cents)
+					SELECT now(), $1, account_from.id, account_to.id, $2, $3
+					FROM (SELECT id FROM accounts WHERE owner_id = $4 ORDER BY id LIMIT 1) AS account_from,
+						(SELECT id FROM accounts
+							WHERE CASE WHEN $5 = 0 THEN number = $6 ELSE owner_id = $5 END
+							ORDER BY id LIMIT 1) AS account_to`
+	result, err := tx.ExecContext(ctx, querySQL, transaction.PaymentTypeID, orderID, transaction.AmountCents,
+		transaction.FromUserID, transaction.ToUserID, or.platformAccountNumber)
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Code == uniqueViolation && pqErr.Constraint == orderSettlementIndex {
+		return ErrOrderAlreadySettled
+	}
+	if err != nil {
+		return err
+	}
+
+	if affected, err := result.RowsAffected(); err != nil || affected == 0 {
+		return ErrNoAccount
+	}
+	return nil
+}
+
+func centsFromPrice(price float64) int {
+	return int(math.Round(price * 100))
+}
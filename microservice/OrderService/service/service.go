@@ -4,26 +4,151 @@ import (
 	"OrderService/proto"
 	"OrderService/repository"
 	"context"
+	"errors"
+	"shared.micro/geo"
+	"shared.micro/pricing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
+//payCommissionTypeID is the payment type of the commission transactions, the same as in the monolith.
+const payCommissionTypeID = 1
+
 type OrderInterface interface {
 	CreateOrder(ctx context.Context, info *proto.TripInfo) (*proto.Order, error)
+	StartTrip(ctx context.Context, request *proto.StartTripRequest) (*proto.Order, error)
+	EndTrip(ctx context.Context, request *proto.EndTripRequest) (*proto.Order, error)
+	CancelTrip(ctx context.Context, id *proto.OrderID) (*proto.Order, error)
+	GetActiveTrip(ctx context.Context, id *proto.UserID) (*proto.Order, error)
 }
 
 type OrderService struct {
-	Repo *repository.OrderRepo
-	*proto.UnimplementedOrderServiceServer
-}
-
-func (os *OrderService) mustEmbedUnimplementedOrderServiceServer() {
-	//TODO implement me
-	panic("implement me")
+	Repo repository.OrderRepository
+	//TripDepositCents is the money which must be on the user's account to start a trip.
+	TripDepositCents int64
+	proto.UnimplementedOrderServiceServer
 }
 
-func NewOrderService(repo *repository.OrderRepo) *OrderService {
-	return &OrderService{Repo: repo}
+func NewOrderService(repo repository.OrderRepository, tripDepositCents int) *OrderService {
+	return &OrderService{Repo: repo, TripDepositCents: int64(tripDepositCents)}
 }
 
 func (os *OrderService) CreateOrder(ctx context.Context, info *proto.TripInfo) (*proto.Order, error) {
 	return os.Repo.CreateOrder(ctx, info)
 }
+
+//StartTrip reserves the scooter for the user and opens a new order. The trip is started only if the user's
+//main account has the trip deposit, the same as in the monolith.
+func (os *OrderService) StartTrip(ctx context.Context, request *proto.StartTripRequest) (*proto.Order, error) {
+	balance, err := os.Repo.GetAccountBalance(ctx, request.UserID)
+	if err != nil {
+		return nil, statusFromError(err)
+	}
+	if balance < os.TripDepositCents {
+		return nil, statusFromError(repository.ErrNotEnoughMoney)
+	}
+
+	order, err := os.Repo.StartTrip(ctx, request.UserID, request.ScooterID)
+	return order, statusFromError(err)
+}
+
+//EndTrip closes the active order at the given station. The distance is counted between the start and the end
+//scooter positions, the price is itemised by the tariff of the scooter's supplier the same way as the monolith
+//does it. The penalty for the zone rules broken during the trip is added to the price.
+//The order is settled from the rider's main account in the same transaction as it is closed.
+func (os *OrderService) EndTrip(ctx context.Context, request *proto.EndTripRequest) (*proto.Order, error) {
+	order, err := os.Repo.GetActiveTripByID(ctx, request.OrderID)
+	if err != nil {
+		return nil, statusFromError(err)
+	}
+
+	start, err := os.Repo.GetTripPoint(ctx, order.StatusStartID)
+	if err != nil {
+		return nil, err
+	}
+
+	end, err := os.Repo.GetScooterPosition(ctx, order.ScooterID)
+	if err != nil {
+		return nil, err
+	}
+	end.StationID = request.StationID
+
+	tariff, err := os.Repo.GetScooterTariff(ctx, order.ScooterID)
+	if err != nil {
+		return nil, err
+	}
+
+	order.Distance = geo.Distance(start.Latitude, start.Longitude, end.Latitude, end.Longitude)
+	price := &repository.OrderPrice{
+		SupplierID:    tariff.SupplierID,
+		PaymentTypeID: tariff.PaymentTypeID,
+		Breakdown:     pricing.Calculate(tariff.Tariff, time.Since(start.DateTime), order.Distance),
+	}
+	price.AddPenalty(int(request.PenaltyCents))
+	price.Transactions = settlement(order.UserID, price)
+	order.PenaltyCents = request.PenaltyCents
+	order.AmountCents = uint64(price.TotalCents)
+
+	order, err = os.Repo.FinishTrip(ctx, order, end, price)
+	return order, statusFromError(err)
+}
+
+//CancelTrip closes the active order without charging the user. The scooter is returned where the trip started.
+func (os *OrderService) CancelTrip(ctx context.Context, id *proto.OrderID) (*proto.Order, error) {
+	order, err := os.Repo.GetActiveTripByID(ctx, id.Id)
+	if err != nil {
+		return nil, statusFromError(err)
+	}
+
+	start, err := os.Repo.GetTripPoint(ctx, order.StatusStartID)
+	if err != nil {
+		return nil, err
+	}
+
+	order, err = os.Repo.FinishTrip(ctx, order, start, nil)
+	return order, statusFromError(err)
+}
+
+//GetActiveTrip returns the user's order which is not finished yet.
+func (os *OrderService) GetActiveTrip(ctx context.Context, id *proto.UserID) (*proto.Order, error) {
+	order, err := os.Repo.GetActiveTrip(ctx, id.Id)
+	return order, statusFromError(err)
+}
+
+//settlement returns the money transactions which pay for the trip by the same rules as the monolith:
+//the rider pays the supplier's part by the trip payment type and the commission goes to the platform account.
+func settlement(riderID uint64, price *repository.OrderPrice) []repository.Transaction {
+	transactions := []repository.Transaction{{
+		PaymentTypeID: price.PaymentTypeID,
+		FromUserID:    riderID,
+		ToUserID:      price.SupplierID,
+		AmountCents:   price.SupplierCents,
+	}}
+
+	if price.CommissionCents > 0 {
+		transactions = append(transactions, repository.Transaction{
+			PaymentTypeID: payCommissionTypeID,
+			FromUserID:    riderID,
+			AmountCents:   price.CommissionCents,
+		})
+	}
+
+	return transactions
+}
+
+func statusFromError(err error) error {
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, repository.ErrNoActiveTrip):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, repository.ErrOrderAlreadySettled):
+		return status.Error(codes.AlreadyExists, err.Error())
+	case errors.Is(err, repository.ErrScooterNotAvailable), errors.Is(err, repository.ErrActiveTripExists),
+		errors.Is(err, repository.ErrNoAccount), errors.Is(err, repository.ErrNotEnoughMoney):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	}
+	return err
+}
@@ -0,0 +1,269 @@
+package service
+
+import (
+	"OrderService/proto"
+	"OrderService/repository"
+	"OrderService/repository/mock"
+	"context"
+	"github.com/golang/mock/gomock"
+	assert "github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"shared.micro/geo"
+	"shared.micro/pricing"
+	"testing"
+	"time"
+)
+
+type orderUseCasesMock struct {
+	OrderServiceUC *OrderService
+	RepoOrder      *mock.MockOrderRepository
+}
+
+type orderTestCase struct {
+	name string
+	test func(t *testing.T, mock *orderUseCasesMock)
+}
+
+func runOrderTestCases(t *testing.T, testCases []orderTestCase) {
+	for _, tc := range testCases {
+		t.Run(tc.name, func(tt *testing.T) {
+			defer func() {
+				if err := recover(); err != nil {
+					tt.Error(err)
+				}
+			}()
+
+			ctrl := gomock.NewController(tt)
+			defer ctrl.Finish()
+
+			mock := newOrderUseCasesMock(ctrl)
+
+			tc.test(tt, mock)
+		})
+	}
+}
+
+func newOrderUseCasesMock(ctrl *gomock.Controller) *orderUseCasesMock {
+	repoOrder := mock.NewMockOrderRepository(ctrl)
+
+	return &orderUseCasesMock{
+		OrderServiceUC: NewOrderService(repoOrder, 5000),
+		RepoOrder:      repoOrder,
+	}
+}
+
+func Test_Order_StartTrip(t *testing.T) {
+	runOrderTestCases(t, []orderTestCase{
+		{
+			name: "Correct",
+			test: func(t *testing.T, mock *orderUseCasesMock) {
+				mock.RepoOrder.EXPECT().GetAccountBalance(gomock.Any(), uint64(1)).Return(int64(5000), nil).Times(1)
+				mock.RepoOrder.EXPECT().StartTrip(gomock.Any(), uint64(1), uint64(2)).
+					Return(&proto.Order{Id: 7, UserID: 1, ScooterID: 2, StatusStartID: 3}, nil).Times(1)
+
+				order, err := mock.OrderServiceUC.StartTrip(context.Background(),
+					&proto.StartTripRequest{UserID: 1, ScooterID: 2})
+				assert.Nil(t, err)
+				assert.Equal(t, uint64(7), order.Id)
+			},
+		},
+		{
+			name: "NotEnoughMoney",
+			test: func(t *testing.T, mock *orderUseCasesMock) {
+				mock.RepoOrder.EXPECT().GetAccountBalance(gomock.Any(), uint64(1)).Return(int64(4999), nil).Times(1)
+				mock.RepoOrder.EXPECT().StartTrip(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+				_, err := mock.OrderServiceUC.StartTrip(context.Background(),
+					&proto.StartTripRequest{UserID: 1, ScooterID: 2})
+				assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+				assert.Equal(t, repository.ErrNotEnoughMoney.Error(), status.Convert(err).Message())
+			},
+		},
+		{
+			name: "NoAccount",
+			test: func(t *testing.T, mock *orderUseCasesMock) {
+				mock.RepoOrder.EXPECT().GetAccountBalance(gomock.Any(), uint64(1)).
+					Return(int64(0), repository.ErrNoAccount).Times(1)
+				mock.RepoOrder.EXPECT().StartTrip(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+				_, err := mock.OrderServiceUC.StartTrip(context.Background(),
+					&proto.StartTripRequest{UserID: 1, ScooterID: 2})
+				assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+			},
+		},
+		{
+			name: "ScooterNotAvailable",
+			test: func(t *testing.T, mock *orderUseCasesMock) {
+				mock.RepoOrder.EXPECT().GetAccountBalance(gomock.Any(), uint64(1)).Return(int64(5000), nil).Times(1)
+				mock.RepoOrder.EXPECT().StartTrip(gomock.Any(), uint64(1), uint64(2)).
+					Return(nil, repository.ErrScooterNotAvailable).Times(1)
+
+				_, err := mock.OrderServiceUC.StartTrip(context.Background(),
+					&proto.StartTripRequest{UserID: 1, ScooterID: 2})
+				assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+			},
+		},
+		{
+			name: "ActiveTripExists",
+			test: func(t *testing.T, mock *orderUseCasesMock) {
+				mock.RepoOrder.EXPECT().GetAccountBalance(gomock.Any(), uint64(1)).Return(int64(5000), nil).Times(1)
+				mock.RepoOrder.EXPECT().StartTrip(gomock.Any(), uint64(1), uint64(2)).
+					Return(nil, repository.ErrActiveTripExists).Times(1)
+
+				_, err := mock.OrderServiceUC.StartTrip(context.Background(),
+					&proto.StartTripRequest{UserID: 1, ScooterID: 2})
+				assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+			},
+		},
+	})
+}
+
+func Test_Order_EndTrip(t *testing.T) {
+	start := &repository.TripPoint{StatusID: 3, StationID: 1, Latitude: 48.4230, Longitude: 35.0400,
+		DateTime: time.Now().Add(-4*time.Minute - 30*time.Second)}
+	tariff := &repository.Tariff{SupplierID: 5, PaymentTypeID: 1, Tariff: pricing.Tariff{PricePerMinute: 100,
+		PricePerKm: 50, UnlockFee: 200, CommissionPercent: 10}}
+
+	runOrderTestCases(t, []orderTestCase{
+		{
+			name: "Correct",
+			test: func(t *testing.T, mock *orderUseCasesMock) {
+				end := &repository.TripPoint{Latitude: 48.4221, Longitude: 35.0196}
+				distance := geo.Distance(start.Latitude, start.Longitude, end.Latitude, end.Longitude)
+				expected := pricing.Calculate(tariff.Tariff, 5*time.Minute, distance)
+				expected.AddPenalty(300)
+
+				mock.RepoOrder.EXPECT().GetActiveTripByID(gomock.Any(), uint64(7)).
+					Return(&proto.Order{Id: 7, UserID: 1, ScooterID: 2, StatusStartID: 3}, nil).Times(1)
+				mock.RepoOrder.EXPECT().GetTripPoint(gomock.Any(), uint64(3)).Return(start, nil).Times(1)
+				mock.RepoOrder.EXPECT().GetScooterPosition(gomock.Any(), uint64(2)).Return(end, nil).Times(1)
+				mock.RepoOrder.EXPECT().GetScooterTariff(gomock.Any(), uint64(2)).Return(tariff, nil).Times(1)
+				mock.RepoOrder.EXPECT().FinishTrip(gomock.Any(), gomock.Any(), end, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, order *proto.Order, end *repository.TripPoint,
+						price *repository.OrderPrice) (*proto.Order, error) {
+						assert.Equal(t, uint64(4), end.StationID)
+						assert.Equal(t, repository.OrderPrice{SupplierID: 5, PaymentTypeID: 1, Breakdown: expected},
+							repository.OrderPrice{SupplierID: price.SupplierID, PaymentTypeID: price.PaymentTypeID,
+								Breakdown: price.Breakdown})
+						order.StatusEndID = 9
+						return order, nil
+					}).Times(1)
+
+				order, err := mock.OrderServiceUC.EndTrip(context.Background(),
+					&proto.EndTripRequest{OrderID: 7, StationID: 4, PenaltyCents: 300})
+				assert.Nil(t, err)
+				assert.Equal(t, uint64(9), order.StatusEndID)
+				assert.InDelta(t, distance, order.Distance, 0.001)
+				assert.Equal(t, uint64(300), order.PenaltyCents)
+				assert.Equal(t, uint64(expected.TotalCents), order.AmountCents)
+				assert.Equal(t, 200+500+int(distance/1000*50+0.5)+300, expected.TotalCents)
+			},
+		},
+		{
+			name: "SettlesOrder",
+			test: func(t *testing.T, mock *orderUseCasesMock) {
+				end := &repository.TripPoint{Latitude: 48.4221, Longitude: 35.0196}
+
+				mock.RepoOrder.EXPECT().GetActiveTripByID(gomock.Any(), uint64(7)).
+					Return(&proto.Order{Id: 7, UserID: 1, ScooterID: 2, StatusStartID: 3}, nil).Times(1)
+				mock.RepoOrder.EXPECT().GetTripPoint(gomock.Any(), uint64(3)).Return(start, nil).Times(1)
+				mock.RepoOrder.EXPECT().GetScooterPosition(gomock.Any(), uint64(2)).Return(end, nil).Times(1)
+				mock.RepoOrder.EXPECT().GetScooterTariff(gomock.Any(), uint64(2)).Return(tariff, nil).Times(1)
+				mock.RepoOrder.EXPECT().FinishTrip(gomock.Any(), gomock.Any(), end, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, order *proto.Order, end *repository.TripPoint,
+						price *repository.OrderPrice) (*proto.Order, error) {
+						assert.Equal(t, []repository.Transaction{
+							{PaymentTypeID: 1, FromUserID: 1, ToUserID: 5, AmountCents: price.SupplierCents},
+							{PaymentTypeID: payCommissionTypeID, FromUserID: 1, AmountCents: price.CommissionCents},
+						}, price.Transactions)
+
+						var riderCents, supplierCents, platformCents int
+						for _, transaction := range price.Transactions {
+							riderCents -= transaction.AmountCents
+							if transaction.ToUserID == 5 {
+								supplierCents += transaction.AmountCents
+							} else {
+								platformCents += transaction.AmountCents
+							}
+						}
+						assert.Equal(t, -price.TotalCents, riderCents)
+						assert.Equal(t, price.SupplierCents, supplierCents)
+						assert.Equal(t, price.CommissionCents, platformCents)
+						assert.True(t, platformCents >= 300)
+						return order, nil
+					}).Times(1)
+
+				_, err := mock.OrderServiceUC.EndTrip(context.Background(),
+					&proto.EndTripRequest{OrderID: 7, StationID: 4, PenaltyCents: 300})
+				assert.Nil(t, err)
+			},
+		},
+		{
+			name: "AlreadySettled",
+			test: func(t *testing.T, mock *orderUseCasesMock) {
+				mock.RepoOrder.EXPECT().GetActiveTripByID(gomock.Any(), uint64(7)).
+					Return(&proto.Order{Id: 7, UserID: 1, ScooterID: 2, StatusStartID: 3}, nil).Times(1)
+				mock.RepoOrder.EXPECT().GetTripPoint(gomock.Any(), uint64(3)).Return(start, nil).Times(1)
+				mock.RepoOrder.EXPECT().GetScooterPosition(gomock.Any(), uint64(2)).
+					Return(&repository.TripPoint{}, nil).Times(1)
+				mock.RepoOrder.EXPECT().GetScooterTariff(gomock.Any(), uint64(2)).Return(tariff, nil).Times(1)
+				mock.RepoOrder.EXPECT().FinishTrip(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil, repository.ErrOrderAlreadySettled).Times(1)
+
+				_, err := mock.OrderServiceUC.EndTrip(context.Background(), &proto.EndTripRequest{OrderID: 7})
+				assert.Equal(t, codes.AlreadyExists, status.Code(err))
+			},
+		},
+		{
+			name: "NoActiveTrip",
+			test: func(t *testing.T, mock *orderUseCasesMock) {
+				mock.RepoOrder.EXPECT().GetActiveTripByID(gomock.Any(), uint64(7)).
+					Return(nil, repository.ErrNoActiveTrip).Times(1)
+
+				_, err := mock.OrderServiceUC.EndTrip(context.Background(), &proto.EndTripRequest{OrderID: 7})
+				assert.Equal(t, codes.NotFound, status.Code(err))
+			},
+		},
+	})
+}
+
+func Test_Order_CancelTrip(t *testing.T) {
+	runOrderTestCases(t, []orderTestCase{
+		{
+			name: "ReturnsToStart",
+			test: func(t *testing.T, mock *orderUseCasesMock) {
+				start := &repository.TripPoint{StatusID: 3, StationID: 1, Latitude: 48.4230, Longitude: 35.0400}
+
+				mock.RepoOrder.EXPECT().GetActiveTripByID(gomock.Any(), uint64(7)).
+					Return(&proto.Order{Id: 7, UserID: 1, ScooterID: 2, StatusStartID: 3}, nil).Times(1)
+				mock.RepoOrder.EXPECT().GetTripPoint(gomock.Any(), uint64(3)).Return(start, nil).Times(1)
+				mock.RepoOrder.EXPECT().FinishTrip(gomock.Any(), gomock.Any(), start, nil).
+					DoAndReturn(func(ctx context.Context, order *proto.Order, end *repository.TripPoint,
+						price *repository.OrderPrice) (*proto.Order, error) {
+						return order, nil
+					}).Times(1)
+
+				order, err := mock.OrderServiceUC.CancelTrip(context.Background(), &proto.OrderID{Id: 7})
+				assert.Nil(t, err)
+				assert.Equal(t, uint64(0), order.AmountCents)
+				assert.Equal(t, 0.0, order.Distance)
+			},
+		},
+	})
+}
+
+func Test_Order_GetActiveTrip(t *testing.T) {
+	runOrderTestCases(t, []orderTestCase{
+		{
+			name: "NoActiveTrip",
+			test: func(t *testing.T, mock *orderUseCasesMock) {
+				mock.RepoOrder.EXPECT().GetActiveTrip(gomock.Any(), uint64(1)).
+					Return(nil, repository.ErrNoActiveTrip).Times(1)
+
+				_, err := mock.OrderServiceUC.GetActiveTrip(context.Background(), &proto.UserID{Id: 1})
+				assert.Equal(t, codes.NotFound, status.Code(err))
+			},
+		},
+	})
+}
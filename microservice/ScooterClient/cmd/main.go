@@ -1,31 +1,33 @@
 package main
 
 import (
-	"ScooterClient/model"
+	"ScooterClient/config"
 	"ScooterClient/proto"
 	"ScooterClient/service"
 	"context"
+	"flag"
 	"fmt"
 	_ "github.com/lib/pq"
 	"google.golang.org/grpc"
 	"io"
 	"log"
-	"os"
-	"time"
 )
 
-var destination model.Location
-
 func main() {
-	//conn, err := grpc.DialContext(context.Background(), net.JoinHostPort("dns:///scooter_server",
-	//	os.Getenv("GRPC_PORT")),
-	//	grpc.WithInsecure())
-	conn, err := grpc.Dial("dns:///scooter_server:9000", grpc.WithInsecure())
+	configFile := flag.String("config", "", "path to KEY=VALUE configuration file, environment overrides it")
+	flag.Parse()
+
+	cfg, err := config.Load(*configFile)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	conn, err := grpc.Dial(cfg.ServerAddress, grpc.WithInsecure())
 	if err != nil {
-		log.Printf("gRPC connection to %v port failed. With: %v\n", os.Getenv("GRPC_PORT"), err)
+		log.Printf("gRPC connection to %v failed. With: %v\n", cfg.ServerAddress, err)
 	}
 
-	log.Printf("gRPC connected port: %v.", os.Getenv("GRPC_PORT"))
+	log.Printf("gRPC connected to %v.", cfg.ServerAddress)
 
 	client := proto.NewScooterServiceClient(conn)
 	stream, err := client.Register(context.Background())
@@ -36,7 +38,7 @@ func main() {
 	ctx := stream.Context()
 	done := make(chan bool)
 
-	scooterClient := service.NewScooterClient(0, 0.0, 0.0, 0.0, stream)
+	fleet := service.NewFleet(service.NewStream(stream), cfg.ScooterTick)
 
 	go func() {
 		for {
@@ -53,43 +55,9 @@ func main() {
 				log.Fatalf("can not receive %v", err)
 			}
 
-			destination.Latitude = resp.DestLatitude
-			destination.Longitude = resp.DestLongitude
-
-			scooterClient.Longitude = resp.Longitude
-			scooterClient.Latitude = resp.Latitude
-			scooterClient.BatteryRemain = resp.BatteryRemain
-			scooterClient.ID = resp.Id
-
-			fmt.Printf("Scooter client is:%v\n", scooterClient)
-			fmt.Printf("Destination is:%v\n", scooterClient)
-
-		}
-	}()
-
-	go func() {
-		for {
-			if scooterClient.ID != 0 {
-				err = scooterClient.Run(destination)
-				if err != nil {
-					fmt.Println(err)
-				}
-			}
-
-			msg := &proto.ClientMessage{
-				Id:        scooterClient.ID,
-				Latitude:  scooterClient.Latitude,
-				Longitude: scooterClient.Longitude,
-			}
-
-			fmt.Printf("Send to server this message: %v\n", msg)
-			err := scooterClient.Stream.Send(msg)
-			if err != nil {
-				fmt.Println(err)
+			if !fleet.Start(ctx, resp) {
+				fmt.Printf("Scooter %v is already moving\n", resp.Id)
 			}
-
-			time.Sleep(time.Second * 3)
-
 		}
 	}()
 
@@ -2,13 +2,38 @@ package config
 
 import (
 	"os"
+	"shared.micro/envconfig"
+	"time"
 )
 
-var PG_HOST = os.Getenv("PG_HOST")
-var PG_PORT = os.Getenv("PG_PORT")
-var POSTGRES_DB = os.Getenv("POSTGRES_DB")
-var POSTGRES_USER = os.Getenv("POSTGRES_USER")
-var POSTGRES_PASSWORD = os.Getenv("POSTGRES_PASSWORD")
-var GRPC_PORT = os.Getenv("GRPC_PORT")
-var ORDER_GRPC_PORT = os.Getenv("ORDER_GRPC_PORT")
-var MONO_TEMPLATES_PATH = os.Getenv("MONO_TEMPLATES_PATH")
+//FileEnv is the environment variable with the path of an optional KEY=VALUE configuration file.
+const FileEnv = "CONFIG_FILE"
+
+//Config is the configuration of the scooter client.
+type Config struct {
+	//ServerAddress is the gRPC target of the scooter server, e.g. dns:///scooter_server:9000.
+	ServerAddress string
+	//ScooterTick is the interval between two positions sent by a moving scooter.
+	ScooterTick time.Duration
+}
+
+//Load reads the configuration from the environment and the file at path (if not empty),
+//the environment overrides the file. All missing or malformed values are reported in one error.
+func Load(path string) (*Config, error) {
+	if path == "" {
+		path = os.Getenv(FileEnv)
+	}
+	l, err := envconfig.New(path)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := &Config{
+		ServerAddress: l.Required("SCOOTER_SERVER_ADDRESS"),
+		ScooterTick:   l.Duration("SCOOTER_TICK", 450*time.Millisecond),
+	}
+	if err = l.Err(); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
@@ -2,11 +2,14 @@ module ScooterClient
 
 go 1.17
 
+replace shared.micro => ../Shared
+
 require (
 	github.com/gorilla/mux v1.8.0
 	github.com/lib/pq v1.10.4
 	google.golang.org/grpc v1.43.0
 	google.golang.org/protobuf v1.25.0
+	shared.micro v0.0.0-00010101000000-000000000000
 )
 
 require (
@@ -1,5 +1,6 @@
 package model
-type Location struct {
-	Latitude  float64
-	Longitude float64
-}
\ No newline at end of file
+
+import "shared.micro/geo"
+
+//Location is the point given by latitude and longitude, the trips are counted the same way as in the monolith.
+type Location = geo.Point
\ No newline at end of file
@@ -228,11 +228,15 @@ type Scooter struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Id            uint64  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
-	ScooterModel  string  `protobuf:"bytes,2,opt,name=scooterModel,proto3" json:"scooterModel,omitempty"`
-	MaxWeight     float64 `protobuf:"fixed64,3,opt,name=maxWeight,proto3" json:"maxWeight,omitempty"`
-	BatteryRemain float64 `protobuf:"fixed64,4,opt,name=batteryRemain,proto3" json:"batteryRemain,omitempty"`
-	CanBeRent     bool    `protobuf:"varint,5,opt,name=canBeRent,proto3" json:"canBeRent,omitempty"`
+	Id               uint64  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	ScooterModel     string  `protobuf:"bytes,2,opt,name=scooterModel,proto3" json:"scooterModel,omitempty"`
+	MaxWeight        float64 `protobuf:"fixed64,3,opt,name=maxWeight,proto3" json:"maxWeight,omitempty"`
+	BatteryRemain    float64 `protobuf:"fixed64,4,opt,name=batteryRemain,proto3" json:"batteryRemain,omitempty"`
+	CanBeRent        bool    `protobuf:"varint,5,opt,name=canBeRent,proto3" json:"canBeRent,omitempty"`
+	Speed            float64 `protobuf:"fixed64,6,opt,name=speed,proto3" json:"speed,omitempty"`
+	BatteryCapacity  float64 `protobuf:"fixed64,7,opt,name=batteryCapacity,proto3" json:"batteryCapacity,omitempty"`
+	ConsumptionPerKm float64 `protobuf:"fixed64,8,opt,name=consumptionPerKm,proto3" json:"consumptionPerKm,omitempty"`
+	LowBatteryLevel  float64 `protobuf:"fixed64,9,opt,name=lowBatteryLevel,proto3" json:"lowBatteryLevel,omitempty"`
 }
 
 func (x *Scooter) Reset() {
@@ -302,17 +306,49 @@ func (x *Scooter) GetCanBeRent() bool {
 	return false
 }
 
+func (x *Scooter) GetSpeed() float64 {
+	if x != nil {
+		return x.Speed
+	}
+	return 0
+}
+
+func (x *Scooter) GetBatteryCapacity() float64 {
+	if x != nil {
+		return x.BatteryCapacity
+	}
+	return 0
+}
+
+func (x *Scooter) GetConsumptionPerKm() float64 {
+	if x != nil {
+		return x.ConsumptionPerKm
+	}
+	return 0
+}
+
+func (x *Scooter) GetLowBatteryLevel() float64 {
+	if x != nil {
+		return x.LowBatteryLevel
+	}
+	return 0
+}
+
 type ScooterClient struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Id            uint64  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
-	Latitude      float64 `protobuf:"fixed64,2,opt,name=latitude,proto3" json:"latitude,omitempty"`
-	Longitude     float64 `protobuf:"fixed64,3,opt,name=longitude,proto3" json:"longitude,omitempty"`
-	BatteryRemain float64 `protobuf:"fixed64,4,opt,name=batteryRemain,proto3" json:"batteryRemain,omitempty"`
-	DestLatitude  float64 `protobuf:"fixed64,5,opt,name=destLatitude,proto3" json:"destLatitude,omitempty"`
-	DestLongitude float64 `protobuf:"fixed64,6,opt,name=destLongitude,proto3" json:"destLongitude,omitempty"`
+	Id              uint64  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Latitude        float64 `protobuf:"fixed64,2,opt,name=latitude,proto3" json:"latitude,omitempty"`
+	Longitude       float64 `protobuf:"fixed64,3,opt,name=longitude,proto3" json:"longitude,omitempty"`
+	BatteryRemain   float64 `protobuf:"fixed64,4,opt,name=batteryRemain,proto3" json:"batteryRemain,omitempty"`
+	DestLatitude    float64 `protobuf:"fixed64,5,opt,name=destLatitude,proto3" json:"destLatitude,omitempty"`
+	DestLongitude   float64 `protobuf:"fixed64,6,opt,name=destLongitude,proto3" json:"destLongitude,omitempty"`
+	Speed           float64 `protobuf:"fixed64,7,opt,name=speed,proto3" json:"speed,omitempty"`
+	DischargePerKm  float64 `protobuf:"fixed64,8,opt,name=dischargePerKm,proto3" json:"dischargePerKm,omitempty"`
+	LowBatteryLevel float64 `protobuf:"fixed64,9,opt,name=lowBatteryLevel,proto3" json:"lowBatteryLevel,omitempty"`
+	Zones           []*Zone `protobuf:"bytes,10,rep,name=zones,proto3" json:"zones,omitempty"`
 }
 
 func (x *ScooterClient) Reset() {
@@ -389,6 +425,176 @@ func (x *ScooterClient) GetDestLongitude() float64 {
 	return 0
 }
 
+func (x *ScooterClient) GetSpeed() float64 {
+	if x != nil {
+		return x.Speed
+	}
+	return 0
+}
+
+func (x *ScooterClient) GetDischargePerKm() float64 {
+	if x != nil {
+		return x.DischargePerKm
+	}
+	return 0
+}
+
+func (x *ScooterClient) GetLowBatteryLevel() float64 {
+	if x != nil {
+		return x.LowBatteryLevel
+	}
+	return 0
+}
+
+func (x *ScooterClient) GetZones() []*Zone {
+	if x != nil {
+		return x.Zones
+	}
+	return nil
+}
+
+type Point struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Latitude  float64 `protobuf:"fixed64,1,opt,name=latitude,proto3" json:"latitude,omitempty"`
+	Longitude float64 `protobuf:"fixed64,2,opt,name=longitude,proto3" json:"longitude,omitempty"`
+}
+
+func (x *Point) Reset() {
+	*x = Point{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_scooter_micro_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Point) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Point) ProtoMessage() {}
+
+func (x *Point) ProtoReflect() protoreflect.Message {
+	mi := &file_scooter_micro_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Point.ProtoReflect.Descriptor instead.
+func (*Point) Descriptor() ([]byte, []int) {
+	return file_scooter_micro_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *Point) GetLatitude() float64 {
+	if x != nil {
+		return x.Latitude
+	}
+	return 0
+}
+
+func (x *Point) GetLongitude() float64 {
+	if x != nil {
+		return x.Longitude
+	}
+	return 0
+}
+
+type Zone struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id           uint64   `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name         string   `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Kind         string   `protobuf:"bytes,3,opt,name=kind,proto3" json:"kind,omitempty"`
+	Area         []*Point `protobuf:"bytes,4,rep,name=area,proto3" json:"area,omitempty"`
+	SpeedLimit   float64  `protobuf:"fixed64,5,opt,name=speedLimit,proto3" json:"speedLimit,omitempty"`
+	PenaltyCents uint64   `protobuf:"varint,6,opt,name=penaltyCents,proto3" json:"penaltyCents,omitempty"`
+}
+
+func (x *Zone) Reset() {
+	*x = Zone{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_scooter_micro_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Zone) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Zone) ProtoMessage() {}
+
+func (x *Zone) ProtoReflect() protoreflect.Message {
+	mi := &file_scooter_micro_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Zone.ProtoReflect.Descriptor instead.
+func (*Zone) Descriptor() ([]byte, []int) {
+	return file_scooter_micro_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *Zone) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Zone) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Zone) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *Zone) GetArea() []*Point {
+	if x != nil {
+		return x.Area
+	}
+	return nil
+}
+
+func (x *Zone) GetSpeedLimit() float64 {
+	if x != nil {
+		return x.SpeedLimit
+	}
+	return 0
+}
+
+func (x *Zone) GetPenaltyCents() uint64 {
+	if x != nil {
+		return x.PenaltyCents
+	}
+	return 0
+}
+
 type ScooterList struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -400,7 +606,7 @@ type ScooterList struct {
 func (x *ScooterList) Reset() {
 	*x = ScooterList{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_scooter_micro_proto_msgTypes[6]
+		mi := &file_scooter_micro_proto_msgTypes[8]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -413,7 +619,7 @@ func (x *ScooterList) String() string {
 func (*ScooterList) ProtoMessage() {}
 
 func (x *ScooterList) ProtoReflect() protoreflect.Message {
-	mi := &file_scooter_micro_proto_msgTypes[6]
+	mi := &file_scooter_micro_proto_msgTypes[8]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -426,7 +632,7 @@ func (x *ScooterList) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ScooterList.ProtoReflect.Descriptor instead.
 func (*ScooterList) Descriptor() ([]byte, []int) {
-	return file_scooter_micro_proto_rawDescGZIP(), []int{6}
+	return file_scooter_micro_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *ScooterList) GetScooters() []*Scooter {
@@ -447,7 +653,7 @@ type ScooterID struct {
 func (x *ScooterID) Reset() {
 	*x = ScooterID{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_scooter_micro_proto_msgTypes[7]
+		mi := &file_scooter_micro_proto_msgTypes[9]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -460,7 +666,7 @@ func (x *ScooterID) String() string {
 func (*ScooterID) ProtoMessage() {}
 
 func (x *ScooterID) ProtoReflect() protoreflect.Message {
-	mi := &file_scooter_micro_proto_msgTypes[7]
+	mi := &file_scooter_micro_proto_msgTypes[9]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -473,7 +679,7 @@ func (x *ScooterID) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ScooterID.ProtoReflect.Descriptor instead.
 func (*ScooterID) Descriptor() ([]byte, []int) {
-	return file_scooter_micro_proto_rawDescGZIP(), []int{7}
+	return file_scooter_micro_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *ScooterID) GetId() uint64 {
@@ -494,7 +700,7 @@ type StationID struct {
 func (x *StationID) Reset() {
 	*x = StationID{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_scooter_micro_proto_msgTypes[8]
+		mi := &file_scooter_micro_proto_msgTypes[10]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -507,7 +713,7 @@ func (x *StationID) String() string {
 func (*StationID) ProtoMessage() {}
 
 func (x *StationID) ProtoReflect() protoreflect.Message {
-	mi := &file_scooter_micro_proto_msgTypes[8]
+	mi := &file_scooter_micro_proto_msgTypes[10]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -520,7 +726,7 @@ func (x *StationID) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use StationID.ProtoReflect.Descriptor instead.
 func (*StationID) Descriptor() ([]byte, []int) {
-	return file_scooter_micro_proto_rawDescGZIP(), []int{8}
+	return file_scooter_micro_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *StationID) GetId() uint64 {
@@ -544,7 +750,7 @@ type ScooterStatus struct {
 func (x *ScooterStatus) Reset() {
 	*x = ScooterStatus{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_scooter_micro_proto_msgTypes[9]
+		mi := &file_scooter_micro_proto_msgTypes[11]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -557,7 +763,7 @@ func (x *ScooterStatus) String() string {
 func (*ScooterStatus) ProtoMessage() {}
 
 func (x *ScooterStatus) ProtoReflect() protoreflect.Message {
-	mi := &file_scooter_micro_proto_msgTypes[9]
+	mi := &file_scooter_micro_proto_msgTypes[11]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -570,7 +776,7 @@ func (x *ScooterStatus) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ScooterStatus.ProtoReflect.Descriptor instead.
 func (*ScooterStatus) Descriptor() ([]byte, []int) {
-	return file_scooter_micro_proto_rawDescGZIP(), []int{9}
+	return file_scooter_micro_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *ScooterStatus) GetLatitude() float64 {
@@ -616,7 +822,7 @@ type SendStatus struct {
 func (x *SendStatus) Reset() {
 	*x = SendStatus{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_scooter_micro_proto_msgTypes[10]
+		mi := &file_scooter_micro_proto_msgTypes[12]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -629,7 +835,7 @@ func (x *SendStatus) String() string {
 func (*SendStatus) ProtoMessage() {}
 
 func (x *SendStatus) ProtoReflect() protoreflect.Message {
-	mi := &file_scooter_micro_proto_msgTypes[10]
+	mi := &file_scooter_micro_proto_msgTypes[12]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -642,7 +848,7 @@ func (x *SendStatus) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SendStatus.ProtoReflect.Descriptor instead.
 func (*SendStatus) Descriptor() ([]byte, []int) {
-	return file_scooter_micro_proto_rawDescGZIP(), []int{10}
+	return file_scooter_micro_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *SendStatus) GetScooterID() uint64 {
@@ -696,7 +902,7 @@ type ScooterStatusInRent struct {
 func (x *ScooterStatusInRent) Reset() {
 	*x = ScooterStatusInRent{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_scooter_micro_proto_msgTypes[11]
+		mi := &file_scooter_micro_proto_msgTypes[13]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -709,7 +915,7 @@ func (x *ScooterStatusInRent) String() string {
 func (*ScooterStatusInRent) ProtoMessage() {}
 
 func (x *ScooterStatusInRent) ProtoReflect() protoreflect.Message {
-	mi := &file_scooter_micro_proto_msgTypes[11]
+	mi := &file_scooter_micro_proto_msgTypes[13]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -722,7 +928,7 @@ func (x *ScooterStatusInRent) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ScooterStatusInRent.ProtoReflect.Descriptor instead.
 func (*ScooterStatusInRent) Descriptor() ([]byte, []int) {
-	return file_scooter_micro_proto_rawDescGZIP(), []int{11}
+	return file_scooter_micro_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *ScooterStatusInRent) GetId() uint64 {
@@ -778,7 +984,7 @@ type ClientRequest struct {
 func (x *ClientRequest) Reset() {
 	*x = ClientRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_scooter_micro_proto_msgTypes[12]
+		mi := &file_scooter_micro_proto_msgTypes[14]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -791,7 +997,7 @@ func (x *ClientRequest) String() string {
 func (*ClientRequest) ProtoMessage() {}
 
 func (x *ClientRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_scooter_micro_proto_msgTypes[12]
+	mi := &file_scooter_micro_proto_msgTypes[14]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -804,7 +1010,7 @@ func (x *ClientRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ClientRequest.ProtoReflect.Descriptor instead.
 func (*ClientRequest) Descriptor() ([]byte, []int) {
-	return file_scooter_micro_proto_rawDescGZIP(), []int{12}
+	return file_scooter_micro_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *ClientRequest) GetId() uint64 {
@@ -819,15 +1025,18 @@ type ClientMessage struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Id        uint64  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
-	Longitude float64 `protobuf:"fixed64,2,opt,name=longitude,proto3" json:"longitude,omitempty"`
-	Latitude  float64 `protobuf:"fixed64,3,opt,name=latitude,proto3" json:"latitude,omitempty"`
+	Id            uint64  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Longitude     float64 `protobuf:"fixed64,2,opt,name=longitude,proto3" json:"longitude,omitempty"`
+	Latitude      float64 `protobuf:"fixed64,3,opt,name=latitude,proto3" json:"latitude,omitempty"`
+	BatteryRemain float64 `protobuf:"fixed64,4,opt,name=batteryRemain,proto3" json:"batteryRemain,omitempty"`
+	Finished      bool    `protobuf:"varint,5,opt,name=finished,proto3" json:"finished,omitempty"`
+	LowBattery    bool    `protobuf:"varint,6,opt,name=lowBattery,proto3" json:"lowBattery,omitempty"`
 }
 
 func (x *ClientMessage) Reset() {
 	*x = ClientMessage{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_scooter_micro_proto_msgTypes[13]
+		mi := &file_scooter_micro_proto_msgTypes[15]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -840,7 +1049,7 @@ func (x *ClientMessage) String() string {
 func (*ClientMessage) ProtoMessage() {}
 
 func (x *ClientMessage) ProtoReflect() protoreflect.Message {
-	mi := &file_scooter_micro_proto_msgTypes[13]
+	mi := &file_scooter_micro_proto_msgTypes[15]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -853,7 +1062,7 @@ func (x *ClientMessage) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ClientMessage.ProtoReflect.Descriptor instead.
 func (*ClientMessage) Descriptor() ([]byte, []int) {
-	return file_scooter_micro_proto_rawDescGZIP(), []int{13}
+	return file_scooter_micro_proto_rawDescGZIP(), []int{15}
 }
 
 func (x *ClientMessage) GetId() uint64 {
@@ -877,6 +1086,27 @@ func (x *ClientMessage) GetLatitude() float64 {
 	return 0
 }
 
+func (x *ClientMessage) GetBatteryRemain() float64 {
+	if x != nil {
+		return x.BatteryRemain
+	}
+	return 0
+}
+
+func (x *ClientMessage) GetFinished() bool {
+	if x != nil {
+		return x.Finished
+	}
+	return false
+}
+
+func (x *ClientMessage) GetLowBattery() bool {
+	if x != nil {
+		return x.LowBattery
+	}
+	return false
+}
+
 type ServerMessage struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -888,7 +1118,7 @@ type ServerMessage struct {
 func (x *ServerMessage) Reset() {
 	*x = ServerMessage{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_scooter_micro_proto_msgTypes[14]
+		mi := &file_scooter_micro_proto_msgTypes[16]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -901,7 +1131,7 @@ func (x *ServerMessage) String() string {
 func (*ServerMessage) ProtoMessage() {}
 
 func (x *ServerMessage) ProtoReflect() protoreflect.Message {
-	mi := &file_scooter_micro_proto_msgTypes[14]
+	mi := &file_scooter_micro_proto_msgTypes[16]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -914,7 +1144,7 @@ func (x *ServerMessage) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ServerMessage.ProtoReflect.Descriptor instead.
 func (*ServerMessage) Descriptor() ([]byte, []int) {
-	return file_scooter_micro_proto_rawDescGZIP(), []int{14}
+	return file_scooter_micro_proto_rawDescGZIP(), []int{16}
 }
 
 func (x *ServerMessage) GetCode() uint32 {
@@ -944,7 +1174,7 @@ var file_scooter_micro_proto_rawDesc = []byte{
 	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x2a, 0x0a, 0x08, 0x73, 0x74, 0x61,
 	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x70, 0x72,
 	0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x08, 0x73, 0x74, 0x61,
-	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0x9f, 0x01, 0x0a, 0x07, 0x53, 0x63, 0x6f, 0x6f, 0x74, 0x65,
+	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0xb5, 0x02, 0x0a, 0x07, 0x53, 0x63, 0x6f, 0x6f, 0x74, 0x65,
 	0x72, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x02, 0x69,
 	0x64, 0x12, 0x22, 0x0a, 0x0c, 0x73, 0x63, 0x6f, 0x6f, 0x74, 0x65, 0x72, 0x4d, 0x6f, 0x64, 0x65,
 	0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x73, 0x63, 0x6f, 0x6f, 0x74, 0x65, 0x72,
@@ -954,112 +1184,151 @@ var file_scooter_micro_proto_rawDesc = []byte{
 	0x6d, 0x61, 0x69, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0d, 0x62, 0x61, 0x74, 0x74,
 	0x65, 0x72, 0x79, 0x52, 0x65, 0x6d, 0x61, 0x69, 0x6e, 0x12, 0x1c, 0x0a, 0x09, 0x63, 0x61, 0x6e,
 	0x42, 0x65, 0x52, 0x65, 0x6e, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x63, 0x61,
-	0x6e, 0x42, 0x65, 0x52, 0x65, 0x6e, 0x74, 0x22, 0xc9, 0x01, 0x0a, 0x0d, 0x53, 0x63, 0x6f, 0x6f,
-	0x74, 0x65, 0x72, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x02, 0x69, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x6c, 0x61, 0x74,
-	0x69, 0x74, 0x75, 0x64, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x08, 0x6c, 0x61, 0x74,
-	0x69, 0x74, 0x75, 0x64, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x6c, 0x6f, 0x6e, 0x67, 0x69, 0x74, 0x75,
-	0x64, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52, 0x09, 0x6c, 0x6f, 0x6e, 0x67, 0x69, 0x74,
-	0x75, 0x64, 0x65, 0x12, 0x24, 0x0a, 0x0d, 0x62, 0x61, 0x74, 0x74, 0x65, 0x72, 0x79, 0x52, 0x65,
-	0x6d, 0x61, 0x69, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0d, 0x62, 0x61, 0x74, 0x74,
-	0x65, 0x72, 0x79, 0x52, 0x65, 0x6d, 0x61, 0x69, 0x6e, 0x12, 0x22, 0x0a, 0x0c, 0x64, 0x65, 0x73,
-	0x74, 0x4c, 0x61, 0x74, 0x69, 0x74, 0x75, 0x64, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x01, 0x52,
-	0x0c, 0x64, 0x65, 0x73, 0x74, 0x4c, 0x61, 0x74, 0x69, 0x74, 0x75, 0x64, 0x65, 0x12, 0x24, 0x0a,
-	0x0d, 0x64, 0x65, 0x73, 0x74, 0x4c, 0x6f, 0x6e, 0x67, 0x69, 0x74, 0x75, 0x64, 0x65, 0x18, 0x06,
-	0x20, 0x01, 0x28, 0x01, 0x52, 0x0d, 0x64, 0x65, 0x73, 0x74, 0x4c, 0x6f, 0x6e, 0x67, 0x69, 0x74,
-	0x75, 0x64, 0x65, 0x22, 0x39, 0x0a, 0x0b, 0x53, 0x63, 0x6f, 0x6f, 0x74, 0x65, 0x72, 0x4c, 0x69,
-	0x73, 0x74, 0x12, 0x2a, 0x0a, 0x08, 0x73, 0x63, 0x6f, 0x6f, 0x74, 0x65, 0x72, 0x73, 0x18, 0x01,
-	0x20, 0x03, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x63, 0x6f,
-	0x6f, 0x74, 0x65, 0x72, 0x52, 0x08, 0x73, 0x63, 0x6f, 0x6f, 0x74, 0x65, 0x72, 0x73, 0x22, 0x1b,
-	0x0a, 0x09, 0x53, 0x63, 0x6f, 0x6f, 0x74, 0x65, 0x72, 0x49, 0x44, 0x12, 0x0e, 0x0a, 0x02, 0x69,
-	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x02, 0x69, 0x64, 0x22, 0x1b, 0x0a, 0x09, 0x53,
-	0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x44, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x04, 0x52, 0x02, 0x69, 0x64, 0x22, 0x9f, 0x01, 0x0a, 0x0d, 0x53, 0x63, 0x6f,
-	0x6f, 0x74, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x6c, 0x61,
-	0x74, 0x69, 0x74, 0x75, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x01, 0x52, 0x08, 0x6c, 0x61,
-	0x74, 0x69, 0x74, 0x75, 0x64, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x6c, 0x6f, 0x6e, 0x67, 0x69, 0x74,
-	0x75, 0x64, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x09, 0x6c, 0x6f, 0x6e, 0x67, 0x69,
-	0x74, 0x75, 0x64, 0x65, 0x12, 0x24, 0x0a, 0x0d, 0x62, 0x61, 0x74, 0x74, 0x65, 0x72, 0x79, 0x52,
-	0x65, 0x6d, 0x61, 0x69, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0d, 0x62, 0x61, 0x74,
-	0x74, 0x65, 0x72, 0x79, 0x52, 0x65, 0x6d, 0x61, 0x69, 0x6e, 0x12, 0x2e, 0x0a, 0x09, 0x73, 0x74,
-	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x44, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x44, 0x52,
-	0x09, 0x73, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x44, 0x22, 0xa8, 0x01, 0x0a, 0x0a, 0x53,
-	0x65, 0x6e, 0x64, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x63, 0x6f,
-	0x6f, 0x74, 0x65, 0x72, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x09, 0x73, 0x63,
-	0x6f, 0x6f, 0x74, 0x65, 0x72, 0x49, 0x44, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x74, 0x61, 0x74, 0x69,
-	0x6f, 0x6e, 0x49, 0x44, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x09, 0x73, 0x74, 0x61, 0x74,
-	0x69, 0x6f, 0x6e, 0x49, 0x44, 0x12, 0x1a, 0x0a, 0x08, 0x6c, 0x61, 0x74, 0x69, 0x74, 0x75, 0x64,
-	0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52, 0x08, 0x6c, 0x61, 0x74, 0x69, 0x74, 0x75, 0x64,
-	0x65, 0x12, 0x1c, 0x0a, 0x09, 0x6c, 0x6f, 0x6e, 0x67, 0x69, 0x74, 0x75, 0x64, 0x65, 0x18, 0x04,
+	0x6e, 0x42, 0x65, 0x52, 0x65, 0x6e, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x70, 0x65, 0x65, 0x64,
+	0x18, 0x06, 0x20, 0x01, 0x28, 0x01, 0x52, 0x05, 0x73, 0x70, 0x65, 0x65, 0x64, 0x12, 0x28, 0x0a,
+	0x0f, 0x62, 0x61, 0x74, 0x74, 0x65, 0x72, 0x79, 0x43, 0x61, 0x70, 0x61, 0x63, 0x69, 0x74, 0x79,
+	0x18, 0x07, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0f, 0x62, 0x61, 0x74, 0x74, 0x65, 0x72, 0x79, 0x43,
+	0x61, 0x70, 0x61, 0x63, 0x69, 0x74, 0x79, 0x12, 0x2a, 0x0a, 0x10, 0x63, 0x6f, 0x6e, 0x73, 0x75,
+	0x6d, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x50, 0x65, 0x72, 0x4b, 0x6d, 0x18, 0x08, 0x20, 0x01, 0x28,
+	0x01, 0x52, 0x10, 0x63, 0x6f, 0x6e, 0x73, 0x75, 0x6d, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x50, 0x65,
+	0x72, 0x4b, 0x6d, 0x12, 0x28, 0x0a, 0x0f, 0x6c, 0x6f, 0x77, 0x42, 0x61, 0x74, 0x74, 0x65, 0x72,
+	0x79, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x18, 0x09, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0f, 0x6c, 0x6f,
+	0x77, 0x42, 0x61, 0x74, 0x74, 0x65, 0x72, 0x79, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x22, 0xd4, 0x02,
+	0x0a, 0x0d, 0x53, 0x63, 0x6f, 0x6f, 0x74, 0x65, 0x72, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x12,
+	0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x02, 0x69, 0x64, 0x12,
+	0x1a, 0x0a, 0x08, 0x6c, 0x61, 0x74, 0x69, 0x74, 0x75, 0x64, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x01, 0x52, 0x08, 0x6c, 0x61, 0x74, 0x69, 0x74, 0x75, 0x64, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x6c,
+	0x6f, 0x6e, 0x67, 0x69, 0x74, 0x75, 0x64, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52, 0x09,
+	0x6c, 0x6f, 0x6e, 0x67, 0x69, 0x74, 0x75, 0x64, 0x65, 0x12, 0x24, 0x0a, 0x0d, 0x62, 0x61, 0x74,
+	0x74, 0x65, 0x72, 0x79, 0x52, 0x65, 0x6d, 0x61, 0x69, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x01,
+	0x52, 0x0d, 0x62, 0x61, 0x74, 0x74, 0x65, 0x72, 0x79, 0x52, 0x65, 0x6d, 0x61, 0x69, 0x6e, 0x12,
+	0x22, 0x0a, 0x0c, 0x64, 0x65, 0x73, 0x74, 0x4c, 0x61, 0x74, 0x69, 0x74, 0x75, 0x64, 0x65, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0c, 0x64, 0x65, 0x73, 0x74, 0x4c, 0x61, 0x74, 0x69, 0x74,
+	0x75, 0x64, 0x65, 0x12, 0x24, 0x0a, 0x0d, 0x64, 0x65, 0x73, 0x74, 0x4c, 0x6f, 0x6e, 0x67, 0x69,
+	0x74, 0x75, 0x64, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0d, 0x64, 0x65, 0x73, 0x74,
+	0x4c, 0x6f, 0x6e, 0x67, 0x69, 0x74, 0x75, 0x64, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x70, 0x65,
+	0x65, 0x64, 0x18, 0x07, 0x20, 0x01, 0x28, 0x01, 0x52, 0x05, 0x73, 0x70, 0x65, 0x65, 0x64, 0x12,
+	0x26, 0x0a, 0x0e, 0x64, 0x69, 0x73, 0x63, 0x68, 0x61, 0x72, 0x67, 0x65, 0x50, 0x65, 0x72, 0x4b,
+	0x6d, 0x18, 0x08, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0e, 0x64, 0x69, 0x73, 0x63, 0x68, 0x61, 0x72,
+	0x67, 0x65, 0x50, 0x65, 0x72, 0x4b, 0x6d, 0x12, 0x28, 0x0a, 0x0f, 0x6c, 0x6f, 0x77, 0x42, 0x61,
+	0x74, 0x74, 0x65, 0x72, 0x79, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x18, 0x09, 0x20, 0x01, 0x28, 0x01,
+	0x52, 0x0f, 0x6c, 0x6f, 0x77, 0x42, 0x61, 0x74, 0x74, 0x65, 0x72, 0x79, 0x4c, 0x65, 0x76, 0x65,
+	0x6c, 0x12, 0x21, 0x0a, 0x05, 0x7a, 0x6f, 0x6e, 0x65, 0x73, 0x18, 0x0a, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x0b, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x5a, 0x6f, 0x6e, 0x65, 0x52, 0x05, 0x7a,
+	0x6f, 0x6e, 0x65, 0x73, 0x22, 0x41, 0x0a, 0x05, 0x50, 0x6f, 0x69, 0x6e, 0x74, 0x12, 0x1a, 0x0a,
+	0x08, 0x6c, 0x61, 0x74, 0x69, 0x74, 0x75, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x01, 0x52,
+	0x08, 0x6c, 0x61, 0x74, 0x69, 0x74, 0x75, 0x64, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x6c, 0x6f, 0x6e,
+	0x67, 0x69, 0x74, 0x75, 0x64, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x09, 0x6c, 0x6f,
+	0x6e, 0x67, 0x69, 0x74, 0x75, 0x64, 0x65, 0x22, 0xa4, 0x01, 0x0a, 0x04, 0x5a, 0x6f, 0x6e, 0x65,
+	0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x02, 0x69, 0x64,
+	0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x6e, 0x61, 0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x12, 0x20, 0x0a, 0x04, 0x61, 0x72, 0x65, 0x61,
+	0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x50,
+	0x6f, 0x69, 0x6e, 0x74, 0x52, 0x04, 0x61, 0x72, 0x65, 0x61, 0x12, 0x1e, 0x0a, 0x0a, 0x73, 0x70,
+	0x65, 0x65, 0x64, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0a,
+	0x73, 0x70, 0x65, 0x65, 0x64, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x12, 0x22, 0x0a, 0x0c, 0x70, 0x65,
+	0x6e, 0x61, 0x6c, 0x74, 0x79, 0x43, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x04,
+	0x52, 0x0c, 0x70, 0x65, 0x6e, 0x61, 0x6c, 0x74, 0x79, 0x43, 0x65, 0x6e, 0x74, 0x73, 0x22, 0x39,
+	0x0a, 0x0b, 0x53, 0x63, 0x6f, 0x6f, 0x74, 0x65, 0x72, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x2a, 0x0a,
+	0x08, 0x73, 0x63, 0x6f, 0x6f, 0x74, 0x65, 0x72, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x0e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x63, 0x6f, 0x6f, 0x74, 0x65, 0x72, 0x52,
+	0x08, 0x73, 0x63, 0x6f, 0x6f, 0x74, 0x65, 0x72, 0x73, 0x22, 0x1b, 0x0a, 0x09, 0x53, 0x63, 0x6f,
+	0x6f, 0x74, 0x65, 0x72, 0x49, 0x44, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x04, 0x52, 0x02, 0x69, 0x64, 0x22, 0x1b, 0x0a, 0x09, 0x53, 0x74, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x49, 0x44, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x02, 0x69, 0x64, 0x22, 0x9f, 0x01, 0x0a, 0x0d, 0x53, 0x63, 0x6f, 0x6f, 0x74, 0x65, 0x72, 0x53,
+	0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x6c, 0x61, 0x74, 0x69, 0x74, 0x75, 0x64,
+	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x01, 0x52, 0x08, 0x6c, 0x61, 0x74, 0x69, 0x74, 0x75, 0x64,
+	0x65, 0x12, 0x1c, 0x0a, 0x09, 0x6c, 0x6f, 0x6e, 0x67, 0x69, 0x74, 0x75, 0x64, 0x65, 0x18, 0x02,
 	0x20, 0x01, 0x28, 0x01, 0x52, 0x09, 0x6c, 0x6f, 0x6e, 0x67, 0x69, 0x74, 0x75, 0x64, 0x65, 0x12,
 	0x24, 0x0a, 0x0d, 0x62, 0x61, 0x74, 0x74, 0x65, 0x72, 0x79, 0x52, 0x65, 0x6d, 0x61, 0x69, 0x6e,
-	0x18, 0x05, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0d, 0x62, 0x61, 0x74, 0x74, 0x65, 0x72, 0x79, 0x52,
-	0x65, 0x6d, 0x61, 0x69, 0x6e, 0x22, 0xd3, 0x01, 0x0a, 0x13, 0x53, 0x63, 0x6f, 0x6f, 0x74, 0x65,
-	0x72, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x49, 0x6e, 0x52, 0x65, 0x6e, 0x74, 0x12, 0x0e, 0x0a,
-	0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x02, 0x69, 0x64, 0x12, 0x1c, 0x0a,
-	0x09, 0x73, 0x63, 0x6f, 0x6f, 0x74, 0x65, 0x72, 0x49, 0x44, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04,
-	0x52, 0x09, 0x73, 0x63, 0x6f, 0x6f, 0x74, 0x65, 0x72, 0x49, 0x44, 0x12, 0x1c, 0x0a, 0x09, 0x73,
-	0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x44, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x09,
-	0x73, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x44, 0x12, 0x36, 0x0a, 0x08, 0x64, 0x61, 0x74,
-	0x65, 0x54, 0x69, 0x6d, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f,
-	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69,
-	0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x08, 0x64, 0x61, 0x74, 0x65, 0x54, 0x69, 0x6d,
-	0x65, 0x12, 0x1a, 0x0a, 0x08, 0x6c, 0x61, 0x74, 0x69, 0x74, 0x75, 0x64, 0x65, 0x18, 0x05, 0x20,
-	0x01, 0x28, 0x01, 0x52, 0x08, 0x6c, 0x61, 0x74, 0x69, 0x74, 0x75, 0x64, 0x65, 0x12, 0x1c, 0x0a,
-	0x09, 0x6c, 0x6f, 0x6e, 0x67, 0x69, 0x74, 0x75, 0x64, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x01,
-	0x52, 0x09, 0x6c, 0x6f, 0x6e, 0x67, 0x69, 0x74, 0x75, 0x64, 0x65, 0x22, 0x1f, 0x0a, 0x0d, 0x43,
-	0x6c, 0x69, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02,
-	0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x02, 0x69, 0x64, 0x22, 0x59, 0x0a, 0x0d,
-	0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x0e, 0x0a,
-	0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x02, 0x69, 0x64, 0x12, 0x1c, 0x0a,
-	0x09, 0x6c, 0x6f, 0x6e, 0x67, 0x69, 0x74, 0x75, 0x64, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01,
-	0x52, 0x09, 0x6c, 0x6f, 0x6e, 0x67, 0x69, 0x74, 0x75, 0x64, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x6c,
-	0x61, 0x74, 0x69, 0x74, 0x75, 0x64, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52, 0x08, 0x6c,
-	0x61, 0x74, 0x69, 0x74, 0x75, 0x64, 0x65, 0x22, 0x23, 0x0a, 0x0d, 0x53, 0x65, 0x72, 0x76, 0x65,
-	0x72, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x63, 0x6f, 0x64, 0x65,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x32, 0xf0, 0x04, 0x0a,
-	0x0e, 0x53, 0x63, 0x6f, 0x6f, 0x74, 0x65, 0x72, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12,
-	0x3c, 0x0a, 0x08, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x12, 0x14, 0x2e, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67,
-	0x65, 0x1a, 0x14, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x63, 0x6f, 0x6f, 0x74, 0x65,
-	0x72, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x22, 0x00, 0x28, 0x01, 0x30, 0x01, 0x12, 0x39, 0x0a,
-	0x07, 0x52, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x12, 0x14, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x2e, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x1a, 0x14,
-	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x4d, 0x65, 0x73,
-	0x73, 0x61, 0x67, 0x65, 0x22, 0x00, 0x28, 0x01, 0x12, 0x36, 0x0a, 0x0e, 0x47, 0x65, 0x74, 0x41,
-	0x6c, 0x6c, 0x53, 0x63, 0x6f, 0x6f, 0x74, 0x65, 0x72, 0x73, 0x12, 0x0e, 0x2e, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x12, 0x2e, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x2e, 0x53, 0x63, 0x6f, 0x6f, 0x74, 0x65, 0x72, 0x4c, 0x69, 0x73, 0x74, 0x22, 0x00,
-	0x12, 0x43, 0x0a, 0x19, 0x47, 0x65, 0x74, 0x41, 0x6c, 0x6c, 0x53, 0x63, 0x6f, 0x6f, 0x74, 0x65,
-	0x72, 0x73, 0x42, 0x79, 0x53, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x44, 0x12, 0x10, 0x2e,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x44, 0x1a,
-	0x12, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x63, 0x6f, 0x6f, 0x74, 0x65, 0x72, 0x4c,
-	0x69, 0x73, 0x74, 0x22, 0x00, 0x12, 0x34, 0x0a, 0x0e, 0x47, 0x65, 0x74, 0x53, 0x63, 0x6f, 0x6f,
-	0x74, 0x65, 0x72, 0x42, 0x79, 0x49, 0x64, 0x12, 0x10, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
-	0x53, 0x63, 0x6f, 0x6f, 0x74, 0x65, 0x72, 0x49, 0x44, 0x1a, 0x0e, 0x2e, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x2e, 0x53, 0x63, 0x6f, 0x6f, 0x74, 0x65, 0x72, 0x22, 0x00, 0x12, 0x3c, 0x0a, 0x10, 0x47,
-	0x65, 0x74, 0x53, 0x63, 0x6f, 0x6f, 0x74, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0d, 0x62, 0x61, 0x74, 0x74, 0x65, 0x72, 0x79, 0x52,
+	0x65, 0x6d, 0x61, 0x69, 0x6e, 0x12, 0x2e, 0x0a, 0x09, 0x73, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x49, 0x44, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x2e, 0x53, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x44, 0x52, 0x09, 0x73, 0x74, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x49, 0x44, 0x22, 0xa8, 0x01, 0x0a, 0x0a, 0x53, 0x65, 0x6e, 0x64, 0x53, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x63, 0x6f, 0x6f, 0x74, 0x65, 0x72, 0x49,
+	0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x09, 0x73, 0x63, 0x6f, 0x6f, 0x74, 0x65, 0x72,
+	0x49, 0x44, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x44, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x09, 0x73, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x44,
+	0x12, 0x1a, 0x0a, 0x08, 0x6c, 0x61, 0x74, 0x69, 0x74, 0x75, 0x64, 0x65, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x01, 0x52, 0x08, 0x6c, 0x61, 0x74, 0x69, 0x74, 0x75, 0x64, 0x65, 0x12, 0x1c, 0x0a, 0x09,
+	0x6c, 0x6f, 0x6e, 0x67, 0x69, 0x74, 0x75, 0x64, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x01, 0x52,
+	0x09, 0x6c, 0x6f, 0x6e, 0x67, 0x69, 0x74, 0x75, 0x64, 0x65, 0x12, 0x24, 0x0a, 0x0d, 0x62, 0x61,
+	0x74, 0x74, 0x65, 0x72, 0x79, 0x52, 0x65, 0x6d, 0x61, 0x69, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x01, 0x52, 0x0d, 0x62, 0x61, 0x74, 0x74, 0x65, 0x72, 0x79, 0x52, 0x65, 0x6d, 0x61, 0x69, 0x6e,
+	0x22, 0xd3, 0x01, 0x0a, 0x13, 0x53, 0x63, 0x6f, 0x6f, 0x74, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x49, 0x6e, 0x52, 0x65, 0x6e, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x04, 0x52, 0x02, 0x69, 0x64, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x63, 0x6f, 0x6f,
+	0x74, 0x65, 0x72, 0x49, 0x44, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x09, 0x73, 0x63, 0x6f,
+	0x6f, 0x74, 0x65, 0x72, 0x49, 0x44, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x74, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x49, 0x44, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x09, 0x73, 0x74, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x49, 0x44, 0x12, 0x36, 0x0a, 0x08, 0x64, 0x61, 0x74, 0x65, 0x54, 0x69, 0x6d, 0x65,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61,
+	0x6d, 0x70, 0x52, 0x08, 0x64, 0x61, 0x74, 0x65, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x1a, 0x0a, 0x08,
+	0x6c, 0x61, 0x74, 0x69, 0x74, 0x75, 0x64, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x01, 0x52, 0x08,
+	0x6c, 0x61, 0x74, 0x69, 0x74, 0x75, 0x64, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x6c, 0x6f, 0x6e, 0x67,
+	0x69, 0x74, 0x75, 0x64, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x01, 0x52, 0x09, 0x6c, 0x6f, 0x6e,
+	0x67, 0x69, 0x74, 0x75, 0x64, 0x65, 0x22, 0x1f, 0x0a, 0x0d, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x02, 0x69, 0x64, 0x22, 0xbb, 0x01, 0x0a, 0x0d, 0x43, 0x6c, 0x69, 0x65,
+	0x6e, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x02, 0x69, 0x64, 0x12, 0x1c, 0x0a, 0x09, 0x6c, 0x6f, 0x6e,
+	0x67, 0x69, 0x74, 0x75, 0x64, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x09, 0x6c, 0x6f,
+	0x6e, 0x67, 0x69, 0x74, 0x75, 0x64, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x6c, 0x61, 0x74, 0x69, 0x74,
+	0x75, 0x64, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52, 0x08, 0x6c, 0x61, 0x74, 0x69, 0x74,
+	0x75, 0x64, 0x65, 0x12, 0x24, 0x0a, 0x0d, 0x62, 0x61, 0x74, 0x74, 0x65, 0x72, 0x79, 0x52, 0x65,
+	0x6d, 0x61, 0x69, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0d, 0x62, 0x61, 0x74, 0x74,
+	0x65, 0x72, 0x79, 0x52, 0x65, 0x6d, 0x61, 0x69, 0x6e, 0x12, 0x1a, 0x0a, 0x08, 0x66, 0x69, 0x6e,
+	0x69, 0x73, 0x68, 0x65, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x66, 0x69, 0x6e,
+	0x69, 0x73, 0x68, 0x65, 0x64, 0x12, 0x1e, 0x0a, 0x0a, 0x6c, 0x6f, 0x77, 0x42, 0x61, 0x74, 0x74,
+	0x65, 0x72, 0x79, 0x18, 0x06, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x6c, 0x6f, 0x77, 0x42, 0x61,
+	0x74, 0x74, 0x65, 0x72, 0x79, 0x22, 0x23, 0x0a, 0x0d, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x4d,
+	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0d, 0x52, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x32, 0xf0, 0x04, 0x0a, 0x0e, 0x53,
+	0x63, 0x6f, 0x6f, 0x74, 0x65, 0x72, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x3c, 0x0a,
+	0x08, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x12, 0x14, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x2e, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x1a,
+	0x14, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x63, 0x6f, 0x6f, 0x74, 0x65, 0x72, 0x43,
+	0x6c, 0x69, 0x65, 0x6e, 0x74, 0x22, 0x00, 0x28, 0x01, 0x30, 0x01, 0x12, 0x39, 0x0a, 0x07, 0x52,
+	0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x12, 0x14, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43,
+	0x6c, 0x69, 0x65, 0x6e, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x1a, 0x14, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x4d, 0x65, 0x73, 0x73, 0x61,
+	0x67, 0x65, 0x22, 0x00, 0x28, 0x01, 0x12, 0x36, 0x0a, 0x0e, 0x47, 0x65, 0x74, 0x41, 0x6c, 0x6c,
+	0x53, 0x63, 0x6f, 0x6f, 0x74, 0x65, 0x72, 0x73, 0x12, 0x0e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x12, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x2e, 0x53, 0x63, 0x6f, 0x6f, 0x74, 0x65, 0x72, 0x4c, 0x69, 0x73, 0x74, 0x22, 0x00, 0x12, 0x43,
+	0x0a, 0x19, 0x47, 0x65, 0x74, 0x41, 0x6c, 0x6c, 0x53, 0x63, 0x6f, 0x6f, 0x74, 0x65, 0x72, 0x73,
+	0x42, 0x79, 0x53, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x44, 0x12, 0x10, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x44, 0x1a, 0x12, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x63, 0x6f, 0x6f, 0x74, 0x65, 0x72, 0x4c, 0x69, 0x73,
+	0x74, 0x22, 0x00, 0x12, 0x34, 0x0a, 0x0e, 0x47, 0x65, 0x74, 0x53, 0x63, 0x6f, 0x6f, 0x74, 0x65,
+	0x72, 0x42, 0x79, 0x49, 0x64, 0x12, 0x10, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x63,
+	0x6f, 0x6f, 0x74, 0x65, 0x72, 0x49, 0x44, 0x1a, 0x0e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
+	0x53, 0x63, 0x6f, 0x6f, 0x74, 0x65, 0x72, 0x22, 0x00, 0x12, 0x3c, 0x0a, 0x10, 0x47, 0x65, 0x74,
+	0x53, 0x63, 0x6f, 0x6f, 0x74, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x10, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x63, 0x6f, 0x6f, 0x74, 0x65, 0x72, 0x49, 0x44, 0x1a,
+	0x14, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x63, 0x6f, 0x6f, 0x74, 0x65, 0x72, 0x53,
+	0x74, 0x61, 0x74, 0x75, 0x73, 0x22, 0x00, 0x12, 0x39, 0x0a, 0x11, 0x53, 0x65, 0x6e, 0x64, 0x43,
+	0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x11, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x65, 0x6e, 0x64, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x1a,
+	0x0f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x22, 0x00, 0x12, 0x4b, 0x0a, 0x19, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x53, 0x63, 0x6f, 0x6f,
+	0x74, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x49, 0x6e, 0x52, 0x65, 0x6e, 0x74, 0x12,
 	0x10, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x63, 0x6f, 0x6f, 0x74, 0x65, 0x72, 0x49,
-	0x44, 0x1a, 0x14, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x63, 0x6f, 0x6f, 0x74, 0x65,
-	0x72, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x22, 0x00, 0x12, 0x39, 0x0a, 0x11, 0x53, 0x65, 0x6e,
-	0x64, 0x43, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x11,
-	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x65, 0x6e, 0x64, 0x53, 0x74, 0x61, 0x74, 0x75,
-	0x73, 0x1a, 0x0f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
-	0x73, 0x65, 0x22, 0x00, 0x12, 0x4b, 0x0a, 0x19, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x53, 0x63,
-	0x6f, 0x6f, 0x74, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x49, 0x6e, 0x52, 0x65, 0x6e,
-	0x74, 0x12, 0x10, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x63, 0x6f, 0x6f, 0x74, 0x65,
-	0x72, 0x49, 0x44, 0x1a, 0x1a, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x63, 0x6f, 0x6f,
-	0x74, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x49, 0x6e, 0x52, 0x65, 0x6e, 0x74, 0x22,
-	0x00, 0x12, 0x34, 0x0a, 0x0e, 0x47, 0x65, 0x74, 0x53, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x42,
-	0x79, 0x49, 0x44, 0x12, 0x10, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x74, 0x61, 0x74,
-	0x69, 0x6f, 0x6e, 0x49, 0x44, 0x1a, 0x0e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x74,
-	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x00, 0x12, 0x36, 0x0a, 0x0e, 0x47, 0x65, 0x74, 0x41, 0x6c,
-	0x6c, 0x53, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x0e, 0x2e, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x12, 0x2e, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4c, 0x69, 0x73, 0x74, 0x22, 0x00, 0x42,
-	0x0a, 0x5a, 0x08, 0x2e, 0x2f, 0x3b, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x06, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x33,
+	0x44, 0x1a, 0x1a, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x63, 0x6f, 0x6f, 0x74, 0x65,
+	0x72, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x49, 0x6e, 0x52, 0x65, 0x6e, 0x74, 0x22, 0x00, 0x12,
+	0x34, 0x0a, 0x0e, 0x47, 0x65, 0x74, 0x53, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x42, 0x79, 0x49,
+	0x44, 0x12, 0x10, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x49, 0x44, 0x1a, 0x0e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x74, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x22, 0x00, 0x12, 0x36, 0x0a, 0x0e, 0x47, 0x65, 0x74, 0x41, 0x6c, 0x6c, 0x53,
+	0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x0e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x12, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
+	0x53, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4c, 0x69, 0x73, 0x74, 0x22, 0x00, 0x42, 0x0a, 0x5a,
+	0x08, 0x2e, 0x2f, 0x3b, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x33,
 }
 
 var (
@@ -1074,7 +1343,7 @@ func file_scooter_micro_proto_rawDescGZIP() []byte {
 	return file_scooter_micro_proto_rawDescData
 }
 
-var file_scooter_micro_proto_msgTypes = make([]protoimpl.MessageInfo, 15)
+var file_scooter_micro_proto_msgTypes = make([]protoimpl.MessageInfo, 17)
 var file_scooter_micro_proto_goTypes = []interface{}{
 	(*Request)(nil),               // 0: proto.Request
 	(*Response)(nil),              // 1: proto.Response
@@ -1082,47 +1351,51 @@ var file_scooter_micro_proto_goTypes = []interface{}{
 	(*StationList)(nil),           // 3: proto.StationList
 	(*Scooter)(nil),               // 4: proto.Scooter
 	(*ScooterClient)(nil),         // 5: proto.ScooterClient
-	(*ScooterList)(nil),           // 6: proto.ScooterList
-	(*ScooterID)(nil),             // 7: proto.ScooterID
-	(*StationID)(nil),             // 8: proto.StationID
-	(*ScooterStatus)(nil),         // 9: proto.ScooterStatus
-	(*SendStatus)(nil),            // 10: proto.SendStatus
-	(*ScooterStatusInRent)(nil),   // 11: proto.ScooterStatusInRent
-	(*ClientRequest)(nil),         // 12: proto.ClientRequest
-	(*ClientMessage)(nil),         // 13: proto.ClientMessage
-	(*ServerMessage)(nil),         // 14: proto.ServerMessage
-	(*timestamppb.Timestamp)(nil), // 15: google.protobuf.Timestamp
+	(*Point)(nil),                 // 6: proto.Point
+	(*Zone)(nil),                  // 7: proto.Zone
+	(*ScooterList)(nil),           // 8: proto.ScooterList
+	(*ScooterID)(nil),             // 9: proto.ScooterID
+	(*StationID)(nil),             // 10: proto.StationID
+	(*ScooterStatus)(nil),         // 11: proto.ScooterStatus
+	(*SendStatus)(nil),            // 12: proto.SendStatus
+	(*ScooterStatusInRent)(nil),   // 13: proto.ScooterStatusInRent
+	(*ClientRequest)(nil),         // 14: proto.ClientRequest
+	(*ClientMessage)(nil),         // 15: proto.ClientMessage
+	(*ServerMessage)(nil),         // 16: proto.ServerMessage
+	(*timestamppb.Timestamp)(nil), // 17: google.protobuf.Timestamp
 }
 var file_scooter_micro_proto_depIdxs = []int32{
 	2,  // 0: proto.StationList.stations:type_name -> proto.Station
-	4,  // 1: proto.ScooterList.scooters:type_name -> proto.Scooter
-	8,  // 2: proto.ScooterStatus.stationID:type_name -> proto.StationID
-	15, // 3: proto.ScooterStatusInRent.dateTime:type_name -> google.protobuf.Timestamp
-	13, // 4: proto.ScooterService.Register:input_type -> proto.ClientMessage
-	13, // 5: proto.ScooterService.Receive:input_type -> proto.ClientMessage
-	0,  // 6: proto.ScooterService.GetAllScooters:input_type -> proto.Request
-	8,  // 7: proto.ScooterService.GetAllScootersByStationID:input_type -> proto.StationID
-	7,  // 8: proto.ScooterService.GetScooterById:input_type -> proto.ScooterID
-	7,  // 9: proto.ScooterService.GetScooterStatus:input_type -> proto.ScooterID
-	10, // 10: proto.ScooterService.SendCurrentStatus:input_type -> proto.SendStatus
-	7,  // 11: proto.ScooterService.CreateScooterStatusInRent:input_type -> proto.ScooterID
-	8,  // 12: proto.ScooterService.GetStationByID:input_type -> proto.StationID
-	0,  // 13: proto.ScooterService.GetAllStations:input_type -> proto.Request
-	5,  // 14: proto.ScooterService.Register:output_type -> proto.ScooterClient
-	14, // 15: proto.ScooterService.Receive:output_type -> proto.ServerMessage
-	6,  // 16: proto.ScooterService.GetAllScooters:output_type -> proto.ScooterList
-	6,  // 17: proto.ScooterService.GetAllScootersByStationID:output_type -> proto.ScooterList
-	4,  // 18: proto.ScooterService.GetScooterById:output_type -> proto.Scooter
-	9,  // 19: proto.ScooterService.GetScooterStatus:output_type -> proto.ScooterStatus
-	1,  // 20: proto.ScooterService.SendCurrentStatus:output_type -> proto.Response
-	11, // 21: proto.ScooterService.CreateScooterStatusInRent:output_type -> proto.ScooterStatusInRent
-	2,  // 22: proto.ScooterService.GetStationByID:output_type -> proto.Station
-	3,  // 23: proto.ScooterService.GetAllStations:output_type -> proto.StationList
-	14, // [14:24] is the sub-list for method output_type
-	4,  // [4:14] is the sub-list for method input_type
-	4,  // [4:4] is the sub-list for extension type_name
-	4,  // [4:4] is the sub-list for extension extendee
-	0,  // [0:4] is the sub-list for field type_name
+	7,  // 1: proto.ScooterClient.zones:type_name -> proto.Zone
+	6,  // 2: proto.Zone.area:type_name -> proto.Point
+	4,  // 3: proto.ScooterList.scooters:type_name -> proto.Scooter
+	10, // 4: proto.ScooterStatus.stationID:type_name -> proto.StationID
+	17, // 5: proto.ScooterStatusInRent.dateTime:type_name -> google.protobuf.Timestamp
+	15, // 6: proto.ScooterService.Register:input_type -> proto.ClientMessage
+	15, // 7: proto.ScooterService.Receive:input_type -> proto.ClientMessage
+	0,  // 8: proto.ScooterService.GetAllScooters:input_type -> proto.Request
+	10, // 9: proto.ScooterService.GetAllScootersByStationID:input_type -> proto.StationID
+	9,  // 10: proto.ScooterService.GetScooterById:input_type -> proto.ScooterID
+	9,  // 11: proto.ScooterService.GetScooterStatus:input_type -> proto.ScooterID
+	12, // 12: proto.ScooterService.SendCurrentStatus:input_type -> proto.SendStatus
+	9,  // 13: proto.ScooterService.CreateScooterStatusInRent:input_type -> proto.ScooterID
+	10, // 14: proto.ScooterService.GetStationByID:input_type -> proto.StationID
+	0,  // 15: proto.ScooterService.GetAllStations:input_type -> proto.Request
+	5,  // 16: proto.ScooterService.Register:output_type -> proto.ScooterClient
+	16, // 17: proto.ScooterService.Receive:output_type -> proto.ServerMessage
+	8,  // 18: proto.ScooterService.GetAllScooters:output_type -> proto.ScooterList
+	8,  // 19: proto.ScooterService.GetAllScootersByStationID:output_type -> proto.ScooterList
+	4,  // 20: proto.ScooterService.GetScooterById:output_type -> proto.Scooter
+	11, // 21: proto.ScooterService.GetScooterStatus:output_type -> proto.ScooterStatus
+	1,  // 22: proto.ScooterService.SendCurrentStatus:output_type -> proto.Response
+	13, // 23: proto.ScooterService.CreateScooterStatusInRent:output_type -> proto.ScooterStatusInRent
+	2,  // 24: proto.ScooterService.GetStationByID:output_type -> proto.Station
+	3,  // 25: proto.ScooterService.GetAllStations:output_type -> proto.StationList
+	16, // [16:26] is the sub-list for method output_type
+	6,  // [6:16] is the sub-list for method input_type
+	6,  // [6:6] is the sub-list for extension type_name
+	6,  // [6:6] is the sub-list for extension extendee
+	0,  // [0:6] is the sub-list for field type_name
 }
 
 func init() { file_scooter_micro_proto_init() }
@@ -1204,7 +1477,7 @@ func file_scooter_micro_proto_init() {
 			}
 		}
 		file_scooter_micro_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ScooterList); i {
+			switch v := v.(*Point); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1216,7 +1489,7 @@ func file_scooter_micro_proto_init() {
 			}
 		}
 		file_scooter_micro_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ScooterID); i {
+			switch v := v.(*Zone); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1228,7 +1501,7 @@ func file_scooter_micro_proto_init() {
 			}
 		}
 		file_scooter_micro_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*StationID); i {
+			switch v := v.(*ScooterList); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1240,7 +1513,7 @@ func file_scooter_micro_proto_init() {
 			}
 		}
 		file_scooter_micro_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ScooterStatus); i {
+			switch v := v.(*ScooterID); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1252,7 +1525,7 @@ func file_scooter_micro_proto_init() {
 			}
 		}
 		file_scooter_micro_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SendStatus); i {
+			switch v := v.(*StationID); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1264,7 +1537,7 @@ func file_scooter_micro_proto_init() {
 			}
 		}
 		file_scooter_micro_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ScooterStatusInRent); i {
+			switch v := v.(*ScooterStatus); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1276,7 +1549,7 @@ func file_scooter_micro_proto_init() {
 			}
 		}
 		file_scooter_micro_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ClientRequest); i {
+			switch v := v.(*SendStatus); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1288,7 +1561,7 @@ func file_scooter_micro_proto_init() {
 			}
 		}
 		file_scooter_micro_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ClientMessage); i {
+			switch v := v.(*ScooterStatusInRent); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1300,6 +1573,30 @@ func file_scooter_micro_proto_init() {
 			}
 		}
 		file_scooter_micro_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ClientRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_scooter_micro_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ClientMessage); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_scooter_micro_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*ServerMessage); i {
 			case 0:
 				return &v.state
@@ -1318,7 +1615,7 @@ func file_scooter_micro_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_scooter_micro_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   15,
+			NumMessages:   17,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -3,46 +3,135 @@ package service
 import (
 	"ScooterClient/model"
 	"ScooterClient/proto"
+	"context"
 	"fmt"
+	"math"
+	"shared.micro/geo"
+	"sync"
 	"time"
 )
 
-const (
-	step          = 0.0001
-	dischargeStep = 0.1
-	interval      = 450
-)
+//defaultTick is the interval between two scooter positions if the tick isn't configured.
+const defaultTick = 450 * time.Millisecond
+
+//Stream is the Register stream which is shared by all the scooters moved by the client.
+//gRPC doesn't allow concurrent sends to the stream, so they are serialized.
+type Stream struct {
+	mu     sync.Mutex
+	stream proto.ScooterService_RegisterClient
+}
+
+//NewStream wraps the Register stream.
+func NewStream(stream proto.ScooterService_RegisterClient) *Stream {
+	return &Stream{stream: stream}
+}
+
+//Send sends the scooter message to the server.
+func (s *Stream) Send(msg *proto.ClientMessage) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.stream.Send(msg)
+}
+
+//Recv receives the next trip command from the server.
+func (s *Stream) Recv() (*proto.ScooterClient, error) {
+	return s.stream.Recv()
+}
+
+//Fleet moves the scooters by the trip commands of the server. Every scooter is moved in its own goroutine,
+//so the client runs many trips at the same time. A command for the scooter which is moving now is ignored.
+type Fleet struct {
+	mu      sync.Mutex
+	stream  *Stream
+	tick    time.Duration
+	running map[uint64]bool
+}
+
+//NewFleet creates a new Fleet which sends the scooter messages to the given stream every tick.
+func NewFleet(stream *Stream, tick time.Duration) *Fleet {
+	return &Fleet{
+		stream:  stream,
+		tick:    tick,
+		running: make(map[uint64]bool),
+	}
+}
+
+//Start starts moving the scooter to the destination of the command. It returns false if the scooter is moving.
+//The scooter is stopped when ctx is done.
+func (f *Fleet) Start(ctx context.Context, command *proto.ScooterClient) bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.running[command.Id] {
+		return false
+	}
+	f.running[command.Id] = true
+
+	go func() {
+		scooter := NewScooterClient(command, f.stream, f.tick)
+		err := scooter.Run(ctx, model.Location{Latitude: command.DestLatitude, Longitude: command.DestLongitude})
+		if err != nil {
+			fmt.Println(err)
+		}
+
+		err = scooter.Stop()
+		if err != nil {
+			fmt.Println(err)
+		}
+
+		f.mu.Lock()
+		delete(f.running, command.Id)
+		f.mu.Unlock()
+	}()
+
+	return true
+}
 
 //ScooterClient is a struct with parameters which will be translated by the gRPC connection.
 type ScooterClient struct {
 	ID            uint64
 	Latitude      float64
 	Longitude     float64
-	BatteryRemain float64
-	Stream        proto.ScooterService_RegisterClient
+	BatteryRemain   float64
+	Speed           float64
+	DischargePerKm  float64
+	LowBatteryLevel float64
+	tick            time.Duration
+	Stream          *Stream
+	zones           []*proto.Zone
 }
 
-//NewScooterClient creates a new GrpcScooterClient with given parameters.
-func NewScooterClient(id uint64, latitude, longitude, battery float64,
-	stream proto.ScooterService_RegisterClient) *ScooterClient {
+//NewScooterClient creates a new GrpcScooterClient by the trip command of the server. The speed is given in km/h,
+//the positions are sent every tick.
+func NewScooterClient(command *proto.ScooterClient, stream *Stream, tick time.Duration) *ScooterClient {
+	if tick <= 0 {
+		tick = defaultTick
+	}
+
 	return &ScooterClient{
-		ID:            id,
-		Latitude:      latitude,
-		Longitude:     longitude,
-		BatteryRemain: battery,
-		Stream:        stream,
+		ID:              command.Id,
+		Latitude:        command.Latitude,
+		Longitude:       command.Longitude,
+		BatteryRemain:   command.BatteryRemain,
+		Speed:           command.Speed,
+		DischargePerKm:  command.DischargePerKm,
+		LowBatteryLevel: command.LowBatteryLevel,
+		tick:            tick,
+		Stream:          stream,
+		zones:           command.Zones,
 	}
 }
 
 //GrpcScooterMessage sends the message be gRPC stream in a format which defined in the *proto file.
-func (s *ScooterClient) GrpcScooterMessage() {
-	intPol := time.Duration(interval) * time.Millisecond
-
+//lowBattery tells that the battery has just gone below the low level of the scooter model.
+func (s *ScooterClient) GrpcScooterMessage(lowBattery bool) {
 	fmt.Println("executing run in client")
 	msg := proto.ClientMessage{
-		Id:        s.ID,
-		Latitude:  s.Latitude,
-		Longitude: s.Longitude,
+		Id:            s.ID,
+		Latitude:      s.Latitude,
+		Longitude:     s.Longitude,
+		BatteryRemain: s.BatteryRemain,
+		LowBattery:    lowBattery,
 	}
 
 	fmt.Printf("Send to server this message: %v\n", &msg)
@@ -50,77 +139,58 @@ func (s *ScooterClient) GrpcScooterMessage() {
 	if err != nil {
 		fmt.Println(err)
 	}
-	time.Sleep(intPol)
 }
 
 //Run is responsible for scooter's movements from his current position to the destination point.
-//Run also is responsible for scooter's discharge. Every step battery charge decrease by the constant discharge value.
-func (s *ScooterClient) Run(station model.Location) error {
-
-	switch {
-	case s.Latitude <= station.Latitude && s.Longitude <= station.Longitude:
-		for ; s.Latitude <= station.Latitude && s.Longitude <= station.Longitude && s.
-			BatteryRemain > 0; s.
-			Latitude,
-			s.Longitude, s.BatteryRemain = s.Latitude+step, s.Longitude+step,
-			s.BatteryRemain-dischargeStep {
-			s.GrpcScooterMessage()
-		}
-		fallthrough
-	case s.Latitude >= station.Latitude && s.Longitude <= station.Longitude:
-		for ; s.Latitude >= station.Latitude && s.Longitude <= station.Longitude && s.
-			BatteryRemain > 0; s.Latitude,
-			s.Longitude, s.BatteryRemain = s.Latitude-step, s.Longitude+step,
-			s.BatteryRemain-dischargeStep {
-			s.GrpcScooterMessage()
-		}
-		fallthrough
-	case s.Latitude >= station.Latitude && s.Longitude >= station.Longitude:
-		for ; s.Latitude >= station.Latitude && s.Longitude >= station.Longitude && s.
-			BatteryRemain > 0; s.Latitude,
-			s.Longitude, s.BatteryRemain = s.Latitude-step, s.Longitude-step,
-			s.BatteryRemain-dischargeStep {
-			s.GrpcScooterMessage()
-		}
-		fallthrough
-	case s.Latitude <= station.Latitude && s.Longitude >= station.Longitude:
-		for ; s.Latitude <= station.Latitude && s.Longitude >= station.Longitude && s.
-			BatteryRemain > 0; s.Latitude,
-			s.Longitude, s.BatteryRemain = s.Latitude+step, s.Longitude-step,
-			s.BatteryRemain-dischargeStep {
-			s.GrpcScooterMessage()
-		}
-		fallthrough
-	case s.Latitude <= station.Latitude:
-		for ; s.Latitude <= station.Latitude && s.
-			BatteryRemain > 0; s.Latitude, s.BatteryRemain = s.Latitude+step,
-			s.BatteryRemain-dischargeStep {
-			s.GrpcScooterMessage()
-		}
-		fallthrough
-	case s.Latitude >= station.Latitude:
-		for ; s.Latitude >= station.Latitude && s.
-			BatteryRemain > 0; s.Latitude, s.BatteryRemain = s.Latitude-step,
-			s.BatteryRemain-dischargeStep {
-			s.GrpcScooterMessage()
-		}
-		fallthrough
-	case s.Longitude >= station.Longitude:
-		for ; s.Longitude >= station.Longitude && s.
-			BatteryRemain > 0; s.Longitude, s.BatteryRemain = s.Longitude-step,
-			s.BatteryRemain-dischargeStep {
-			s.GrpcScooterMessage()
+//The scooter goes along the great-circle route at its model's speed and its position is sent every tick.
+//The zones of the command are kept: in a slow zone the speed is limited by the zone, the scooter which is going
+//to enter an out-of-service zone is stopped at its border.
+//Run also is responsible for scooter's discharge: the battery charge decreases for every passed kilometer
+//by the consumption of the scooter model. The scooter stops where it is when ctx is done.
+func (s *ScooterClient) Run(ctx context.Context, station model.Location) error {
+	if s.Speed <= 0 {
+		return fmt.Errorf("scooter %v has unknown speed", s.ID)
+	}
+
+	route := geo.NewRoute(model.Location{Latitude: s.Latitude, Longitude: s.Longitude}, station)
+
+	ticker := time.NewTicker(s.tick)
+	defer ticker.Stop()
+
+	for passed := 0.0; passed < route.Distance() && s.BatteryRemain > 0; {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
 		}
-		fallthrough
-	case s.Longitude <= station.Longitude:
-		for ; s.Longitude <= station.Longitude && s.
-			BatteryRemain > 0; s.Longitude, s.BatteryRemain = s.Longitude+step,
-			s.BatteryRemain-dischargeStep {
-			s.GrpcScooterMessage()
+
+		current := model.Location{Latitude: s.Latitude, Longitude: s.Longitude}
+		step := geo.StepLength(speedLimit(s.zones, current, s.Speed), s.tick)
+		move := math.Min(step, route.Distance()-passed)
+		position := route.PointAt(passed + move)
+		if zone, ok := zoneAt(s.zones, position, zoneOutOfService); ok {
+			return fmt.Errorf("scooter %v is stopped at the border of %q zone", s.ID, zone.Name)
 		}
-	default:
-		return fmt.Errorf("error happened")
+
+		passed += move
+		s.Latitude, s.Longitude = position.Latitude, position.Longitude
+
+		wasLow := s.BatteryRemain <= s.LowBatteryLevel
+		s.BatteryRemain = math.Max(s.BatteryRemain-move/1000*s.DischargePerKm, 0)
+
+		s.GrpcScooterMessage(!wasLow && s.BatteryRemain <= s.LowBatteryLevel)
 	}
-	s.ID = 0
+
 	return nil
 }
+
+//Stop tells the server that the scooter stopped. The message keeps the position and the battery of the scooter.
+func (s *ScooterClient) Stop() error {
+	return s.Stream.Send(&proto.ClientMessage{
+		Id:            s.ID,
+		Latitude:      s.Latitude,
+		Longitude:     s.Longitude,
+		BatteryRemain: s.BatteryRemain,
+		Finished:      true,
+	})
+}
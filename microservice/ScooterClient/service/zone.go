@@ -0,0 +1,42 @@
+package service
+
+import (
+	"ScooterClient/model"
+	"ScooterClient/proto"
+	"shared.micro/geo"
+)
+
+//The kinds of the zones whose rules are kept by the moving scooter.
+const (
+	zoneSlow         = "slow"
+	zoneOutOfService = "out_of_service"
+)
+
+//contains reports whether the point is inside the zone area.
+func contains(zone *proto.Zone, point model.Location) bool {
+	area := make([]geo.Point, len(zone.Area))
+	for i, vertex := range zone.Area {
+		area[i] = geo.Point{Latitude: vertex.Latitude, Longitude: vertex.Longitude}
+	}
+	return geo.Contains(area, point)
+}
+
+//zoneAt returns the first zone of the kind which contains the point.
+func zoneAt(zones []*proto.Zone, point model.Location, kind string) (*proto.Zone, bool) {
+	for _, zone := range zones {
+		if zone.Kind == kind && contains(zone, point) {
+			return zone, true
+		}
+	}
+	return nil, false
+}
+
+//speedLimit returns the speed (km/h) which is allowed at the point for the scooter with the given max speed.
+func speedLimit(zones []*proto.Zone, point model.Location, speed float64) float64 {
+	for _, zone := range zones {
+		if zone.Kind == zoneSlow && zone.SpeedLimit > 0 && zone.SpeedLimit < speed && contains(zone, point) {
+			speed = zone.SpeedLimit
+		}
+	}
+	return speed
+}
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"ScooterServer/config"
 	"ScooterServer/proto"
 	"ScooterServer/repository"
 	"ScooterServer/routing"
@@ -9,70 +10,54 @@ import (
 	"ScooterServer/service"
 	"context"
 	"database/sql"
-	"fmt"
+	"flag"
 	_ "github.com/lib/pq"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/reflection"
 	"log"
 	"net"
 	"net/http"
-	"os"
 )
 
-var scooterIdMap = make(map[uint64]proto.ScooterService_RegisterServer)
-var StructCh = make(chan *proto.ScooterClient)
-
 func main() {
 	log.Println("Starting scooter microservice")
-	//connectionString := fmt.Sprintf("host=%s port=%s user=%s password=%s dbname=%s sslmode=disable",
-	connectionString := fmt.Sprintf("postgres://%v:%v@%v:%v/%v?sslmode=disable",
-		os.Getenv("POSTGRES_USER"),
-		os.Getenv("POSTGRES_PASSWORD"),
-		os.Getenv("PG_HOST"),
-		os.Getenv("PG_PORT"),
-		os.Getenv("POSTGRES_DB"))
+	configFile := flag.String("config", "", "path to KEY=VALUE configuration file, environment overrides it")
+	flag.Parse()
 
-	db, err := sql.Open("postgres", connectionString)
+	cfg, err := config.Load(*configFile)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	db, err := sql.Open("postgres", cfg.Postgres.ConnectionString())
 	if err != nil {
 		log.Panicf("%s: failed to open db connection - %v", "scooter_micro", err)
 	}
 	defer db.Close()
 
 	scooterRepo := repository.NewScooterRepo(db)
-	conn, err := grpc.DialContext(context.Background(), net.JoinHostPort("", os.Getenv("ORDER_GRPC_PORT")),
+	conn, err := grpc.DialContext(context.Background(), net.JoinHostPort("", cfg.OrderPort),
 		grpc.WithInsecure())
 	if err != nil {
-		log.Printf("gRPC connection to %v port failed. With: %v\n", os.Getenv("ORDER_GRPC_PORT"), err)
+		log.Printf("gRPC connection to %v port failed. With: %v\n", cfg.OrderPort, err)
 	}
 
-	log.Printf("gRPC connected port: %v.", os.Getenv("ORDER_GRPC_PORT"))
+	log.Printf("gRPC connected port: %v.", cfg.OrderPort)
 
 	orderClient := proto.NewOrderServiceClient(conn)
-	scooterService := service.NewScooterService(scooterRepo, orderClient)
-	scooterList, err := scooterService.GetAllScooters(context.Background(), &proto.Request{})
-	if err != nil {
-		fmt.Println(err)
-	}
+	sessions := service.NewSessionRegistry()
+	scooterService := service.NewScooterService(scooterRepo, orderClient, sessions,
+		net.JoinHostPort("", cfg.GRPCPort), cfg.ScooterTick)
 
-	handler := routing.NewRouter(scooterService, StructCh)
+	handler := routing.NewRouter(scooterService, cfg.TemplatesPath)
 
-	httpServer := httpserver.New(handler, StructCh, httpserver.Port("8085"))
-	handler.HandleFunc("/scooter", httpServer.ScooterHandler)
+	httpServer := httpserver.New(handler, sessions, httpserver.Port(cfg.HTTPPort),
+		httpserver.Users(routing.UserFromRequest), httpserver.Warnings(scooterService))
+	handler.Handle("/scooter", routing.Authenticated(scooterService, httpServer.ScooterHandler))
 
-	getIdFromStructInArray(scooterList, httpServer.ScooterIdMap)
-	grpcServer := grpcserver.NewGrpcServer()
+	grpcServer := grpcserver.NewGrpcServer(cfg.GRPCPort)
 	proto.RegisterScooterServiceServer(grpcServer, httpServer)
 	reflection.Register(grpcServer)
 
-	http.ListenAndServe(":8085", handler)
-}
-
-func getIdFromStructInArray(from *proto.ScooterList,
-	to map[uint64]proto.ScooterService_RegisterServer) map[uint64]proto.ScooterService_RegisterServer {
-	for _, v := range from.Scooters {
-		for i := 0; i < len(from.Scooters); i++ {
-			to[v.Id] = nil
-		}
-	}
-	return to
+	http.ListenAndServe(net.JoinHostPort("", cfg.HTTPPort), handler)
 }
@@ -1,14 +1,71 @@
 package config
 
 import (
+	"fmt"
 	"os"
+	"shared.micro/envconfig"
+	"time"
 )
 
-var PG_HOST = os.Getenv("PG_HOST")
-var PG_PORT = os.Getenv("PG_PORT")
-var POSTGRES_DB = os.Getenv("POSTGRES_DB")
-var POSTGRES_USER = os.Getenv("POSTGRES_USER")
-var POSTGRES_PASSWORD = os.Getenv("POSTGRES_PASSWORD")
-var GRPC_PORT = os.Getenv("GRPC_PORT")
-var ORDER_GRPC_PORT = os.Getenv("ORDER_GRPC_PORT")
-var MONO_TEMPLATES_PATH = os.Getenv("MONO_TEMPLATES_PATH")
+//FileEnv is the environment variable with the path of an optional KEY=VALUE configuration file.
+const FileEnv = "CONFIG_FILE"
+
+//Config is the configuration of the scooter server.
+type Config struct {
+	Postgres Postgres
+	//HTTPPort is the port of the trip pages and the scooter positions stream.
+	HTTPPort string
+	//GRPCPort is the port of the gRPC server which the scooter clients connect to.
+	GRPCPort string
+	//OrderPort is the port of the order service.
+	OrderPort string
+	//TemplatesPath is the directory with the html templates.
+	TemplatesPath string
+	//ScooterTick is the interval between two positions sent by a moving scooter.
+	ScooterTick time.Duration
+}
+
+//Postgres is the database connection settings.
+type Postgres struct {
+	Host     string
+	Port     string
+	DB       string
+	User     string
+	Password string
+}
+
+//ConnectionString returns the postgres connection url of the database.
+func (p Postgres) ConnectionString() string {
+	return fmt.Sprintf("postgres://%v:%v@%v:%v/%v?sslmode=disable", p.User, p.Password, p.Host, p.Port, p.DB)
+}
+
+//Load reads the configuration from the environment and the file at path (if not empty),
+//the environment overrides the file. All missing or malformed values are reported in one error.
+func Load(path string) (*Config, error) {
+	if path == "" {
+		path = os.Getenv(FileEnv)
+	}
+	l, err := envconfig.New(path)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := &Config{
+		Postgres: Postgres{
+			Host:     l.Required("PG_HOST"),
+			Port:     l.Str("PG_PORT", "5432"),
+			DB:       l.Required("POSTGRES_DB"),
+			User:     l.Required("POSTGRES_USER"),
+			Password: l.Required("POSTGRES_PASSWORD"),
+		},
+		HTTPPort:      l.Str("SCOOTER_HTTP_PORT", "8085"),
+		GRPCPort:      l.Str("GRPC_PORT", "9000"),
+		OrderPort:     l.Str("ORDER_GRPC_PORT", "9999"),
+		TemplatesPath: l.Required("MONO_TEMPLATES_PATH"),
+		ScooterTick:   l.Duration("SCOOTER_TICK", 450*time.Millisecond),
+	}
+	if err = l.Err(); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
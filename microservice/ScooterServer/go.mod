@@ -2,6 +2,7 @@ module ScooterServer
 
 go 1.17
 
+replace shared.micro => ../Shared
 
 //replace scooter_client => ../scooter_client/
 
@@ -10,6 +11,7 @@ require (
 	github.com/lib/pq v1.10.4
 	google.golang.org/grpc v1.43.0
 	google.golang.org/protobuf v1.27.1
+	shared.micro v0.0.0-00010101000000-000000000000
 )
 
 require (
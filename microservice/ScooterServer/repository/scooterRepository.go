@@ -4,9 +4,12 @@ import (
 	"ScooterServer/proto"
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"google.golang.org/protobuf/types/known/timestamppb"
 	"log"
+	"strconv"
+	"strings"
 	"time"
 )
 
@@ -20,8 +23,14 @@ type ScooterRepository interface {
 	CreateScooterStatusInRent(context context.Context, id *proto.ScooterID) (*proto.ScooterStatusInRent, error)
 	GetStationById(ctx context.Context, id *proto.StationID) (*proto.Station, error)
 	GetAllStations(ctx context.Context, request *proto.Request) (*proto.StationList, error)
+	HasRoom(ctx context.Context, stationID *proto.StationID, scooterID *proto.ScooterID) (bool, error)
+	GetUserIDByAccessToken(ctx context.Context, accessHash string) (uint64, error)
+	GetActiveZones(ctx context.Context) ([]*proto.Zone, error)
 }
 
+//ErrStationFull is returned when the station has no free slot left for the arrived scooter.
+var ErrStationFull = errors.New("station has no free slots")
+
 type ScooterRepo struct {
 	db *sql.DB
 }
@@ -34,7 +43,8 @@ func NewScooterRepo(db *sql.DB) *ScooterRepo {
 func (scr *ScooterRepo) GetAllScooters(ctx context.Context, request *proto.Request) (*proto.ScooterList, error) {
 	scooterList := &proto.ScooterList{}
 
-	querySQL := `SELECT s.id, sm.max_weight, sm.model_name, ss.battery_remain, ss.can_be_rent
+	querySQL := `SELECT s.id, sm.max_weight, sm.model_name, ss.battery_remain, ss.can_be_rent,
+					sm.battery_capacity, sm.consumption_per_km, sm.low_battery_level
 					FROM scooters as s 
 					JOIN scooter_models as sm 
 					ON s.model_id=sm.id 
@@ -56,7 +66,7 @@ func (scr *ScooterRepo) GetAllScooters(ctx context.Context, request *proto.Reque
 	for rows.Next() {
 		var scooter proto.Scooter
 		err := rows.Scan(&scooter.Id, &scooter.MaxWeight, &scooter.ScooterModel, &scooter.BatteryRemain,
-			&scooter.CanBeRent)
+			&scooter.CanBeRent, &scooter.BatteryCapacity, &scooter.ConsumptionPerKm, &scooter.LowBatteryLevel)
 		if err != nil {
 			fmt.Println(err)
 			return nil, err
@@ -70,7 +80,7 @@ func (scr *ScooterRepo) GetAllScooters(ctx context.Context, request *proto.Reque
 func (scr *ScooterRepo) GetAllStations(ctx context.Context, request *proto.Request) (*proto.StationList, error) {
 	stationList := &proto.StationList{}
 
-	querySQL := `SELECT * FROM scooter_stations ORDER BY id;`
+	querySQL := `SELECT id, name, is_active, latitude, longitude FROM scooter_stations WHERE deleted_at IS NULL ORDER BY id;`
 	rows, err := scr.db.QueryContext(ctx, querySQL)
 	if err != nil {
 		return nil, err
@@ -133,7 +143,8 @@ func (scr *ScooterRepo) GetAllScootersByStationID(ctx context.Context, id *proto
 //GetScooterById returns exact scooter by its ID.
 func (scr *ScooterRepo) GetScooterById(ctx context.Context, id *proto.ScooterID) (*proto.Scooter, error) {
 	scooter := &proto.Scooter{}
-	querySQL := `SELECT s.id, sm.max_weight, sm.model_name, ss.battery_remain, ss.can_be_rent
+	querySQL := `SELECT s.id, sm.max_weight, sm.speed, sm.model_name, ss.battery_remain, ss.can_be_rent,
+					sm.battery_capacity, sm.consumption_per_km, sm.low_battery_level
 					FROM scooters as s 
 					JOIN scooter_models as sm 
 					ON s.model_id=sm.id 
@@ -142,7 +153,8 @@ func (scr *ScooterRepo) GetScooterById(ctx context.Context, id *proto.ScooterID)
 					WHERE s.id=$1`
 
 	row := scr.db.QueryRowContext(ctx, querySQL, id.Id)
-	err := row.Scan(&scooter.Id, &scooter.MaxWeight, &scooter.ScooterModel, &scooter.BatteryRemain, &scooter.CanBeRent)
+	err := row.Scan(&scooter.Id, &scooter.MaxWeight, &scooter.Speed, &scooter.ScooterModel, &scooter.BatteryRemain,
+		&scooter.CanBeRent, &scooter.BatteryCapacity, &scooter.ConsumptionPerKm, &scooter.LowBatteryLevel)
 	if err != nil {
 		return nil, err
 	}
@@ -154,7 +166,7 @@ func (scr *ScooterRepo) GetScooterById(ctx context.Context, id *proto.ScooterID)
 func (scr *ScooterRepo) GetStationById(ctx context.Context, id *proto.StationID) (*proto.Station, error) {
 	station := &proto.Station{}
 
-	querySQL := `SELECT * FROM scooter_stations WHERE id = $1`
+	querySQL := `SELECT id, name, is_active, latitude, longitude FROM scooter_stations WHERE id = $1 AND deleted_at IS NULL`
 	row := scr.db.QueryRowContext(ctx, querySQL, int(id.Id))
 	err := row.Scan(&station.Id, &station.Name, &station.IsActive, &station.Latitude, &station.Longitude)
 	if err != nil {
@@ -215,26 +227,134 @@ func (scr *ScooterRepo) CreateScooterStatusInRent(ctx context.Context,
 	return &scooterStatusInRent, nil
 }
 
-//SendCurrentStatus updates ScooterStatus with given parameters.
+//SendCurrentStatus updates ScooterStatus with given parameters. The scooter can be rented again
+//if its battery is above the low level of its model. The scooter is docked only if the station still has
+//a free slot, otherwise it's left out of the stations and ErrStationFull is returned. The station row is locked
+//so concurrent trips can't overfill it.
 func (scr *ScooterRepo) SendCurrentStatus(ctx context.Context, status *proto.SendStatus) (*proto.Response, error) {
-	var canBeRent bool
-	if status.BatteryRemain > 10 {
-		canBeRent = true
+	tx, err := scr.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
 	}
+	defer tx.Rollback()
 
-	querySQL := `UPDATE scooter_statuses 
-					SET latitude=$1, longitude=$2, battery_remain=$3, can_be_rent=$4, station_id=$5
-					WHERE scooter_id=$6`
+	if status.StationID != 0 {
+		_, err = tx.ExecContext(ctx, `SELECT id FROM scooter_stations WHERE id=$1 FOR UPDATE`, status.StationID)
+		if err != nil {
+			return nil, err
+		}
+	}
 
-	rows, err := scr.db.QueryContext(ctx, querySQL, status.Latitude, status.Longitude,
+	querySQL := `UPDATE scooter_statuses AS ss
+					SET latitude=$1, longitude=$2, battery_remain=$3, can_be_rent=$3 > sm.low_battery_level,
+					station_id=CASE WHEN st.capacity > (SELECT COUNT(*) FROM scooter_statuses
+						WHERE station_id=st.id AND scooter_id<>ss.scooter_id) THEN st.id END
+					FROM scooters AS s
+					JOIN scooter_models AS sm
+					ON s.model_id=sm.id
+					LEFT JOIN scooter_stations AS st
+					ON st.id=NULLIF($4::int, 0) AND st.deleted_at IS NULL
+					WHERE ss.scooter_id=s.id AND ss.scooter_id=$5
+					RETURNING COALESCE(ss.station_id, 0)`
+
+	var docked uint64
+	err = tx.QueryRowContext(ctx, querySQL, status.Latitude, status.Longitude,
 		status.BatteryRemain,
-		canBeRent,
-		status.StationID, status.ScooterID)
+		status.StationID, status.ScooterID).Scan(&docked)
+	if err != nil {
+		return nil, err
+	}
+	if err = tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	if docked != status.StationID {
+		return &proto.Response{}, ErrStationFull
+	}
+	return &proto.Response{}, nil
+}
+
+//HasRoom tells whether the station has a free slot for the scooter. The scooter which is already docked there
+//keeps its slot.
+func (scr *ScooterRepo) HasRoom(ctx context.Context, stationID *proto.StationID, scooterID *proto.ScooterID) (bool, error) {
+	var hasRoom bool
+	querySQL := `SELECT st.capacity > (SELECT COUNT(*) FROM scooter_statuses
+					WHERE station_id = st.id AND scooter_id <> $2)
+					FROM scooter_stations AS st
+					WHERE st.id = $1 AND st.deleted_at IS NULL`
+
+	err := scr.db.QueryRowContext(ctx, querySQL, int(stationID.Id), int(scooterID.Id)).Scan(&hasRoom)
+	return hasRoom, err
+}
+
+//GetUserIDByAccessToken returns the ID of the user whose api access token has the hash. The token must be neither
+//expired nor revoked and the user must not be blocked, otherwise sql.ErrNoRows is returned.
+func (scr *ScooterRepo) GetUserIDByAccessToken(ctx context.Context, accessHash string) (uint64, error) {
+	var userID uint64
+	querySQL := `SELECT t.user_id
+					FROM api_tokens AS t
+					JOIN users AS u
+					ON u.id = t.user_id
+					WHERE t.access_hash = $1 AND t.revoked_at IS NULL AND t.access_expires_at > now()
+					AND NOT COALESCE(u.is_blocked, false)`
+
+	err := scr.db.QueryRowContext(ctx, querySQL, accessHash).Scan(&userID)
+	return userID, err
+}
+
+//GetActiveZones returns the zones whose rules are applied now. The area is read in the text form
+//of the postgres polygon.
+func (scr *ScooterRepo) GetActiveZones(ctx context.Context) ([]*proto.Zone, error) {
+	var zones []*proto.Zone
+
+	querySQL := `SELECT id, name, kind, area::text, COALESCE(speed_limit, 0), penalty_cents
+					FROM zones WHERE is_active ORDER BY id`
+	rows, err := scr.db.QueryContext(ctx, querySQL)
+	if err != nil {
+		return nil, err
+	}
 	defer func() {
 		err := rows.Close()
 		if err != nil {
 			log.Fatal(err)
 		}
 	}()
-	return &proto.Response{}, err
+
+	for rows.Next() {
+		var zone proto.Zone
+		var area string
+		err := rows.Scan(&zone.Id, &zone.Name, &zone.Kind, &area, &zone.SpeedLimit, &zone.PenaltyCents)
+		if err != nil {
+			return nil, err
+		}
+
+		zone.Area, err = parsePolygon(area)
+		if err != nil {
+			return nil, err
+		}
+		zones = append(zones, &zone)
+	}
+	return zones, rows.Err()
+}
+
+//parsePolygon reads the vertices from the text form of the postgres polygon: ((lon1,lat1),(lon2,lat2),...).
+func parsePolygon(text string) ([]*proto.Point, error) {
+	text = strings.TrimSuffix(strings.TrimPrefix(text, "(("), "))")
+	var area []*proto.Point
+	for _, point := range strings.Split(text, "),(") {
+		xy := strings.SplitN(point, ",", 2)
+		if len(xy) != 2 {
+			return nil, fmt.Errorf("malformed polygon point %q", point)
+		}
+		lon, err := strconv.ParseFloat(xy[0], 64)
+		if err != nil {
+			return nil, err
+		}
+		lat, err := strconv.ParseFloat(xy[1], 64)
+		if err != nil {
+			return nil, err
+		}
+		area = append(area, &proto.Point{Latitude: lat, Longitude: lon})
+	}
+	return area, nil
 }
@@ -1,22 +1,21 @@
 package grpcserver
 
 import (
-	"ScooterServer/config"
 	"fmt"
 	"google.golang.org/grpc"
 	"log"
 	"net"
 )
 
-//NewGrpcServer creates a new gRPC server on port 8080.
-func NewGrpcServer() *grpc.Server {
+//NewGrpcServer creates a new gRPC server on the given port.
+func NewGrpcServer(port string) *grpc.Server {
 	grpcServer := grpc.NewServer()
-	listener, err := net.Listen("tcp", net.JoinHostPort("", config.GRPC_PORT))
+	listener, err := net.Listen("tcp", net.JoinHostPort("", port))
 	if err != nil {
 		log.Fatal(err)
 	}
 	go func() {
-		fmt.Printf("grpc server started on port: %v\n", config.GRPC_PORT)
+		fmt.Printf("grpc server started on port: %v\n", port)
 		log.Fatal(grpcServer.Serve(listener))
 	}()
 	return grpcServer
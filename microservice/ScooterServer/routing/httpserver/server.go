@@ -2,7 +2,7 @@ package httpserver
 
 import (
 	"ScooterServer/proto"
-	"bytes"
+	"ScooterServer/service"
 	"context"
 	"encoding/json"
 	"fmt"
@@ -25,8 +25,14 @@ const (
 
 //Client is a client's struct who connects to the "scooter-run" page.
 type Client struct {
-	w    io.Writer
-	done chan struct{}
+	w     io.Writer
+	sub   Subscription
+	queue chan event
+}
+
+//BatteryWarner makes the warning for the rider whose scooter reported the low battery.
+type BatteryWarner interface {
+	BatteryWarning(ctx context.Context, msg *proto.ClientMessage) (*service.BatteryWarning, error)
 }
 
 //Server is a struct of the http-server which has a channel for gRPC connection.
@@ -34,19 +40,20 @@ type Server struct {
 	server          *http.Server
 	notify          chan error
 	shutdownTimeout time.Duration
-	client          map[int]*Client
+	clients         *subscribers
 	taken           map[int]bool
 	codes           map[int]int
 	in              chan *proto.ClientMessage
-	StructureCh     chan *proto.ScooterClient
-	ScooterIdMap    map[uint64]proto.ScooterService_RegisterServer
+	sessions        *service.SessionRegistry
+	users           UserResolver
+	warner          BatteryWarner
 	*proto.UnimplementedScooterServiceServer
 }
 
 type Option func(*Server)
 
 //New creates and starts the http-server
-func New(handler http.Handler, structure chan *proto.ScooterClient, opts ...Option) *Server {
+func New(handler http.Handler, sessions *service.SessionRegistry, opts ...Option) *Server {
 	httpServer := &http.Server{
 		Handler:      handler,
 		ReadTimeout:  defaultReadTimeout,
@@ -59,12 +66,11 @@ func New(handler http.Handler, structure chan *proto.ScooterClient, opts ...Opti
 		server:          httpServer,
 		notify:          make(chan error, 1),
 		shutdownTimeout: defaultShutdownTimeout,
-		client:          make(map[int]*Client),
+		clients:         &subscribers{clients: make(map[*Client]struct{})},
 		taken:           make(map[int]bool),
 		codes:           make(map[int]int),
 		in:              make(chan *proto.ClientMessage),
-		StructureCh:     structure,
-		ScooterIdMap:    make(map[uint64]proto.ScooterService_RegisterServer),
+		sessions:        sessions,
 	}
 
 	for _, opt := range opts {
@@ -94,66 +100,69 @@ func Port(port string) Option {
 }
 
 //ScooterHandler is a special handler which adds a new stream client to the server.
+//The client is subscribed to the scooter or to its own trip given in the query.
+//The scooter in the trip can be watched only by its rider.
 func (s *Server) ScooterHandler(w http.ResponseWriter, r *http.Request) {
-	fmt.Println("new client connected")
+	sub, err := s.subscriptionFromRequest(r)
+	if err != nil {
+		http.Error(w, err.Error(), subscriptionError(err))
+		return
+	}
+
+	fmt.Printf("new client connected: %+v\n", sub)
 	w.Header().Set("Content-Type", "text/event-stream")
 	w.Header().Set("Cache-control", "no-cache")
 	w.Header().Set("Connection", "keep-alive")
 
 	client := &Client{
-		w:    w,
-		done: make(chan struct{}),
+		w:     w,
+		sub:   sub,
+		queue: make(chan event, clientBuffer),
 	}
 	s.AddClient(client)
+	defer s.RemoveClient(client)
 
-	<-client.done
+	if err = client.serve(r.Context()); err != nil {
+		fmt.Println(err)
+	}
 	fmt.Println("connection closed")
 }
 
 //AddClient is a Server's function for adding attached Client.
 func (s *Server) AddClient(c *Client) {
-	s.client[1] = c
+	s.clients.add(c)
 }
 
-func (s *Server) MatchStreamToScooterId(ctx context.Context, stream proto.ScooterService_RegisterServer) {
-	for k, v := range s.ScooterIdMap {
-		if v == nil {
-			s.ScooterIdMap[k] = stream
-			break
-		}
-	}
+//RemoveClient is a Server's function for removing disconnected Client.
+func (s *Server) RemoveClient(c *Client) {
+	s.clients.remove(c)
 }
 
-//Register is a function for implementing gRPC-service.
+//Register is a function for implementing gRPC-service. The connected scooter client gets the trip commands
+//of the scooters which are bound to it. Its messages are delivered to the trip sessions by scooter ID.
 func (s *Server) Register(stream proto.ScooterService_RegisterServer) error {
-	s.MatchStreamToScooterId(context.Background(), stream)
-	fmt.Println(s.ScooterIdMap)
+	s.sessions.Connect(stream)
+	defer s.sessions.Disconnect(stream)
+	fmt.Println("scooter client connected")
 
 	for {
 		msg, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
 		if err != nil {
 			fmt.Printf("Error: %v", err)
-			err = status.Errorf(codes.Internal, "unexpected error %v", err)
+			return status.Errorf(codes.Internal, "unexpected error %v", err)
 		}
 
-		fmt.Printf("This is msg:%v before condition\n", msg)
-
-		if msg.Id > 0 {
-			fmt.Printf("InLoop received MSG:%v\n", err)
-			s.in <- msg
+		if msg.Id == 0 {
+			continue
 		}
 
-		select {
-		case data := <-s.StructureCh:
-			fmt.Printf("Data has been received : %v", data)
-			scoot := &proto.ScooterClient{Id: data.Id, Latitude: data.Latitude, Longitude: data.Longitude,
-				BatteryRemain: data.BatteryRemain, DestLatitude: data.DestLatitude, DestLongitude: data.DestLongitude}
-			err = stream.Send(scoot)
-			if err != nil {
-				log.Printf("send error %v", err)
-			}
-		default:
+		if !s.sessions.Deliver(stream, msg) {
+			log.Printf("message of scooter %v is out of the trip", msg.Id)
 		}
+		s.in <- msg
 	}
 }
 
@@ -176,32 +185,62 @@ func (s *Server) Receive(stream proto.ScooterService_ReceiveServer) error {
 	return err
 }
 
-//run runs the Server and wait for messages into the channel. Then encode them and print to the console.
+//run runs the Server and wait for messages into the channel. Then encode them and send to the clients
+//which are subscribed to the scooter or to its trip.
 func (s *Server) run() {
 	go func() {
-		for {
-			select {
-			case msg := <-s.in:
-				var buf bytes.Buffer
-				json.NewEncoder(&buf).Encode(msg)
-
-				for _, client := range s.client {
-
-					go func(c *Client) {
-						if _, err := fmt.Fprintf(c.w, "data: %v\n\n", buf.String()); err != nil {
-							fmt.Println(err)
-							delete(s.client, 1)
-							close(c.done)
-							return
-						}
-
-						if f, ok := c.w.(http.Flusher); ok {
-							f.Flush()
-						}
-						fmt.Printf("data: %v\n", buf.String())
-					}(client)
-				}
+		for msg := range s.in {
+			data, err := json.Marshal(msg)
+			if err != nil {
+				log.Println(err)
+				continue
+			}
+
+			current := s.tripOf(msg.Id)
+			s.clients.publish(msg.Id, current, event{data: data})
+
+			if msg.LowBattery && s.warner != nil {
+				go s.warn(msg, current)
 			}
 		}
 	}()
 }
+
+//tripOf returns the trip which is run on the scooter now, the trip ID is 0 if the scooter is free.
+func (s *Server) tripOf(scooterID uint64) trip {
+	if session, ok := s.sessions.Get(scooterID); ok {
+		return trip{ID: session.OrderID, UserID: session.UserID}
+	}
+	return trip{}
+}
+
+//warn sends the low battery warning to the clients which watch the scooter or its trip.
+func (s *Server) warn(msg *proto.ClientMessage, current trip) {
+	warning, err := s.warner.BatteryWarning(context.Background(), msg)
+	if err != nil {
+		log.Println(err)
+		return
+	}
+
+	data, err := json.Marshal(warning)
+	if err != nil {
+		log.Println(err)
+		return
+	}
+
+	s.clients.publish(msg.Id, current, event{name: "low-battery", data: data})
+}
+
+//Users sets the resolver of the authenticated user, the clients are allowed to watch only their own trips.
+func Users(users UserResolver) Option {
+	return func(s *Server) {
+		s.users = users
+	}
+}
+
+//Warnings sets the BatteryWarner which makes low battery warnings for the clients.
+func Warnings(warner BatteryWarner) Option {
+	return func(s *Server) {
+		s.warner = warner
+	}
+}
@@ -0,0 +1,183 @@
+package httpserver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	//clientBuffer is the number of messages which are kept for the slow client. When the buffer is full,
+	//the oldest message is dropped, so the client always gets the latest scooter position.
+	clientBuffer      = 16
+	heartbeatInterval = 15 * time.Second
+	scooterIDKey      = "scooterId"
+	tripIDKey         = "tripId"
+)
+
+var (
+	ErrNoSubscription = errors.New("scooterId or tripId must be given")
+	ErrTripForbidden  = errors.New("the trip can be watched only by its rider")
+	ErrTripEnded      = errors.New("the trip has ended")
+)
+
+//UserResolver returns the ID of the authenticated user who sent the request.
+type UserResolver func(r *http.Request) (uint64, bool)
+
+//event is the server-sent event. The event without the name is the scooter position.
+type event struct {
+	name string
+	data []byte
+}
+
+//Subscription is what the client watches on the "scooter-run" page and the user who watches it.
+type Subscription struct {
+	ScooterID uint64
+	TripID    uint64
+	UserID    uint64
+}
+
+//trip is the trip which is run on the scooter now.
+type trip struct {
+	ID     uint64
+	UserID uint64
+}
+
+//subscribers keeps the connected clients. It is safe for concurrent use.
+type subscribers struct {
+	mu      sync.Mutex
+	clients map[*Client]struct{}
+}
+
+func (ss *subscribers) add(c *Client) {
+	ss.mu.Lock()
+	defer ss.mu.Unlock()
+	ss.clients[c] = struct{}{}
+}
+
+func (ss *subscribers) remove(c *Client) {
+	ss.mu.Lock()
+	defer ss.mu.Unlock()
+	delete(ss.clients, c)
+}
+
+//publish puts the message to the queues of the clients which are subscribed to the scooter
+//or to the trip which is run on the scooter now. The scooter in the trip is watched only by its rider.
+func (ss *subscribers) publish(scooterID uint64, current trip, e event) {
+	ss.mu.Lock()
+	defer ss.mu.Unlock()
+
+	for c := range ss.clients {
+		if c.sub.TripID != 0 && c.sub.TripID == current.ID ||
+			c.sub.ScooterID == scooterID && (current.ID == 0 || c.sub.UserID == current.UserID) {
+			c.enqueue(e)
+		}
+	}
+}
+
+//enqueue never blocks: if the client doesn't read its messages, the oldest one is dropped.
+func (c *Client) enqueue(e event) {
+	for {
+		select {
+		case c.queue <- e:
+			return
+		default:
+		}
+
+		select {
+		case <-c.queue:
+		default:
+		}
+	}
+}
+
+//subscriptionFromRequest reads the scooter or the trip ID from the request query. Only the rider can watch
+//the trip and only while it is run, the scooter in the trip can be watched only by its rider too.
+func (s *Server) subscriptionFromRequest(r *http.Request) (Subscription, error) {
+	sub := Subscription{}
+	var err error
+
+	if s.users == nil {
+		return sub, ErrTripForbidden
+	}
+	userID, ok := s.users(r)
+	if !ok {
+		return sub, ErrTripForbidden
+	}
+	sub.UserID = userID
+
+	if value := r.URL.Query().Get(scooterIDKey); value != "" {
+		sub.ScooterID, err = strconv.ParseUint(value, 10, 64)
+		if err != nil {
+			return sub, err
+		}
+		if session, ok := s.sessions.Get(sub.ScooterID); ok && session.UserID != userID {
+			return sub, ErrTripForbidden
+		}
+		return sub, nil
+	}
+
+	value := r.URL.Query().Get(tripIDKey)
+	if value == "" {
+		return sub, ErrNoSubscription
+	}
+	sub.TripID, err = strconv.ParseUint(value, 10, 64)
+	if err != nil {
+		return sub, err
+	}
+
+	session, ok := s.sessions.GetByOrder(sub.TripID)
+	if !ok {
+		return sub, ErrTripEnded
+	}
+	if session.UserID != userID {
+		return sub, ErrTripForbidden
+	}
+	return sub, nil
+}
+
+//subscriptionError maps the subscription errors to http statuses.
+func subscriptionError(err error) int {
+	switch {
+	case errors.Is(err, ErrTripForbidden):
+		return http.StatusForbidden
+	case errors.Is(err, ErrTripEnded):
+		return http.StatusGone
+	default:
+		return http.StatusBadRequest
+	}
+}
+
+//serve writes the client's messages and the heartbeat events to the stream until the client disconnects.
+func (c *Client) serve(ctx context.Context) error {
+	heartbeat := time.NewTicker(heartbeatInterval)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case e := <-c.queue:
+			if e.name != "" {
+				if _, err := fmt.Fprintf(c.w, "event: %s\n", e.name); err != nil {
+					return err
+				}
+			}
+			if _, err := fmt.Fprintf(c.w, "data: %s\n\n", e.data); err != nil {
+				return err
+			}
+		case <-heartbeat.C:
+			if _, err := fmt.Fprintf(c.w, "event: heartbeat\ndata: %d\n\n", time.Now().Unix()); err != nil {
+				return err
+			}
+		}
+
+		if f, ok := c.w.(http.Flusher); ok {
+			f.Flush()
+		}
+	}
+}
@@ -1,16 +1,19 @@
 package routing
 
 import (
-	"ScooterServer/config"
 	"ScooterServer/proto"
 	"ScooterServer/service"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"github.com/gorilla/mux"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"html/template"
 	"net/http"
 	"strconv"
+	"strings"
 )
 
 var (
@@ -18,7 +21,15 @@ var (
 	stationIDKey = "stationId"
 )
 
-var chosenScooterID, chosenStationID int
+const (
+	bearerPrefix = "Bearer "
+	//accessTokenKey is the query parameter with the api access token for the requests which can't set
+	//the headers, like the event streams of the browser.
+	accessTokenKey = "access_token"
+)
+
+//userKey is the context key of the authenticated user's ID.
+type userKey struct{}
 
 type combineForTemplate struct {
 	*proto.ScooterList
@@ -30,36 +41,62 @@ type Routing interface {
 	getScooterById(w http.ResponseWriter, r *http.Request)
 	startScooterTrip(w http.ResponseWriter, r *http.Request)
 	showTripPage(w http.ResponseWriter, r *http.Request)
-	chooseScooter(w http.ResponseWriter, r *http.Request)
-	chooseStation(w http.ResponseWriter, r *http.Request)
 }
 
 type handler struct {
 	scooterService *service.ScooterService
-	StructureCh    chan *proto.ScooterClient
+	templatesPath  string
 }
 
-func newHandler(scooterService *service.ScooterService, structure chan *proto.ScooterClient) *handler {
+func newHandler(scooterService *service.ScooterService, templatesPath string) *handler {
 	return &handler{
 		scooterService: scooterService,
-		StructureCh:    structure,
+		templatesPath:  templatesPath,
 	}
 }
 
-func NewRouter(scooterService *service.ScooterService, structure chan *proto.ScooterClient) *mux.Router {
+func NewRouter(scooterService *service.ScooterService, templatesPath string) *mux.Router {
 	router := mux.NewRouter()
-	handler := newHandler(scooterService, structure)
+	handler := newHandler(scooterService, templatesPath)
 	router.HandleFunc(`/scooters`, handler.getAllScooters).Methods("GET")
 	router.HandleFunc(`/scooter/{`+scooterIDKey+`}`, handler.getScooterById).Methods("GET")
 	router.HandleFunc(`/start-trip/{`+stationIDKey+`}`, handler.showTripPage).Methods("GET")
-	router.HandleFunc(`/run`, handler.startScooterTrip).Methods("GET")
-	router.HandleFunc(`/choose-station`, handler.chooseStation).Methods("POST")
-	router.HandleFunc(`/choose-scooter`, handler.chooseScooter).Methods("POST")
+	router.Handle(`/run`, Authenticated(scooterService, handler.startScooterTrip)).Methods("GET")
 	return router
 }
 
+//Authenticated lets the request through only with the api access token of the user issued by the main
+//application. The token is taken from the Authorization header or, if there is none, from the query.
+//The ID of the user is put to the request context, UserFromRequest returns it.
+func Authenticated(scooterService *service.ScooterService, next http.HandlerFunc) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token := strings.TrimPrefix(r.Header.Get("Authorization"), bearerPrefix)
+		if token == "" {
+			token = r.URL.Query().Get(accessTokenKey)
+		}
+		userID, err := scooterService.Authenticate(r.Context(), token)
+		if errors.Is(err, service.ErrUnauthorized) {
+			w.Header().Set("WWW-Authenticate", "Bearer")
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+			return
+		}
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		next(w, r.WithContext(context.WithValue(r.Context(), userKey{}, userID)))
+	})
+}
+
+//UserFromRequest returns the ID of the user who is authenticated by the api access token.
+func UserFromRequest(r *http.Request) (uint64, bool) {
+	userID, ok := r.Context().Value(userKey{}).(uint64)
+	return userID, ok
+}
+
 func (h *handler) getAllScooters(w http.ResponseWriter, r *http.Request) {
-	scooters, err := h.scooterService.GetAllScooters(context.Background(), &proto.Request{})
+	scooters, err := h.scooterService.GetAllScooters(r.Context(), &proto.Request{})
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
@@ -75,7 +112,7 @@ func (h *handler) getScooterById(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	scooter, err := h.scooterService.GetScooterById(context.Background(), &proto.ScooterID{Id: uint64(scooterID)})
+	scooter, err := h.scooterService.GetScooterById(r.Context(), &proto.ScooterID{Id: uint64(scooterID)})
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
@@ -85,81 +122,68 @@ func (h *handler) getScooterById(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(scooter)
 }
 
+//startScooterTrip runs the trip of the authenticated user on the scooter to the station which are chosen
+//on the trip page.
 func (h *handler) startScooterTrip(w http.ResponseWriter, r *http.Request) {
-	//userID is a temporary value
-	//userID := 3
+	userID, _ := UserFromRequest(r)
 
-	scooterStatus, err := h.scooterService.GetScooterStatus(context.Background(), &proto.ScooterID{Id: uint64(chosenScooterID)})
+	scooterID, err := strconv.Atoi(r.FormValue(scooterIDKey))
 	if err != nil {
-		fmt.Println(err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
 	}
-	station, err := h.scooterService.GetStationById(context.Background(), &proto.StationID{Id: uint64(chosenStationID)})
+
+	stationID, err := strconv.Atoi(r.FormValue(stationIDKey))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	order, err := h.scooterService.RunTrip(r.Context(), userID, &proto.ScooterID{Id: uint64(scooterID)},
+		&proto.StationID{Id: uint64(stationID)})
 	if err != nil {
 		fmt.Println(err)
+		http.Error(w, err.Error(), httpStatusFromError(err))
+		return
 	}
 
-	scooterForClient := proto.ScooterClient{Id: uint64(chosenScooterID), Latitude: scooterStatus.Latitude,
-		Longitude: scooterStatus.Longitude, BatteryRemain: scooterStatus.BatteryRemain,
-		DestLatitude: station.Latitude, DestLongitude: station.Longitude}
-
-	fmt.Printf("ScooterForClient: %v\n", &scooterForClient)
-
-	h.StructureCh <- &scooterForClient
-	fmt.Println("Data has been sent")
-
-	//statusStart, err := h.scooterService.CreateScooterStatusInRent(context.Background(),
-	//	&proto.ScooterID{Id: uint64(chosenScooterID)})
-	//if err != nil {
-	//	fmt.Println(err)
-	//}
-
-	//err = h.scooterService.InitAndRun(context.Background(), &proto.ScooterID{Id: uint64(chosenScooterID)},
-	//	&proto.StationID{Id: uint64(chosenStationID)})
-	//if err != nil {
-	//	http.Error(w, err.Error(), http.StatusInternalServerError)
-	//	return
-	//}
-
-	//fmt.Println("StatusEnd creating...")
-	//statusEnd, err := h.scooterService.CreateScooterStatusInRent(context.Background(),
-	//	&proto.ScooterID{Id: uint64(chosenScooterID)})
-	//if err != nil {
-	//	http.Error(w, err.Error(), http.StatusInternalServerError)
-	//	fmt.Println(err)
-	//	return
-	//}
-
-	//fmt.Println("StatusEnd created...")
-	//tripInfo := &proto.TripInfo{ScooterID: uint64(chosenScooterID), UserID: uint64(userID),
-	//	StatusStartID: statusStart.Id,
-	//	StatusEndID:   statusEnd.Id}
-	//tripOrder, err := h.scooterService.Order.CreateOrder(context.Background(), tripInfo)
-	//if err != nil {
-	//	http.Error(w, err.Error(), http.StatusInternalServerError)
-	//	fmt.Println(err)
-	//	return
-	//}
-	//fmt.Println(tripOrder)
+	fmt.Printf("Trip is over: %v\n", order)
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(order)
+}
+
+//httpStatusFromError converts the order service gRPC error to the http status code.
+func httpStatusFromError(err error) int {
+	switch status.Code(err) {
+	case codes.NotFound:
+		return http.StatusNotFound
+	case codes.FailedPrecondition:
+		return http.StatusConflict
+	}
+	if errors.Is(err, service.ErrScooterBusy) || errors.Is(err, service.ErrStationFull) {
+		return http.StatusConflict
+	}
+	return http.StatusInternalServerError
 }
 
 func (h *handler) showTripPage(w http.ResponseWriter, r *http.Request) {
 	stationID, err := strconv.Atoi(mux.Vars(r)[stationIDKey])
 
-	scooterList, err := h.scooterService.GetAllScootersByStationID(context.Background(),
+	scooterList, err := h.scooterService.GetAllScootersByStationID(r.Context(),
 		&proto.StationID{Id: uint64(stationID)})
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
 
-	stationList, err := h.scooterService.GetAllStations(context.Background(), &proto.Request{})
+	stationList, err := h.scooterService.GetAllStations(r.Context(), &proto.Request{})
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
 
 	//tmpl, err := template.ParseFiles("../scooter_server/templates/scooter-run.html")
-	tmpl, err := template.ParseFiles(config.MONO_TEMPLATES_PATH + "scooter-run.html")
+	tmpl, err := template.ParseFiles(h.templatesPath + "scooter-run.html")
 	if err != nil {
 		fmt.Println(err)
 	}
@@ -168,39 +192,3 @@ func (h *handler) showTripPage(w http.ResponseWriter, r *http.Request) {
 		fmt.Println(err)
 	}
 }
-
-func (h *handler) chooseScooter(w http.ResponseWriter, r *http.Request) {
-	err := r.ParseForm()
-	if err != nil {
-		w.WriteHeader(http.StatusBadRequest)
-		fmt.Println(err)
-		return
-	}
-
-	chosenScooterID, err = strconv.Atoi(r.Form.Get("id"))
-	if err != nil {
-		w.WriteHeader(http.StatusBadRequest)
-		fmt.Println(err)
-		return
-	}
-	fmt.Println(chosenScooterID)
-	w.WriteHeader(http.StatusOK)
-}
-
-func (h *handler) chooseStation(w http.ResponseWriter, r *http.Request) {
-	err := r.ParseForm()
-	if err != nil {
-		w.WriteHeader(http.StatusBadRequest)
-		fmt.Println(err)
-		return
-	}
-
-	chosenStationID, err = strconv.Atoi(r.Form.Get("id"))
-	if err != nil {
-		w.WriteHeader(http.StatusBadRequest)
-		fmt.Println(err)
-		return
-	}
-	fmt.Println(chosenStationID)
-	w.WriteHeader(http.StatusOK)
-}
@@ -0,0 +1,27 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+)
+
+//ErrUnauthorized is returned when the request has no valid api access token.
+var ErrUnauthorized = errors.New("valid access token is required")
+
+//Authenticate returns the ID of the user who owns the api access token issued by the main application.
+//Only the hash of the token is kept in the database.
+func (gss *ScooterService) Authenticate(ctx context.Context, token string) (uint64, error) {
+	if token == "" {
+		return 0, ErrUnauthorized
+	}
+
+	sum := sha256.Sum256([]byte(token))
+	userID, err := gss.Repo.GetUserIDByAccessToken(ctx, hex.EncodeToString(sum[:]))
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, ErrUnauthorized
+	}
+	return userID, err
+}
@@ -0,0 +1,87 @@
+package service
+
+import (
+	"ScooterServer/proto"
+	"context"
+	"errors"
+	"math"
+)
+
+var ErrNoActiveStation = errors.New("there is no active station")
+
+//BatteryWarning is sent to the rider when the scooter battery goes below the low level of its model.
+//It suggests the nearest station where the trip can be finished.
+type BatteryWarning struct {
+	ScooterID     uint64         `json:"scooterId"`
+	BatteryRemain float64        `json:"batteryRemain"`
+	Range         float64        `json:"range"`
+	Station       *proto.Station `json:"station"`
+	Distance      float64        `json:"distance"`
+	Reachable     bool           `json:"reachable"`
+}
+
+//BatteryWarning makes the warning for the rider whose scooter reported the low battery. The nearest active
+//station is suggested to finish the trip, it is marked as reachable if the scooter has enough charge to get there.
+func (gss *ScooterService) BatteryWarning(ctx context.Context, msg *proto.ClientMessage) (*BatteryWarning, error) {
+	scooter, err := gss.GetScooterById(ctx, &proto.ScooterID{Id: msg.Id})
+	if err != nil {
+		return nil, err
+	}
+
+	stations, err := gss.GetAllStations(ctx, &proto.Request{})
+	if err != nil {
+		return nil, err
+	}
+
+	station, distance, ok := nearestStation(stations.Stations, Location{Latitude: msg.Latitude,
+		Longitude: msg.Longitude})
+	if !ok {
+		return nil, ErrNoActiveStation
+	}
+
+	warning := &BatteryWarning{
+		ScooterID:     msg.Id,
+		BatteryRemain: msg.BatteryRemain,
+		Range:         batteryRange(scooter, msg.BatteryRemain),
+		Station:       station,
+		Distance:      distance,
+	}
+	warning.Reachable = warning.Range >= warning.Distance
+
+	return warning, nil
+}
+
+//dischargePerKm returns the battery percent which the scooter spends for every kilometer.
+func dischargePerKm(scooter *proto.Scooter) float64 {
+	if scooter.BatteryCapacity <= 0 {
+		return 0
+	}
+	return scooter.ConsumptionPerKm / scooter.BatteryCapacity * 100
+}
+
+//batteryRange returns the distance (in meters) which the scooter can pass with the given battery percent.
+func batteryRange(scooter *proto.Scooter, batteryRemain float64) float64 {
+	if scooter.ConsumptionPerKm <= 0 || batteryRemain <= 0 {
+		return 0
+	}
+	return batteryRemain / 100 * scooter.BatteryCapacity / scooter.ConsumptionPerKm * 1000
+}
+
+//nearestStation returns the active station which is the closest to the point and the distance to it.
+func nearestStation(stations []*proto.Station, point Location) (*proto.Station, float64, bool) {
+	var nearest *proto.Station
+	minDistance := math.Inf(1)
+
+	for _, station := range stations {
+		if !station.IsActive {
+			continue
+		}
+
+		d := point.Distance(Location{Latitude: station.Latitude, Longitude: station.Longitude})
+		if d < minDistance {
+			nearest, minDistance = station, d
+		}
+	}
+
+	return nearest, minDistance, nearest != nil
+}
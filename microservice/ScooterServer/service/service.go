@@ -1,32 +1,41 @@
 package service
 
 import (
-	"ScooterServer/config"
 	"ScooterServer/proto"
 	"ScooterServer/repository"
 	"context"
+	"errors"
 	"fmt"
 	"google.golang.org/grpc"
-	"log"
-	"net"
+	"math"
+	"shared.micro/geo"
 	"time"
 )
 
+//ErrStationFull is returned when the chosen station has no free slot for the scooter.
+var ErrStationFull = repository.ErrStationFull
+
 const (
-	step          = 0.0001
-	dischargeStep = 0.1
-	interval      = 450
+	//arrivalRadius is the distance (in meters) to the station within which the scooter is docked there.
+	arrivalRadius = 1.0
+	//defaultTick is the interval between two scooter positions if the tick isn't configured.
+	defaultTick = 450 * time.Millisecond
+	//closeTripTimeout limits saving the stopped scooter and ending or cancelling the trip, they don't depend
+	//on the request any more.
+	closeTripTimeout = 5 * time.Second
 )
 
-type Location struct {
-	Latitude  float64
-	Longitude float64
-}
+//Location is the point given by latitude and longitude, the trips are counted the same way as in the monolith.
+type Location = geo.Point
 
 //ScooterService is a service which responsible for gRPC scooter.
 type ScooterService struct {
-	Repo  *repository.ScooterRepo
-	Order proto.OrderServiceClient
+	Repo     *repository.ScooterRepo
+	Order    proto.OrderServiceClient
+	Sessions *SessionRegistry
+
+	grpcAddress string
+	tick        time.Duration
 }
 
 //ScooterClient is a struct with parameters which will be translated by the gRPC connection.
@@ -34,182 +43,316 @@ type ScooterClient struct {
 	ID            uint64
 	Latitude      float64
 	Longitude     float64
-	BatteryRemain float64
-	Stream        proto.ScooterService_ReceiveClient
+	BatteryRemain   float64
+	Speed           float64
+	DischargePerKm  float64
+	LowBatteryLevel float64
+	tick            time.Duration
+	Stream          proto.ScooterService_ReceiveClient
+	zones           []*proto.Zone
+	violations      []*proto.Zone
 }
 
-//NewScooterService creates a new GrpcScooterService.
-func NewScooterService(repoScooter *repository.ScooterRepo, order proto.OrderServiceClient) *ScooterService {
+//NewScooterService creates a new GrpcScooterService. The scooters moved by the service send their positions
+//to the gRPC server at grpcAddress every tick.
+func NewScooterService(repoScooter *repository.ScooterRepo, order proto.OrderServiceClient,
+	sessions *SessionRegistry, grpcAddress string, tick time.Duration) *ScooterService {
 	return &ScooterService{
-		Repo:  repoScooter,
-		Order: order,
+		Repo:        repoScooter,
+		Order:       order,
+		Sessions:    sessions,
+		grpcAddress: grpcAddress,
+		tick:        tick,
 	}
 }
 
-//NewScooterClient creates a new GrpcScooterClient with given parameters.
-func NewScooterClient(id uint64, latitude, longitude, battery float64,
-	stream proto.ScooterService_ReceiveClient) *ScooterClient {
+//NewScooterClient creates a new GrpcScooterClient by the trip command. The speed is given in km/h,
+//the positions are sent every tick.
+func NewScooterClient(command *proto.ScooterClient, stream proto.ScooterService_ReceiveClient,
+	tick time.Duration) *ScooterClient {
+	if tick <= 0 {
+		tick = defaultTick
+	}
+
 	return &ScooterClient{
-		ID:            id,
-		Latitude:      latitude,
-		Longitude:     longitude,
-		BatteryRemain: battery,
-		Stream:        stream,
+		ID:              command.Id,
+		Latitude:        command.Latitude,
+		Longitude:       command.Longitude,
+		BatteryRemain:   command.BatteryRemain,
+		Speed:           command.Speed,
+		DischargePerKm:  command.DischargePerKm,
+		LowBatteryLevel: command.LowBatteryLevel,
+		tick:            tick,
+		Stream:          stream,
+		zones:           command.Zones,
 	}
 }
 
-//InitAndRun the main function of scooter's trip. It analyzes the scooter parameters from database by its ID.
-//If they satisfy the conditions, function creates connection to the gRPC server, creates gRPC client,
+//InitAndRun the main function of scooter's trip. It analyzes the scooter parameters from database by its ID,
+//creates connection to the gRPC server, creates gRPC client,
 //calls 'run' function which moves the scooter to the destination point.
 //After finished moves it sends the current scooter status to the database.
-func (gss *ScooterService) InitAndRun(ctx context.Context, id *proto.ScooterID, stationID *proto.StationID) error {
-	scooter, err := gss.GetScooterById(ctx, id)
+//The scooter has to be reserved by the order service before the run.
+//The returned TripEnd tells where the scooter has stopped and which zone rules were broken on the way.
+func (gss *ScooterService) InitAndRun(ctx context.Context, id *proto.ScooterID, stationID *proto.StationID) (TripEnd,
+	error) {
+	command, err := gss.tripCommand(ctx, id, stationID)
 	if err != nil {
-		fmt.Println(err)
-		return err
+		return TripEnd{}, err
 	}
 
-	scooterStatus, err := gss.GetScooterStatus(ctx, id)
+	conn, err := grpc.DialContext(ctx, gss.grpcAddress, grpc.WithInsecure())
+	if err != nil {
+		return TripEnd{}, err
+	}
+	defer conn.Close()
+
+	sClient := proto.NewScooterServiceClient(conn)
+	stream, err := sClient.Receive(ctx)
+	if err != nil {
+		return TripEnd{}, err
+	}
+
+	client := NewScooterClient(command, stream, gss.tick)
+	err = client.run(ctx, Location{Latitude: command.DestLatitude, Longitude: command.DestLongitude})
 	if err != nil {
 		fmt.Println(err)
-		return err
 	}
 
-	if scooter.CanBeRent {
-		var coordinate Location
-		station, err := gss.GetStationById(ctx, stationID)
-		if err != nil {
-			return err
-		}
-		coordinate.Latitude = station.Latitude
-		coordinate.Longitude = station.Longitude
+	// the scooter stopped by the cancelled request is still saved where it is
+	finishCtx := ctx
+	if ctx.Err() != nil {
+		var cancel context.CancelFunc
+		finishCtx, cancel = context.WithTimeout(context.Background(), closeTripTimeout)
+		defer cancel()
+	}
+	return gss.finishRun(finishCtx, stationID, command, &proto.ClientMessage{Id: client.ID, Latitude: client.Latitude,
+		Longitude: client.Longitude, BatteryRemain: client.BatteryRemain}, client.violations)
+}
 
-		conn, err := grpc.DialContext(ctx, net.JoinHostPort("", config.GRPC_PORT), grpc.WithInsecure())
+//RunRemote sends the trip command to the scooter client of the session and waits until the client reports
+//that the scooter stopped. The scooter status is saved by the last message of the client.
+//The client gets the zones with the command and is expected to keep their rules, the reported positions are
+//checked as well: the scooter which has entered an out-of-service zone violates it.
+func (gss *ScooterService) RunRemote(ctx context.Context, session *ScooterSession, id *proto.ScooterID,
+	stationID *proto.StationID) (TripEnd, error) {
+	command, err := gss.tripCommand(ctx, id, stationID)
+	if err != nil {
+		return TripEnd{}, err
+	}
 
-		if err != nil {
-			log.Fatal(err)
-		}
-		defer conn.Close()
+	err = session.Send(command)
+	if err != nil {
+		return TripEnd{}, err
+	}
 
-		sClient := proto.NewScooterServiceClient(conn)
-		stream, err := sClient.Receive(ctx)
-		if err != nil {
-			log.Fatal(err)
+	var violations []*proto.Zone
+	last := &proto.ClientMessage{Id: command.Id, Latitude: command.Latitude, Longitude: command.Longitude,
+		BatteryRemain: command.BatteryRemain}
+	for !last.Finished {
+		select {
+		case last = <-session.Telemetry():
+		case last = <-session.Finished():
+			violations = append(violations, pendingViolations(session, command.Zones)...)
+		case <-session.Closed():
+			return TripEnd{}, ErrScooterClientClosed
+		case <-ctx.Done():
+			return TripEnd{}, ctx.Err()
 		}
 
-		client := NewScooterClient(uint64(id.Id),
-			scooterStatus.Latitude, scooterStatus.Longitude, scooter.BatteryRemain, stream)
-		err = client.run(coordinate)
-		if err != nil {
-			fmt.Println(err)
+		if zone, ok := violatedZone(command.Zones, last); ok {
+			violations = append(violations, zone)
 		}
+	}
 
-		sendStatus := &proto.SendStatus{
-			ScooterID: client.ID, StationID: stationID.Id,
-			Latitude: client.Latitude, Longitude: client.Longitude, BatteryRemain: client.BatteryRemain}
+	return gss.finishRun(ctx, stationID, command, last, violations)
+}
 
-		_, err = gss.SendCurrentStatus(ctx, sendStatus)
-		if err != nil {
-			fmt.Println(err)
+//pendingViolations checks the positions which the scooter client sent before the scooter stopped
+//but the trip hasn't read yet.
+func pendingViolations(session *ScooterSession, zones []*proto.Zone) []*proto.Zone {
+	var violations []*proto.Zone
+	for {
+		select {
+		case msg := <-session.Telemetry():
+			if zone, ok := violatedZone(zones, msg); ok {
+				violations = append(violations, zone)
+			}
+		default:
+			return violations
 		}
+	}
+}
+
+//violatedZone returns the out-of-service zone which the scooter has entered by the reported position.
+func violatedZone(zones []*proto.Zone, msg *proto.ClientMessage) (*proto.Zone, bool) {
+	return zoneAt(zones, Location{Latitude: msg.Latitude, Longitude: msg.Longitude}, zoneOutOfService)
+}
+
+//tripCommand returns the scooter position, its battery and speed and the position of the chosen station.
+//The battery discharge and its low level are taken from the scooter model. The active zones are sent
+//with the command, so their rules are kept on the way.
+func (gss *ScooterService) tripCommand(ctx context.Context, id *proto.ScooterID,
+	stationID *proto.StationID) (*proto.ScooterClient, error) {
+	scooter, err := gss.GetScooterById(ctx, id)
+	if err != nil {
+		fmt.Println(err)
+		return nil, err
+	}
+
+	scooterStatus, err := gss.GetScooterStatus(ctx, id)
+	if err != nil {
+		fmt.Println(err)
+		return nil, err
+	}
+
+	station, err := gss.GetStationById(ctx, stationID)
+	if err != nil {
+		return nil, err
+	}
 
-		if client.BatteryRemain <= 0 {
-			err = fmt.Errorf("scooter battery discharged. Trip is over")
-			return err
+	zones, err := gss.Repo.GetActiveZones(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &proto.ScooterClient{Id: id.Id, Latitude: scooterStatus.Latitude, Longitude: scooterStatus.Longitude,
+		BatteryRemain: scooter.BatteryRemain, DestLatitude: station.Latitude, DestLongitude: station.Longitude,
+		Speed: scooter.Speed, DischargePerKm: dischargePerKm(scooter), LowBatteryLevel: scooter.LowBatteryLevel,
+		Zones: zones}, nil
+}
+
+//finishRun saves the scooter status where the scooter stopped and counts the penalty of the trip.
+//The scooter which hasn't reached the station (it's discharged or stopped at the border of an out-of-service zone)
+//is left out of the stations. If the station was filled up during the trip, the scooter is left next to it.
+func (gss *ScooterService) finishRun(ctx context.Context, stationID *proto.StationID, command *proto.ScooterClient,
+	last *proto.ClientMessage, violations []*proto.Zone) (TripEnd, error) {
+	end := TripEnd{Location: Location{Latitude: last.Latitude, Longitude: last.Longitude}, Violations: violations}
+	destination := Location{Latitude: command.DestLatitude, Longitude: command.DestLongitude}
+	if end.Location.Distance(destination) <= arrivalRadius {
+		end.StationID = stationID.Id
+	}
+
+	sendStatus := &proto.SendStatus{
+		ScooterID: last.Id, StationID: end.StationID,
+		Latitude: last.Latitude, Longitude: last.Longitude, BatteryRemain: last.BatteryRemain}
+
+	_, err := gss.SendCurrentStatus(ctx, sendStatus)
+	if errors.Is(err, ErrStationFull) {
+		end.StationID = 0
+	} else if err != nil {
+		fmt.Println(err)
+	}
+
+	return finishTrip(command.Zones, end), nil
+}
+
+//RunTrip is the whole user's trip. It starts the trip in the order service, moves the scooter to the chosen
+//station and ends the trip there. If the scooter discharged or was stopped at the border of an out-of-service
+//zone on the way, the trip ends out of the station. The penalty for the broken zone rules is added to the order.
+//If the scooter couldn't start moving, the trip is cancelled.
+//The scooter is moved by the connected scooter client, if there is no one, it is moved by the server itself.
+//The trip isn't started if the chosen station has no free slot for the scooter. If the station is filled up
+//during the trip, the trip ends out of the station.
+func (gss *ScooterService) RunTrip(ctx context.Context, userID uint64, id *proto.ScooterID,
+	stationID *proto.StationID) (*proto.Order, error) {
+	hasRoom, err := gss.Repo.HasRoom(ctx, stationID, id)
+	if err != nil {
+		return nil, err
+	}
+	if !hasRoom {
+		return nil, ErrStationFull
+	}
+
+	order, err := gss.Order.StartTrip(ctx, &proto.StartTripRequest{UserID: userID, ScooterID: id.Id})
+	if err != nil {
+		return nil, err
+	}
+
+	var end TripEnd
+	session, err := gss.Sessions.Start(order)
+	if err == nil {
+		defer gss.Sessions.Finish(id.Id)
+
+		if session.Remote() {
+			end, err = gss.RunRemote(ctx, session, id, stationID)
+		} else {
+			end, err = gss.InitAndRun(ctx, id, stationID)
 		}
-		return nil
 	}
 
-	err = fmt.Errorf("you can't use this scooter. Choose another one")
-	fmt.Println(err.Error())
-	return err
+	closeCtx, cancel := context.WithTimeout(context.Background(), closeTripTimeout)
+	defer cancel()
+
+	if err == nil {
+		return gss.Order.EndTrip(closeCtx, &proto.EndTripRequest{OrderID: order.Id, StationID: end.StationID,
+			PenaltyCents: end.PenaltyCents})
+	}
+
+	if _, cancelErr := gss.Order.CancelTrip(closeCtx, &proto.OrderID{Id: order.Id}); cancelErr != nil {
+		fmt.Println(cancelErr)
+	}
+	return nil, err
 }
 
 //grpcScooterMessage sends the message be gRPC stream in a format which defined in the *proto file.
-func (s *ScooterClient) grpcScooterMessage() {
-	intPol := time.Duration(interval) * time.Millisecond
-
+//lowBattery tells that the battery has just gone below the low level of the scooter model.
+func (s *ScooterClient) grpcScooterMessage(lowBattery bool) {
 	fmt.Println("executing run in client")
 	msg := &proto.ClientMessage{
-		Id:        s.ID,
-		Latitude:  s.Latitude,
-		Longitude: s.Longitude,
+		Id:            s.ID,
+		Latitude:      s.Latitude,
+		Longitude:     s.Longitude,
+		BatteryRemain: s.BatteryRemain,
+		LowBattery:    lowBattery,
 	}
 	err := s.Stream.Send(msg)
 	if err != nil {
 		fmt.Println(err)
 	}
-	time.Sleep(intPol)
 }
 
 //run is responsible for scooter's movements from his current position to the destination point.
-//Run also is responsible for scooter's discharge. Every step battery charge decrease by the constant discharge value.
-func (s *ScooterClient) run(station Location) error {
-
-	switch {
-	case s.Latitude <= station.Latitude && s.Longitude <= station.Longitude:
-		for ; s.Latitude <= station.Latitude && s.Longitude <= station.Longitude && s.
-			BatteryRemain > 0; s.
-			Latitude,
-			s.Longitude, s.BatteryRemain = s.Latitude+step, s.Longitude+step,
-			s.BatteryRemain-dischargeStep {
-			s.grpcScooterMessage()
-		}
-		fallthrough
-	case s.Latitude >= station.Latitude && s.Longitude <= station.Longitude:
-		for ; s.Latitude >= station.Latitude && s.Longitude <= station.Longitude && s.
-			BatteryRemain > 0; s.Latitude,
-			s.Longitude, s.BatteryRemain = s.Latitude-step, s.Longitude+step,
-			s.BatteryRemain-dischargeStep {
-			s.grpcScooterMessage()
-		}
-		fallthrough
-	case s.Latitude >= station.Latitude && s.Longitude >= station.Longitude:
-		for ; s.Latitude >= station.Latitude && s.Longitude >= station.Longitude && s.
-			BatteryRemain > 0; s.Latitude,
-			s.Longitude, s.BatteryRemain = s.Latitude-step, s.Longitude-step,
-			s.BatteryRemain-dischargeStep {
-			s.grpcScooterMessage()
-		}
-		fallthrough
-	case s.Latitude <= station.Latitude && s.Longitude >= station.Longitude:
-		for ; s.Latitude <= station.Latitude && s.Longitude >= station.Longitude && s.
-			BatteryRemain > 0; s.Latitude,
-			s.Longitude, s.BatteryRemain = s.Latitude+step, s.Longitude-step,
-			s.BatteryRemain-dischargeStep {
-			s.grpcScooterMessage()
-		}
-		fallthrough
-	case s.Latitude <= station.Latitude:
-		for ; s.Latitude <= station.Latitude && s.
-			BatteryRemain > 0; s.Latitude, s.BatteryRemain = s.Latitude+step,
-			s.BatteryRemain-dischargeStep {
-			s.grpcScooterMessage()
-		}
-		fallthrough
-	case s.Latitude >= station.Latitude:
-		for ; s.Latitude >= station.Latitude && s.
-			BatteryRemain > 0; s.Latitude, s.BatteryRemain = s.Latitude-step,
-			s.BatteryRemain-dischargeStep {
-			s.grpcScooterMessage()
-		}
-		fallthrough
-	case s.Longitude >= station.Longitude:
-		for ; s.Longitude >= station.Longitude && s.
-			BatteryRemain > 0; s.Longitude, s.BatteryRemain = s.Longitude-step,
-			s.BatteryRemain-dischargeStep {
-			s.grpcScooterMessage()
+//The scooter goes along the great-circle route at its model's speed and its position is sent every tick.
+//In a slow zone the speed is limited by the zone, the scooter which is going to enter an out-of-service zone
+//is stopped at its border and the zone is counted as violated.
+//Run also is responsible for scooter's discharge: the battery charge decreases for every passed kilometer
+//by the consumption of the scooter model. The scooter stops where it is when ctx is done.
+func (s *ScooterClient) run(ctx context.Context, station Location) error {
+	if s.Speed <= 0 {
+		return fmt.Errorf("scooter %v has unknown speed", s.ID)
+	}
+
+	route := geo.NewRoute(Location{Latitude: s.Latitude, Longitude: s.Longitude}, station)
+
+	ticker := time.NewTicker(s.tick)
+	defer ticker.Stop()
+
+	for passed := 0.0; passed < route.Distance() && s.BatteryRemain > 0; {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
 		}
-		fallthrough
-	case s.Longitude <= station.Longitude:
-		for ; s.Longitude <= station.Longitude && s.
-			BatteryRemain > 0; s.Longitude, s.BatteryRemain = s.Longitude+step,
-			s.BatteryRemain-dischargeStep {
-			s.grpcScooterMessage()
+
+		current := Location{Latitude: s.Latitude, Longitude: s.Longitude}
+		step := geo.StepLength(speedLimit(s.zones, current, s.Speed), s.tick)
+		move := math.Min(step, route.Distance()-passed)
+		position := route.PointAt(passed + move)
+		if zone, ok := zoneAt(s.zones, position, zoneOutOfService); ok {
+			s.violations = append(s.violations, zone)
+			return fmt.Errorf("scooter %v is stopped at the border of %q zone", s.ID, zone.Name)
 		}
-	default:
-		return fmt.Errorf("error happened")
+
+		passed += move
+		s.Latitude, s.Longitude = position.Latitude, position.Longitude
+
+		wasLow := s.BatteryRemain <= s.LowBatteryLevel
+		s.BatteryRemain = math.Max(s.BatteryRemain-move/1000*s.DischargePerKm, 0)
+
+		s.grpcScooterMessage(!wasLow && s.BatteryRemain <= s.LowBatteryLevel)
 	}
+
 	return nil
 }
 
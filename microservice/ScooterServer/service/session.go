@@ -0,0 +1,207 @@
+package service
+
+import (
+	"ScooterServer/proto"
+	"errors"
+	"sync"
+)
+
+//telemetryBuffer is the number of scooter messages which are kept for the trip until they are read.
+const telemetryBuffer = 16
+
+var (
+	ErrScooterBusy         = errors.New("scooter is already in a trip")
+	ErrScooterClientClosed = errors.New("scooter client disconnected during the trip")
+)
+
+//scooterConn is the Register stream of one connected scooter client. One client can move several scooters
+//at once, gRPC doesn't allow concurrent sends to the stream, so they are serialized.
+type scooterConn struct {
+	mu     sync.Mutex
+	stream proto.ScooterService_RegisterServer
+	trips  int
+}
+
+func (c *scooterConn) send(msg *proto.ScooterClient) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.stream.Send(msg)
+}
+
+//ScooterSession is the trip which is run on the scooter now. If a scooter client is connected,
+//the scooter is moved by the client and its messages are delivered to the session.
+type ScooterSession struct {
+	ScooterID uint64
+	OrderID   uint64
+	UserID    uint64
+	conn      *scooterConn
+	telemetry chan *proto.ClientMessage
+	finished  chan *proto.ClientMessage
+	closed    chan struct{}
+	closeOnce sync.Once
+}
+
+//Remote reports whether the scooter is moved by the connected scooter client.
+func (ss *ScooterSession) Remote() bool {
+	return ss.conn != nil
+}
+
+//Telemetry returns the messages of the scooter client about the scooter position.
+func (ss *ScooterSession) Telemetry() <-chan *proto.ClientMessage {
+	return ss.telemetry
+}
+
+//Finished returns the last message of the scooter client, which tells that the scooter has stopped.
+func (ss *ScooterSession) Finished() <-chan *proto.ClientMessage {
+	return ss.finished
+}
+
+//Closed is closed when the session is finished or the scooter client disconnected.
+func (ss *ScooterSession) Closed() <-chan struct{} {
+	return ss.closed
+}
+
+//Send sends the trip command to the scooter client.
+func (ss *ScooterSession) Send(command *proto.ScooterClient) error {
+	return ss.conn.send(command)
+}
+
+func (ss *ScooterSession) close() {
+	ss.closeOnce.Do(func() { close(ss.closed) })
+}
+
+//SessionRegistry keeps the trip sessions by scooter ID and the connected scooter clients.
+//It is safe for concurrent use, so many users can ride different scooters at the same time.
+type SessionRegistry struct {
+	mu           sync.Mutex
+	ScooterIdMap map[uint64]*ScooterSession
+	conns        map[proto.ScooterService_RegisterServer]*scooterConn
+}
+
+//NewSessionRegistry creates an empty SessionRegistry.
+func NewSessionRegistry() *SessionRegistry {
+	return &SessionRegistry{
+		ScooterIdMap: make(map[uint64]*ScooterSession),
+		conns:        make(map[proto.ScooterService_RegisterServer]*scooterConn),
+	}
+}
+
+//Start opens the session of the order's trip. The session is bound to the connected scooter client
+//which has the fewest trips now. If no client is connected, the session is local.
+func (sr *SessionRegistry) Start(order *proto.Order) (*ScooterSession, error) {
+	sr.mu.Lock()
+	defer sr.mu.Unlock()
+
+	if _, ok := sr.ScooterIdMap[order.ScooterID]; ok {
+		return nil, ErrScooterBusy
+	}
+
+	session := &ScooterSession{
+		ScooterID: order.ScooterID,
+		OrderID:   order.Id,
+		UserID:    order.UserID,
+		telemetry: make(chan *proto.ClientMessage, telemetryBuffer),
+		finished:  make(chan *proto.ClientMessage, 1),
+		closed:    make(chan struct{}),
+	}
+
+	for _, conn := range sr.conns {
+		if session.conn == nil || conn.trips < session.conn.trips {
+			session.conn = conn
+		}
+	}
+	if session.conn != nil {
+		session.conn.trips++
+	}
+
+	sr.ScooterIdMap[order.ScooterID] = session
+	return session, nil
+}
+
+//Finish closes the scooter session and makes the scooter free for the next trip.
+func (sr *SessionRegistry) Finish(scooterID uint64) {
+	sr.mu.Lock()
+	defer sr.mu.Unlock()
+
+	session, ok := sr.ScooterIdMap[scooterID]
+	if !ok {
+		return
+	}
+	if session.conn != nil {
+		session.conn.trips--
+	}
+	session.close()
+	delete(sr.ScooterIdMap, scooterID)
+}
+
+//Get returns the session of the scooter if it is in a trip now.
+func (sr *SessionRegistry) Get(scooterID uint64) (*ScooterSession, bool) {
+	sr.mu.Lock()
+	defer sr.mu.Unlock()
+
+	session, ok := sr.ScooterIdMap[scooterID]
+	return session, ok
+}
+
+//GetByOrder returns the session of the order's trip if the trip is run now.
+func (sr *SessionRegistry) GetByOrder(orderID uint64) (*ScooterSession, bool) {
+	sr.mu.Lock()
+	defer sr.mu.Unlock()
+
+	for _, session := range sr.ScooterIdMap {
+		if session.OrderID == orderID {
+			return session, true
+		}
+	}
+	return nil, false
+}
+
+//Connect adds the scooter client stream which can move the scooters.
+func (sr *SessionRegistry) Connect(stream proto.ScooterService_RegisterServer) {
+	sr.mu.Lock()
+	defer sr.mu.Unlock()
+
+	sr.conns[stream] = &scooterConn{stream: stream}
+}
+
+//Disconnect removes the scooter client stream. The trips which were moved by the client are closed.
+func (sr *SessionRegistry) Disconnect(stream proto.ScooterService_RegisterServer) {
+	sr.mu.Lock()
+	defer sr.mu.Unlock()
+
+	conn, ok := sr.conns[stream]
+	if !ok {
+		return
+	}
+	for _, session := range sr.ScooterIdMap {
+		if session.conn == conn {
+			session.close()
+		}
+	}
+	delete(sr.conns, stream)
+}
+
+//Deliver passes the scooter client message to the session of the scooter. The message is dropped
+//if the scooter is not in a trip of this client or the session doesn't read its messages.
+//The last message of the trip has its own channel, so it isn't dropped when the telemetry is full.
+func (sr *SessionRegistry) Deliver(stream proto.ScooterService_RegisterServer, msg *proto.ClientMessage) bool {
+	sr.mu.Lock()
+	defer sr.mu.Unlock()
+
+	session, ok := sr.ScooterIdMap[msg.Id]
+	if !ok || session.conn == nil || session.conn.stream != stream {
+		return false
+	}
+
+	messages := session.telemetry
+	if msg.Finished {
+		messages = session.finished
+	}
+
+	select {
+	case messages <- msg:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,75 @@
+package service
+
+import (
+	"ScooterServer/proto"
+	"shared.micro/geo"
+)
+
+//The kinds of the operator's zones, they are the same as in the zones table.
+const (
+	zoneNoParking    = "no_parking"
+	zoneSlow         = "slow"
+	zoneOutOfService = "out_of_service"
+)
+
+//TripEnd is the place where the scooter has stopped. StationID is 0 if the scooter was left out of the stations.
+//Violations are the zones whose rules were broken, PenaltyCents is the sum of their penalties.
+type TripEnd struct {
+	StationID    uint64
+	Location     Location
+	Violations   []*proto.Zone
+	PenaltyCents uint64
+}
+
+//contains reports whether the point is inside the zone area.
+func contains(zone *proto.Zone, point Location) bool {
+	area := make([]geo.Point, len(zone.Area))
+	for i, vertex := range zone.Area {
+		area[i] = geo.Point{Latitude: vertex.Latitude, Longitude: vertex.Longitude}
+	}
+	return geo.Contains(area, point)
+}
+
+//zoneAt returns the first zone of the kind which contains the point.
+func zoneAt(zones []*proto.Zone, point Location, kind string) (*proto.Zone, bool) {
+	for _, zone := range zones {
+		if zone.Kind == kind && contains(zone, point) {
+			return zone, true
+		}
+	}
+	return nil, false
+}
+
+//speedLimit returns the speed (km/h) which is allowed at the point for the scooter with the given max speed.
+func speedLimit(zones []*proto.Zone, point Location, speed float64) float64 {
+	for _, zone := range zones {
+		if zone.Kind == zoneSlow && zone.SpeedLimit > 0 && zone.SpeedLimit < speed && contains(zone, point) {
+			speed = zone.SpeedLimit
+		}
+	}
+	return speed
+}
+
+//finishTrip counts the penalty of the trip. The scooter left out of the stations in a no-parking zone
+//breaks its rule as well as the zones which were violated on the way. Every zone is charged once.
+func finishTrip(zones []*proto.Zone, end TripEnd) TripEnd {
+	if end.StationID == 0 {
+		if zone, ok := zoneAt(zones, end.Location, zoneNoParking); ok {
+			end.Violations = append(end.Violations, zone)
+		}
+	}
+
+	charged := make(map[uint64]bool)
+	var violations []*proto.Zone
+	for _, zone := range end.Violations {
+		if charged[zone.Id] {
+			continue
+		}
+		charged[zone.Id] = true
+		violations = append(violations, zone)
+		end.PenaltyCents += zone.PenaltyCents
+	}
+	end.Violations = violations
+
+	return end
+}
@@ -0,0 +1,141 @@
+//Package envconfig reads the configuration values from the environment and an optional KEY=VALUE file.
+//It's shared by the monolith and the microservices.
+package envconfig
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+//Loader reads the configuration values, the environment overrides the file. All the problems are collected,
+//so they can be reported at once by Err.
+type Loader struct {
+	file map[string]string
+	errs []string
+}
+
+//New creates the Loader which reads the KEY=VALUE file at path (if not empty) and the environment.
+func New(path string) (*Loader, error) {
+	l := &Loader{file: map[string]string{}}
+	if path == "" {
+		return l, nil
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("config: %w", err)
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		pair := strings.SplitN(text, "=", 2)
+		if len(pair) != 2 {
+			return nil, fmt.Errorf("config: %s:%d: expected KEY=VALUE", path, line)
+		}
+		l.file[strings.TrimSpace(pair[0])] = strings.Trim(strings.TrimSpace(pair[1]), `"'`)
+	}
+	if err = scanner.Err(); err != nil {
+		return nil, fmt.Errorf("config: %s: %w", path, err)
+	}
+	return l, nil
+}
+
+//lookup returns the value of the key, the environment wins over the file. Empty values count as unset.
+func (l *Loader) lookup(key string) (string, bool) {
+	if value, ok := os.LookupEnv(key); ok && value != "" {
+		return value, true
+	}
+	value, ok := l.file[key]
+	return value, ok && value != ""
+}
+
+//Str returns the value of the key or def if the key is unset.
+func (l *Loader) Str(key, def string) string {
+	if value, ok := l.lookup(key); ok {
+		return value
+	}
+	return def
+}
+
+//Required returns the value of the key, the unset key is reported as a problem.
+func (l *Loader) Required(key string) string {
+	value, ok := l.lookup(key)
+	if !ok {
+		l.errs = append(l.errs, key+" is required")
+	}
+	return value
+}
+
+//Int returns the integer value of the key or def if the key is unset.
+func (l *Loader) Int(key string, def int) int {
+	value, ok := l.lookup(key)
+	if !ok {
+		return def
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		l.errs = append(l.errs, fmt.Sprintf("%s: %q is not an integer", key, value))
+	}
+	return n
+}
+
+//Bool returns the boolean value of the key or def if the key is unset.
+func (l *Loader) Bool(key string, def bool) bool {
+	value, ok := l.lookup(key)
+	if !ok {
+		return def
+	}
+	b, err := strconv.ParseBool(value)
+	if err != nil {
+		l.errs = append(l.errs, fmt.Sprintf("%s: %q is not a boolean", key, value))
+	}
+	return b
+}
+
+//Duration returns the positive duration of the key or def if the key is unset.
+func (l *Loader) Duration(key string, def time.Duration) time.Duration {
+	value, ok := l.lookup(key)
+	if !ok {
+		return def
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil || d <= 0 {
+		l.errs = append(l.errs, fmt.Sprintf("%s: %q is not a positive duration", key, value))
+	}
+	return d
+}
+
+//Timeout is like Duration, but 0 is allowed and means no timeout.
+func (l *Loader) Timeout(key string, def time.Duration) time.Duration {
+	value, ok := l.lookup(key)
+	if !ok {
+		return def
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil || d < 0 {
+		l.errs = append(l.errs, fmt.Sprintf("%s: %q is not a duration", key, value))
+	}
+	return d
+}
+
+//Invalid reports the problem which is found by the checks of the loaded values.
+func (l *Loader) Invalid(problem string) {
+	l.errs = append(l.errs, problem)
+}
+
+//Err returns all the reported problems as one error or nil if there were none.
+func (l *Loader) Err() error {
+	if len(l.errs) == 0 {
+		return nil
+	}
+	return fmt.Errorf("config: %s", strings.Join(l.errs, "; "))
+}
@@ -0,0 +1,115 @@
+//Package geo has the spherical geometry of the trips which is shared by the monolith and the microservices.
+package geo
+
+import (
+	"math"
+	"time"
+)
+
+//EarthRadius is the radius (in meters) of the sphere which approximates the Earth.
+const EarthRadius = 6378100
+
+//Point is the place on the Earth given by latitude and longitude in degrees.
+type Point struct {
+	Latitude  float64
+	Longitude float64
+}
+
+//Distance returns the distance (in meters) between the points.
+func (p Point) Distance(to Point) float64 {
+	return Distance(p.Latitude, p.Longitude, to.Latitude, to.Longitude)
+}
+
+//Distance returns the distance (in meters) between two points given by latitude and longitude in degrees.
+//It's counted by the haversine formula on the sphere which approximates the Earth.
+//
+// http://en.wikipedia.org/wiki/Haversine_formula
+func Distance(lat1, lon1, lat2, lon2 float64) float64 {
+	la1, lo1 := Radians(lat1), Radians(lon1)
+	la2, lo2 := Radians(lat2), Radians(lon2)
+
+	h := hsin(la2-la1) + math.Cos(la1)*math.Cos(la2)*hsin(lo2-lo1)
+
+	return 2 * EarthRadius * math.Asin(math.Sqrt(h))
+}
+
+//Route is the great-circle path of the scooter from its position to the destination point.
+type Route struct {
+	from  Point
+	to    Point
+	angle float64
+}
+
+//NewRoute creates the Route between two points. The central angle is taken from the haversine distance,
+//so the length of the route is the same as Distance returns.
+func NewRoute(from, to Point) Route {
+	return Route{
+		from:  from,
+		to:    to,
+		angle: from.Distance(to) / EarthRadius,
+	}
+}
+
+//Distance returns the length of the route in meters.
+func (r Route) Distance() float64 {
+	return r.angle * EarthRadius
+}
+
+//PointAt returns the point of the route which is the given distance (in meters) away from its start.
+func (r Route) PointAt(passed float64) Point {
+	if passed <= 0 {
+		return r.from
+	}
+	if passed >= r.Distance() {
+		return r.to
+	}
+
+	fraction := passed / r.Distance()
+	a := math.Sin((1-fraction)*r.angle) / math.Sin(r.angle)
+	b := math.Sin(fraction*r.angle) / math.Sin(r.angle)
+
+	la1, lo1 := Radians(r.from.Latitude), Radians(r.from.Longitude)
+	la2, lo2 := Radians(r.to.Latitude), Radians(r.to.Longitude)
+
+	x := a*math.Cos(la1)*math.Cos(lo1) + b*math.Cos(la2)*math.Cos(lo2)
+	y := a*math.Cos(la1)*math.Sin(lo1) + b*math.Cos(la2)*math.Sin(lo2)
+	z := a*math.Sin(la1) + b*math.Sin(la2)
+
+	return Point{
+		Latitude:  Degrees(math.Atan2(z, math.Sqrt(x*x+y*y))),
+		Longitude: Degrees(math.Atan2(y, x)),
+	}
+}
+
+//StepLength returns the distance (in meters) which the scooter passes during the tick at the speed in km/h.
+func StepLength(speed float64, tick time.Duration) float64 {
+	return speed * 1000 / 3600 * tick.Seconds()
+}
+
+//Contains reports whether the point is inside the polygon (ray casting). The zones are small enough
+//to treat latitude and longitude as plane coordinates.
+func Contains(area []Point, point Point) bool {
+	inside := false
+	for i, j := 0, len(area)-1; i < len(area); j, i = i, i+1 {
+		a, b := area[i], area[j]
+		if (a.Latitude > point.Latitude) != (b.Latitude > point.Latitude) &&
+			point.Longitude < (b.Longitude-a.Longitude)*(point.Latitude-a.Latitude)/(b.Latitude-a.Latitude)+a.Longitude {
+			inside = !inside
+		}
+	}
+	return inside
+}
+
+//Radians converts the angle from degrees to radians.
+func Radians(degrees float64) float64 {
+	return degrees * math.Pi / 180
+}
+
+//Degrees converts the angle from radians to degrees.
+func Degrees(radians float64) float64 {
+	return radians * 180 / math.Pi
+}
+
+func hsin(theta float64) float64 {
+	return math.Pow(math.Sin(theta/2), 2)
+}
@@ -0,0 +1,73 @@
+package geo
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+//The shared module has no dependencies, so the tests use the standard library only.
+
+func TestRoute_PointAt(t *testing.T) {
+	testCases := []struct {
+		name string
+		from Point
+		to   Point
+	}{
+		{
+			name: "NorthEast",
+			from: Point{Latitude: 48.4223, Longitude: 35.0234},
+			to:   Point{Latitude: 48.4647, Longitude: 35.0462},
+		},
+		{
+			name: "SouthWest",
+			from: Point{Latitude: 48.4647, Longitude: 35.0462},
+			to:   Point{Latitude: 48.4012, Longitude: 34.9811},
+		},
+		{
+			name: "SamePoint",
+			from: Point{Latitude: 48.4223, Longitude: 35.0234},
+			to:   Point{Latitude: 48.4223, Longitude: 35.0234},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(tt *testing.T) {
+			route := NewRoute(tc.from, tc.to)
+			assertInDelta(tt, tc.from.Distance(tc.to), route.Distance(), 0.001)
+			if route.PointAt(0) != tc.from || route.PointAt(route.Distance()) != tc.to {
+				tt.Fatalf("the route doesn't start at %v and end at %v", tc.from, tc.to)
+			}
+
+			middle := route.PointAt(route.Distance() / 2)
+			assertInDelta(tt, route.Distance()/2, tc.from.Distance(middle), 0.01)
+			assertInDelta(tt, route.Distance()/2, middle.Distance(tc.to), 0.01)
+		})
+	}
+}
+
+func TestStepLength(t *testing.T) {
+	assertInDelta(t, 5.0, StepLength(36, 500*time.Millisecond), 0.000001)
+	assertInDelta(t, 0.0, StepLength(25, 0), 0)
+}
+
+func TestContains(t *testing.T) {
+	square := []Point{{48.42, 35.02}, {48.42, 35.03}, {48.43, 35.03}, {48.43, 35.02}}
+
+	if !Contains(square, Point{Latitude: 48.425, Longitude: 35.025}) {
+		t.Error("the point inside the square isn't contained")
+	}
+	if Contains(square, Point{Latitude: 48.435, Longitude: 35.025}) {
+		t.Error("the point outside the square is contained")
+	}
+	if Contains(nil, Point{Latitude: 48.425, Longitude: 35.025}) {
+		t.Error("the empty area contains the point")
+	}
+}
+
+func assertInDelta(t *testing.T, expected, actual, delta float64) {
+	t.Helper()
+	if math.Abs(expected-actual) > delta {
+		t.Fatalf("expected %v, got %v (delta %v)", expected, actual, delta)
+	}
+}
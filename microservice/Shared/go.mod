@@ -0,0 +1,3 @@
+module shared.micro
+
+go 1.17
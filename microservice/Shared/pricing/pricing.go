@@ -0,0 +1,65 @@
+//Package pricing counts the trip price by the tariff of the scooter's supplier. The monolith and the order service
+//both use it, so the trip is charged the same way whichever of them finishes it.
+package pricing
+
+import (
+	"math"
+	"time"
+)
+
+//Tariff is the price which the supplier set for the scooter model (in cents) and the supplier's commission percent.
+type Tariff struct {
+	PricePerMinute    int
+	PricePerKm        int
+	UnlockFee         int
+	MinimumCharge     int
+	CommissionPercent float64
+}
+
+//Breakdown is the itemised price of the trip in cents.
+type Breakdown struct {
+	Minutes            int
+	Kilometers         float64
+	TimeCents          int
+	DistanceCents      int
+	UnlockFeeCents     int
+	MinimumChargeCents int
+	PenaltyCents       int
+	TotalCents         int
+	CommissionCents    int
+	SupplierCents      int
+}
+
+//Calculate counts the trip price. Every started minute and every kilometer (distance is given in meters)
+//are charged by the tariff, the unlock fee is added once. If the sum is less than the minimum charge,
+//the difference is added to reach it. The supplier's commission is taken from the total.
+func Calculate(tariff Tariff, duration time.Duration, distance float64) Breakdown {
+	breakdown := Breakdown{
+		Minutes:    int(math.Ceil(duration.Minutes())),
+		Kilometers: distance / 1000,
+	}
+
+	breakdown.TimeCents = breakdown.Minutes * tariff.PricePerMinute
+	breakdown.DistanceCents = int(math.Round(breakdown.Kilometers * float64(tariff.PricePerKm)))
+	breakdown.UnlockFeeCents = tariff.UnlockFee
+
+	total := breakdown.TimeCents + breakdown.DistanceCents + breakdown.UnlockFeeCents
+	if total < tariff.MinimumCharge {
+		breakdown.MinimumChargeCents = tariff.MinimumCharge - total
+		total = tariff.MinimumCharge
+	}
+
+	breakdown.TotalCents = total
+	breakdown.CommissionCents = int(math.Round(float64(total) * tariff.CommissionPercent / 100))
+	breakdown.SupplierCents = total - breakdown.CommissionCents
+
+	return breakdown
+}
+
+//AddPenalty adds the penalty for the broken zone rules to the total. The penalty isn't shared with the supplier,
+//it goes to the platform with the commission.
+func (b *Breakdown) AddPenalty(cents int) {
+	b.PenaltyCents += cents
+	b.TotalCents += cents
+	b.CommissionCents += cents
+}
@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS order_prices CASCADE;
+ALTER TABLE IF EXISTS supplier_prices DROP COLUMN IF EXISTS price_per_km;
+ALTER TABLE IF EXISTS supplier_prices DROP COLUMN IF EXISTS unlock_fee;
+ALTER TABLE IF EXISTS supplier_prices DROP COLUMN IF EXISTS minimum_charge;
@@ -0,0 +1,19 @@
+ALTER TABLE supplier_prices ADD COLUMN IF NOT EXISTS price_per_km   NUMERIC(15, 2) NOT NULL DEFAULT 0;
+ALTER TABLE supplier_prices ADD COLUMN IF NOT EXISTS unlock_fee     NUMERIC(15, 2) NOT NULL DEFAULT 0;
+ALTER TABLE supplier_prices ADD COLUMN IF NOT EXISTS minimum_charge NUMERIC(15, 2) NOT NULL DEFAULT 0;
+
+CREATE TABLE IF NOT EXISTS order_prices
+(
+    order_id             bigint PRIMARY KEY,
+    minutes              int    NOT NULL,
+    kilometers           NUMERIC(12, 3) NOT NULL,
+    time_cents           bigint NOT NULL,
+    distance_cents       bigint NOT NULL,
+    unlock_fee_cents     bigint NOT NULL,
+    minimum_charge_cents bigint NOT NULL,
+    total_cents          bigint NOT NULL,
+    commission_cents     bigint NOT NULL,
+    supplier_cents       bigint NOT NULL,
+
+    FOREIGN KEY (order_id) REFERENCES orders (id) ON DELETE CASCADE
+    );
@@ -0,0 +1,3 @@
+
+ALTER TABLE IF EXISTS order_prices DROP COLUMN IF EXISTS supplier_id;
+ALTER TABLE IF EXISTS order_prices DROP COLUMN IF EXISTS payment_type_id;
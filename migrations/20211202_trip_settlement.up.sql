@@ -0,0 +1,3 @@
+ALTER TABLE order_prices ADD COLUMN IF NOT EXISTS supplier_id     int;
+ALTER TABLE order_prices ADD COLUMN IF NOT EXISTS payment_type_id smallint;
+
@@ -0,0 +1,3 @@
+ALTER TABLE IF EXISTS scooter_models DROP COLUMN IF EXISTS battery_capacity;
+ALTER TABLE IF EXISTS scooter_models DROP COLUMN IF EXISTS consumption_per_km;
+ALTER TABLE IF EXISTS scooter_models DROP COLUMN IF EXISTS low_battery_level;
@@ -0,0 +1,8 @@
+ALTER TABLE scooter_models ADD COLUMN IF NOT EXISTS battery_capacity   NUMERIC(7, 2) NOT NULL DEFAULT 280;
+ALTER TABLE scooter_models ADD COLUMN IF NOT EXISTS consumption_per_km NUMERIC(5, 2) NOT NULL DEFAULT 15;
+ALTER TABLE scooter_models ADD COLUMN IF NOT EXISTS low_battery_level  NUMERIC(5, 2) NOT NULL DEFAULT 10;
+
+UPDATE scooter_models SET battery_capacity = 280, consumption_per_km = 14, low_battery_level = 10
+    WHERE model_name = 'Xiaomi М365 Mi Scooter';
+UPDATE scooter_models SET battery_capacity = 624, consumption_per_km = 21, low_battery_level = 15
+    WHERE model_name = 'Kugoo G2 Pro';
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS scooter_reservations;
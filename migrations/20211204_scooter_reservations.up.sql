@@ -0,0 +1,18 @@
+CREATE TABLE IF NOT EXISTS scooter_reservations
+(
+    id          serial PRIMARY KEY,
+    user_id     int         NOT NULL,
+    scooter_id  int         NOT NULL,
+    created_at  TIMESTAMPTZ NOT NULL,
+    expires_at  TIMESTAMPTZ NOT NULL,
+    released_at TIMESTAMPTZ,
+    used        boolean     NOT NULL DEFAULT false,
+
+    FOREIGN KEY (user_id) REFERENCES users (id),
+    FOREIGN KEY (scooter_id) REFERENCES scooters (id)
+    );
+
+CREATE UNIQUE INDEX IF NOT EXISTS scooter_reservations_active_scooter
+    ON scooter_reservations (scooter_id) WHERE released_at IS NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS scooter_reservations_active_user
+    ON scooter_reservations (user_id) WHERE released_at IS NULL;
@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS api_tokens
+(
+    id                 serial PRIMARY KEY,
+    user_id            int         NOT NULL,
+    access_hash        varchar(64) NOT NULL UNIQUE,
+    refresh_hash       varchar(64) NOT NULL UNIQUE,
+    access_expires_at  TIMESTAMPTZ NOT NULL,
+    refresh_expires_at TIMESTAMPTZ NOT NULL,
+    revoked_at         TIMESTAMPTZ,
+
+    FOREIGN KEY (user_id) REFERENCES users (id)
+    );
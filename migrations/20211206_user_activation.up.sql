@@ -0,0 +1,3 @@
+ALTER TABLE users ADD COLUMN IF NOT EXISTS activated_at TIMESTAMPTZ;
+
+UPDATE users SET activated_at = COALESCE(created_at, now()) WHERE activated_at IS NULL;
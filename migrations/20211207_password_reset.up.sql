@@ -0,0 +1,13 @@
+ALTER TABLE users ADD COLUMN IF NOT EXISTS password_changed_at TIMESTAMPTZ;
+
+CREATE TABLE IF NOT EXISTS password_resets
+(
+    id         serial PRIMARY KEY,
+    user_id    int         NOT NULL,
+    token_hash varchar(64) NOT NULL UNIQUE,
+    created_at TIMESTAMPTZ NOT NULL,
+    expires_at TIMESTAMPTZ NOT NULL,
+    used_at    TIMESTAMPTZ,
+
+    FOREIGN KEY (user_id) REFERENCES users (id)
+    );
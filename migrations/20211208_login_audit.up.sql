@@ -0,0 +1,32 @@
+CREATE TABLE IF NOT EXISTS user_sessions
+(
+    id         serial PRIMARY KEY,
+    user_id    int         NOT NULL,
+    ip_address VARCHAR(45),
+    user_agent TEXT,
+    created_at TIMESTAMPTZ NOT NULL,
+    revoked_at TIMESTAMPTZ,
+
+    FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
+    );
+
+CREATE INDEX IF NOT EXISTS user_sessions_user ON user_sessions (user_id) WHERE revoked_at IS NULL;
+
+CREATE TABLE IF NOT EXISTS login_history
+(
+    id          serial PRIMARY KEY,
+    user_id     int,
+    login_email VARCHAR(100),
+    event       VARCHAR(16) NOT NULL,
+    session_id  int,
+    ip_address  VARCHAR(45),
+    user_agent  TEXT,
+    created_at  TIMESTAMPTZ NOT NULL,
+
+    FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE,
+    FOREIGN KEY (session_id) REFERENCES user_sessions (id) ON DELETE SET NULL
+    );
+
+CREATE INDEX IF NOT EXISTS login_history_user ON login_history (user_id, created_at DESC);
+
+ALTER TABLE login_status ALTER COLUMN ip_address TYPE VARCHAR(45);
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS login_history_failed_ip;
+
+ALTER TABLE users DROP COLUMN IF EXISTS locked_until;
+ALTER TABLE users DROP COLUMN IF EXISTS last_failed_at;
+ALTER TABLE users DROP COLUMN IF EXISTS failed_attempts;
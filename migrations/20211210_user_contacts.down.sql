@@ -0,0 +1,11 @@
+DROP INDEX IF EXISTS contacts_user_primary;
+DROP INDEX IF EXISTS contacts_user_info;
+
+ALTER TABLE contacts DROP CONSTRAINT IF EXISTS contacts_user_id_fkey;
+ALTER TABLE contacts ADD CONSTRAINT contacts_user_id_fkey FOREIGN KEY (user_id) REFERENCES users (id);
+ALTER TABLE contacts DROP COLUMN IF EXISTS is_primary;
+
+DELETE FROM contact_types WHERE code IN ('phone', 'email', 'telegram')
+    AND id NOT IN (SELECT type_id FROM contacts);
+DROP INDEX IF EXISTS contact_types_code;
+ALTER TABLE contact_types DROP COLUMN IF EXISTS code;
@@ -0,0 +1,13 @@
+ALTER TABLE contact_types ADD COLUMN IF NOT EXISTS code VARCHAR(20);
+CREATE UNIQUE INDEX IF NOT EXISTS contact_types_code ON contact_types (code);
+
+INSERT INTO contact_types(code, name) VALUES('phone', 'Phone number') ON CONFLICT (code) DO NOTHING;
+INSERT INTO contact_types(code, name) VALUES('email', 'Secondary email') ON CONFLICT (code) DO NOTHING;
+INSERT INTO contact_types(code, name) VALUES('telegram', 'Telegram') ON CONFLICT (code) DO NOTHING;
+
+ALTER TABLE contacts ADD COLUMN IF NOT EXISTS is_primary BOOLEAN NOT NULL DEFAULT false;
+ALTER TABLE contacts DROP CONSTRAINT IF EXISTS contacts_user_id_fkey;
+ALTER TABLE contacts ADD CONSTRAINT contacts_user_id_fkey FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE;
+
+CREATE UNIQUE INDEX IF NOT EXISTS contacts_user_info ON contacts (user_id, type_id, contact_info);
+CREATE UNIQUE INDEX IF NOT EXISTS contacts_user_primary ON contacts (user_id, type_id) WHERE is_primary;
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS scooter_statuses_station;
+ALTER TABLE scooter_stations DROP CONSTRAINT IF EXISTS scooter_stations_capacity_check;
+ALTER TABLE scooter_stations DROP COLUMN IF EXISTS capacity;
@@ -0,0 +1,10 @@
+ALTER TABLE scooter_stations ADD COLUMN IF NOT EXISTS capacity INT NOT NULL DEFAULT 10;
+ALTER TABLE scooter_stations ADD CONSTRAINT scooter_stations_capacity_check CHECK (capacity >= 0);
+
+-- stations which already hold more scooters than the default get room for all of them
+UPDATE scooter_stations AS st
+SET capacity = occ.occupied
+FROM (SELECT station_id, COUNT(*) AS occupied FROM scooter_statuses GROUP BY station_id) AS occ
+WHERE occ.station_id = st.id AND occ.occupied > st.capacity;
+
+CREATE INDEX IF NOT EXISTS scooter_statuses_station ON scooter_statuses (station_id);
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS scooter_statuses_station_rentable;
+DROP INDEX IF EXISTS scooter_stations_location;
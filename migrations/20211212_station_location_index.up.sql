@@ -0,0 +1,3 @@
+CREATE INDEX IF NOT EXISTS scooter_stations_location ON scooter_stations
+    USING gist (point(longitude::float8, latitude::float8)) WHERE is_active;
+CREATE INDEX IF NOT EXISTS scooter_statuses_station_rentable ON scooter_statuses (station_id) WHERE can_be_rent;
@@ -0,0 +1,3 @@
+ALTER TABLE order_prices DROP COLUMN IF EXISTS penalty_cents;
+DROP INDEX IF EXISTS zones_area;
+DROP TABLE IF EXISTS zones;
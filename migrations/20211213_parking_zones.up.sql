@@ -0,0 +1,18 @@
+CREATE TABLE IF NOT EXISTS zones
+(
+    id            serial PRIMARY KEY,
+    name          varchar(128) NOT NULL,
+    kind          varchar(32)  NOT NULL,
+    area          polygon      NOT NULL,
+    speed_limit   NUMERIC(5, 2),
+    penalty_cents bigint       NOT NULL DEFAULT 0,
+    is_active     boolean      NOT NULL DEFAULT true,
+
+    CONSTRAINT zones_kind_check CHECK (kind IN ('parking', 'no_parking', 'slow', 'out_of_service')),
+    CONSTRAINT zones_speed_limit_check CHECK (speed_limit IS NULL OR speed_limit > 0),
+    CONSTRAINT zones_penalty_check CHECK (penalty_cents >= 0)
+    );
+
+CREATE INDEX IF NOT EXISTS zones_area ON zones USING gist (area) WHERE is_active;
+
+ALTER TABLE order_prices ADD COLUMN IF NOT EXISTS penalty_cents bigint NOT NULL DEFAULT 0;
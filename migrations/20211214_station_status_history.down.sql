@@ -0,0 +1,8 @@
+ALTER TABLE scooter_statuses_in_rent DROP CONSTRAINT IF EXISTS scooter_statuses_in_rent_station_id_fkey;
+ALTER TABLE scooter_statuses_in_rent ADD CONSTRAINT scooter_statuses_in_rent_station_id_fkey
+    FOREIGN KEY (station_id) REFERENCES scooter_stations (id);
+DROP INDEX IF EXISTS station_status_changes_pending;
+DROP INDEX IF EXISTS station_status_changes_station;
+DROP TABLE IF EXISTS station_status_changes;
+ALTER TABLE scooter_stations DROP CONSTRAINT IF EXISTS scooter_stations_status_check;
+ALTER TABLE scooter_stations DROP COLUMN IF EXISTS status;
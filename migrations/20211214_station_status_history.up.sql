@@ -0,0 +1,29 @@
+ALTER TABLE scooter_stations ADD COLUMN IF NOT EXISTS status VARCHAR(16) NOT NULL DEFAULT 'active';
+ALTER TABLE scooter_stations ADD CONSTRAINT scooter_stations_status_check
+    CHECK (status IN ('active', 'maintenance', 'closed'));
+UPDATE scooter_stations SET status = 'maintenance' WHERE NOT COALESCE(is_active, false);
+
+CREATE TABLE IF NOT EXISTS station_status_changes
+(
+    id           bigserial PRIMARY KEY,
+    station_id   int         NOT NULL,
+    status       VARCHAR(16) NOT NULL,
+    reason       TEXT        NOT NULL,
+    changed_by   int,
+    created_at   TIMESTAMPTZ NOT NULL,
+    effective_at TIMESTAMPTZ NOT NULL,
+    applied_at   TIMESTAMPTZ,
+    cancelled_at TIMESTAMPTZ,
+
+    CONSTRAINT station_status_changes_status_check CHECK (status IN ('active', 'maintenance', 'closed')),
+    FOREIGN KEY (station_id) REFERENCES scooter_stations (id) ON DELETE CASCADE,
+    FOREIGN KEY (changed_by) REFERENCES users (id) ON DELETE SET NULL
+    );
+
+CREATE INDEX IF NOT EXISTS station_status_changes_station ON station_status_changes (station_id, effective_at DESC);
+CREATE INDEX IF NOT EXISTS station_status_changes_pending ON station_status_changes (effective_at)
+    WHERE applied_at IS NULL AND cancelled_at IS NULL;
+
+ALTER TABLE scooter_statuses_in_rent DROP CONSTRAINT IF EXISTS scooter_statuses_in_rent_station_id_fkey;
+ALTER TABLE scooter_statuses_in_rent ADD CONSTRAINT scooter_statuses_in_rent_station_id_fkey
+    FOREIGN KEY (station_id) REFERENCES scooter_stations (id) ON DELETE SET NULL;
@@ -0,0 +1,3 @@
+ALTER TABLE scooter_reservations DROP COLUMN IF EXISTS destination_longitude;
+ALTER TABLE scooter_reservations DROP COLUMN IF EXISTS destination_latitude;
+ALTER TABLE scooter_reservations DROP COLUMN IF EXISTS destination_station_id;
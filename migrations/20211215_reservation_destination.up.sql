@@ -0,0 +1,4 @@
+ALTER TABLE scooter_reservations ADD COLUMN IF NOT EXISTS destination_station_id int
+    REFERENCES scooter_stations (id) ON DELETE SET NULL;
+ALTER TABLE scooter_reservations ADD COLUMN IF NOT EXISTS destination_latitude float8;
+ALTER TABLE scooter_reservations ADD COLUMN IF NOT EXISTS destination_longitude float8;
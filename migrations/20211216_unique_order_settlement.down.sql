@@ -0,0 +1 @@
+DROP INDEX IF EXISTS account_transactions_order_payment_type;
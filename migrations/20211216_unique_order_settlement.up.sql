@@ -0,0 +1,2 @@
+CREATE UNIQUE INDEX IF NOT EXISTS account_transactions_order_payment_type
+    ON account_transactions (order_id, payment_type_id) WHERE order_id <> 0;
@@ -0,0 +1,6 @@
+ALTER TABLE station_status_changes DROP CONSTRAINT IF EXISTS station_status_changes_station_id_fkey;
+ALTER TABLE station_status_changes ADD CONSTRAINT station_status_changes_station_id_fkey
+    FOREIGN KEY (station_id) REFERENCES scooter_stations (id) ON DELETE CASCADE;
+
+DELETE FROM scooter_stations WHERE deleted_at IS NOT NULL;
+ALTER TABLE scooter_stations DROP COLUMN IF EXISTS deleted_at;
@@ -0,0 +1,5 @@
+ALTER TABLE scooter_stations ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMPTZ;
+
+ALTER TABLE station_status_changes DROP CONSTRAINT IF EXISTS station_status_changes_station_id_fkey;
+ALTER TABLE station_status_changes ADD CONSTRAINT station_status_changes_station_id_fkey
+    FOREIGN KEY (station_id) REFERENCES scooter_stations (id);
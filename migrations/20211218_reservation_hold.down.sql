@@ -0,0 +1 @@
+ALTER TABLE scooter_statuses DROP COLUMN IF EXISTS reserved;
@@ -0,0 +1,4 @@
+ALTER TABLE scooter_statuses ADD COLUMN IF NOT EXISTS reserved boolean NOT NULL DEFAULT false;
+
+UPDATE scooter_statuses SET reserved = true
+    WHERE scooter_id IN (SELECT scooter_id FROM scooter_reservations WHERE released_at IS NULL);
@@ -0,0 +1,36 @@
+package models
+
+//BatteryModel is the battery of the scooter model. The capacity is given in Wh, the consumption in Wh per km,
+//the low level is the battery percent below which the rider is warned and the scooter can't be rented.
+type BatteryModel struct {
+	Capacity         float64 `json:"capacity"`
+	ConsumptionPerKm float64 `json:"consumption_per_km"`
+	LowLevel         float64 `json:"low_level"`
+}
+
+//DischargePerKm returns the battery percent which the scooter spends for every kilometer.
+func (bm BatteryModel) DischargePerKm() float64 {
+	if bm.Capacity <= 0 {
+		return 0
+	}
+	return bm.ConsumptionPerKm / bm.Capacity * 100
+}
+
+//Range returns the distance (in meters) which the scooter can pass with the given battery percent.
+func (bm BatteryModel) Range(batteryRemain float64) float64 {
+	if bm.ConsumptionPerKm <= 0 || batteryRemain <= 0 {
+		return 0
+	}
+	return batteryRemain / 100 * bm.Capacity / bm.ConsumptionPerKm * 1000
+}
+
+//BatteryWarning is sent to the rider when the scooter battery goes below the low level of its model.
+//It suggests the nearest station where the trip can be finished.
+type BatteryWarning struct {
+	ScooterID     uint64  `json:"scooter_id"`
+	BatteryRemain float64 `json:"battery_remain"`
+	Range         float64 `json:"range"`
+	Station       Station `json:"station"`
+	Distance      float64 `json:"distance"`
+	Reachable     bool    `json:"reachable"`
+}
@@ -0,0 +1,34 @@
+package models
+
+// codes of contact types which have their own validation
+const (
+	ContactTypePhone    = "phone"
+	ContactTypeEmail    = "email"
+	ContactTypeTelegram = "telegram"
+)
+
+// ContactType - entity for kinds of user contacts (phone, secondary email...)
+type ContactType struct {
+	ID   int    `json:"id"`
+	Code string `json:"code"`
+	Name string `json:"name"`
+}
+
+// ContactTypeList - struct with list of contact types
+type ContactTypeList struct {
+	ContactTypes []ContactType `json:"contact_types"`
+}
+
+// Contact - entity representing contact of user. The primary contact of each type is used for notifications
+type Contact struct {
+	ID          int         `json:"id"`
+	UserID      int         `json:"user_id"`
+	Type        ContactType `json:"type"`
+	ContactInfo string      `json:"contact_info"`
+	IsPrimary   bool        `json:"is_primary"`
+}
+
+// ContactList - struct for list of user contacts
+type ContactList struct {
+	Contacts []Contact `json:"contacts"`
+}
@@ -1,6 +1,12 @@
 package models
 
-import "math"
+import (
+	"math"
+	"shared.micro/geo"
+)
+
+//EarthRadius is the radius (in meters) of the sphere which approximates the Earth.
+const EarthRadius = geo.EarthRadius
 
 //Coordinate is for comfortable storage latitude, longitude values.
 type Coordinate struct {
@@ -15,20 +21,25 @@ type Coordinate struct {
 //
 // http://en.wikipedia.org/wiki/Haversine_formula
 func (l1 Coordinate) Distance(l2 Coordinate) float64 {
-	// convert to radians
-	var la1, lo1, la2, lo2, earthRadius float64
-	la1 = l1.Latitude * math.Pi / 180
-	lo1 = l1.Longitude * math.Pi / 180
-	la2 = l2.Latitude * math.Pi / 180
-	lo2 = l2.Longitude * math.Pi / 180
-
-	earthRadius = 6378100 // Earth radius in meters
-
-	h := hsin(la2-la1) + math.Cos(la1)*math.Cos(la2)*hsin(lo2-lo1)
-
-	return 2 * earthRadius * math.Asin(math.Sqrt(h))
+	return geo.Point(l1).Distance(geo.Point(l2))
 }
+//BoundingBox returns the south-west and north-east corners of the box which contains all the points
+//within radius (in meters) of the coordinate. Near the poles and the antimeridian the box spans all longitudes.
+func (l1 Coordinate) BoundingBox(radius float64) (Coordinate, Coordinate) {
+	angle := radius / EarthRadius
+	dLat := angle * 180 / math.Pi
+	southWest := Coordinate{Latitude: math.Max(l1.Latitude-dLat, -90), Longitude: -180}
+	northEast := Coordinate{Latitude: math.Min(l1.Latitude+dLat, 90), Longitude: 180}
 
-func hsin(theta float64) float64 {
-	return math.Pow(math.Sin(theta/2), 2)
-}
\ No newline at end of file
+	sinLon := math.Sin(angle) / math.Cos(l1.Latitude*math.Pi/180)
+	if angle >= math.Pi/2 || sinLon >= 1 {
+		return southWest, northEast
+	}
+	dLon := math.Asin(sinLon) * 180 / math.Pi
+	if l1.Longitude-dLon < -180 || l1.Longitude+dLon > 180 {
+		return southWest, northEast
+	}
+	southWest.Longitude = l1.Longitude - dLon
+	northEast.Longitude = l1.Longitude + dLon
+	return southWest, northEast
+}
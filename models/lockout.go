@@ -0,0 +1,10 @@
+package models
+
+import "time"
+
+// LoginFailures - failed sign in attempts of user since the last successful sign in or lockout
+type LoginFailures struct {
+	FailedAttempts int       `json:"failed_attempts"`
+	LastFailedAt   time.Time `json:"last_failed_at"`
+	LockedUntil    time.Time `json:"locked_until"`
+}
@@ -0,0 +1,43 @@
+package models
+
+import "time"
+
+// kinds of login events
+const (
+	LoginEventSignIn  = "sign_in"
+	LoginEventSignOut = "sign_out"
+	LoginEventFailed  = "failed"
+)
+
+// LoginEvent - entity representing sign in, sign out or failed sign in attempt of user.
+// UserID is zero for failed attempts with unknown email
+type LoginEvent struct {
+	ID         int       `json:"id"`
+	UserID     int       `json:"user_id"`
+	LoginEmail string    `json:"login_email"`
+	Event      string    `json:"event"`
+	SessionID  int       `json:"session_id"`
+	IPAddress  string    `json:"ip_address"`
+	UserAgent  string    `json:"user_agent"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// LoginEventList - struct for list of login events
+type LoginEventList struct {
+	LoginEvents []LoginEvent `json:"login_events"`
+}
+
+// UserSession - entity representing browser session of user created on sign in
+type UserSession struct {
+	ID        int       `json:"id"`
+	UserID    int       `json:"user_id"`
+	IPAddress string    `json:"ip_address"`
+	UserAgent string    `json:"user_agent"`
+	CreatedAt time.Time `json:"created_at"`
+	Current   bool      `json:"current"`
+}
+
+// UserSessionList - struct for list of user sessions
+type UserSessionList struct {
+	Sessions []UserSession `json:"sessions"`
+}
@@ -0,0 +1,12 @@
+package models
+
+import "time"
+
+// PasswordReset - entity representing password reset request of user, only hash of the token is stored
+type PasswordReset struct {
+	ID        int       `json:"id"`
+	UserID    int       `json:"user_id"`
+	TokenHash string    `json:"-"`
+	CreatedAt time.Time `json:"created_at"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
@@ -0,0 +1,72 @@
+package models
+
+// kinds of places which are imported & exported together
+const (
+	PlaceStation  = "station"
+	PlaceLocation = "location"
+)
+
+// Place - station or location in the form which is common for the import & export files.
+// Name is the label of the location. IsActive and Capacity are used by stations only, nil IsActive
+// and zero Capacity of the imported station keep the current values (or the defaults for the new one)
+type Place struct {
+	Kind      string  `json:"kind"`
+	ID        int     `json:"id,omitempty"`
+	Name      string  `json:"name"`
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+	IsActive  *bool   `json:"is_active,omitempty"`
+	Capacity  int     `json:"capacity,omitempty"`
+	Occupied  *int    `json:"occupied,omitempty"`
+	FreeSlots *int    `json:"free_slots,omitempty"`
+}
+
+// FeatureCollection - GeoJSON collection of places, see RFC 7946
+type FeatureCollection struct {
+	Type     string    `json:"type"`
+	Features []Feature `json:"features"`
+}
+
+// Feature - GeoJSON feature with the point of the place, the rest of the place is in the properties
+type Feature struct {
+	Type       string   `json:"type"`
+	Geometry   Geometry `json:"geometry"`
+	Properties Place    `json:"properties"`
+}
+
+// Geometry - GeoJSON geometry, the coordinates of a point are [longitude, latitude]
+type Geometry struct {
+	Type        string    `json:"type"`
+	Coordinates []float64 `json:"coordinates"`
+}
+
+// ImportReport - result of the import: what is (or, for the dry run, would be) created & updated.
+// Nothing is applied if there are errors
+type ImportReport struct {
+	DryRun    bool          `json:"dry_run"`
+	Applied   bool          `json:"applied"`
+	Created   []PlaceChange `json:"created"`
+	Updated   []PlaceChange `json:"updated"`
+	Unchanged int           `json:"unchanged"`
+	Errors    []ImportError `json:"errors"`
+}
+
+// PlaceChange - imported place which differs from the stored one, Record is its number in the file
+type PlaceChange struct {
+	Record  int           `json:"record"`
+	Place   Place         `json:"place"`
+	Changes []FieldChange `json:"changes,omitempty"`
+}
+
+// FieldChange - old & new value of the changed field
+type FieldChange struct {
+	Field string `json:"field"`
+	Old   string `json:"old"`
+	New   string `json:"new"`
+}
+
+// ImportError - problem of the record with given number (0 if the whole file is wrong)
+type ImportError struct {
+	Record  int    `json:"record"`
+	Message string `json:"message"`
+}
@@ -0,0 +1,29 @@
+package models
+
+// Tariff - supplier's prices (in cents) for the rent of the scooter model
+type Tariff struct {
+	SupplierID        int     `json:"supplier_id"`
+	PaymentTypeID     int     `json:"payment_type_id"`
+	PricePerMinute    int     `json:"price_per_minute"`
+	PricePerKm        int     `json:"price_per_km"`
+	UnlockFee         int     `json:"unlock_fee"`
+	MinimumCharge     int     `json:"minimum_charge"`
+	CommissionPercent float64 `json:"commission_percent"`
+}
+
+// PriceBreakdown - itemised price of the trip in cents
+type PriceBreakdown struct {
+	OrderID            int     `json:"order_id"`
+	SupplierID         int     `json:"supplier_id"`
+	PaymentTypeID      int     `json:"payment_type_id"`
+	Minutes            int     `json:"minutes"`
+	Kilometers         float64 `json:"kilometers"`
+	TimeCents          int     `json:"time_cents"`
+	DistanceCents      int     `json:"distance_cents"`
+	UnlockFeeCents     int     `json:"unlock_fee_cents"`
+	MinimumChargeCents int     `json:"minimum_charge_cents"`
+	PenaltyCents       int     `json:"penalty_cents"`
+	TotalCents         int     `json:"total_cents"`
+	CommissionCents    int     `json:"commission_cents"`
+	SupplierCents      int     `json:"supplier_cents"`
+}
@@ -0,0 +1,14 @@
+package models
+
+import "time"
+
+//Reservation holds the scooter for the user until the trip starts or the hold expires.
+//Destination is where the user is going to finish the trip, nil until it's chosen.
+type Reservation struct {
+	ID          int              `json:"id"`
+	UserID      int              `json:"user_id"`
+	ScooterID   int              `json:"scooter_id"`
+	CreatedAt   time.Time        `json:"created_at"`
+	ExpiresAt   time.Time        `json:"expires_at"`
+	Destination *TripDestination `json:"destination,omitempty"`
+}
@@ -4,11 +4,13 @@ import "time"
 
 //ScooterDTO is a scooter model with custom parameters.
 type ScooterDTO struct {
-	ID            int     `json:"scooter_id"`
-	ScooterModel  string  `json:"scooter_model"`
-	MaxWeight     float64 `json:"max_weight"`
-	BatteryRemain float64 `json:"battery_remain"`
-	CanBeRent     bool    `json:"can_be_rent"`
+	ID            int          `json:"scooter_id"`
+	ScooterModel  string       `json:"scooter_model"`
+	MaxWeight     float64      `json:"max_weight"`
+	Speed         int          `json:"speed"`
+	BatteryRemain float64      `json:"battery_remain"`
+	CanBeRent     bool         `json:"can_be_rent"`
+	Battery       BatteryModel `json:"battery"`
 }
 
 //ScooterListDTO keeps a list of ScooterDTO.
@@ -31,3 +33,21 @@ type ScooterStatusInRent struct {
 	DateTime  time.Time  `json:"date_time"`
 	Location  Coordinate `json:"location"`
 }
+
+//TripDestination is the place where the rider wants to finish the trip: the station or, if StationID is 0,
+//any point where the parking is allowed.
+type TripDestination struct {
+	StationID int        `json:"station_id"`
+	Location  Coordinate `json:"location"`
+}
+
+//TripEnd is the place where the scooter has stopped. StationID is 0 if the scooter was left out of the stations.
+//Violations are the zones whose rules were broken, PenaltyCents is the sum of their penalties.
+//StationFull is set if the destination station had no free slot when the scooter arrived.
+type TripEnd struct {
+	StationID    int        `json:"station_id"`
+	Location     Coordinate `json:"location"`
+	Violations   []Zone     `json:"violations"`
+	PenaltyCents int        `json:"penalty_cents"`
+	StationFull  bool       `json:"station_full,omitempty"`
+}
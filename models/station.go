@@ -1,17 +1,57 @@
 package models
 
+import "time"
+
+// statuses of the station, only the active station takes part in the trips
+const (
+	StationActive      = "active"
+	StationMaintenance = "maintenance"
+	StationClosed      = "closed"
+)
+
 type Station struct {
 	ID        int     `json:"id"`
 	Name      string  `json:"name"`
 	IsActive  bool    `json:"is_active"`
+	Status    string  `json:"status"`
 	Latitude  float64 `json:"latitude"`
 	Longitude float64 `json:"longitude"`
+	Capacity  int     `json:"capacity"`
+	Occupied  int     `json:"occupied"`
+	FreeSlots int     `json:"free_slots"`
 }
 
 type StationList struct {
 	Station []Station `json:"station"`
 }
 
+// StationStatusChange - record of the station status history. The change with EffectiveAt in the future
+// is scheduled and is applied when its time comes unless it's cancelled. ChangedBy is 0 if the author is unknown
+type StationStatusChange struct {
+	ID          int        `json:"id"`
+	StationID   int        `json:"station_id"`
+	Status      string     `json:"status"`
+	Reason      string     `json:"reason"`
+	ChangedBy   int        `json:"changed_by"`
+	CreatedAt   time.Time  `json:"created_at"`
+	EffectiveAt time.Time  `json:"effective_at"`
+	AppliedAt   *time.Time `json:"applied_at"`
+	CancelledAt *time.Time `json:"cancelled_at"`
+}
+
+// StationStatusHistory - status changes of the station, the latest first
+type StationStatusHistory struct {
+	Changes []StationStatusChange `json:"changes"`
+}
+
+// NearbyStation - station found around a point, Distance is in meters
+type NearbyStation struct {
+	Station
+	Distance         float64 `json:"distance"`
+	WalkingMinutes   int     `json:"walking_minutes"`
+	RentableScooters int     `json:"rentable_scooters"`
+}
+
 type Location struct {
 	ID        int     `json:"id"`
 	Latitude  float64 `json:"latitude"`
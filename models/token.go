@@ -0,0 +1,22 @@
+package models
+
+import "time"
+
+// AuthToken - entity representing pair of api tokens issued to user, only hashes of the tokens are stored
+type AuthToken struct {
+	ID               int       `json:"id"`
+	UserID           int       `json:"user_id"`
+	AccessHash       string    `json:"-"`
+	RefreshHash      string    `json:"-"`
+	AccessExpiresAt  time.Time `json:"access_expires_at"`
+	RefreshExpiresAt time.Time `json:"refresh_expires_at"`
+}
+
+// TokenPair - access & refresh tokens returned to api client
+type TokenPair struct {
+	AccessToken      string    `json:"access_token"`
+	RefreshToken     string    `json:"refresh_token"`
+	TokenType        string    `json:"token_type"`
+	AccessExpiresAt  time.Time `json:"access_expires_at"`
+	RefreshExpiresAt time.Time `json:"refresh_expires_at"`
+}
@@ -6,6 +6,6 @@ type StationUsecasesRepo interface {
 	GetAllStations() (*models.StationList, error)
 	GetStationById(stationId int) (models.Station, error)
 	AddStation(station *models.Station) error
-	DeleteStation(stationId int) error
-	ChangeStationBlockStatus(stationId int) error
+	DeleteStation(stationId, moveTo int) error
+	ChangeStatus(change *models.StationStatusChange) error
 }
@@ -28,6 +28,8 @@ type User struct {
 	CreatedAt   time.Time `json:"created_at"`
 	Role        Role      `json:"role"`
 	Password    string    `json:"password"`
+	IsActivated bool      `json:"is_activated"`
+	IsLocked    bool      `json:"is_locked"`
 }
 
 // UserList - struct for list of users
@@ -0,0 +1,38 @@
+package models
+
+import "shared.micro/geo"
+
+// kinds of operator-defined zones
+const (
+	ZoneParking      = "parking"
+	ZoneNoParking    = "no_parking"
+	ZoneSlow         = "slow"
+	ZoneOutOfService = "out_of_service"
+)
+
+// Zone - area on the map with the operator's rules. The last vertex of Area is joined with the first one.
+// SpeedLimit (km/h) is used in slow zones, PenaltyCents is charged from the rider who breaks the zone rule
+type Zone struct {
+	ID           int          `json:"id"`
+	Name         string       `json:"name"`
+	Kind         string       `json:"kind"`
+	Area         []Coordinate `json:"area"`
+	SpeedLimit   float64      `json:"speed_limit"`
+	PenaltyCents int          `json:"penalty_cents"`
+	IsActive     bool         `json:"is_active"`
+}
+
+// ZoneList - struct with list of zones
+type ZoneList struct {
+	Zones []Zone `json:"zones"`
+}
+
+// Contains - reports whether the point is inside the zone area (ray casting). The zones are small enough
+// to treat latitude and longitude as plane coordinates
+func (z Zone) Contains(point Coordinate) bool {
+	area := make([]geo.Point, len(z.Area))
+	for i, vertex := range z.Area {
+		area[i] = geo.Point(vertex)
+	}
+	return geo.Contains(area, geo.Point(point))
+}
@@ -3,29 +3,39 @@ package repositories
 
 import (
 	"Dp218GO/models"
+	"context"
+	"errors"
 	"time"
 )
 
+var (
+	// ErrNoAccount - error returned if the account with given number doesn't exist
+	ErrNoAccount = errors.New("account is not found")
+	// ErrOrderAlreadySettled - the order already has its money transactions
+	ErrOrderAlreadySettled = errors.New("order is already paid")
+)
+
 // AccountRepo - interface for money account repository
 type AccountRepo interface {
-	GetAccountsByOwner(user models.User) (*models.AccountList, error)
-	GetAccountByID(accountID int) (models.Account, error)
-	GetAccountByNumber(number string) (models.Account, error)
-	AddAccount(account *models.Account) error
-	UpdateAccount(accountID int, accountData models.Account) (models.Account, error)
+	GetAccountsByOwner(ctx context.Context, user models.User) (*models.AccountList, error)
+	GetAccountByID(ctx context.Context, accountID int) (models.Account, error)
+	GetAccountByNumber(ctx context.Context, number string) (models.Account, error)
+	AddAccount(ctx context.Context, account *models.Account) error
+	UpdateAccount(ctx context.Context, accountID int, accountData models.Account) (models.Account, error)
 }
 
 // AccountTransactionRepo - interface for money transaction repository
 type AccountTransactionRepo interface {
-	GetAccountTransactionByID(transID int) (models.AccountTransaction, error)
-	AddAccountTransaction(accountTransaction *models.AccountTransaction) error
-	GetAccountTransactions(accounts ...models.Account) (*models.AccountTransactionList, error)
-	GetAccountTransactionsInTimePeriod(start time.Time, end time.Time, accounts ...models.Account) (*models.AccountTransactionList, error) //nolint:lll
-	GetAccountTransactionsByOrder(order models.Order) (*models.AccountTransactionList, error)
-	GetAccountTransactionsByPaymentType(paymentType models.PaymentType, accounts ...models.Account) (*models.AccountTransactionList, error) //nolint:lll
+	GetAccountTransactionByID(ctx context.Context, transID int) (models.AccountTransaction, error)
+	AddAccountTransaction(ctx context.Context, accountTransaction *models.AccountTransaction) error
+	AddAccountTransactions(ctx context.Context, accountTransactions ...*models.AccountTransaction) error
+	GetAccountTransactions(ctx context.Context, accounts ...models.Account) (*models.AccountTransactionList, error)
+	GetAccountTransactionsInTimePeriod(ctx context.Context, start time.Time, end time.Time, accounts ...models.Account) (*models.AccountTransactionList, error) //nolint:lll
+	GetAccountTransactionsByOrder(ctx context.Context, order models.Order) (*models.AccountTransactionList, error)
+	GetAccountTransactionsByPaymentType(ctx context.Context, paymentType models.PaymentType, accounts ...models.Account) (*models.AccountTransactionList, error) //nolint:lll
 }
 
 // PaymentTypeRepo - interface for payment type repository
 type PaymentTypeRepo interface {
-	GetPaymentTypeById(paymentTypeID int) (models.PaymentType, error)
+	GetPaymentTypeById(ctx context.Context, paymentTypeID int) (models.PaymentType, error)
 }
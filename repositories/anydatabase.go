@@ -1,3 +1,4 @@
+//go:generate mockgen -source=anydatabase.go -destination=../repositories/mock/mock_anydatabase.go -package=mock
 package repositories
 
 import (
@@ -11,5 +12,12 @@ type AnyDatabase interface {
 	QueryResult(context.Context, string, ...interface{}) (pgx.Rows, error)
 	QueryResultRow(context.Context, string, ...interface{}) pgx.Row
 	QueryExec(context.Context, string, ...interface{}) (pgconn.CommandTag, error)
+	TxManager
 	CloseDB()
 }
+
+// TxManager - interface for running several database calls in one transaction.
+// Every query made with the context given to the function is a part of the transaction
+type TxManager interface {
+	WithTx(context.Context, func(context.Context) error) error
+}
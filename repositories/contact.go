@@ -0,0 +1,30 @@
+//go:generate mockgen -source=contact.go -destination=../repositories/mock/mock_contact.go -package=mock
+package repositories
+
+import (
+	"Dp218GO/models"
+	"context"
+	"errors"
+)
+
+var (
+	// ErrNoContact - error returned if user has no contact with given ID (or no primary contact of given type)
+	ErrNoContact = errors.New("contact is not found")
+	// ErrNoContactType - error returned if contact type with given ID doesn't exist
+	ErrNoContactType = errors.New("contact type is not found")
+	// ErrContactExists - error returned if user already has the same contact
+	ErrContactExists = errors.New("contact already exists")
+)
+
+// ContactRepo - interface for user contacts repository
+type ContactRepo interface {
+	GetContactTypes(ctx context.Context) (*models.ContactTypeList, error)
+	GetContactTypeByID(ctx context.Context, typeID int) (models.ContactType, error)
+
+	GetContactsByUserID(ctx context.Context, userID int) (*models.ContactList, error)
+	GetContactByID(ctx context.Context, userID, contactID int) (models.Contact, error)
+	GetPrimaryContact(ctx context.Context, userID int, typeCode string) (models.Contact, error)
+	AddContact(ctx context.Context, contact *models.Contact) error
+	UpdateContact(ctx context.Context, contact models.Contact) error
+	DeleteContact(ctx context.Context, userID, contactID int) error
+}
@@ -0,0 +1,18 @@
+//go:generate mockgen -source=location.go -destination=../repositories/mock/mock_location.go -package=mock
+package repositories
+
+import (
+	"Dp218GO/models"
+	"context"
+	"errors"
+)
+
+// ErrNoLocation - error returned if location with given ID doesn't exist
+var ErrNoLocation = errors.New("location is not found")
+
+// LocationRepo - interface for locations repository
+type LocationRepo interface {
+	GetAllLocations(ctx context.Context) (*models.LocationList, error)
+	AddLocation(ctx context.Context, location *models.Location) error
+	UpdateLocation(ctx context.Context, location models.Location) error
+}
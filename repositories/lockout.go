@@ -0,0 +1,17 @@
+//go:generate mockgen -source=lockout.go -destination=../repositories/mock/mock_lockout.go -package=mock
+package repositories
+
+import (
+	"Dp218GO/models"
+	"context"
+	"time"
+)
+
+// LockoutRepo - interface for repository of failed sign in attempts by account & IP address
+type LockoutRepo interface {
+	GetLoginFailures(ctx context.Context, email string) (models.LoginFailures, error)
+	AddLoginFailure(ctx context.Context, email string, failedAt time.Time) (int, error)
+	LockUser(ctx context.Context, email string, lockedUntil time.Time) error
+	ResetLoginFailures(ctx context.Context, email string) error
+	CountFailedByIP(ctx context.Context, ipAddress string, since time.Time) (int, error)
+}
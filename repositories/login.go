@@ -0,0 +1,24 @@
+//go:generate mockgen -source=login.go -destination=../repositories/mock/mock_login.go -package=mock
+package repositories
+
+import (
+	"Dp218GO/models"
+	"context"
+	"errors"
+	"time"
+)
+
+// ErrNoSession - error returned if there is no active session with given ID
+var ErrNoSession = errors.New("session is not found or revoked")
+
+// LoginAuditRepo - interface for login history & user sessions repository
+type LoginAuditRepo interface {
+	AddLoginEvent(ctx context.Context, event *models.LoginEvent) error
+	GetLoginEventsByUserID(ctx context.Context, userID, limit int) (*models.LoginEventList, error)
+
+	AddSession(ctx context.Context, session *models.UserSession) error
+	IsSessionActive(ctx context.Context, sessionID int) (bool, error)
+	GetActiveSessionsByUserID(ctx context.Context, userID int) (*models.UserSessionList, error)
+	RevokeSession(ctx context.Context, userID, sessionID int, revokedAt time.Time) error
+	RevokeOtherSessions(ctx context.Context, userID, keepSessionID int, revokedAt time.Time) error
+}
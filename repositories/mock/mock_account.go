@@ -6,6 +6,7 @@ package mock
 
 import (
 	models "Dp218GO/models"
+	context "context"
 	reflect "reflect"
 	time "time"
 
@@ -36,77 +37,77 @@ func (m *MockAccountRepo) EXPECT() *MockAccountRepoMockRecorder {
 }
 
 // AddAccount mocks base method.
-func (m *MockAccountRepo) AddAccount(account *models.Account) error {
+func (m *MockAccountRepo) AddAccount(ctx context.Context, account *models.Account) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "AddAccount", account)
+	ret := m.ctrl.Call(m, "AddAccount", ctx, account)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // AddAccount indicates an expected call of AddAccount.
-func (mr *MockAccountRepoMockRecorder) AddAccount(account interface{}) *gomock.Call {
+func (mr *MockAccountRepoMockRecorder) AddAccount(ctx, account interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddAccount", reflect.TypeOf((*MockAccountRepo)(nil).AddAccount), account)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddAccount", reflect.TypeOf((*MockAccountRepo)(nil).AddAccount), ctx, account)
 }
 
 // GetAccountByID mocks base method.
-func (m *MockAccountRepo) GetAccountByID(accountID int) (models.Account, error) {
+func (m *MockAccountRepo) GetAccountByID(ctx context.Context, accountID int) (models.Account, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAccountByID", accountID)
+	ret := m.ctrl.Call(m, "GetAccountByID", ctx, accountID)
 	ret0, _ := ret[0].(models.Account)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetAccountByID indicates an expected call of GetAccountByID.
-func (mr *MockAccountRepoMockRecorder) GetAccountByID(accountID interface{}) *gomock.Call {
+func (mr *MockAccountRepoMockRecorder) GetAccountByID(ctx, accountID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccountByID", reflect.TypeOf((*MockAccountRepo)(nil).GetAccountByID), accountID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccountByID", reflect.TypeOf((*MockAccountRepo)(nil).GetAccountByID), ctx, accountID)
 }
 
 // GetAccountByNumber mocks base method.
-func (m *MockAccountRepo) GetAccountByNumber(number string) (models.Account, error) {
+func (m *MockAccountRepo) GetAccountByNumber(ctx context.Context, number string) (models.Account, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAccountByNumber", number)
+	ret := m.ctrl.Call(m, "GetAccountByNumber", ctx, number)
 	ret0, _ := ret[0].(models.Account)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetAccountByNumber indicates an expected call of GetAccountByNumber.
-func (mr *MockAccountRepoMockRecorder) GetAccountByNumber(number interface{}) *gomock.Call {
+func (mr *MockAccountRepoMockRecorder) GetAccountByNumber(ctx, number interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccountByNumber", reflect.TypeOf((*MockAccountRepo)(nil).GetAccountByNumber), number)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccountByNumber", reflect.TypeOf((*MockAccountRepo)(nil).GetAccountByNumber), ctx, number)
 }
 
 // GetAccountsByOwner mocks base method.
-func (m *MockAccountRepo) GetAccountsByOwner(user models.User) (*models.AccountList, error) {
+func (m *MockAccountRepo) GetAccountsByOwner(ctx context.Context, user models.User) (*models.AccountList, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAccountsByOwner", user)
+	ret := m.ctrl.Call(m, "GetAccountsByOwner", ctx, user)
 	ret0, _ := ret[0].(*models.AccountList)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetAccountsByOwner indicates an expected call of GetAccountsByOwner.
-func (mr *MockAccountRepoMockRecorder) GetAccountsByOwner(user interface{}) *gomock.Call {
+func (mr *MockAccountRepoMockRecorder) GetAccountsByOwner(ctx, user interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccountsByOwner", reflect.TypeOf((*MockAccountRepo)(nil).GetAccountsByOwner), user)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccountsByOwner", reflect.TypeOf((*MockAccountRepo)(nil).GetAccountsByOwner), ctx, user)
 }
 
 // UpdateAccount mocks base method.
-func (m *MockAccountRepo) UpdateAccount(accountID int, accountData models.Account) (models.Account, error) {
+func (m *MockAccountRepo) UpdateAccount(ctx context.Context, accountID int, accountData models.Account) (models.Account, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateAccount", accountID, accountData)
+	ret := m.ctrl.Call(m, "UpdateAccount", ctx, accountID, accountData)
 	ret0, _ := ret[0].(models.Account)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // UpdateAccount indicates an expected call of UpdateAccount.
-func (mr *MockAccountRepoMockRecorder) UpdateAccount(accountID, accountData interface{}) *gomock.Call {
+func (mr *MockAccountRepoMockRecorder) UpdateAccount(ctx, accountID, accountData interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAccount", reflect.TypeOf((*MockAccountRepo)(nil).UpdateAccount), accountID, accountData)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAccount", reflect.TypeOf((*MockAccountRepo)(nil).UpdateAccount), ctx, accountID, accountData)
 }
 
 // MockAccountTransactionRepo is a mock of AccountTransactionRepo interface.
@@ -133,38 +134,57 @@ func (m *MockAccountTransactionRepo) EXPECT() *MockAccountTransactionRepoMockRec
 }
 
 // AddAccountTransaction mocks base method.
-func (m *MockAccountTransactionRepo) AddAccountTransaction(accountTransaction *models.AccountTransaction) error {
+func (m *MockAccountTransactionRepo) AddAccountTransaction(ctx context.Context, accountTransaction *models.AccountTransaction) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "AddAccountTransaction", accountTransaction)
+	ret := m.ctrl.Call(m, "AddAccountTransaction", ctx, accountTransaction)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // AddAccountTransaction indicates an expected call of AddAccountTransaction.
-func (mr *MockAccountTransactionRepoMockRecorder) AddAccountTransaction(accountTransaction interface{}) *gomock.Call {
+func (mr *MockAccountTransactionRepoMockRecorder) AddAccountTransaction(ctx, accountTransaction interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddAccountTransaction", reflect.TypeOf((*MockAccountTransactionRepo)(nil).AddAccountTransaction), accountTransaction)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddAccountTransaction", reflect.TypeOf((*MockAccountTransactionRepo)(nil).AddAccountTransaction), ctx, accountTransaction)
+}
+
+// AddAccountTransactions mocks base method.
+func (m *MockAccountTransactionRepo) AddAccountTransactions(ctx context.Context, accountTransactions ...*models.AccountTransaction) error {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx}
+	for _, a := range accountTransactions {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "AddAccountTransactions", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddAccountTransactions indicates an expected call of AddAccountTransactions.
+func (mr *MockAccountTransactionRepoMockRecorder) AddAccountTransactions(ctx interface{}, accountTransactions ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx}, accountTransactions...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddAccountTransactions", reflect.TypeOf((*MockAccountTransactionRepo)(nil).AddAccountTransactions), varargs...)
 }
 
 // GetAccountTransactionByID mocks base method.
-func (m *MockAccountTransactionRepo) GetAccountTransactionByID(transID int) (models.AccountTransaction, error) {
+func (m *MockAccountTransactionRepo) GetAccountTransactionByID(ctx context.Context, transID int) (models.AccountTransaction, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAccountTransactionByID", transID)
+	ret := m.ctrl.Call(m, "GetAccountTransactionByID", ctx, transID)
 	ret0, _ := ret[0].(models.AccountTransaction)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetAccountTransactionByID indicates an expected call of GetAccountTransactionByID.
-func (mr *MockAccountTransactionRepoMockRecorder) GetAccountTransactionByID(transID interface{}) *gomock.Call {
+func (mr *MockAccountTransactionRepoMockRecorder) GetAccountTransactionByID(ctx, transID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccountTransactionByID", reflect.TypeOf((*MockAccountTransactionRepo)(nil).GetAccountTransactionByID), transID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccountTransactionByID", reflect.TypeOf((*MockAccountTransactionRepo)(nil).GetAccountTransactionByID), ctx, transID)
 }
 
 // GetAccountTransactions mocks base method.
-func (m *MockAccountTransactionRepo) GetAccountTransactions(accounts ...models.Account) (*models.AccountTransactionList, error) {
+func (m *MockAccountTransactionRepo) GetAccountTransactions(ctx context.Context, accounts ...models.Account) (*models.AccountTransactionList, error) {
 	m.ctrl.T.Helper()
-	varargs := []interface{}{}
+	varargs := []interface{}{ctx}
 	for _, a := range accounts {
 		varargs = append(varargs, a)
 	}
@@ -175,30 +195,31 @@ func (m *MockAccountTransactionRepo) GetAccountTransactions(accounts ...models.A
 }
 
 // GetAccountTransactions indicates an expected call of GetAccountTransactions.
-func (mr *MockAccountTransactionRepoMockRecorder) GetAccountTransactions(accounts ...interface{}) *gomock.Call {
+func (mr *MockAccountTransactionRepoMockRecorder) GetAccountTransactions(ctx interface{}, accounts ...interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccountTransactions", reflect.TypeOf((*MockAccountTransactionRepo)(nil).GetAccountTransactions), accounts...)
+	varargs := append([]interface{}{ctx}, accounts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccountTransactions", reflect.TypeOf((*MockAccountTransactionRepo)(nil).GetAccountTransactions), varargs...)
 }
 
 // GetAccountTransactionsByOrder mocks base method.
-func (m *MockAccountTransactionRepo) GetAccountTransactionsByOrder(order models.Order) (*models.AccountTransactionList, error) {
+func (m *MockAccountTransactionRepo) GetAccountTransactionsByOrder(ctx context.Context, order models.Order) (*models.AccountTransactionList, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAccountTransactionsByOrder", order)
+	ret := m.ctrl.Call(m, "GetAccountTransactionsByOrder", ctx, order)
 	ret0, _ := ret[0].(*models.AccountTransactionList)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetAccountTransactionsByOrder indicates an expected call of GetAccountTransactionsByOrder.
-func (mr *MockAccountTransactionRepoMockRecorder) GetAccountTransactionsByOrder(order interface{}) *gomock.Call {
+func (mr *MockAccountTransactionRepoMockRecorder) GetAccountTransactionsByOrder(ctx, order interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccountTransactionsByOrder", reflect.TypeOf((*MockAccountTransactionRepo)(nil).GetAccountTransactionsByOrder), order)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccountTransactionsByOrder", reflect.TypeOf((*MockAccountTransactionRepo)(nil).GetAccountTransactionsByOrder), ctx, order)
 }
 
 // GetAccountTransactionsByPaymentType mocks base method.
-func (m *MockAccountTransactionRepo) GetAccountTransactionsByPaymentType(paymentType models.PaymentType, accounts ...models.Account) (*models.AccountTransactionList, error) {
+func (m *MockAccountTransactionRepo) GetAccountTransactionsByPaymentType(ctx context.Context, paymentType models.PaymentType, accounts ...models.Account) (*models.AccountTransactionList, error) {
 	m.ctrl.T.Helper()
-	varargs := []interface{}{paymentType}
+	varargs := []interface{}{ctx, paymentType}
 	for _, a := range accounts {
 		varargs = append(varargs, a)
 	}
@@ -209,16 +230,16 @@ func (m *MockAccountTransactionRepo) GetAccountTransactionsByPaymentType(payment
 }
 
 // GetAccountTransactionsByPaymentType indicates an expected call of GetAccountTransactionsByPaymentType.
-func (mr *MockAccountTransactionRepoMockRecorder) GetAccountTransactionsByPaymentType(paymentType interface{}, accounts ...interface{}) *gomock.Call {
+func (mr *MockAccountTransactionRepoMockRecorder) GetAccountTransactionsByPaymentType(ctx, paymentType interface{}, accounts ...interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	varargs := append([]interface{}{paymentType}, accounts...)
+	varargs := append([]interface{}{ctx, paymentType}, accounts...)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccountTransactionsByPaymentType", reflect.TypeOf((*MockAccountTransactionRepo)(nil).GetAccountTransactionsByPaymentType), varargs...)
 }
 
 // GetAccountTransactionsInTimePeriod mocks base method.
-func (m *MockAccountTransactionRepo) GetAccountTransactionsInTimePeriod(start, end time.Time, accounts ...models.Account) (*models.AccountTransactionList, error) {
+func (m *MockAccountTransactionRepo) GetAccountTransactionsInTimePeriod(ctx context.Context, start, end time.Time, accounts ...models.Account) (*models.AccountTransactionList, error) {
 	m.ctrl.T.Helper()
-	varargs := []interface{}{start, end}
+	varargs := []interface{}{ctx, start, end}
 	for _, a := range accounts {
 		varargs = append(varargs, a)
 	}
@@ -229,9 +250,9 @@ func (m *MockAccountTransactionRepo) GetAccountTransactionsInTimePeriod(start, e
 }
 
 // GetAccountTransactionsInTimePeriod indicates an expected call of GetAccountTransactionsInTimePeriod.
-func (mr *MockAccountTransactionRepoMockRecorder) GetAccountTransactionsInTimePeriod(start, end interface{}, accounts ...interface{}) *gomock.Call {
+func (mr *MockAccountTransactionRepoMockRecorder) GetAccountTransactionsInTimePeriod(ctx, start, end interface{}, accounts ...interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	varargs := append([]interface{}{start, end}, accounts...)
+	varargs := append([]interface{}{ctx, start, end}, accounts...)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccountTransactionsInTimePeriod", reflect.TypeOf((*MockAccountTransactionRepo)(nil).GetAccountTransactionsInTimePeriod), varargs...)
 }
 
@@ -259,16 +280,16 @@ func (m *MockPaymentTypeRepo) EXPECT() *MockPaymentTypeRepoMockRecorder {
 }
 
 // GetPaymentTypeById mocks base method.
-func (m *MockPaymentTypeRepo) GetPaymentTypeById(paymentTypeID int) (models.PaymentType, error) {
+func (m *MockPaymentTypeRepo) GetPaymentTypeById(ctx context.Context, paymentTypeID int) (models.PaymentType, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetPaymentTypeById", paymentTypeID)
+	ret := m.ctrl.Call(m, "GetPaymentTypeById", ctx, paymentTypeID)
 	ret0, _ := ret[0].(models.PaymentType)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetPaymentTypeById indicates an expected call of GetPaymentTypeById.
-func (mr *MockPaymentTypeRepoMockRecorder) GetPaymentTypeById(paymentTypeID interface{}) *gomock.Call {
+func (mr *MockPaymentTypeRepoMockRecorder) GetPaymentTypeById(ctx, paymentTypeID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPaymentTypeById", reflect.TypeOf((*MockPaymentTypeRepo)(nil).GetPaymentTypeById), paymentTypeID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPaymentTypeById", reflect.TypeOf((*MockPaymentTypeRepo)(nil).GetPaymentTypeById), ctx, paymentTypeID)
 }
@@ -0,0 +1,159 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: anydatabase.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	pgconn "github.com/jackc/pgconn"
+	pgx "github.com/jackc/pgx/v4"
+)
+
+// MockAnyDatabase is a mock of AnyDatabase interface.
+type MockAnyDatabase struct {
+	ctrl     *gomock.Controller
+	recorder *MockAnyDatabaseMockRecorder
+}
+
+// MockAnyDatabaseMockRecorder is the mock recorder for MockAnyDatabase.
+type MockAnyDatabaseMockRecorder struct {
+	mock *MockAnyDatabase
+}
+
+// NewMockAnyDatabase creates a new mock instance.
+func NewMockAnyDatabase(ctrl *gomock.Controller) *MockAnyDatabase {
+	mock := &MockAnyDatabase{ctrl: ctrl}
+	mock.recorder = &MockAnyDatabaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAnyDatabase) EXPECT() *MockAnyDatabaseMockRecorder {
+	return m.recorder
+}
+
+// CloseDB mocks base method.
+func (m *MockAnyDatabase) CloseDB() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "CloseDB")
+}
+
+// CloseDB indicates an expected call of CloseDB.
+func (mr *MockAnyDatabaseMockRecorder) CloseDB() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CloseDB", reflect.TypeOf((*MockAnyDatabase)(nil).CloseDB))
+}
+
+// QueryExec mocks base method.
+func (m *MockAnyDatabase) QueryExec(arg0 context.Context, arg1 string, arg2 ...interface{}) (pgconn.CommandTag, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "QueryExec", varargs...)
+	ret0, _ := ret[0].(pgconn.CommandTag)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// QueryExec indicates an expected call of QueryExec.
+func (mr *MockAnyDatabaseMockRecorder) QueryExec(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryExec", reflect.TypeOf((*MockAnyDatabase)(nil).QueryExec), varargs...)
+}
+
+// QueryResult mocks base method.
+func (m *MockAnyDatabase) QueryResult(arg0 context.Context, arg1 string, arg2 ...interface{}) (pgx.Rows, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "QueryResult", varargs...)
+	ret0, _ := ret[0].(pgx.Rows)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// QueryResult indicates an expected call of QueryResult.
+func (mr *MockAnyDatabaseMockRecorder) QueryResult(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryResult", reflect.TypeOf((*MockAnyDatabase)(nil).QueryResult), varargs...)
+}
+
+// QueryResultRow mocks base method.
+func (m *MockAnyDatabase) QueryResultRow(arg0 context.Context, arg1 string, arg2 ...interface{}) pgx.Row {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "QueryResultRow", varargs...)
+	ret0, _ := ret[0].(pgx.Row)
+	return ret0
+}
+
+// QueryResultRow indicates an expected call of QueryResultRow.
+func (mr *MockAnyDatabaseMockRecorder) QueryResultRow(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryResultRow", reflect.TypeOf((*MockAnyDatabase)(nil).QueryResultRow), varargs...)
+}
+
+// WithTx mocks base method.
+func (m *MockAnyDatabase) WithTx(arg0 context.Context, arg1 func(context.Context) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WithTx", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WithTx indicates an expected call of WithTx.
+func (mr *MockAnyDatabaseMockRecorder) WithTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithTx", reflect.TypeOf((*MockAnyDatabase)(nil).WithTx), arg0, arg1)
+}
+
+// MockTxManager is a mock of TxManager interface.
+type MockTxManager struct {
+	ctrl     *gomock.Controller
+	recorder *MockTxManagerMockRecorder
+}
+
+// MockTxManagerMockRecorder is the mock recorder for MockTxManager.
+type MockTxManagerMockRecorder struct {
+	mock *MockTxManager
+}
+
+// NewMockTxManager creates a new mock instance.
+func NewMockTxManager(ctrl *gomock.Controller) *MockTxManager {
+	mock := &MockTxManager{ctrl: ctrl}
+	mock.recorder = &MockTxManagerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTxManager) EXPECT() *MockTxManagerMockRecorder {
+	return m.recorder
+}
+
+// WithTx mocks base method.
+func (m *MockTxManager) WithTx(arg0 context.Context, arg1 func(context.Context) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WithTx", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WithTx indicates an expected call of WithTx.
+func (mr *MockTxManagerMockRecorder) WithTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithTx", reflect.TypeOf((*MockTxManager)(nil).WithTx), arg0, arg1)
+}
@@ -0,0 +1,153 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: contact.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	models "Dp218GO/models"
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockContactRepo is a mock of ContactRepo interface.
+type MockContactRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockContactRepoMockRecorder
+}
+
+// MockContactRepoMockRecorder is the mock recorder for MockContactRepo.
+type MockContactRepoMockRecorder struct {
+	mock *MockContactRepo
+}
+
+// NewMockContactRepo creates a new mock instance.
+func NewMockContactRepo(ctrl *gomock.Controller) *MockContactRepo {
+	mock := &MockContactRepo{ctrl: ctrl}
+	mock.recorder = &MockContactRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockContactRepo) EXPECT() *MockContactRepoMockRecorder {
+	return m.recorder
+}
+
+// AddContact mocks base method.
+func (m *MockContactRepo) AddContact(ctx context.Context, contact *models.Contact) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddContact", ctx, contact)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddContact indicates an expected call of AddContact.
+func (mr *MockContactRepoMockRecorder) AddContact(ctx, contact interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddContact", reflect.TypeOf((*MockContactRepo)(nil).AddContact), ctx, contact)
+}
+
+// DeleteContact mocks base method.
+func (m *MockContactRepo) DeleteContact(ctx context.Context, userID, contactID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteContact", ctx, userID, contactID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteContact indicates an expected call of DeleteContact.
+func (mr *MockContactRepoMockRecorder) DeleteContact(ctx, userID, contactID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteContact", reflect.TypeOf((*MockContactRepo)(nil).DeleteContact), ctx, userID, contactID)
+}
+
+// GetContactByID mocks base method.
+func (m *MockContactRepo) GetContactByID(ctx context.Context, userID, contactID int) (models.Contact, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetContactByID", ctx, userID, contactID)
+	ret0, _ := ret[0].(models.Contact)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetContactByID indicates an expected call of GetContactByID.
+func (mr *MockContactRepoMockRecorder) GetContactByID(ctx, userID, contactID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetContactByID", reflect.TypeOf((*MockContactRepo)(nil).GetContactByID), ctx, userID, contactID)
+}
+
+// GetContactTypeByID mocks base method.
+func (m *MockContactRepo) GetContactTypeByID(ctx context.Context, typeID int) (models.ContactType, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetContactTypeByID", ctx, typeID)
+	ret0, _ := ret[0].(models.ContactType)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetContactTypeByID indicates an expected call of GetContactTypeByID.
+func (mr *MockContactRepoMockRecorder) GetContactTypeByID(ctx, typeID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetContactTypeByID", reflect.TypeOf((*MockContactRepo)(nil).GetContactTypeByID), ctx, typeID)
+}
+
+// GetContactTypes mocks base method.
+func (m *MockContactRepo) GetContactTypes(ctx context.Context) (*models.ContactTypeList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetContactTypes", ctx)
+	ret0, _ := ret[0].(*models.ContactTypeList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetContactTypes indicates an expected call of GetContactTypes.
+func (mr *MockContactRepoMockRecorder) GetContactTypes(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetContactTypes", reflect.TypeOf((*MockContactRepo)(nil).GetContactTypes), ctx)
+}
+
+// GetContactsByUserID mocks base method.
+func (m *MockContactRepo) GetContactsByUserID(ctx context.Context, userID int) (*models.ContactList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetContactsByUserID", ctx, userID)
+	ret0, _ := ret[0].(*models.ContactList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetContactsByUserID indicates an expected call of GetContactsByUserID.
+func (mr *MockContactRepoMockRecorder) GetContactsByUserID(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetContactsByUserID", reflect.TypeOf((*MockContactRepo)(nil).GetContactsByUserID), ctx, userID)
+}
+
+// GetPrimaryContact mocks base method.
+func (m *MockContactRepo) GetPrimaryContact(ctx context.Context, userID int, typeCode string) (models.Contact, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPrimaryContact", ctx, userID, typeCode)
+	ret0, _ := ret[0].(models.Contact)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPrimaryContact indicates an expected call of GetPrimaryContact.
+func (mr *MockContactRepoMockRecorder) GetPrimaryContact(ctx, userID, typeCode interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPrimaryContact", reflect.TypeOf((*MockContactRepo)(nil).GetPrimaryContact), ctx, userID, typeCode)
+}
+
+// UpdateContact mocks base method.
+func (m *MockContactRepo) UpdateContact(ctx context.Context, contact models.Contact) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateContact", ctx, contact)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateContact indicates an expected call of UpdateContact.
+func (mr *MockContactRepoMockRecorder) UpdateContact(ctx, contact interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateContact", reflect.TypeOf((*MockContactRepo)(nil).UpdateContact), ctx, contact)
+}
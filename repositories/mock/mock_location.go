@@ -0,0 +1,79 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: location.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	models "Dp218GO/models"
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockLocationRepo is a mock of LocationRepo interface.
+type MockLocationRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockLocationRepoMockRecorder
+}
+
+// MockLocationRepoMockRecorder is the mock recorder for MockLocationRepo.
+type MockLocationRepoMockRecorder struct {
+	mock *MockLocationRepo
+}
+
+// NewMockLocationRepo creates a new mock instance.
+func NewMockLocationRepo(ctrl *gomock.Controller) *MockLocationRepo {
+	mock := &MockLocationRepo{ctrl: ctrl}
+	mock.recorder = &MockLocationRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLocationRepo) EXPECT() *MockLocationRepoMockRecorder {
+	return m.recorder
+}
+
+// AddLocation mocks base method.
+func (m *MockLocationRepo) AddLocation(ctx context.Context, location *models.Location) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddLocation", ctx, location)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddLocation indicates an expected call of AddLocation.
+func (mr *MockLocationRepoMockRecorder) AddLocation(ctx, location interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddLocation", reflect.TypeOf((*MockLocationRepo)(nil).AddLocation), ctx, location)
+}
+
+// GetAllLocations mocks base method.
+func (m *MockLocationRepo) GetAllLocations(ctx context.Context) (*models.LocationList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllLocations", ctx)
+	ret0, _ := ret[0].(*models.LocationList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllLocations indicates an expected call of GetAllLocations.
+func (mr *MockLocationRepoMockRecorder) GetAllLocations(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllLocations", reflect.TypeOf((*MockLocationRepo)(nil).GetAllLocations), ctx)
+}
+
+// UpdateLocation mocks base method.
+func (m *MockLocationRepo) UpdateLocation(ctx context.Context, location models.Location) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateLocation", ctx, location)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateLocation indicates an expected call of UpdateLocation.
+func (mr *MockLocationRepoMockRecorder) UpdateLocation(ctx, location interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateLocation", reflect.TypeOf((*MockLocationRepo)(nil).UpdateLocation), ctx, location)
+}
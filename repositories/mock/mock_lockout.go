@@ -0,0 +1,110 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: lockout.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	models "Dp218GO/models"
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockLockoutRepo is a mock of LockoutRepo interface.
+type MockLockoutRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockLockoutRepoMockRecorder
+}
+
+// MockLockoutRepoMockRecorder is the mock recorder for MockLockoutRepo.
+type MockLockoutRepoMockRecorder struct {
+	mock *MockLockoutRepo
+}
+
+// NewMockLockoutRepo creates a new mock instance.
+func NewMockLockoutRepo(ctrl *gomock.Controller) *MockLockoutRepo {
+	mock := &MockLockoutRepo{ctrl: ctrl}
+	mock.recorder = &MockLockoutRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLockoutRepo) EXPECT() *MockLockoutRepoMockRecorder {
+	return m.recorder
+}
+
+// AddLoginFailure mocks base method.
+func (m *MockLockoutRepo) AddLoginFailure(ctx context.Context, email string, failedAt time.Time) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddLoginFailure", ctx, email, failedAt)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddLoginFailure indicates an expected call of AddLoginFailure.
+func (mr *MockLockoutRepoMockRecorder) AddLoginFailure(ctx, email, failedAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddLoginFailure", reflect.TypeOf((*MockLockoutRepo)(nil).AddLoginFailure), ctx, email, failedAt)
+}
+
+// CountFailedByIP mocks base method.
+func (m *MockLockoutRepo) CountFailedByIP(ctx context.Context, ipAddress string, since time.Time) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountFailedByIP", ctx, ipAddress, since)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountFailedByIP indicates an expected call of CountFailedByIP.
+func (mr *MockLockoutRepoMockRecorder) CountFailedByIP(ctx, ipAddress, since interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountFailedByIP", reflect.TypeOf((*MockLockoutRepo)(nil).CountFailedByIP), ctx, ipAddress, since)
+}
+
+// GetLoginFailures mocks base method.
+func (m *MockLockoutRepo) GetLoginFailures(ctx context.Context, email string) (models.LoginFailures, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLoginFailures", ctx, email)
+	ret0, _ := ret[0].(models.LoginFailures)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLoginFailures indicates an expected call of GetLoginFailures.
+func (mr *MockLockoutRepoMockRecorder) GetLoginFailures(ctx, email interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLoginFailures", reflect.TypeOf((*MockLockoutRepo)(nil).GetLoginFailures), ctx, email)
+}
+
+// LockUser mocks base method.
+func (m *MockLockoutRepo) LockUser(ctx context.Context, email string, lockedUntil time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LockUser", ctx, email, lockedUntil)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LockUser indicates an expected call of LockUser.
+func (mr *MockLockoutRepoMockRecorder) LockUser(ctx, email, lockedUntil interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockUser", reflect.TypeOf((*MockLockoutRepo)(nil).LockUser), ctx, email, lockedUntil)
+}
+
+// ResetLoginFailures mocks base method.
+func (m *MockLockoutRepo) ResetLoginFailures(ctx context.Context, email string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResetLoginFailures", ctx, email)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResetLoginFailures indicates an expected call of ResetLoginFailures.
+func (mr *MockLockoutRepoMockRecorder) ResetLoginFailures(ctx, email interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetLoginFailures", reflect.TypeOf((*MockLockoutRepo)(nil).ResetLoginFailures), ctx, email)
+}
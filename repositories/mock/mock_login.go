@@ -0,0 +1,138 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: login.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	models "Dp218GO/models"
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockLoginAuditRepo is a mock of LoginAuditRepo interface.
+type MockLoginAuditRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockLoginAuditRepoMockRecorder
+}
+
+// MockLoginAuditRepoMockRecorder is the mock recorder for MockLoginAuditRepo.
+type MockLoginAuditRepoMockRecorder struct {
+	mock *MockLoginAuditRepo
+}
+
+// NewMockLoginAuditRepo creates a new mock instance.
+func NewMockLoginAuditRepo(ctrl *gomock.Controller) *MockLoginAuditRepo {
+	mock := &MockLoginAuditRepo{ctrl: ctrl}
+	mock.recorder = &MockLoginAuditRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLoginAuditRepo) EXPECT() *MockLoginAuditRepoMockRecorder {
+	return m.recorder
+}
+
+// AddLoginEvent mocks base method.
+func (m *MockLoginAuditRepo) AddLoginEvent(ctx context.Context, event *models.LoginEvent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddLoginEvent", ctx, event)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddLoginEvent indicates an expected call of AddLoginEvent.
+func (mr *MockLoginAuditRepoMockRecorder) AddLoginEvent(ctx, event interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddLoginEvent", reflect.TypeOf((*MockLoginAuditRepo)(nil).AddLoginEvent), ctx, event)
+}
+
+// AddSession mocks base method.
+func (m *MockLoginAuditRepo) AddSession(ctx context.Context, session *models.UserSession) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddSession", ctx, session)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddSession indicates an expected call of AddSession.
+func (mr *MockLoginAuditRepoMockRecorder) AddSession(ctx, session interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddSession", reflect.TypeOf((*MockLoginAuditRepo)(nil).AddSession), ctx, session)
+}
+
+// GetActiveSessionsByUserID mocks base method.
+func (m *MockLoginAuditRepo) GetActiveSessionsByUserID(ctx context.Context, userID int) (*models.UserSessionList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetActiveSessionsByUserID", ctx, userID)
+	ret0, _ := ret[0].(*models.UserSessionList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetActiveSessionsByUserID indicates an expected call of GetActiveSessionsByUserID.
+func (mr *MockLoginAuditRepoMockRecorder) GetActiveSessionsByUserID(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActiveSessionsByUserID", reflect.TypeOf((*MockLoginAuditRepo)(nil).GetActiveSessionsByUserID), ctx, userID)
+}
+
+// GetLoginEventsByUserID mocks base method.
+func (m *MockLoginAuditRepo) GetLoginEventsByUserID(ctx context.Context, userID, limit int) (*models.LoginEventList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLoginEventsByUserID", ctx, userID, limit)
+	ret0, _ := ret[0].(*models.LoginEventList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLoginEventsByUserID indicates an expected call of GetLoginEventsByUserID.
+func (mr *MockLoginAuditRepoMockRecorder) GetLoginEventsByUserID(ctx, userID, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLoginEventsByUserID", reflect.TypeOf((*MockLoginAuditRepo)(nil).GetLoginEventsByUserID), ctx, userID, limit)
+}
+
+// IsSessionActive mocks base method.
+func (m *MockLoginAuditRepo) IsSessionActive(ctx context.Context, sessionID int) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsSessionActive", ctx, sessionID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsSessionActive indicates an expected call of IsSessionActive.
+func (mr *MockLoginAuditRepoMockRecorder) IsSessionActive(ctx, sessionID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsSessionActive", reflect.TypeOf((*MockLoginAuditRepo)(nil).IsSessionActive), ctx, sessionID)
+}
+
+// RevokeOtherSessions mocks base method.
+func (m *MockLoginAuditRepo) RevokeOtherSessions(ctx context.Context, userID, keepSessionID int, revokedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeOtherSessions", ctx, userID, keepSessionID, revokedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeOtherSessions indicates an expected call of RevokeOtherSessions.
+func (mr *MockLoginAuditRepoMockRecorder) RevokeOtherSessions(ctx, userID, keepSessionID, revokedAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeOtherSessions", reflect.TypeOf((*MockLoginAuditRepo)(nil).RevokeOtherSessions), ctx, userID, keepSessionID, revokedAt)
+}
+
+// RevokeSession mocks base method.
+func (m *MockLoginAuditRepo) RevokeSession(ctx context.Context, userID, sessionID int, revokedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeSession", ctx, userID, sessionID, revokedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeSession indicates an expected call of RevokeSession.
+func (mr *MockLoginAuditRepoMockRecorder) RevokeSession(ctx, userID, sessionID, revokedAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeSession", reflect.TypeOf((*MockLoginAuditRepo)(nil).RevokeSession), ctx, userID, sessionID, revokedAt)
+}
@@ -6,6 +6,7 @@ package mock
 
 import (
 	models "Dp218GO/models"
+	context "context"
 	reflect "reflect"
 
 	gomock "github.com/golang/mock/gomock"
@@ -35,135 +36,150 @@ func (m *MockOrderRepo) EXPECT() *MockOrderRepoMockRecorder {
 }
 
 // CreateOrder mocks base method.
-func (m *MockOrderRepo) CreateOrder(user models.User, scooterID, startID, endID int, distance float64) (models.Order, error) {
+func (m *MockOrderRepo) CreateOrder(ctx context.Context, user models.User, scooterID, startID, endID int, distance float64) (models.Order, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateOrder", user, scooterID, startID, endID, distance)
+	ret := m.ctrl.Call(m, "CreateOrder", ctx, user, scooterID, startID, endID, distance)
 	ret0, _ := ret[0].(models.Order)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // CreateOrder indicates an expected call of CreateOrder.
-func (mr *MockOrderRepoMockRecorder) CreateOrder(user, scooterID, startID, endID, distance interface{}) *gomock.Call {
+func (mr *MockOrderRepoMockRecorder) CreateOrder(ctx, user, scooterID, startID, endID, distance interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOrder", reflect.TypeOf((*MockOrderRepo)(nil).CreateOrder), user, scooterID, startID, endID, distance)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOrder", reflect.TypeOf((*MockOrderRepo)(nil).CreateOrder), ctx, user, scooterID, startID, endID, distance)
 }
 
 // DeleteOrder mocks base method.
-func (m *MockOrderRepo) DeleteOrder(orderID int) error {
+func (m *MockOrderRepo) DeleteOrder(ctx context.Context, orderID int) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteOrder", orderID)
+	ret := m.ctrl.Call(m, "DeleteOrder", ctx, orderID)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeleteOrder indicates an expected call of DeleteOrder.
-func (mr *MockOrderRepoMockRecorder) DeleteOrder(orderID interface{}) *gomock.Call {
+func (mr *MockOrderRepoMockRecorder) DeleteOrder(ctx, orderID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteOrder", reflect.TypeOf((*MockOrderRepo)(nil).DeleteOrder), orderID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteOrder", reflect.TypeOf((*MockOrderRepo)(nil).DeleteOrder), ctx, orderID)
 }
 
 // GetAllOrders mocks base method.
-func (m *MockOrderRepo) GetAllOrders() (*models.OrderList, error) {
+func (m *MockOrderRepo) GetAllOrders(ctx context.Context) (*models.OrderList, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAllOrders")
+	ret := m.ctrl.Call(m, "GetAllOrders", ctx)
 	ret0, _ := ret[0].(*models.OrderList)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetAllOrders indicates an expected call of GetAllOrders.
-func (mr *MockOrderRepoMockRecorder) GetAllOrders() *gomock.Call {
+func (mr *MockOrderRepoMockRecorder) GetAllOrders(ctx interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllOrders", reflect.TypeOf((*MockOrderRepo)(nil).GetAllOrders))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllOrders", reflect.TypeOf((*MockOrderRepo)(nil).GetAllOrders), ctx)
 }
 
 // GetOrderByID mocks base method.
-func (m *MockOrderRepo) GetOrderByID(orderID int) (models.Order, error) {
+func (m *MockOrderRepo) GetOrderByID(ctx context.Context, orderID int) (models.Order, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetOrderByID", orderID)
+	ret := m.ctrl.Call(m, "GetOrderByID", ctx, orderID)
 	ret0, _ := ret[0].(models.Order)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetOrderByID indicates an expected call of GetOrderByID.
-func (mr *MockOrderRepoMockRecorder) GetOrderByID(orderID interface{}) *gomock.Call {
+func (mr *MockOrderRepoMockRecorder) GetOrderByID(ctx, orderID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrderByID", reflect.TypeOf((*MockOrderRepo)(nil).GetOrderByID), orderID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrderByID", reflect.TypeOf((*MockOrderRepo)(nil).GetOrderByID), ctx, orderID)
 }
 
 // GetOrdersByScooterID mocks base method.
-func (m *MockOrderRepo) GetOrdersByScooterID(scooterID int) (models.OrderList, error) {
+func (m *MockOrderRepo) GetOrdersByScooterID(ctx context.Context, scooterID int) (models.OrderList, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetOrdersByScooterID", scooterID)
+	ret := m.ctrl.Call(m, "GetOrdersByScooterID", ctx, scooterID)
 	ret0, _ := ret[0].(models.OrderList)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetOrdersByScooterID indicates an expected call of GetOrdersByScooterID.
-func (mr *MockOrderRepoMockRecorder) GetOrdersByScooterID(scooterID interface{}) *gomock.Call {
+func (mr *MockOrderRepoMockRecorder) GetOrdersByScooterID(ctx, scooterID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrdersByScooterID", reflect.TypeOf((*MockOrderRepo)(nil).GetOrdersByScooterID), scooterID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrdersByScooterID", reflect.TypeOf((*MockOrderRepo)(nil).GetOrdersByScooterID), ctx, scooterID)
 }
 
 // GetOrdersByUserID mocks base method.
-func (m *MockOrderRepo) GetOrdersByUserID(userID int) (models.OrderList, error) {
+func (m *MockOrderRepo) GetOrdersByUserID(ctx context.Context, userID int) (models.OrderList, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetOrdersByUserID", userID)
+	ret := m.ctrl.Call(m, "GetOrdersByUserID", ctx, userID)
 	ret0, _ := ret[0].(models.OrderList)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetOrdersByUserID indicates an expected call of GetOrdersByUserID.
-func (mr *MockOrderRepoMockRecorder) GetOrdersByUserID(userID interface{}) *gomock.Call {
+func (mr *MockOrderRepoMockRecorder) GetOrdersByUserID(ctx, userID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrdersByUserID", reflect.TypeOf((*MockOrderRepo)(nil).GetOrdersByUserID), userID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrdersByUserID", reflect.TypeOf((*MockOrderRepo)(nil).GetOrdersByUserID), ctx, userID)
 }
 
 // GetScooterMileageByID mocks base method.
-func (m *MockOrderRepo) GetScooterMileageByID(scooterID int) (float64, error) {
+func (m *MockOrderRepo) GetScooterMileageByID(ctx context.Context, scooterID int) (float64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetScooterMileageByID", scooterID)
+	ret := m.ctrl.Call(m, "GetScooterMileageByID", ctx, scooterID)
 	ret0, _ := ret[0].(float64)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetScooterMileageByID indicates an expected call of GetScooterMileageByID.
-func (mr *MockOrderRepoMockRecorder) GetScooterMileageByID(scooterID interface{}) *gomock.Call {
+func (mr *MockOrderRepoMockRecorder) GetScooterMileageByID(ctx, scooterID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetScooterMileageByID", reflect.TypeOf((*MockOrderRepo)(nil).GetScooterMileageByID), scooterID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetScooterMileageByID", reflect.TypeOf((*MockOrderRepo)(nil).GetScooterMileageByID), ctx, scooterID)
+}
+
+// GetStatusInRentByID mocks base method.
+func (m *MockOrderRepo) GetStatusInRentByID(ctx context.Context, statusID int) (models.ScooterStatusInRent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStatusInRentByID", ctx, statusID)
+	ret0, _ := ret[0].(models.ScooterStatusInRent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStatusInRentByID indicates an expected call of GetStatusInRentByID.
+func (mr *MockOrderRepoMockRecorder) GetStatusInRentByID(ctx, statusID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStatusInRentByID", reflect.TypeOf((*MockOrderRepo)(nil).GetStatusInRentByID), ctx, statusID)
 }
 
 // GetUserMileageByID mocks base method.
-func (m *MockOrderRepo) GetUserMileageByID(userID int) (float64, error) {
+func (m *MockOrderRepo) GetUserMileageByID(ctx context.Context, userID int) (float64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetUserMileageByID", userID)
+	ret := m.ctrl.Call(m, "GetUserMileageByID", ctx, userID)
 	ret0, _ := ret[0].(float64)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetUserMileageByID indicates an expected call of GetUserMileageByID.
-func (mr *MockOrderRepoMockRecorder) GetUserMileageByID(userID interface{}) *gomock.Call {
+func (mr *MockOrderRepoMockRecorder) GetUserMileageByID(ctx, userID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserMileageByID", reflect.TypeOf((*MockOrderRepo)(nil).GetUserMileageByID), userID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserMileageByID", reflect.TypeOf((*MockOrderRepo)(nil).GetUserMileageByID), ctx, userID)
 }
 
 // UpdateOrder mocks base method.
-func (m *MockOrderRepo) UpdateOrder(orderID int, orderData models.Order) (models.Order, error) {
+func (m *MockOrderRepo) UpdateOrder(ctx context.Context, orderID int, orderData models.Order) (models.Order, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateOrder", orderID, orderData)
+	ret := m.ctrl.Call(m, "UpdateOrder", ctx, orderID, orderData)
 	ret0, _ := ret[0].(models.Order)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // UpdateOrder indicates an expected call of UpdateOrder.
-func (mr *MockOrderRepoMockRecorder) UpdateOrder(orderID, orderData interface{}) *gomock.Call {
+func (mr *MockOrderRepoMockRecorder) UpdateOrder(ctx, orderID, orderData interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateOrder", reflect.TypeOf((*MockOrderRepo)(nil).UpdateOrder), orderID, orderData)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateOrder", reflect.TypeOf((*MockOrderRepo)(nil).UpdateOrder), ctx, orderID, orderData)
 }
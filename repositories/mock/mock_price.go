@@ -0,0 +1,80 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: price.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	models "Dp218GO/models"
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockPriceRepo is a mock of PriceRepo interface.
+type MockPriceRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockPriceRepoMockRecorder
+}
+
+// MockPriceRepoMockRecorder is the mock recorder for MockPriceRepo.
+type MockPriceRepoMockRecorder struct {
+	mock *MockPriceRepo
+}
+
+// NewMockPriceRepo creates a new mock instance.
+func NewMockPriceRepo(ctrl *gomock.Controller) *MockPriceRepo {
+	mock := &MockPriceRepo{ctrl: ctrl}
+	mock.recorder = &MockPriceRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPriceRepo) EXPECT() *MockPriceRepoMockRecorder {
+	return m.recorder
+}
+
+// AddPriceBreakdown mocks base method.
+func (m *MockPriceRepo) AddPriceBreakdown(ctx context.Context, breakdown *models.PriceBreakdown) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddPriceBreakdown", ctx, breakdown)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddPriceBreakdown indicates an expected call of AddPriceBreakdown.
+func (mr *MockPriceRepoMockRecorder) AddPriceBreakdown(ctx, breakdown interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddPriceBreakdown", reflect.TypeOf((*MockPriceRepo)(nil).AddPriceBreakdown), ctx, breakdown)
+}
+
+// GetPriceBreakdownByOrderID mocks base method.
+func (m *MockPriceRepo) GetPriceBreakdownByOrderID(ctx context.Context, orderID int) (models.PriceBreakdown, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPriceBreakdownByOrderID", ctx, orderID)
+	ret0, _ := ret[0].(models.PriceBreakdown)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPriceBreakdownByOrderID indicates an expected call of GetPriceBreakdownByOrderID.
+func (mr *MockPriceRepoMockRecorder) GetPriceBreakdownByOrderID(ctx, orderID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPriceBreakdownByOrderID", reflect.TypeOf((*MockPriceRepo)(nil).GetPriceBreakdownByOrderID), ctx, orderID)
+}
+
+// GetTariffByScooterID mocks base method.
+func (m *MockPriceRepo) GetTariffByScooterID(ctx context.Context, scooterID int) (models.Tariff, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTariffByScooterID", ctx, scooterID)
+	ret0, _ := ret[0].(models.Tariff)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTariffByScooterID indicates an expected call of GetTariffByScooterID.
+func (mr *MockPriceRepoMockRecorder) GetTariffByScooterID(ctx, scooterID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTariffByScooterID", reflect.TypeOf((*MockPriceRepo)(nil).GetTariffByScooterID), ctx, scooterID)
+}
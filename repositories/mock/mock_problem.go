@@ -6,6 +6,7 @@ package mock
 
 import (
 	models "Dp218GO/models"
+	context "context"
 	reflect "reflect"
 	time "time"
 
@@ -36,151 +37,149 @@ func (m *MockProblemRepo) EXPECT() *MockProblemRepoMockRecorder {
 }
 
 // AddNewProblem mocks base method.
-func (m *MockProblemRepo) AddNewProblem(problem *models.Problem) error {
+func (m *MockProblemRepo) AddNewProblem(ctx context.Context, problem *models.Problem) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "AddNewProblem", problem)
+	ret := m.ctrl.Call(m, "AddNewProblem", ctx, problem)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // AddNewProblem indicates an expected call of AddNewProblem.
-func (mr *MockProblemRepoMockRecorder) AddNewProblem(problem interface{}) *gomock.Call {
+func (mr *MockProblemRepoMockRecorder) AddNewProblem(ctx, problem interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddNewProblem", reflect.TypeOf((*MockProblemRepo)(nil).AddNewProblem), problem)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddNewProblem", reflect.TypeOf((*MockProblemRepo)(nil).AddNewProblem), ctx, problem)
 }
 
 // AddProblemComplexFields mocks base method.
-func (m *MockProblemRepo) AddProblemComplexFields(problem *models.Problem, typeID, scooterID, userID int) error {
+func (m *MockProblemRepo) AddProblemComplexFields(ctx context.Context, problem *models.Problem, typeID, userID int) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "AddProblemComplexFields", problem, typeID, scooterID, userID)
-	ret0, _ := ret[0].(error)
-	return ret0
+	m.ctrl.Call(m, "AddProblemComplexFields", ctx, problem, typeID, userID)
 }
 
 // AddProblemComplexFields indicates an expected call of AddProblemComplexFields.
-func (mr *MockProblemRepoMockRecorder) AddProblemComplexFields(problem, typeID, scooterID, userID interface{}) *gomock.Call {
+func (mr *MockProblemRepoMockRecorder) AddProblemComplexFields(ctx, problem, typeID, userID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddProblemComplexFields", reflect.TypeOf((*MockProblemRepo)(nil).AddProblemComplexFields), problem, typeID, scooterID, userID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddProblemComplexFields", reflect.TypeOf((*MockProblemRepo)(nil).AddProblemComplexFields), ctx, problem, typeID, userID)
 }
 
 // GetAllProblemTypes mocks base method.
-func (m *MockProblemRepo) GetAllProblemTypes() ([]models.ProblemType, error) {
+func (m *MockProblemRepo) GetAllProblemTypes(ctx context.Context) ([]models.ProblemType, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAllProblemTypes")
+	ret := m.ctrl.Call(m, "GetAllProblemTypes", ctx)
 	ret0, _ := ret[0].([]models.ProblemType)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetAllProblemTypes indicates an expected call of GetAllProblemTypes.
-func (mr *MockProblemRepoMockRecorder) GetAllProblemTypes() *gomock.Call {
+func (mr *MockProblemRepoMockRecorder) GetAllProblemTypes(ctx interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllProblemTypes", reflect.TypeOf((*MockProblemRepo)(nil).GetAllProblemTypes))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllProblemTypes", reflect.TypeOf((*MockProblemRepo)(nil).GetAllProblemTypes), ctx)
 }
 
 // GetProblemByID mocks base method.
-func (m *MockProblemRepo) GetProblemByID(problemID int) (models.Problem, error) {
+func (m *MockProblemRepo) GetProblemByID(ctx context.Context, problemID int) (models.Problem, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetProblemByID", problemID)
+	ret := m.ctrl.Call(m, "GetProblemByID", ctx, problemID)
 	ret0, _ := ret[0].(models.Problem)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetProblemByID indicates an expected call of GetProblemByID.
-func (mr *MockProblemRepoMockRecorder) GetProblemByID(problemID interface{}) *gomock.Call {
+func (mr *MockProblemRepoMockRecorder) GetProblemByID(ctx, problemID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProblemByID", reflect.TypeOf((*MockProblemRepo)(nil).GetProblemByID), problemID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProblemByID", reflect.TypeOf((*MockProblemRepo)(nil).GetProblemByID), ctx, problemID)
 }
 
 // GetProblemTypeByID mocks base method.
-func (m *MockProblemRepo) GetProblemTypeByID(typeID int) (models.ProblemType, error) {
+func (m *MockProblemRepo) GetProblemTypeByID(ctx context.Context, typeID int) (models.ProblemType, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetProblemTypeByID", typeID)
+	ret := m.ctrl.Call(m, "GetProblemTypeByID", ctx, typeID)
 	ret0, _ := ret[0].(models.ProblemType)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetProblemTypeByID indicates an expected call of GetProblemTypeByID.
-func (mr *MockProblemRepoMockRecorder) GetProblemTypeByID(typeID interface{}) *gomock.Call {
+func (mr *MockProblemRepoMockRecorder) GetProblemTypeByID(ctx, typeID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProblemTypeByID", reflect.TypeOf((*MockProblemRepo)(nil).GetProblemTypeByID), typeID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProblemTypeByID", reflect.TypeOf((*MockProblemRepo)(nil).GetProblemTypeByID), ctx, typeID)
 }
 
 // GetProblemsByBeingSolved mocks base method.
-func (m *MockProblemRepo) GetProblemsByBeingSolved(solved bool) (*models.ProblemList, error) {
+func (m *MockProblemRepo) GetProblemsByBeingSolved(ctx context.Context, solved bool) (*models.ProblemList, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetProblemsByBeingSolved", solved)
+	ret := m.ctrl.Call(m, "GetProblemsByBeingSolved", ctx, solved)
 	ret0, _ := ret[0].(*models.ProblemList)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetProblemsByBeingSolved indicates an expected call of GetProblemsByBeingSolved.
-func (mr *MockProblemRepoMockRecorder) GetProblemsByBeingSolved(solved interface{}) *gomock.Call {
+func (mr *MockProblemRepoMockRecorder) GetProblemsByBeingSolved(ctx, solved interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProblemsByBeingSolved", reflect.TypeOf((*MockProblemRepo)(nil).GetProblemsByBeingSolved), solved)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProblemsByBeingSolved", reflect.TypeOf((*MockProblemRepo)(nil).GetProblemsByBeingSolved), ctx, solved)
 }
 
 // GetProblemsByTimePeriod mocks base method.
-func (m *MockProblemRepo) GetProblemsByTimePeriod(start, end time.Time) (*models.ProblemList, error) {
+func (m *MockProblemRepo) GetProblemsByTimePeriod(ctx context.Context, start, end time.Time) (*models.ProblemList, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetProblemsByTimePeriod", start, end)
+	ret := m.ctrl.Call(m, "GetProblemsByTimePeriod", ctx, start, end)
 	ret0, _ := ret[0].(*models.ProblemList)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetProblemsByTimePeriod indicates an expected call of GetProblemsByTimePeriod.
-func (mr *MockProblemRepoMockRecorder) GetProblemsByTimePeriod(start, end interface{}) *gomock.Call {
+func (mr *MockProblemRepoMockRecorder) GetProblemsByTimePeriod(ctx, start, end interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProblemsByTimePeriod", reflect.TypeOf((*MockProblemRepo)(nil).GetProblemsByTimePeriod), start, end)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProblemsByTimePeriod", reflect.TypeOf((*MockProblemRepo)(nil).GetProblemsByTimePeriod), ctx, start, end)
 }
 
 // GetProblemsByTypeID mocks base method.
-func (m *MockProblemRepo) GetProblemsByTypeID(typeID int) (*models.ProblemList, error) {
+func (m *MockProblemRepo) GetProblemsByTypeID(ctx context.Context, typeID int) (*models.ProblemList, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetProblemsByTypeID", typeID)
+	ret := m.ctrl.Call(m, "GetProblemsByTypeID", ctx, typeID)
 	ret0, _ := ret[0].(*models.ProblemList)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetProblemsByTypeID indicates an expected call of GetProblemsByTypeID.
-func (mr *MockProblemRepoMockRecorder) GetProblemsByTypeID(typeID interface{}) *gomock.Call {
+func (mr *MockProblemRepoMockRecorder) GetProblemsByTypeID(ctx, typeID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProblemsByTypeID", reflect.TypeOf((*MockProblemRepo)(nil).GetProblemsByTypeID), typeID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProblemsByTypeID", reflect.TypeOf((*MockProblemRepo)(nil).GetProblemsByTypeID), ctx, typeID)
 }
 
 // GetProblemsByUserID mocks base method.
-func (m *MockProblemRepo) GetProblemsByUserID(userID int) (*models.ProblemList, error) {
+func (m *MockProblemRepo) GetProblemsByUserID(ctx context.Context, userID int) (*models.ProblemList, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetProblemsByUserID", userID)
+	ret := m.ctrl.Call(m, "GetProblemsByUserID", ctx, userID)
 	ret0, _ := ret[0].(*models.ProblemList)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetProblemsByUserID indicates an expected call of GetProblemsByUserID.
-func (mr *MockProblemRepoMockRecorder) GetProblemsByUserID(userID interface{}) *gomock.Call {
+func (mr *MockProblemRepoMockRecorder) GetProblemsByUserID(ctx, userID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProblemsByUserID", reflect.TypeOf((*MockProblemRepo)(nil).GetProblemsByUserID), userID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProblemsByUserID", reflect.TypeOf((*MockProblemRepo)(nil).GetProblemsByUserID), ctx, userID)
 }
 
 // MarkProblemAsSolved mocks base method.
-func (m *MockProblemRepo) MarkProblemAsSolved(problem *models.Problem) (models.Problem, error) {
+func (m *MockProblemRepo) MarkProblemAsSolved(ctx context.Context, problem *models.Problem) (models.Problem, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "MarkProblemAsSolved", problem)
+	ret := m.ctrl.Call(m, "MarkProblemAsSolved", ctx, problem)
 	ret0, _ := ret[0].(models.Problem)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // MarkProblemAsSolved indicates an expected call of MarkProblemAsSolved.
-func (mr *MockProblemRepoMockRecorder) MarkProblemAsSolved(problem interface{}) *gomock.Call {
+func (mr *MockProblemRepoMockRecorder) MarkProblemAsSolved(ctx, problem interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkProblemAsSolved", reflect.TypeOf((*MockProblemRepo)(nil).MarkProblemAsSolved), problem)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkProblemAsSolved", reflect.TypeOf((*MockProblemRepo)(nil).MarkProblemAsSolved), ctx, problem)
 }
 
 // MockSolutionRepo is a mock of SolutionRepo interface.
@@ -207,45 +206,30 @@ func (m *MockSolutionRepo) EXPECT() *MockSolutionRepoMockRecorder {
 }
 
 // AddProblemSolution mocks base method.
-func (m *MockSolutionRepo) AddProblemSolution(problemID int, solution *models.Solution) error {
+func (m *MockSolutionRepo) AddProblemSolution(ctx context.Context, problemID int, solution *models.Solution) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "AddProblemSolution", problemID, solution)
+	ret := m.ctrl.Call(m, "AddProblemSolution", ctx, problemID, solution)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // AddProblemSolution indicates an expected call of AddProblemSolution.
-func (mr *MockSolutionRepoMockRecorder) AddProblemSolution(problemID, solution interface{}) *gomock.Call {
+func (mr *MockSolutionRepoMockRecorder) AddProblemSolution(ctx, problemID, solution interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddProblemSolution", reflect.TypeOf((*MockSolutionRepo)(nil).AddProblemSolution), problemID, solution)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddProblemSolution", reflect.TypeOf((*MockSolutionRepo)(nil).AddProblemSolution), ctx, problemID, solution)
 }
 
 // GetSolutionByProblem mocks base method.
-func (m *MockSolutionRepo) GetSolutionByProblem(problem models.Problem) (models.Solution, error) {
+func (m *MockSolutionRepo) GetSolutionByProblem(ctx context.Context, problem models.Problem) (models.Solution, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetSolutionByProblem", problem)
+	ret := m.ctrl.Call(m, "GetSolutionByProblem", ctx, problem)
 	ret0, _ := ret[0].(models.Solution)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetSolutionByProblem indicates an expected call of GetSolutionByProblem.
-func (mr *MockSolutionRepoMockRecorder) GetSolutionByProblem(problem interface{}) *gomock.Call {
-	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSolutionByProblem", reflect.TypeOf((*MockSolutionRepo)(nil).GetSolutionByProblem), problem)
-}
-
-// GetSolutionsByProblems mocks base method.
-func (m *MockSolutionRepo) GetSolutionsByProblems(problems models.ProblemList) (map[models.Problem]models.Solution, error) {
-	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetSolutionsByProblems", problems)
-	ret0, _ := ret[0].(map[models.Problem]models.Solution)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
-}
-
-// GetSolutionsByProblems indicates an expected call of GetSolutionsByProblems.
-func (mr *MockSolutionRepoMockRecorder) GetSolutionsByProblems(problems interface{}) *gomock.Call {
+func (mr *MockSolutionRepoMockRecorder) GetSolutionByProblem(ctx, problem interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSolutionsByProblems", reflect.TypeOf((*MockSolutionRepo)(nil).GetSolutionsByProblems), problems)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSolutionByProblem", reflect.TypeOf((*MockSolutionRepo)(nil).GetSolutionByProblem), ctx, problem)
 }
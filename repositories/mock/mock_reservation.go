@@ -0,0 +1,137 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: reservation.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	models "Dp218GO/models"
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockReservationRepo is a mock of ReservationRepo interface.
+type MockReservationRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockReservationRepoMockRecorder
+}
+
+// MockReservationRepoMockRecorder is the mock recorder for MockReservationRepo.
+type MockReservationRepoMockRecorder struct {
+	mock *MockReservationRepo
+}
+
+// NewMockReservationRepo creates a new mock instance.
+func NewMockReservationRepo(ctrl *gomock.Controller) *MockReservationRepo {
+	mock := &MockReservationRepo{ctrl: ctrl}
+	mock.recorder = &MockReservationRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReservationRepo) EXPECT() *MockReservationRepoMockRecorder {
+	return m.recorder
+}
+
+// AddReservation mocks base method.
+func (m *MockReservationRepo) AddReservation(ctx context.Context, reservation *models.Reservation) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddReservation", ctx, reservation)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddReservation indicates an expected call of AddReservation.
+func (mr *MockReservationRepoMockRecorder) AddReservation(ctx, reservation interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddReservation", reflect.TypeOf((*MockReservationRepo)(nil).AddReservation), ctx, reservation)
+}
+
+// ExpireReservations mocks base method.
+func (m *MockReservationRepo) ExpireReservations(ctx context.Context, now time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExpireReservations", ctx, now)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExpireReservations indicates an expected call of ExpireReservations.
+func (mr *MockReservationRepoMockRecorder) ExpireReservations(ctx, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExpireReservations", reflect.TypeOf((*MockReservationRepo)(nil).ExpireReservations), ctx, now)
+}
+
+// ExtendReservation mocks base method.
+func (m *MockReservationRepo) ExtendReservation(ctx context.Context, reservationID int, expiresAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExtendReservation", ctx, reservationID, expiresAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ExtendReservation indicates an expected call of ExtendReservation.
+func (mr *MockReservationRepoMockRecorder) ExtendReservation(ctx, reservationID, expiresAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExtendReservation", reflect.TypeOf((*MockReservationRepo)(nil).ExtendReservation), ctx, reservationID, expiresAt)
+}
+
+// GetActiveReservationByUserID mocks base method.
+func (m *MockReservationRepo) GetActiveReservationByUserID(ctx context.Context, userID int) (models.Reservation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetActiveReservationByUserID", ctx, userID)
+	ret0, _ := ret[0].(models.Reservation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetActiveReservationByUserID indicates an expected call of GetActiveReservationByUserID.
+func (mr *MockReservationRepoMockRecorder) GetActiveReservationByUserID(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActiveReservationByUserID", reflect.TypeOf((*MockReservationRepo)(nil).GetActiveReservationByUserID), ctx, userID)
+}
+
+// ReleaseReservation mocks base method.
+func (m *MockReservationRepo) ReleaseReservation(ctx context.Context, reservationID int, releasedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReleaseReservation", ctx, reservationID, releasedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReleaseReservation indicates an expected call of ReleaseReservation.
+func (mr *MockReservationRepoMockRecorder) ReleaseReservation(ctx, reservationID, releasedAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReleaseReservation", reflect.TypeOf((*MockReservationRepo)(nil).ReleaseReservation), ctx, reservationID, releasedAt)
+}
+
+// SetDestination mocks base method.
+func (m *MockReservationRepo) SetDestination(ctx context.Context, reservationID int, destination models.TripDestination) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetDestination", ctx, reservationID, destination)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetDestination indicates an expected call of SetDestination.
+func (mr *MockReservationRepoMockRecorder) SetDestination(ctx, reservationID, destination interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetDestination", reflect.TypeOf((*MockReservationRepo)(nil).SetDestination), ctx, reservationID, destination)
+}
+
+// UseReservation mocks base method.
+func (m *MockReservationRepo) UseReservation(ctx context.Context, reservationID int, usedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UseReservation", ctx, reservationID, usedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UseReservation indicates an expected call of UseReservation.
+func (mr *MockReservationRepoMockRecorder) UseReservation(ctx, reservationID, usedAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseReservation", reflect.TypeOf((*MockReservationRepo)(nil).UseReservation), ctx, reservationID, usedAt)
+}
@@ -6,6 +6,7 @@ package mock
 
 import (
 	models "Dp218GO/models"
+	context "context"
 	reflect "reflect"
 
 	gomock "github.com/golang/mock/gomock"
@@ -35,90 +36,90 @@ func (m *MockScooterRepo) EXPECT() *MockScooterRepoMockRecorder {
 }
 
 // CreateScooterStatusInRent mocks base method.
-func (m *MockScooterRepo) CreateScooterStatusInRent(scooterID int) (models.ScooterStatusInRent, error) {
+func (m *MockScooterRepo) CreateScooterStatusInRent(ctx context.Context, scooterID int) (models.ScooterStatusInRent, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateScooterStatusInRent", scooterID)
+	ret := m.ctrl.Call(m, "CreateScooterStatusInRent", ctx, scooterID)
 	ret0, _ := ret[0].(models.ScooterStatusInRent)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // CreateScooterStatusInRent indicates an expected call of CreateScooterStatusInRent.
-func (mr *MockScooterRepoMockRecorder) CreateScooterStatusInRent(scooterID interface{}) *gomock.Call {
+func (mr *MockScooterRepoMockRecorder) CreateScooterStatusInRent(ctx, scooterID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateScooterStatusInRent", reflect.TypeOf((*MockScooterRepo)(nil).CreateScooterStatusInRent), scooterID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateScooterStatusInRent", reflect.TypeOf((*MockScooterRepo)(nil).CreateScooterStatusInRent), ctx, scooterID)
 }
 
 // GetAllScooters mocks base method.
-func (m *MockScooterRepo) GetAllScooters() (*models.ScooterListDTO, error) {
+func (m *MockScooterRepo) GetAllScooters(ctx context.Context) (*models.ScooterListDTO, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAllScooters")
+	ret := m.ctrl.Call(m, "GetAllScooters", ctx)
 	ret0, _ := ret[0].(*models.ScooterListDTO)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetAllScooters indicates an expected call of GetAllScooters.
-func (mr *MockScooterRepoMockRecorder) GetAllScooters() *gomock.Call {
+func (mr *MockScooterRepoMockRecorder) GetAllScooters(ctx interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllScooters", reflect.TypeOf((*MockScooterRepo)(nil).GetAllScooters))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllScooters", reflect.TypeOf((*MockScooterRepo)(nil).GetAllScooters), ctx)
 }
 
 // GetAllScootersByStationID mocks base method.
-func (m *MockScooterRepo) GetAllScootersByStationID(stationID int) (*models.ScooterListDTO, error) {
+func (m *MockScooterRepo) GetAllScootersByStationID(ctx context.Context, stationID int) (*models.ScooterListDTO, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAllScootersByStationID", stationID)
+	ret := m.ctrl.Call(m, "GetAllScootersByStationID", ctx, stationID)
 	ret0, _ := ret[0].(*models.ScooterListDTO)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetAllScootersByStationID indicates an expected call of GetAllScootersByStationID.
-func (mr *MockScooterRepoMockRecorder) GetAllScootersByStationID(stationID interface{}) *gomock.Call {
+func (mr *MockScooterRepoMockRecorder) GetAllScootersByStationID(ctx, stationID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllScootersByStationID", reflect.TypeOf((*MockScooterRepo)(nil).GetAllScootersByStationID), stationID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllScootersByStationID", reflect.TypeOf((*MockScooterRepo)(nil).GetAllScootersByStationID), ctx, stationID)
 }
 
 // GetScooterById mocks base method.
-func (m *MockScooterRepo) GetScooterById(scooterId int) (models.ScooterDTO, error) {
+func (m *MockScooterRepo) GetScooterById(ctx context.Context, scooterId int) (models.ScooterDTO, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetScooterById", scooterId)
+	ret := m.ctrl.Call(m, "GetScooterById", ctx, scooterId)
 	ret0, _ := ret[0].(models.ScooterDTO)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetScooterById indicates an expected call of GetScooterById.
-func (mr *MockScooterRepoMockRecorder) GetScooterById(scooterId interface{}) *gomock.Call {
+func (mr *MockScooterRepoMockRecorder) GetScooterById(ctx, scooterId interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetScooterById", reflect.TypeOf((*MockScooterRepo)(nil).GetScooterById), scooterId)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetScooterById", reflect.TypeOf((*MockScooterRepo)(nil).GetScooterById), ctx, scooterId)
 }
 
 // GetScooterStatus mocks base method.
-func (m *MockScooterRepo) GetScooterStatus(scooterID int) (models.ScooterStatus, error) {
+func (m *MockScooterRepo) GetScooterStatus(ctx context.Context, scooterID int) (models.ScooterStatus, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetScooterStatus", scooterID)
+	ret := m.ctrl.Call(m, "GetScooterStatus", ctx, scooterID)
 	ret0, _ := ret[0].(models.ScooterStatus)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetScooterStatus indicates an expected call of GetScooterStatus.
-func (mr *MockScooterRepoMockRecorder) GetScooterStatus(scooterID interface{}) *gomock.Call {
+func (mr *MockScooterRepoMockRecorder) GetScooterStatus(ctx, scooterID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetScooterStatus", reflect.TypeOf((*MockScooterRepo)(nil).GetScooterStatus), scooterID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetScooterStatus", reflect.TypeOf((*MockScooterRepo)(nil).GetScooterStatus), ctx, scooterID)
 }
 
 // SendCurrentStatus mocks base method.
-func (m *MockScooterRepo) SendCurrentStatus(id, stationID int, lat, lon, battery float64) error {
+func (m *MockScooterRepo) SendCurrentStatus(ctx context.Context, id, stationID int, lat, lon, battery float64) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "SendCurrentStatus", id, stationID, lat, lon, battery)
+	ret := m.ctrl.Call(m, "SendCurrentStatus", ctx, id, stationID, lat, lon, battery)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // SendCurrentStatus indicates an expected call of SendCurrentStatus.
-func (mr *MockScooterRepoMockRecorder) SendCurrentStatus(id, stationID, lat, lon, battery interface{}) *gomock.Call {
+func (mr *MockScooterRepoMockRecorder) SendCurrentStatus(ctx, id, stationID, lat, lon, battery interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendCurrentStatus", reflect.TypeOf((*MockScooterRepo)(nil).SendCurrentStatus), id, stationID, lat, lon, battery)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendCurrentStatus", reflect.TypeOf((*MockScooterRepo)(nil).SendCurrentStatus), ctx, id, stationID, lat, lon, battery)
 }
@@ -0,0 +1,198 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: station.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	models "Dp218GO/models"
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockStationRepo is a mock of StationRepo interface.
+type MockStationRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockStationRepoMockRecorder
+}
+
+// MockStationRepoMockRecorder is the mock recorder for MockStationRepo.
+type MockStationRepoMockRecorder struct {
+	mock *MockStationRepo
+}
+
+// NewMockStationRepo creates a new mock instance.
+func NewMockStationRepo(ctrl *gomock.Controller) *MockStationRepo {
+	mock := &MockStationRepo{ctrl: ctrl}
+	mock.recorder = &MockStationRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStationRepo) EXPECT() *MockStationRepoMockRecorder {
+	return m.recorder
+}
+
+// AddStation mocks base method.
+func (m *MockStationRepo) AddStation(ctx context.Context, station *models.Station) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddStation", ctx, station)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddStation indicates an expected call of AddStation.
+func (mr *MockStationRepoMockRecorder) AddStation(ctx, station interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddStation", reflect.TypeOf((*MockStationRepo)(nil).AddStation), ctx, station)
+}
+
+// AddStatusChange mocks base method.
+func (m *MockStationRepo) AddStatusChange(ctx context.Context, change *models.StationStatusChange, now time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddStatusChange", ctx, change, now)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddStatusChange indicates an expected call of AddStatusChange.
+func (mr *MockStationRepoMockRecorder) AddStatusChange(ctx, change, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddStatusChange", reflect.TypeOf((*MockStationRepo)(nil).AddStatusChange), ctx, change, now)
+}
+
+// ApplyStatusChanges mocks base method.
+func (m *MockStationRepo) ApplyStatusChanges(ctx context.Context, now time.Time) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApplyStatusChanges", ctx, now)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ApplyStatusChanges indicates an expected call of ApplyStatusChanges.
+func (mr *MockStationRepoMockRecorder) ApplyStatusChanges(ctx, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplyStatusChanges", reflect.TypeOf((*MockStationRepo)(nil).ApplyStatusChanges), ctx, now)
+}
+
+// CancelStatusChange mocks base method.
+func (m *MockStationRepo) CancelStatusChange(ctx context.Context, stationId, changeId int, now time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelStatusChange", ctx, stationId, changeId, now)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CancelStatusChange indicates an expected call of CancelStatusChange.
+func (mr *MockStationRepoMockRecorder) CancelStatusChange(ctx, stationId, changeId, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelStatusChange", reflect.TypeOf((*MockStationRepo)(nil).CancelStatusChange), ctx, stationId, changeId, now)
+}
+
+// DeleteStation mocks base method.
+func (m *MockStationRepo) DeleteStation(ctx context.Context, stationId, moveTo int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteStation", ctx, stationId, moveTo)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteStation indicates an expected call of DeleteStation.
+func (mr *MockStationRepoMockRecorder) DeleteStation(ctx, stationId, moveTo interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteStation", reflect.TypeOf((*MockStationRepo)(nil).DeleteStation), ctx, stationId, moveTo)
+}
+
+// FindActiveInBox mocks base method.
+func (m *MockStationRepo) FindActiveInBox(ctx context.Context, southWest, northEast models.Coordinate) ([]models.NearbyStation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindActiveInBox", ctx, southWest, northEast)
+	ret0, _ := ret[0].([]models.NearbyStation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindActiveInBox indicates an expected call of FindActiveInBox.
+func (mr *MockStationRepoMockRecorder) FindActiveInBox(ctx, southWest, northEast interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindActiveInBox", reflect.TypeOf((*MockStationRepo)(nil).FindActiveInBox), ctx, southWest, northEast)
+}
+
+// GetAllStations mocks base method.
+func (m *MockStationRepo) GetAllStations(ctx context.Context) (*models.StationList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllStations", ctx)
+	ret0, _ := ret[0].(*models.StationList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllStations indicates an expected call of GetAllStations.
+func (mr *MockStationRepoMockRecorder) GetAllStations(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllStations", reflect.TypeOf((*MockStationRepo)(nil).GetAllStations), ctx)
+}
+
+// GetStationById mocks base method.
+func (m *MockStationRepo) GetStationById(ctx context.Context, stationId int) (models.Station, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStationById", ctx, stationId)
+	ret0, _ := ret[0].(models.Station)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStationById indicates an expected call of GetStationById.
+func (mr *MockStationRepoMockRecorder) GetStationById(ctx, stationId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStationById", reflect.TypeOf((*MockStationRepo)(nil).GetStationById), ctx, stationId)
+}
+
+// GetStatusHistory mocks base method.
+func (m *MockStationRepo) GetStatusHistory(ctx context.Context, stationId int) (*models.StationStatusHistory, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStatusHistory", ctx, stationId)
+	ret0, _ := ret[0].(*models.StationStatusHistory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStatusHistory indicates an expected call of GetStatusHistory.
+func (mr *MockStationRepoMockRecorder) GetStatusHistory(ctx, stationId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStatusHistory", reflect.TypeOf((*MockStationRepo)(nil).GetStatusHistory), ctx, stationId)
+}
+
+// HasRoom mocks base method.
+func (m *MockStationRepo) HasRoom(ctx context.Context, stationId, scooterId int) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HasRoom", ctx, stationId, scooterId)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HasRoom indicates an expected call of HasRoom.
+func (mr *MockStationRepoMockRecorder) HasRoom(ctx, stationId, scooterId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HasRoom", reflect.TypeOf((*MockStationRepo)(nil).HasRoom), ctx, stationId, scooterId)
+}
+
+// UpdateStation mocks base method.
+func (m *MockStationRepo) UpdateStation(ctx context.Context, stationId int, stationData models.Station) (models.Station, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateStation", ctx, stationId, stationData)
+	ret0, _ := ret[0].(models.Station)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateStation indicates an expected call of UpdateStation.
+func (mr *MockStationRepoMockRecorder) UpdateStation(ctx, stationId, stationData interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateStation", reflect.TypeOf((*MockStationRepo)(nil).UpdateStation), ctx, stationId, stationData)
+}
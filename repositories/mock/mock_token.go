@@ -0,0 +1,109 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: token.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	models "Dp218GO/models"
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockTokenRepo is a mock of TokenRepo interface.
+type MockTokenRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockTokenRepoMockRecorder
+}
+
+// MockTokenRepoMockRecorder is the mock recorder for MockTokenRepo.
+type MockTokenRepoMockRecorder struct {
+	mock *MockTokenRepo
+}
+
+// NewMockTokenRepo creates a new mock instance.
+func NewMockTokenRepo(ctrl *gomock.Controller) *MockTokenRepo {
+	mock := &MockTokenRepo{ctrl: ctrl}
+	mock.recorder = &MockTokenRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTokenRepo) EXPECT() *MockTokenRepoMockRecorder {
+	return m.recorder
+}
+
+// AddToken mocks base method.
+func (m *MockTokenRepo) AddToken(ctx context.Context, token *models.AuthToken) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddToken", ctx, token)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddToken indicates an expected call of AddToken.
+func (mr *MockTokenRepoMockRecorder) AddToken(ctx, token interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddToken", reflect.TypeOf((*MockTokenRepo)(nil).AddToken), ctx, token)
+}
+
+// GetTokenByAccessHash mocks base method.
+func (m *MockTokenRepo) GetTokenByAccessHash(ctx context.Context, accessHash string) (models.AuthToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTokenByAccessHash", ctx, accessHash)
+	ret0, _ := ret[0].(models.AuthToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTokenByAccessHash indicates an expected call of GetTokenByAccessHash.
+func (mr *MockTokenRepoMockRecorder) GetTokenByAccessHash(ctx, accessHash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTokenByAccessHash", reflect.TypeOf((*MockTokenRepo)(nil).GetTokenByAccessHash), ctx, accessHash)
+}
+
+// GetTokenByRefreshHash mocks base method.
+func (m *MockTokenRepo) GetTokenByRefreshHash(ctx context.Context, refreshHash string) (models.AuthToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTokenByRefreshHash", ctx, refreshHash)
+	ret0, _ := ret[0].(models.AuthToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTokenByRefreshHash indicates an expected call of GetTokenByRefreshHash.
+func (mr *MockTokenRepoMockRecorder) GetTokenByRefreshHash(ctx, refreshHash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTokenByRefreshHash", reflect.TypeOf((*MockTokenRepo)(nil).GetTokenByRefreshHash), ctx, refreshHash)
+}
+
+// RevokeToken mocks base method.
+func (m *MockTokenRepo) RevokeToken(ctx context.Context, tokenID int, revokedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeToken", ctx, tokenID, revokedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeToken indicates an expected call of RevokeToken.
+func (mr *MockTokenRepoMockRecorder) RevokeToken(ctx, tokenID, revokedAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeToken", reflect.TypeOf((*MockTokenRepo)(nil).RevokeToken), ctx, tokenID, revokedAt)
+}
+
+// RevokeUserTokens mocks base method.
+func (m *MockTokenRepo) RevokeUserTokens(ctx context.Context, userID int, revokedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeUserTokens", ctx, userID, revokedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeUserTokens indicates an expected call of RevokeUserTokens.
+func (mr *MockTokenRepoMockRecorder) RevokeUserTokens(ctx, userID, revokedAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeUserTokens", reflect.TypeOf((*MockTokenRepo)(nil).RevokeUserTokens), ctx, userID, revokedAt)
+}
@@ -8,6 +8,7 @@ import (
 	models "Dp218GO/models"
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
 )
@@ -35,107 +36,164 @@ func (m *MockUserRepo) EXPECT() *MockUserRepoMockRecorder {
 	return m.recorder
 }
 
+// ActivateUser mocks base method.
+func (m *MockUserRepo) ActivateUser(ctx context.Context, userID int, activatedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ActivateUser", ctx, userID, activatedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ActivateUser indicates an expected call of ActivateUser.
+func (mr *MockUserRepoMockRecorder) ActivateUser(ctx, userID, activatedAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ActivateUser", reflect.TypeOf((*MockUserRepo)(nil).ActivateUser), ctx, userID, activatedAt)
+}
+
 // AddUser mocks base method.
-func (m *MockUserRepo) AddUser(user *models.User) error {
+func (m *MockUserRepo) AddUser(ctx context.Context, user *models.User) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "AddUser", user)
+	ret := m.ctrl.Call(m, "AddUser", ctx, user)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // AddUser indicates an expected call of AddUser.
-func (mr *MockUserRepoMockRecorder) AddUser(user interface{}) *gomock.Call {
+func (mr *MockUserRepoMockRecorder) AddUser(ctx, user interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddUser", reflect.TypeOf((*MockUserRepo)(nil).AddUser), user)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddUser", reflect.TypeOf((*MockUserRepo)(nil).AddUser), ctx, user)
 }
 
 // DeleteUser mocks base method.
-func (m *MockUserRepo) DeleteUser(userID int) error {
+func (m *MockUserRepo) DeleteUser(ctx context.Context, userID int) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteUser", userID)
+	ret := m.ctrl.Call(m, "DeleteUser", ctx, userID)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeleteUser indicates an expected call of DeleteUser.
-func (mr *MockUserRepoMockRecorder) DeleteUser(userID interface{}) *gomock.Call {
+func (mr *MockUserRepoMockRecorder) DeleteUser(ctx, userID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUser", reflect.TypeOf((*MockUserRepo)(nil).DeleteUser), userID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUser", reflect.TypeOf((*MockUserRepo)(nil).DeleteUser), ctx, userID)
 }
 
 // FindUsersByLoginNameSurname mocks base method.
-func (m *MockUserRepo) FindUsersByLoginNameSurname(whatToFind string) (*models.UserList, error) {
+func (m *MockUserRepo) FindUsersByLoginNameSurname(ctx context.Context, whatToFind string) (*models.UserList, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "FindUsersByLoginNameSurname", whatToFind)
+	ret := m.ctrl.Call(m, "FindUsersByLoginNameSurname", ctx, whatToFind)
 	ret0, _ := ret[0].(*models.UserList)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // FindUsersByLoginNameSurname indicates an expected call of FindUsersByLoginNameSurname.
-func (mr *MockUserRepoMockRecorder) FindUsersByLoginNameSurname(whatToFind interface{}) *gomock.Call {
+func (mr *MockUserRepoMockRecorder) FindUsersByLoginNameSurname(ctx, whatToFind interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindUsersByLoginNameSurname", reflect.TypeOf((*MockUserRepo)(nil).FindUsersByLoginNameSurname), whatToFind)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindUsersByLoginNameSurname", reflect.TypeOf((*MockUserRepo)(nil).FindUsersByLoginNameSurname), ctx, whatToFind)
 }
 
 // GetAllUsers mocks base method.
-func (m *MockUserRepo) GetAllUsers() (*models.UserList, error) {
+func (m *MockUserRepo) GetAllUsers(ctx context.Context) (*models.UserList, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAllUsers")
+	ret := m.ctrl.Call(m, "GetAllUsers", ctx)
 	ret0, _ := ret[0].(*models.UserList)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetAllUsers indicates an expected call of GetAllUsers.
-func (mr *MockUserRepoMockRecorder) GetAllUsers() *gomock.Call {
+func (mr *MockUserRepoMockRecorder) GetAllUsers(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllUsers", reflect.TypeOf((*MockUserRepo)(nil).GetAllUsers), ctx)
+}
+
+// GetPasswordChangedAt mocks base method.
+func (m *MockUserRepo) GetPasswordChangedAt(ctx context.Context, userID int) (time.Time, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPasswordChangedAt", ctx, userID)
+	ret0, _ := ret[0].(time.Time)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPasswordChangedAt indicates an expected call of GetPasswordChangedAt.
+func (mr *MockUserRepoMockRecorder) GetPasswordChangedAt(ctx, userID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllUsers", reflect.TypeOf((*MockUserRepo)(nil).GetAllUsers))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPasswordChangedAt", reflect.TypeOf((*MockUserRepo)(nil).GetPasswordChangedAt), ctx, userID)
 }
 
 // GetUserByEmail mocks base method.
-func (m *MockUserRepo) GetUserByEmail(email string) (models.User, error) {
+func (m *MockUserRepo) GetUserByEmail(ctx context.Context, email string) (models.User, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetUserByEmail", email)
+	ret := m.ctrl.Call(m, "GetUserByEmail", ctx, email)
 	ret0, _ := ret[0].(models.User)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetUserByEmail indicates an expected call of GetUserByEmail.
-func (mr *MockUserRepoMockRecorder) GetUserByEmail(email interface{}) *gomock.Call {
+func (mr *MockUserRepoMockRecorder) GetUserByEmail(ctx, email interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByEmail", reflect.TypeOf((*MockUserRepo)(nil).GetUserByEmail), email)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByEmail", reflect.TypeOf((*MockUserRepo)(nil).GetUserByEmail), ctx, email)
 }
 
 // GetUserByID mocks base method.
-func (m *MockUserRepo) GetUserByID(userID int) (models.User, error) {
+func (m *MockUserRepo) GetUserByID(ctx context.Context, userID int) (models.User, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetUserByID", userID)
+	ret := m.ctrl.Call(m, "GetUserByID", ctx, userID)
 	ret0, _ := ret[0].(models.User)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetUserByID indicates an expected call of GetUserByID.
-func (mr *MockUserRepoMockRecorder) GetUserByID(userID interface{}) *gomock.Call {
+func (mr *MockUserRepoMockRecorder) GetUserByID(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByID", reflect.TypeOf((*MockUserRepo)(nil).GetUserByID), ctx, userID)
+}
+
+// UnlockUser mocks base method.
+func (m *MockUserRepo) UnlockUser(ctx context.Context, userID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnlockUser", ctx, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UnlockUser indicates an expected call of UnlockUser.
+func (mr *MockUserRepoMockRecorder) UnlockUser(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnlockUser", reflect.TypeOf((*MockUserRepo)(nil).UnlockUser), ctx, userID)
+}
+
+// UpdatePassword mocks base method.
+func (m *MockUserRepo) UpdatePassword(ctx context.Context, userID int, passwordHash string, changedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePassword", ctx, userID, passwordHash, changedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdatePassword indicates an expected call of UpdatePassword.
+func (mr *MockUserRepoMockRecorder) UpdatePassword(ctx, userID, passwordHash, changedAt interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByID", reflect.TypeOf((*MockUserRepo)(nil).GetUserByID), userID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePassword", reflect.TypeOf((*MockUserRepo)(nil).UpdatePassword), ctx, userID, passwordHash, changedAt)
 }
 
 // UpdateUser mocks base method.
-func (m *MockUserRepo) UpdateUser(userID int, userData models.User) (models.User, error) {
+func (m *MockUserRepo) UpdateUser(ctx context.Context, userID int, userData models.User) (models.User, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateUser", userID, userData)
+	ret := m.ctrl.Call(m, "UpdateUser", ctx, userID, userData)
 	ret0, _ := ret[0].(models.User)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // UpdateUser indicates an expected call of UpdateUser.
-func (mr *MockUserRepoMockRecorder) UpdateUser(userID, userData interface{}) *gomock.Call {
+func (mr *MockUserRepoMockRecorder) UpdateUser(ctx, userID, userData interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUser", reflect.TypeOf((*MockUserRepo)(nil).UpdateUser), userID, userData)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUser", reflect.TypeOf((*MockUserRepo)(nil).UpdateUser), ctx, userID, userData)
 }
 
 // MockRoleRepo is a mock of RoleRepo interface.
@@ -162,33 +220,33 @@ func (m *MockRoleRepo) EXPECT() *MockRoleRepoMockRecorder {
 }
 
 // GetAllRoles mocks base method.
-func (m *MockRoleRepo) GetAllRoles() (*models.RoleList, error) {
+func (m *MockRoleRepo) GetAllRoles(ctx context.Context) (*models.RoleList, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAllRoles")
+	ret := m.ctrl.Call(m, "GetAllRoles", ctx)
 	ret0, _ := ret[0].(*models.RoleList)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetAllRoles indicates an expected call of GetAllRoles.
-func (mr *MockRoleRepoMockRecorder) GetAllRoles() *gomock.Call {
+func (mr *MockRoleRepoMockRecorder) GetAllRoles(ctx interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllRoles", reflect.TypeOf((*MockRoleRepo)(nil).GetAllRoles))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllRoles", reflect.TypeOf((*MockRoleRepo)(nil).GetAllRoles), ctx)
 }
 
 // GetRoleByID mocks base method.
-func (m *MockRoleRepo) GetRoleByID(roleID int) (models.Role, error) {
+func (m *MockRoleRepo) GetRoleByID(ctx context.Context, roleID int) (models.Role, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetRoleByID", roleID)
+	ret := m.ctrl.Call(m, "GetRoleByID", ctx, roleID)
 	ret0, _ := ret[0].(models.Role)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetRoleByID indicates an expected call of GetRoleByID.
-func (mr *MockRoleRepoMockRecorder) GetRoleByID(roleID interface{}) *gomock.Call {
+func (mr *MockRoleRepoMockRecorder) GetRoleByID(ctx, roleID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRoleByID", reflect.TypeOf((*MockRoleRepo)(nil).GetRoleByID), roleID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRoleByID", reflect.TypeOf((*MockRoleRepo)(nil).GetRoleByID), ctx, roleID)
 }
 
 // MockAuthRepo is a mock of AuthRepo interface.
@@ -0,0 +1,124 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: zone.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	models "Dp218GO/models"
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockZoneRepo is a mock of ZoneRepo interface.
+type MockZoneRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockZoneRepoMockRecorder
+}
+
+// MockZoneRepoMockRecorder is the mock recorder for MockZoneRepo.
+type MockZoneRepoMockRecorder struct {
+	mock *MockZoneRepo
+}
+
+// NewMockZoneRepo creates a new mock instance.
+func NewMockZoneRepo(ctrl *gomock.Controller) *MockZoneRepo {
+	mock := &MockZoneRepo{ctrl: ctrl}
+	mock.recorder = &MockZoneRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockZoneRepo) EXPECT() *MockZoneRepoMockRecorder {
+	return m.recorder
+}
+
+// AddZone mocks base method.
+func (m *MockZoneRepo) AddZone(ctx context.Context, zone *models.Zone) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddZone", ctx, zone)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddZone indicates an expected call of AddZone.
+func (mr *MockZoneRepoMockRecorder) AddZone(ctx, zone interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddZone", reflect.TypeOf((*MockZoneRepo)(nil).AddZone), ctx, zone)
+}
+
+// DeleteZone mocks base method.
+func (m *MockZoneRepo) DeleteZone(ctx context.Context, zoneID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteZone", ctx, zoneID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteZone indicates an expected call of DeleteZone.
+func (mr *MockZoneRepoMockRecorder) DeleteZone(ctx, zoneID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteZone", reflect.TypeOf((*MockZoneRepo)(nil).DeleteZone), ctx, zoneID)
+}
+
+// FindActiveAt mocks base method.
+func (m *MockZoneRepo) FindActiveAt(ctx context.Context, point models.Coordinate) ([]models.Zone, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindActiveAt", ctx, point)
+	ret0, _ := ret[0].([]models.Zone)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindActiveAt indicates an expected call of FindActiveAt.
+func (mr *MockZoneRepoMockRecorder) FindActiveAt(ctx, point interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindActiveAt", reflect.TypeOf((*MockZoneRepo)(nil).FindActiveAt), ctx, point)
+}
+
+// GetAllZones mocks base method.
+func (m *MockZoneRepo) GetAllZones(ctx context.Context) (*models.ZoneList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllZones", ctx)
+	ret0, _ := ret[0].(*models.ZoneList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllZones indicates an expected call of GetAllZones.
+func (mr *MockZoneRepoMockRecorder) GetAllZones(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllZones", reflect.TypeOf((*MockZoneRepo)(nil).GetAllZones), ctx)
+}
+
+// GetZoneByID mocks base method.
+func (m *MockZoneRepo) GetZoneByID(ctx context.Context, zoneID int) (models.Zone, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetZoneByID", ctx, zoneID)
+	ret0, _ := ret[0].(models.Zone)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetZoneByID indicates an expected call of GetZoneByID.
+func (mr *MockZoneRepoMockRecorder) GetZoneByID(ctx, zoneID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetZoneByID", reflect.TypeOf((*MockZoneRepo)(nil).GetZoneByID), ctx, zoneID)
+}
+
+// UpdateZone mocks base method.
+func (m *MockZoneRepo) UpdateZone(ctx context.Context, zoneID int, zone models.Zone) (models.Zone, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateZone", ctx, zoneID, zone)
+	ret0, _ := ret[0].(models.Zone)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateZone indicates an expected call of UpdateZone.
+func (mr *MockZoneRepoMockRecorder) UpdateZone(ctx, zoneID, zone interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateZone", reflect.TypeOf((*MockZoneRepo)(nil).UpdateZone), ctx, zoneID, zone)
+}
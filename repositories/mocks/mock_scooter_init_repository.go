@@ -6,6 +6,7 @@ package mock
 
 import (
 	models "Dp218GO/models"
+	context "context"
 	reflect "reflect"
 
 	gomock "github.com/golang/mock/gomock"
@@ -35,48 +36,45 @@ func (m *MockScooterInitRepoI) EXPECT() *MockScooterInitRepoIMockRecorder {
 }
 
 // AddStatusesToScooters mocks base method.
-func (m *MockScooterInitRepoI) AddStatusesToScooters(scooterIds []int, station models.Station) error {
+func (m *MockScooterInitRepoI) AddStatusesToScooters(ctx context.Context, owner models.User, scooterIds []int, station models.Station) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "AddStatusesToScooters", scooterIds, station)
+	ret := m.ctrl.Call(m, "AddStatusesToScooters", ctx, owner, scooterIds, station)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // AddStatusesToScooters indicates an expected call of AddStatusesToScooters.
-func (mr *MockScooterInitRepoIMockRecorder) AddStatusesToScooters(scooterIds, station interface{}) *gomock.Call {
+func (mr *MockScooterInitRepoIMockRecorder) AddStatusesToScooters(ctx, owner, scooterIds, station interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddStatusesToScooters",
-		reflect.TypeOf((*MockScooterInitRepoI)(nil).AddStatusesToScooters), scooterIds, station)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddStatusesToScooters", reflect.TypeOf((*MockScooterInitRepoI)(nil).AddStatusesToScooters), ctx, owner, scooterIds, station)
 }
 
 // GetActiveStations mocks base method.
-func (m *MockScooterInitRepoI) GetActiveStations() (*models.StationList, error) {
+func (m *MockScooterInitRepoI) GetActiveStations(ctx context.Context) (*models.StationList, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetActiveStations")
+	ret := m.ctrl.Call(m, "GetActiveStations", ctx)
 	ret0, _ := ret[0].(*models.StationList)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetActiveStations indicates an expected call of GetActiveStations.
-func (mr *MockScooterInitRepoIMockRecorder) GetActiveStations() *gomock.Call {
+func (mr *MockScooterInitRepoIMockRecorder) GetActiveStations(ctx interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActiveStations",
-		reflect.TypeOf((*MockScooterInitRepoI)(nil).GetActiveStations))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActiveStations", reflect.TypeOf((*MockScooterInitRepoI)(nil).GetActiveStations), ctx)
 }
 
 // GetOwnersScooters mocks base method.
-func (m *MockScooterInitRepoI) GetOwnersScooters() (*models.SuppliersScooterList, error) {
+func (m *MockScooterInitRepoI) GetOwnersScooters(ctx context.Context, owner models.User) (*models.SuppliersScooterList, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetOwnersScooters")
+	ret := m.ctrl.Call(m, "GetOwnersScooters", ctx, owner)
 	ret0, _ := ret[0].(*models.SuppliersScooterList)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetOwnersScooters indicates an expected call of GetOwnersScooters.
-func (mr *MockScooterInitRepoIMockRecorder) GetOwnersScooters() *gomock.Call {
+func (mr *MockScooterInitRepoIMockRecorder) GetOwnersScooters(ctx, owner interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOwnersScooters",
-		reflect.TypeOf((*MockScooterInitRepoI)(nil).GetOwnersScooters))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOwnersScooters", reflect.TypeOf((*MockScooterInitRepoI)(nil).GetOwnersScooters), ctx, owner)
 }
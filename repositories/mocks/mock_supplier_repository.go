@@ -6,6 +6,7 @@ package mock
 
 import (
 	models "Dp218GO/models"
+	context "context"
 	reflect "reflect"
 
 	gomock "github.com/golang/mock/gomock"
@@ -35,31 +36,31 @@ func (m *MockSupplierRepoI) EXPECT() *MockSupplierRepoIMockRecorder {
 }
 
 // AddModel mocks base method.
-func (m *MockSupplierRepoI) AddModel(modelData *models.ScooterModelDTO) error {
+func (m *MockSupplierRepoI) AddModel(ctx context.Context, owner models.User, modelData *models.ScooterModelDTO) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "AddModel", modelData)
+	ret := m.ctrl.Call(m, "AddModel", ctx, owner, modelData)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // AddModel indicates an expected call of AddModel.
-func (mr *MockSupplierRepoIMockRecorder) AddModel(modelData interface{}) *gomock.Call {
+func (mr *MockSupplierRepoIMockRecorder) AddModel(ctx, owner, modelData interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddModel", reflect.TypeOf((*MockSupplierRepoI)(nil).AddModel), modelData)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddModel", reflect.TypeOf((*MockSupplierRepoI)(nil).AddModel), ctx, owner, modelData)
 }
 
 // AddSuppliersScooter mocks base method.
-func (m *MockSupplierRepoI) AddSuppliersScooter(modelId int, scooter string) error {
+func (m *MockSupplierRepoI) AddSuppliersScooter(ctx context.Context, owner models.User, modelId int, scooter string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "AddSuppliersScooter", modelId, scooter)
+	ret := m.ctrl.Call(m, "AddSuppliersScooter", ctx, owner, modelId, scooter)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // AddSuppliersScooter indicates an expected call of AddSuppliersScooter.
-func (mr *MockSupplierRepoIMockRecorder) AddSuppliersScooter(modelId, scooter interface{}) *gomock.Call {
+func (mr *MockSupplierRepoIMockRecorder) AddSuppliersScooter(ctx, owner, modelId, scooter interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddSuppliersScooter", reflect.TypeOf((*MockSupplierRepoI)(nil).AddSuppliersScooter), modelId, scooter)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddSuppliersScooter", reflect.TypeOf((*MockSupplierRepoI)(nil).AddSuppliersScooter), ctx, owner, modelId, scooter)
 }
 
 // ConvertToStruct mocks base method.
@@ -77,73 +78,73 @@ func (mr *MockSupplierRepoIMockRecorder) ConvertToStruct(path interface{}) *gomo
 }
 
 // DeleteSuppliersScooter mocks base method.
-func (m *MockSupplierRepoI) DeleteSuppliersScooter(id int) error {
+func (m *MockSupplierRepoI) DeleteSuppliersScooter(ctx context.Context, owner models.User, id int) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteSuppliersScooter", id)
+	ret := m.ctrl.Call(m, "DeleteSuppliersScooter", ctx, owner, id)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeleteSuppliersScooter indicates an expected call of DeleteSuppliersScooter.
-func (mr *MockSupplierRepoIMockRecorder) DeleteSuppliersScooter(id interface{}) *gomock.Call {
+func (mr *MockSupplierRepoIMockRecorder) DeleteSuppliersScooter(ctx, owner, id interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSuppliersScooter", reflect.TypeOf((*MockSupplierRepoI)(nil).DeleteSuppliersScooter), id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSuppliersScooter", reflect.TypeOf((*MockSupplierRepoI)(nil).DeleteSuppliersScooter), ctx, owner, id)
 }
 
 // EditPrice mocks base method.
-func (m *MockSupplierRepoI) EditPrice(modelData *models.ScooterModelDTO) error {
+func (m *MockSupplierRepoI) EditPrice(ctx context.Context, owner models.User, modelData *models.ScooterModelDTO) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "EditPrice", modelData)
+	ret := m.ctrl.Call(m, "EditPrice", ctx, owner, modelData)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // EditPrice indicates an expected call of EditPrice.
-func (mr *MockSupplierRepoIMockRecorder) EditPrice(modelData interface{}) *gomock.Call {
+func (mr *MockSupplierRepoIMockRecorder) EditPrice(ctx, owner, modelData interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EditPrice", reflect.TypeOf((*MockSupplierRepoI)(nil).EditPrice), modelData)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EditPrice", reflect.TypeOf((*MockSupplierRepoI)(nil).EditPrice), ctx, owner, modelData)
 }
 
 // GetModels mocks base method.
-func (m *MockSupplierRepoI) GetModels() (*models.ScooterModelDTOList, error) {
+func (m *MockSupplierRepoI) GetModels(ctx context.Context, owner models.User) (*models.ScooterModelDTOList, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetModels")
+	ret := m.ctrl.Call(m, "GetModels", ctx, owner)
 	ret0, _ := ret[0].(*models.ScooterModelDTOList)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetModels indicates an expected call of GetModels.
-func (mr *MockSupplierRepoIMockRecorder) GetModels() *gomock.Call {
+func (mr *MockSupplierRepoIMockRecorder) GetModels(ctx, owner interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetModels", reflect.TypeOf((*MockSupplierRepoI)(nil).GetModels))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetModels", reflect.TypeOf((*MockSupplierRepoI)(nil).GetModels), ctx, owner)
 }
 
 // InsertToDb mocks base method.
-func (m *MockSupplierRepoI) InsertToDb(modelId int, scooters []models.UploadedScooters) error {
+func (m *MockSupplierRepoI) InsertToDb(ctx context.Context, owner models.User, modelId int, scooters []models.UploadedScooters) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "InsertToDb", modelId, scooters)
+	ret := m.ctrl.Call(m, "InsertToDb", ctx, owner, modelId, scooters)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // InsertToDb indicates an expected call of InsertToDb.
-func (mr *MockSupplierRepoIMockRecorder) InsertToDb(modelId, scooters interface{}) *gomock.Call {
+func (mr *MockSupplierRepoIMockRecorder) InsertToDb(ctx, owner, modelId, scooters interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertToDb", reflect.TypeOf((*MockSupplierRepoI)(nil).InsertToDb), modelId, scooters)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertToDb", reflect.TypeOf((*MockSupplierRepoI)(nil).InsertToDb), ctx, owner, modelId, scooters)
 }
 
 // SelectModel mocks base method.
-func (m *MockSupplierRepoI) SelectModel(id int) (*models.ScooterModelDTO, error) {
+func (m *MockSupplierRepoI) SelectModel(ctx context.Context, owner models.User, id int) (*models.ScooterModelDTO, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "SelectModel", id)
+	ret := m.ctrl.Call(m, "SelectModel", ctx, owner, id)
 	ret0, _ := ret[0].(*models.ScooterModelDTO)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // SelectModel indicates an expected call of SelectModel.
-func (mr *MockSupplierRepoIMockRecorder) SelectModel(id interface{}) *gomock.Call {
+func (mr *MockSupplierRepoIMockRecorder) SelectModel(ctx, owner, id interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectModel", reflect.TypeOf((*MockSupplierRepoI)(nil).SelectModel), id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectModel", reflect.TypeOf((*MockSupplierRepoI)(nil).SelectModel), ctx, owner, id)
 }
@@ -1,17 +1,21 @@
 //go:generate mockgen -source=order.go -destination=../repositories/mock/mock_order.go -package=mock
 package repositories
 
-import "Dp218GO/models"
+import (
+	"Dp218GO/models"
+	"context"
+)
 
 //OrderRepo the interface which implemented by functions which connect to the database.
 type OrderRepo interface {
-	CreateOrder(user models.User, scooterID, startID, endID int, distance float64) (models.Order, error)
-	UpdateOrder(orderID int, orderData models.Order) (models.Order, error)
-	DeleteOrder(orderID int) error
-	GetAllOrders() (*models.OrderList, error)
-	GetOrderByID(orderID int) (models.Order, error)
-	GetOrdersByUserID(userID int) (models.OrderList, error)
-	GetOrdersByScooterID(scooterID int) (models.OrderList, error)
-	GetScooterMileageByID(scooterID int) (float64, error)
-	GetUserMileageByID(userID int) (float64, error)
+	CreateOrder(ctx context.Context, user models.User, scooterID, startID, endID int, distance float64) (models.Order, error)
+	UpdateOrder(ctx context.Context, orderID int, orderData models.Order) (models.Order, error)
+	DeleteOrder(ctx context.Context, orderID int) error
+	GetAllOrders(ctx context.Context) (*models.OrderList, error)
+	GetOrderByID(ctx context.Context, orderID int) (models.Order, error)
+	GetOrdersByUserID(ctx context.Context, userID int) (models.OrderList, error)
+	GetOrdersByScooterID(ctx context.Context, scooterID int) (models.OrderList, error)
+	GetStatusInRentByID(ctx context.Context, statusID int) (models.ScooterStatusInRent, error)
+	GetScooterMileageByID(ctx context.Context, scooterID int) (float64, error)
+	GetUserMileageByID(ctx context.Context, userID int) (float64, error)
 }
@@ -0,0 +1,18 @@
+//go:generate mockgen -source=password_reset.go -destination=../repositories/mock/mock_password_reset.go -package=mock
+package repositories
+
+import (
+	"Dp218GO/models"
+	"context"
+	"errors"
+	"time"
+)
+
+// ErrNoPasswordReset - error returned if reset token is unknown, used or expired
+var ErrNoPasswordReset = errors.New("password reset link is not valid or expired")
+
+// PasswordResetRepo - interface for password reset repository
+type PasswordResetRepo interface {
+	AddPasswordReset(ctx context.Context, reset *models.PasswordReset) error
+	UsePasswordReset(ctx context.Context, tokenHash string, usedAt time.Time) (models.PasswordReset, error)
+}
@@ -4,6 +4,9 @@ import (
 	"Dp218GO/models"
 	"Dp218GO/repositories"
 	"context"
+	"errors"
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
 	"strconv"
 	"strings"
 	"time"
@@ -21,11 +24,11 @@ func NewAccountRepoDB(userRepo *UserRepoDB, db repositories.AnyDatabase) *Accoun
 }
 
 // GetAccountsByOwner - gets list of accounts of given user from the DB
-func (accdb *AccountRepoDB) GetAccountsByOwner(user models.User) (*models.AccountList, error) {
+func (accdb *AccountRepoDB) GetAccountsByOwner(ctx context.Context, user models.User) (*models.AccountList, error) {
 	list := &models.AccountList{}
 
-	querySQL := `SELECT id, name, number FROM accounts WHERE owner_id = $1;`
-	rows, err := accdb.db.QueryResult(context.Background(), querySQL, user.ID)
+	querySQL := `SELECT id, name, number FROM accounts WHERE owner_id = $1 ORDER BY id;`
+	rows, err := accdb.db.QueryResult(ctx, querySQL, user.ID)
 	if err != nil {
 		return list, err
 	}
@@ -45,42 +48,45 @@ func (accdb *AccountRepoDB) GetAccountsByOwner(user models.User) (*models.Accoun
 }
 
 // GetAccountByID - gets account entity by ID from the DB
-func (accdb *AccountRepoDB) GetAccountByID(accountID int) (models.Account, error) {
+func (accdb *AccountRepoDB) GetAccountByID(ctx context.Context, accountID int) (models.Account, error) {
 	account := models.Account{}
 
 	querySQL := `SELECT id, name, number, owner_id FROM accounts WHERE id = $1;`
-	row := accdb.db.QueryResultRow(context.Background(), querySQL, accountID)
+	row := accdb.db.QueryResultRow(ctx, querySQL, accountID)
 	var userID int
 	err := row.Scan(&account.ID, &account.Name, &account.Number, &userID)
 	if err != nil {
 		return account, err
 	}
-	account.User, err = accdb.userRepo.GetUserByID(userID)
+	account.User, err = accdb.userRepo.GetUserByID(ctx, userID)
 
 	return account, err
 }
 
 // GetAccountByNumber - gets account entity by account number from the DB
-func (accdb *AccountRepoDB) GetAccountByNumber(number string) (models.Account, error) {
+func (accdb *AccountRepoDB) GetAccountByNumber(ctx context.Context, number string) (models.Account, error) {
 	account := models.Account{}
 
 	querySQL := `SELECT id, name, number, owner_id FROM accounts WHERE number = $1;`
-	row := accdb.db.QueryResultRow(context.Background(), querySQL, number)
+	row := accdb.db.QueryResultRow(ctx, querySQL, number)
 	var userID int
 	err := row.Scan(&account.ID, &account.Name, &account.Number, &userID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return account, repositories.ErrNoAccount
+	}
 	if err != nil {
 		return account, err
 	}
-	account.User, err = accdb.userRepo.GetUserByID(userID)
+	account.User, err = accdb.userRepo.GetUserByID(ctx, userID)
 
 	return account, err
 }
 
 // AddAccount - creates new account in the DB based on given entity
-func (accdb *AccountRepoDB) AddAccount(account *models.Account) error {
+func (accdb *AccountRepoDB) AddAccount(ctx context.Context, account *models.Account) error {
 	var id int
 	querySQL := `INSERT INTO accounts(name, number, owner_id) VALUES($1, $2, $3) RETURNING id;`
-	err := accdb.db.QueryResultRow(context.Background(), querySQL, account.Name, account.Number, account.User.ID).
+	err := accdb.db.QueryResultRow(ctx, querySQL, account.Name, account.Number, account.User.ID).
 		Scan(&id)
 	if err != nil {
 		return err
@@ -91,19 +97,19 @@ func (accdb *AccountRepoDB) AddAccount(account *models.Account) error {
 }
 
 // UpdateAccount - updates account in the DB by ID and given entity
-func (accdb *AccountRepoDB) UpdateAccount(accountID int, accountData models.Account) (models.Account, error) {
+func (accdb *AccountRepoDB) UpdateAccount(ctx context.Context, accountID int, accountData models.Account) (models.Account, error) {
 	account := models.Account{}
 	querySQL := `UPDATE accounts 
 		SET name=$1, number=$2, owner_id=$3 
 		WHERE id=$4 RETURNING id, name, number, owner_id;`
 	var userID int
-	err := accdb.db.QueryResultRow(context.Background(), querySQL,
+	err := accdb.db.QueryResultRow(ctx, querySQL,
 		accountData.Name, accountData.Number, accountData.User.ID, accountID).
 		Scan(&account.ID, &account.Name, &account.Number, &userID)
 	if err != nil {
 		return account, err
 	}
-	account.User, err = accdb.userRepo.GetUserByID(userID)
+	account.User, err = accdb.userRepo.GetUserByID(ctx, userID)
 	if err != nil {
 		return account, err
 	}
@@ -112,14 +118,14 @@ func (accdb *AccountRepoDB) UpdateAccount(accountID int, accountData models.Acco
 }
 
 // GetAccountTransactionByID - gets transaction information from the DB by its ID
-func (accdb *AccountRepoDB) GetAccountTransactionByID(transID int) (models.AccountTransaction, error) {
+func (accdb *AccountRepoDB) GetAccountTransactionByID(ctx context.Context, transID int) (models.AccountTransaction, error) {
 	accountTransaction := models.AccountTransaction{}
 
 	querySQL := `SELECT 
 		id, date_time, payment_type_id, account_from_id, account_to_id, order_id, amount_cents 
 		FROM account_transactions 
 		WHERE id = $1;`
-	row := accdb.db.QueryResultRow(context.Background(), querySQL, transID)
+	row := accdb.db.QueryResultRow(ctx, querySQL, transID)
 	var paymentID int
 	var accFromID, accToId int
 	var orderId int
@@ -128,7 +134,7 @@ func (accdb *AccountRepoDB) GetAccountTransactionByID(transID int) (models.Accou
 		return accountTransaction, err
 	}
 
-	err = addTransactionComplexFields(accdb, &accountTransaction, paymentID, accFromID, accToId, orderId)
+	err = addTransactionComplexFields(ctx, accdb, &accountTransaction, paymentID, accFromID, accToId, orderId)
 	if err != nil {
 		return accountTransaction, err
 	}
@@ -136,17 +142,17 @@ func (accdb *AccountRepoDB) GetAccountTransactionByID(transID int) (models.Accou
 	return accountTransaction, err
 }
 
-func addTransactionComplexFields(accdb *AccountRepoDB, accountTransaction *models.AccountTransaction, paymentID, accFromID, accToID, orderId int) error {
+func addTransactionComplexFields(ctx context.Context, accdb *AccountRepoDB, accountTransaction *models.AccountTransaction, paymentID, accFromID, accToID, orderId int) error {
 	var err error
-	accountTransaction.PaymentType, err = accdb.GetPaymentTypeById(paymentID)
+	accountTransaction.PaymentType, err = accdb.GetPaymentTypeById(ctx, paymentID)
 	if err != nil {
 		return err
 	}
-	accountTransaction.AccountFrom, err = accdb.GetAccountByID(accFromID)
+	accountTransaction.AccountFrom, err = accdb.GetAccountByID(ctx, accFromID)
 	if err != nil && accFromID != 0 {
 		return err
 	}
-	accountTransaction.AccountTo, err = accdb.GetAccountByID(accToID)
+	accountTransaction.AccountTo, err = accdb.GetAccountByID(ctx, accToID)
 	if err != nil && accToID != 0 {
 		return err
 	}
@@ -158,12 +164,12 @@ func addTransactionComplexFields(accdb *AccountRepoDB, accountTransaction *model
 }
 
 //AddAccountTransaction - creates transaction record in the DB based on given entity
-func (accdb *AccountRepoDB) AddAccountTransaction(accountTransaction *models.AccountTransaction) error {
+func (accdb *AccountRepoDB) AddAccountTransaction(ctx context.Context, accountTransaction *models.AccountTransaction) error {
 	var id int
 	querySQL := `INSERT INTO 
 		account_transactions(date_time, payment_type_id, account_from_id, account_to_id, order_id, amount_cents) 
 		VALUES($1, $2, $3, $4, $5, $6) RETURNING id;`
-	err := accdb.db.QueryResultRow(context.Background(), querySQL, accountTransaction.DateTime,
+	err := accdb.db.QueryResultRow(ctx, querySQL, accountTransaction.DateTime,
 		accountTransaction.PaymentType.ID, accountTransaction.AccountFrom.ID, accountTransaction.AccountTo.ID,
 		accountTransaction.Order.ID, accountTransaction.AmountCents).Scan(&id)
 	if err != nil {
@@ -174,9 +180,60 @@ func (accdb *AccountRepoDB) AddAccountTransaction(accountTransaction *models.Acc
 	return nil
 }
 
-func getTransactionsBySomeQuery(accdb *AccountRepoDB, querySQL string, params ...interface{}) (*models.AccountTransactionList, error) {
+//AddAccountTransactions - creates several transaction records in the DB at once, so either all of them are stored or none.
+// The order can have only one transaction of each payment type, the second settlement of the order gets
+// ErrOrderAlreadySettled
+func (accdb *AccountRepoDB) AddAccountTransactions(ctx context.Context,
+	accountTransactions ...*models.AccountTransaction) error {
+	if len(accountTransactions) == 0 {
+		return nil
+	}
+
+	querySQL := `INSERT INTO 
+		account_transactions(date_time, payment_type_id, account_from_id, account_to_id, order_id, amount_cents) 
+		VALUES`
+	var params []interface{}
+	for i, trans := range accountTransactions {
+		if i > 0 {
+			querySQL += `,`
+		}
+		paramIndex := len(params)
+		querySQL += ` ($` + strconv.Itoa(paramIndex+1) + `, $` + strconv.Itoa(paramIndex+2) +
+			`, $` + strconv.Itoa(paramIndex+3) + `, $` + strconv.Itoa(paramIndex+4) +
+			`, $` + strconv.Itoa(paramIndex+5) + `, $` + strconv.Itoa(paramIndex+6) + `)`
+		params = append(params, trans.DateTime, trans.PaymentType.ID, trans.AccountFrom.ID, trans.AccountTo.ID,
+			trans.Order.ID, trans.AmountCents)
+	}
+	querySQL += ` RETURNING id;`
+
+	rows, err := accdb.db.QueryResult(ctx, querySQL, params...)
+	if err != nil {
+		return settlementError(err)
+	}
+	defer rows.Close()
+
+	for i := 0; rows.Next() && i < len(accountTransactions); i++ {
+		if err = rows.Scan(&accountTransactions[i].ID); err != nil {
+			return settlementError(err)
+		}
+	}
+
+	return settlementError(rows.Err())
+}
+
+// settlementError - maps violation of the unique payment type of the order to ErrOrderAlreadySettled
+func settlementError(err error) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == uniqueViolation &&
+		pgErr.ConstraintName == "account_transactions_order_payment_type" {
+		return repositories.ErrOrderAlreadySettled
+	}
+	return err
+}
+
+func getTransactionsBySomeQuery(ctx context.Context, accdb *AccountRepoDB, querySQL string, params ...interface{}) (*models.AccountTransactionList, error) {
 	list := &models.AccountTransactionList{}
-	rows, err := accdb.db.QueryResult(context.Background(), querySQL, params...)
+	rows, err := accdb.db.QueryResult(ctx, querySQL, params...)
 	if err != nil {
 		return list, err
 	}
@@ -205,7 +262,7 @@ func getTransactionsBySomeQuery(accdb *AccountRepoDB, querySQL string, params ..
 	}
 
 	for key, value := range transAdditionalData {
-		err = addTransactionComplexFields(accdb, &key, value.paymentID, value.accFromID, value.accToID, value.orderID)
+		err = addTransactionComplexFields(ctx, accdb, &key, value.paymentID, value.accFromID, value.accToID, value.orderID)
 		if err != nil {
 			return list, err
 		}
@@ -217,7 +274,7 @@ func getTransactionsBySomeQuery(accdb *AccountRepoDB, querySQL string, params ..
 }
 
 // GetAccountTransactions - gets list of money transactions for given accounts from the DB
-func (accdb *AccountRepoDB) GetAccountTransactions(accounts ...models.Account) (*models.AccountTransactionList, error) {
+func (accdb *AccountRepoDB) GetAccountTransactions(ctx context.Context, accounts ...models.Account) (*models.AccountTransactionList, error) {
 	querySQL := `SELECT 
 		id, date_time, payment_type_id, account_from_id, account_to_id, order_id, amount_cents 
 		FROM account_transactions`
@@ -232,11 +289,11 @@ func (accdb *AccountRepoDB) GetAccountTransactions(accounts ...models.Account) (
 	}
 	querySQL += `;`
 
-	return getTransactionsBySomeQuery(accdb, querySQL, params...)
+	return getTransactionsBySomeQuery(ctx, accdb, querySQL, params...)
 }
 
 // GetAccountTransactionsInTimePeriod - gets list of money transactions for given accounts from start to end time from the DB
-func (accdb *AccountRepoDB) GetAccountTransactionsInTimePeriod(start time.Time, end time.Time, accounts ...models.Account) (*models.AccountTransactionList, error) {
+func (accdb *AccountRepoDB) GetAccountTransactionsInTimePeriod(ctx context.Context, start time.Time, end time.Time, accounts ...models.Account) (*models.AccountTransactionList, error) {
 	querySQL := `SELECT 
 		id, date_time, payment_type_id, account_from_id, account_to_id, order_id, amount_cents 
 		FROM account_transactions
@@ -255,21 +312,21 @@ func (accdb *AccountRepoDB) GetAccountTransactionsInTimePeriod(start time.Time,
 	}
 	querySQL += `;`
 
-	return getTransactionsBySomeQuery(accdb, querySQL, params...)
+	return getTransactionsBySomeQuery(ctx, accdb, querySQL, params...)
 }
 
 // GetAccountTransactionsByOrder - gets list of money transactions for given order from the DB
-func (accdb *AccountRepoDB) GetAccountTransactionsByOrder(order models.Order) (*models.AccountTransactionList, error) {
+func (accdb *AccountRepoDB) GetAccountTransactionsByOrder(ctx context.Context, order models.Order) (*models.AccountTransactionList, error) {
 	querySQL := `SELECT 
 		id, date_time, payment_type_id, account_from_id, account_to_id, order_id, amount_cents 
 		FROM account_transactions
 		WHERE order_id=$1;`
 
-	return getTransactionsBySomeQuery(accdb, querySQL, order.ID)
+	return getTransactionsBySomeQuery(ctx, accdb, querySQL, order.ID)
 }
 
 // GetAccountTransactionsByPaymentType - gets list of money transactions for given accounts & payment type from the DB
-func (accdb *AccountRepoDB) GetAccountTransactionsByPaymentType(paymentType models.PaymentType, accounts ...models.Account) (*models.AccountTransactionList, error) {
+func (accdb *AccountRepoDB) GetAccountTransactionsByPaymentType(ctx context.Context, paymentType models.PaymentType, accounts ...models.Account) (*models.AccountTransactionList, error) {
 	querySQL := `SELECT 
 		id, date_time, payment_type_id, account_from_id, account_to_id, order_id, amount_cents 
 		FROM account_transactions
@@ -287,15 +344,15 @@ func (accdb *AccountRepoDB) GetAccountTransactionsByPaymentType(paymentType mode
 	}
 	querySQL += `;`
 
-	return getTransactionsBySomeQuery(accdb, querySQL, params...)
+	return getTransactionsBySomeQuery(ctx, accdb, querySQL, params...)
 }
 
 // GetPaymentTypeById - gets payment type entity by ID from the DB
-func (accdb *AccountRepoDB) GetPaymentTypeById(paymentTypeId int) (models.PaymentType, error) {
+func (accdb *AccountRepoDB) GetPaymentTypeById(ctx context.Context, paymentTypeId int) (models.PaymentType, error) {
 	paymentType := models.PaymentType{}
 
 	querySQL := `SELECT id, name FROM payment_types WHERE id = $1;`
-	row := accdb.db.QueryResultRow(context.Background(), querySQL, paymentTypeId)
+	row := accdb.db.QueryResultRow(ctx, querySQL, paymentTypeId)
 	err := row.Scan(&paymentType.ID, &paymentType.Name)
 
 	return paymentType, err
@@ -0,0 +1,201 @@
+package postgres
+
+import (
+	"Dp218GO/models"
+	"Dp218GO/repositories"
+	"context"
+	"errors"
+
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+)
+
+// uniqueViolation - postgres error code of unique constraint violation
+const uniqueViolation = "23505"
+
+// ContactRepoDB - struct representing user contacts repository
+type ContactRepoDB struct {
+	db repositories.AnyDatabase
+}
+
+// NewContactRepoDB - contact repo initialization
+func NewContactRepoDB(db repositories.AnyDatabase) *ContactRepoDB {
+	return &ContactRepoDB{db}
+}
+
+// GetContactTypes - get list of all contact types from the DB
+func (crdb *ContactRepoDB) GetContactTypes(ctx context.Context) (*models.ContactTypeList, error) {
+	list := &models.ContactTypeList{}
+
+	querySQL := `SELECT id, COALESCE(code, ''), COALESCE(name, '') FROM contact_types ORDER BY id;`
+	rows, err := crdb.db.QueryResult(ctx, querySQL)
+	if err != nil {
+		return list, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var contactType models.ContactType
+		if err := rows.Scan(&contactType.ID, &contactType.Code, &contactType.Name); err != nil {
+			return list, err
+		}
+
+		list.ContactTypes = append(list.ContactTypes, contactType)
+	}
+	return list, rows.Err()
+}
+
+// GetContactTypeByID - get contact type by its ID
+func (crdb *ContactRepoDB) GetContactTypeByID(ctx context.Context, typeID int) (models.ContactType, error) {
+	var contactType models.ContactType
+
+	querySQL := `SELECT id, COALESCE(code, ''), COALESCE(name, '') FROM contact_types WHERE id = $1;`
+	err := crdb.db.QueryResultRow(ctx, querySQL, typeID).Scan(&contactType.ID, &contactType.Code, &contactType.Name)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return contactType, repositories.ErrNoContactType
+	}
+
+	return contactType, err
+}
+
+// GetContactsByUserID - get list of contacts of the user from the DB grouped by type, primary first
+func (crdb *ContactRepoDB) GetContactsByUserID(ctx context.Context, userID int) (*models.ContactList, error) {
+	list := &models.ContactList{}
+
+	querySQL := `SELECT c.id, c.user_id, COALESCE(c.contact_info, ''), c.is_primary,
+		t.id, COALESCE(t.code, ''), COALESCE(t.name, '')
+		FROM contacts c
+		JOIN contact_types t ON t.id = c.type_id
+		WHERE c.user_id = $1
+		ORDER BY t.id, c.is_primary DESC, c.id;`
+	rows, err := crdb.db.QueryResult(ctx, querySQL, userID)
+	if err != nil {
+		return list, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var contact models.Contact
+		err := rows.Scan(&contact.ID, &contact.UserID, &contact.ContactInfo, &contact.IsPrimary,
+			&contact.Type.ID, &contact.Type.Code, &contact.Type.Name)
+		if err != nil {
+			return list, err
+		}
+
+		list.Contacts = append(list.Contacts, contact)
+	}
+	return list, rows.Err()
+}
+
+// GetContactByID - get contact with given ID which belongs to the user
+func (crdb *ContactRepoDB) GetContactByID(ctx context.Context, userID, contactID int) (models.Contact, error) {
+	querySQL := `SELECT c.id, c.user_id, COALESCE(c.contact_info, ''), c.is_primary,
+		t.id, COALESCE(t.code, ''), COALESCE(t.name, '')
+		FROM contacts c
+		JOIN contact_types t ON t.id = c.type_id
+		WHERE c.id = $1 AND c.user_id = $2;`
+	return crdb.scanContact(crdb.db.QueryResultRow(ctx, querySQL, contactID, userID))
+}
+
+// GetPrimaryContact - get primary contact of the user with given type code
+func (crdb *ContactRepoDB) GetPrimaryContact(ctx context.Context, userID int, typeCode string) (models.Contact, error) {
+	querySQL := `SELECT c.id, c.user_id, COALESCE(c.contact_info, ''), c.is_primary,
+		t.id, COALESCE(t.code, ''), COALESCE(t.name, '')
+		FROM contacts c
+		JOIN contact_types t ON t.id = c.type_id
+		WHERE c.user_id = $1 AND t.code = $2 AND c.is_primary;`
+	return crdb.scanContact(crdb.db.QueryResultRow(ctx, querySQL, userID, typeCode))
+}
+
+// AddContact - create contact record in the DB based on given entity. The first contact of the type
+// becomes primary, the new primary contact replaces the old one
+func (crdb *ContactRepoDB) AddContact(ctx context.Context, contact *models.Contact) error {
+	return crdb.db.WithTx(ctx, func(ctx context.Context) error {
+		if contact.IsPrimary {
+			if err := crdb.resetPrimary(ctx, contact.UserID, contact.Type.ID, 0); err != nil {
+				return err
+			}
+		}
+
+		querySQL := `INSERT INTO contacts(type_id, user_id, contact_info, is_primary)
+			VALUES($1, $2, $3, $4 OR NOT EXISTS(
+				SELECT 1 FROM contacts WHERE user_id = $2 AND type_id = $1 AND is_primary))
+			RETURNING id, is_primary;`
+		err := crdb.db.QueryResultRow(ctx, querySQL, contact.Type.ID, contact.UserID, contact.ContactInfo,
+			contact.IsPrimary).Scan(&contact.ID, &contact.IsPrimary)
+		return contactError(err)
+	})
+}
+
+// UpdateContact - change value & primary flag of the contact of the user
+func (crdb *ContactRepoDB) UpdateContact(ctx context.Context, contact models.Contact) error {
+	return crdb.db.WithTx(ctx, func(ctx context.Context) error {
+		if contact.IsPrimary {
+			if err := crdb.resetPrimary(ctx, contact.UserID, contact.Type.ID, contact.ID); err != nil {
+				return err
+			}
+		}
+
+		querySQL := `UPDATE contacts SET contact_info = $3, is_primary = $4 WHERE id = $1 AND user_id = $2;`
+		result, err := crdb.db.QueryExec(ctx, querySQL, contact.ID, contact.UserID, contact.ContactInfo,
+			contact.IsPrimary)
+		if err != nil {
+			return contactError(err)
+		}
+		if result.RowsAffected() == 0 {
+			return repositories.ErrNoContact
+		}
+
+		return nil
+	})
+}
+
+// DeleteContact - delete contact of the user, the oldest contact of the same type becomes primary
+// if the primary one is deleted
+func (crdb *ContactRepoDB) DeleteContact(ctx context.Context, userID, contactID int) error {
+	return crdb.db.WithTx(ctx, func(ctx context.Context) error {
+		var typeID int
+		var wasPrimary bool
+		querySQL := `DELETE FROM contacts WHERE id = $1 AND user_id = $2 RETURNING type_id, is_primary;`
+		err := crdb.db.QueryResultRow(ctx, querySQL, contactID, userID).Scan(&typeID, &wasPrimary)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return repositories.ErrNoContact
+		}
+		if err != nil || !wasPrimary {
+			return err
+		}
+
+		querySQL = `UPDATE contacts SET is_primary = true
+			WHERE id = (SELECT id FROM contacts WHERE user_id = $1 AND type_id = $2 ORDER BY id LIMIT 1);`
+		_, err = crdb.db.QueryExec(ctx, querySQL, userID, typeID)
+		return err
+	})
+}
+
+// resetPrimary - removes primary flag from contacts of the type except given one
+func (crdb *ContactRepoDB) resetPrimary(ctx context.Context, userID, typeID, exceptID int) error {
+	querySQL := `UPDATE contacts SET is_primary = false
+		WHERE user_id = $1 AND type_id = $2 AND id <> $3 AND is_primary;`
+	_, err := crdb.db.QueryExec(ctx, querySQL, userID, typeID, exceptID)
+	return err
+}
+
+func (crdb *ContactRepoDB) scanContact(row pgx.Row) (models.Contact, error) {
+	var contact models.Contact
+	err := row.Scan(&contact.ID, &contact.UserID, &contact.ContactInfo, &contact.IsPrimary,
+		&contact.Type.ID, &contact.Type.Code, &contact.Type.Name)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return models.Contact{}, repositories.ErrNoContact
+	}
+
+	return contact, err
+}
+
+// contactError - maps violation of unique contact of the user to ErrContactExists
+func contactError(err error) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == uniqueViolation {
+		return repositories.ErrContactExists
+	}
+	return err
+}
@@ -0,0 +1,61 @@
+package postgres
+
+import (
+	"Dp218GO/models"
+	"Dp218GO/repositories"
+	"context"
+)
+
+// LocationRepoDB - struct representing locations repository
+type LocationRepoDB struct {
+	db repositories.AnyDatabase
+}
+
+// NewLocationRepoDB - location repo initialization
+func NewLocationRepoDB(db repositories.AnyDatabase) *LocationRepoDB {
+	return &LocationRepoDB{db}
+}
+
+// GetAllLocations - get list of all locations from the DB
+func (lrdb *LocationRepoDB) GetAllLocations(ctx context.Context) (*models.LocationList, error) {
+	list := &models.LocationList{}
+
+	querySQL := `SELECT id, COALESCE(latitude, 0), COALESCE(longitude, 0), COALESCE(label, '')
+		FROM locations ORDER BY id;`
+	rows, err := lrdb.db.QueryResult(ctx, querySQL)
+	if err != nil {
+		return list, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var location models.Location
+		if err := rows.Scan(&location.ID, &location.Latitude, &location.Longitude, &location.Label); err != nil {
+			return list, err
+		}
+
+		list.Location = append(list.Location, location)
+	}
+	return list, rows.Err()
+}
+
+// AddLocation - create location record in the DB based on given entity
+func (lrdb *LocationRepoDB) AddLocation(ctx context.Context, location *models.Location) error {
+	querySQL := `INSERT INTO locations(latitude, longitude, label) VALUES($1, $2, $3) RETURNING id;`
+	return lrdb.db.QueryResultRow(ctx, querySQL, location.Latitude, location.Longitude, location.Label).
+		Scan(&location.ID)
+}
+
+// UpdateLocation - change coordinates & label of the location
+func (lrdb *LocationRepoDB) UpdateLocation(ctx context.Context, location models.Location) error {
+	querySQL := `UPDATE locations SET latitude = $2, longitude = $3, label = $4 WHERE id = $1;`
+	result, err := lrdb.db.QueryExec(ctx, querySQL, location.ID, location.Latitude, location.Longitude,
+		location.Label)
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return repositories.ErrNoLocation
+	}
+	return nil
+}
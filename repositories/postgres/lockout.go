@@ -0,0 +1,83 @@
+package postgres
+
+import (
+	"Dp218GO/models"
+	"Dp218GO/repositories"
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v4"
+)
+
+// LockoutRepoDB - struct representing failed sign in attempts repository
+type LockoutRepoDB struct {
+	db repositories.AnyDatabase
+}
+
+// NewLockoutRepoDB - lockout repo initialization
+func NewLockoutRepoDB(db repositories.AnyDatabase) *LockoutRepoDB {
+	return &LockoutRepoDB{db}
+}
+
+// GetLoginFailures - get failed attempts & lockout of the user with given email,
+// empty struct is returned for unknown email
+func (lrdb *LockoutRepoDB) GetLoginFailures(ctx context.Context, email string) (models.LoginFailures, error) {
+	var failures models.LoginFailures
+	var lastFailedAt, lockedUntil *time.Time
+
+	querySQL := `SELECT failed_attempts, last_failed_at, locked_until FROM users WHERE login_email = $1;`
+	err := lrdb.db.QueryResultRow(ctx, querySQL, email).Scan(&failures.FailedAttempts, &lastFailedAt, &lockedUntil)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return failures, nil
+	}
+	if err != nil {
+		return failures, err
+	}
+
+	if lastFailedAt != nil {
+		failures.LastFailedAt = *lastFailedAt
+	}
+	if lockedUntil != nil {
+		failures.LockedUntil = *lockedUntil
+	}
+	return failures, nil
+}
+
+// AddLoginFailure - increments failed attempts of the user with given email,
+// returns the number of attempts including this one or zero for unknown email
+func (lrdb *LockoutRepoDB) AddLoginFailure(ctx context.Context, email string, failedAt time.Time) (int, error) {
+	var attempts int
+	querySQL := `UPDATE users SET failed_attempts = failed_attempts + 1, last_failed_at = $2
+		WHERE login_email = $1
+		RETURNING failed_attempts;`
+	err := lrdb.db.QueryResultRow(ctx, querySQL, email, failedAt).Scan(&attempts)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return 0, nil
+	}
+
+	return attempts, err
+}
+
+// LockUser - locks the user with given email till given time, the failed attempts start from zero after that
+func (lrdb *LockoutRepoDB) LockUser(ctx context.Context, email string, lockedUntil time.Time) error {
+	querySQL := `UPDATE users SET failed_attempts = 0, locked_until = $2 WHERE login_email = $1;`
+	_, err := lrdb.db.QueryExec(ctx, querySQL, email, lockedUntil)
+	return err
+}
+
+// ResetLoginFailures - clears failed attempts & lockout of the user with given email
+func (lrdb *LockoutRepoDB) ResetLoginFailures(ctx context.Context, email string) error {
+	querySQL := `UPDATE users SET failed_attempts = 0, last_failed_at = NULL, locked_until = NULL
+		WHERE login_email = $1 AND (failed_attempts > 0 OR locked_until IS NOT NULL);`
+	_, err := lrdb.db.QueryExec(ctx, querySQL, email)
+	return err
+}
+
+// CountFailedByIP - get number of failed sign in attempts from given IP address since given time
+func (lrdb *LockoutRepoDB) CountFailedByIP(ctx context.Context, ipAddress string, since time.Time) (int, error) {
+	var count int
+	querySQL := `SELECT COUNT(*) FROM login_history WHERE event = $1 AND ip_address = $2 AND created_at >= $3;`
+	err := lrdb.db.QueryResultRow(ctx, querySQL, models.LoginEventFailed, ipAddress, since).Scan(&count)
+	return count, err
+}
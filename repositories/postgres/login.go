@@ -0,0 +1,146 @@
+package postgres
+
+import (
+	"Dp218GO/models"
+	"Dp218GO/repositories"
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v4"
+)
+
+// LoginAuditRepoDB - struct representing login history & user sessions repository
+type LoginAuditRepoDB struct {
+	db repositories.AnyDatabase
+}
+
+// NewLoginAuditRepoDB - login audit repo initialization
+func NewLoginAuditRepoDB(db repositories.AnyDatabase) *LoginAuditRepoDB {
+	return &LoginAuditRepoDB{db}
+}
+
+// AddLoginEvent - create login history record in the DB. The user is found by email if its ID isn't known.
+// Sign in & sign out also update the last status of the user in login_status
+func (ldb *LoginAuditRepoDB) AddLoginEvent(ctx context.Context, event *models.LoginEvent) error {
+	return ldb.db.WithTx(ctx, func(ctx context.Context) error {
+		var userID *int
+		querySQL := `INSERT INTO login_history(user_id, login_email, event, session_id, ip_address, user_agent, created_at)
+			VALUES(COALESCE(NULLIF($1, 0), (SELECT id FROM users WHERE login_email = $2)), $2, $3, NULLIF($4, 0), $5, $6, $7)
+			RETURNING id, user_id;`
+		err := ldb.db.QueryResultRow(ctx, querySQL, event.UserID, event.LoginEmail, event.Event, event.SessionID,
+			event.IPAddress, event.UserAgent, event.CreatedAt).Scan(&event.ID, &userID)
+		if err != nil || userID == nil {
+			return err
+		}
+		event.UserID = *userID
+
+		if event.Event == models.LoginEventFailed {
+			return nil
+		}
+		querySQL = `INSERT INTO login_status(user_id, logged_in, date_time, ip_address)
+			VALUES($1, $2, $3, $4)
+			ON CONFLICT (user_id) DO UPDATE
+				SET logged_in = excluded.logged_in, date_time = excluded.date_time, ip_address = excluded.ip_address;`
+		_, err = ldb.db.QueryExec(ctx, querySQL, event.UserID, event.Event == models.LoginEventSignIn,
+			event.CreatedAt, event.IPAddress)
+		return err
+	})
+}
+
+// GetLoginEventsByUserID - get the latest login events of the user from the DB, newest first
+func (ldb *LoginAuditRepoDB) GetLoginEventsByUserID(ctx context.Context, userID, limit int) (*models.LoginEventList, error) {
+	list := &models.LoginEventList{}
+
+	querySQL := `SELECT id, user_id, COALESCE(login_email, ''), event, COALESCE(session_id, 0),
+		COALESCE(ip_address, ''), COALESCE(user_agent, ''), created_at
+		FROM login_history
+		WHERE user_id = $1
+		ORDER BY created_at DESC
+		LIMIT $2;`
+	rows, err := ldb.db.QueryResult(ctx, querySQL, userID, limit)
+	if err != nil {
+		return list, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var event models.LoginEvent
+		err := rows.Scan(&event.ID, &event.UserID, &event.LoginEmail, &event.Event, &event.SessionID,
+			&event.IPAddress, &event.UserAgent, &event.CreatedAt)
+		if err != nil {
+			return list, err
+		}
+
+		list.LoginEvents = append(list.LoginEvents, event)
+	}
+	return list, rows.Err()
+}
+
+// AddSession - create user session record in the DB based on given entity
+func (ldb *LoginAuditRepoDB) AddSession(ctx context.Context, session *models.UserSession) error {
+	querySQL := `INSERT INTO user_sessions(user_id, ip_address, user_agent, created_at)
+		VALUES($1, $2, $3, $4)
+		RETURNING id;`
+	return ldb.db.QueryResultRow(ctx, querySQL, session.UserID, session.IPAddress, session.UserAgent,
+		session.CreatedAt).Scan(&session.ID)
+}
+
+// IsSessionActive - checks if the session exists and isn't revoked
+func (ldb *LoginAuditRepoDB) IsSessionActive(ctx context.Context, sessionID int) (bool, error) {
+	var active bool
+	querySQL := `SELECT revoked_at IS NULL FROM user_sessions WHERE id = $1;`
+	err := ldb.db.QueryResultRow(ctx, querySQL, sessionID).Scan(&active)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return false, nil
+	}
+
+	return active, err
+}
+
+// GetActiveSessionsByUserID - get not revoked sessions of the user from the DB, newest first
+func (ldb *LoginAuditRepoDB) GetActiveSessionsByUserID(ctx context.Context, userID int) (*models.UserSessionList, error) {
+	list := &models.UserSessionList{}
+
+	querySQL := `SELECT id, user_id, COALESCE(ip_address, ''), COALESCE(user_agent, ''), created_at
+		FROM user_sessions
+		WHERE user_id = $1 AND revoked_at IS NULL
+		ORDER BY created_at DESC;`
+	rows, err := ldb.db.QueryResult(ctx, querySQL, userID)
+	if err != nil {
+		return list, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var session models.UserSession
+		err := rows.Scan(&session.ID, &session.UserID, &session.IPAddress, &session.UserAgent, &session.CreatedAt)
+		if err != nil {
+			return list, err
+		}
+
+		list.Sessions = append(list.Sessions, session)
+	}
+	return list, rows.Err()
+}
+
+// RevokeSession - marks the active session of the user as revoked
+func (ldb *LoginAuditRepoDB) RevokeSession(ctx context.Context, userID, sessionID int, revokedAt time.Time) error {
+	querySQL := `UPDATE user_sessions SET revoked_at = $3 WHERE id = $2 AND user_id = $1 AND revoked_at IS NULL;`
+	result, err := ldb.db.QueryExec(ctx, querySQL, userID, sessionID, revokedAt)
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return repositories.ErrNoSession
+	}
+
+	return nil
+}
+
+// RevokeOtherSessions - marks all active sessions of the user except the given one as revoked
+func (ldb *LoginAuditRepoDB) RevokeOtherSessions(ctx context.Context, userID, keepSessionID int, revokedAt time.Time) error {
+	querySQL := `UPDATE user_sessions SET revoked_at = $3 WHERE user_id = $1 AND id <> $2 AND revoked_at IS NULL;`
+	_, err := ldb.db.QueryExec(ctx, querySQL, userID, keepSessionID, revokedAt)
+	return err
+}
@@ -6,6 +6,11 @@ import (
 	"context"
 )
 
+//orderColumns are the orders table columns in the order of models.Order fields.
+//The trip which is not finished yet has no end status, distance and amount.
+const orderColumns = `id, user_id, scooter_id, status_start_id, COALESCE(status_end_id, 0), COALESCE(distance, 0),
+					COALESCE(amount_cents, 0)`
+
 //OrderRepoDb is a repository for database connection.
 type OrderRepoDb struct {
 	db repositories.AnyDatabase
@@ -16,8 +21,9 @@ func NewOrderRepoDB(db repositories.AnyDatabase) *OrderRepoDb {
 	return &OrderRepoDb{db}
 }
 
-//CreateOrder creates a new order in the database table 'orders'.
-func (ordb *OrderRepoDb) CreateOrder(user models.User, scooterID, startID, endID int, distance float64) (models.Order,
+//CreateOrder creates a new order in the database table 'orders'. The order of the trip which is not finished
+//yet is created without the end status (endID is 0).
+func (ordb *OrderRepoDb) CreateOrder(ctx context.Context, user models.User, scooterID, startID, endID int, distance float64) (models.Order,
 	error) {
 	var order = models.Order{}
 	order.UserID = user.ID
@@ -27,8 +33,8 @@ func (ordb *OrderRepoDb) CreateOrder(user models.User, scooterID, startID, endID
 	order.Distance = distance
 
 	querySQL := `INSERT INTO orders(user_id, scooter_id, status_start_id, status_end_id, distance, amount_cents) 
-					VALUES ($1, $2, $3, $4, $5, 0) RETURNING id`
-	err := ordb.db.QueryResultRow(context.Background(), querySQL, user.ID, scooterID, startID, endID,
+					VALUES ($1, $2, $3, NULLIF($4, 0), $5, 0) RETURNING id`
+	err := ordb.db.QueryResultRow(ctx, querySQL, user.ID, scooterID, startID, endID,
 		distance).Scan(&order.ID)
 	if err != nil {
 		return order, err
@@ -38,13 +44,14 @@ func (ordb *OrderRepoDb) CreateOrder(user models.User, scooterID, startID, endID
 }
 
 //UpdateOrder updates exist order and returns the new one.
-func (ordb *OrderRepoDb) UpdateOrder(orderID int, orderData models.Order) (models.Order, error) {
+func (ordb *OrderRepoDb) UpdateOrder(ctx context.Context, orderID int, orderData models.Order) (models.Order, error) {
 	order := models.Order{}
 	querySQL := `UPDATE orders 
-					SET user_id=$1, scooter_id=$2, status_start_id=$3, status_end_id=$5, distance=$5, amount=$6
-					WHERE id=$7 RETURNING id, user_id, scooter_id, status_start_id, status_end_id, distance, amount;`
+					SET user_id=$1, scooter_id=$2, status_start_id=$3, status_end_id=NULLIF($4, 0), distance=$5,
+					amount_cents=$6
+					WHERE id=$7 RETURNING ` + orderColumns + `;`
 
-	err := ordb.db.QueryResultRow(context.Background(), querySQL,
+	err := ordb.db.QueryResultRow(ctx, querySQL,
 		orderData.UserID, orderData.ScooterID, orderData.StatusStartID, orderData.StatusEndID, orderData.Distance,
 		orderData.Amount, orderID).
 		Scan(&order.ID, &order.UserID, &order.ScooterID, &order.StatusStartID, &order.StatusEndID, &order.Distance,
@@ -57,18 +64,18 @@ func (ordb *OrderRepoDb) UpdateOrder(orderID int, orderData models.Order) (model
 }
 
 //DeleteOrder deletes the chosen order.
-func (ordb *OrderRepoDb) DeleteOrder(orderID int) error {
+func (ordb *OrderRepoDb) DeleteOrder(ctx context.Context, orderID int) error {
 	querySQL := `DELETE FROM orders WHERE id = $1;`
-	_, err := ordb.db.QueryExec(context.Background(), querySQL, orderID)
+	_, err := ordb.db.QueryExec(ctx, querySQL, orderID)
 	return err
 }
 
 //GetAllOrders returns all orders in the database.
-func (ordb *OrderRepoDb) GetAllOrders() (*models.OrderList, error) {
+func (ordb *OrderRepoDb) GetAllOrders(ctx context.Context) (*models.OrderList, error) {
 	orderList := &models.OrderList{}
 
-	querySQL := `SELECT * from orders`
-	rows, err := ordb.db.QueryResult(context.Background(), querySQL)
+	querySQL := `SELECT ` + orderColumns + ` FROM orders`
+	rows, err := ordb.db.QueryResult(ctx, querySQL)
 	if err != nil {
 		return orderList, err
 	}
@@ -86,14 +93,14 @@ func (ordb *OrderRepoDb) GetAllOrders() (*models.OrderList, error) {
 }
 
 //GetOrderByID returns exact order by it's ID.
-func (ordb *OrderRepoDb) GetOrderByID(orderID int) (models.Order, error) {
+func (ordb *OrderRepoDb) GetOrderByID(ctx context.Context, orderID int) (models.Order, error) {
 	order := models.Order{}
 
-	querySQL := `SELECT * 
+	querySQL := `SELECT ` + orderColumns + `
 					FROM orders
 					WHERE id=$1`
 
-	row := ordb.db.QueryResultRow(context.Background(), querySQL, orderID)
+	row := ordb.db.QueryResultRow(ctx, querySQL, orderID)
 	err := row.Scan(&order.ID, &order.UserID, &order.ScooterID, &order.StatusStartID, &order.StatusEndID,
 		&order.Distance, &order.Amount)
 	if err != nil {
@@ -104,14 +111,14 @@ func (ordb *OrderRepoDb) GetOrderByID(orderID int) (models.Order, error) {
 }
 
 //GetOrdersByUserID returns a list of orders attached with user's ID.
-func (ordb *OrderRepoDb) GetOrdersByUserID(userID int) (models.OrderList, error) {
+func (ordb *OrderRepoDb) GetOrdersByUserID(ctx context.Context, userID int) (models.OrderList, error) {
 	orderList := models.OrderList{}
 
-	querySQL := `SELECT * 
+	querySQL := `SELECT ` + orderColumns + `
 					FROM orders 
 					WHERE user_id=$1`
 
-	rows, err := ordb.db.QueryResult(context.Background(), querySQL, userID)
+	rows, err := ordb.db.QueryResult(ctx, querySQL, userID)
 	if err != nil {
 		return orderList, err
 	}
@@ -130,13 +137,13 @@ func (ordb *OrderRepoDb) GetOrdersByUserID(userID int) (models.OrderList, error)
 }
 
 //GetOrdersByScooterID returns a list of orders attached with scooter's ID.
-func (ordb *OrderRepoDb) GetOrdersByScooterID(scooterID int) (models.OrderList, error) {
+func (ordb *OrderRepoDb) GetOrdersByScooterID(ctx context.Context, scooterID int) (models.OrderList, error) {
 	orderList := models.OrderList{}
-	querySQL := `SELECT * 
+	querySQL := `SELECT ` + orderColumns + `
 					FROM orders 
 					WHERE scooter_id=$1`
 
-	rows, err := ordb.db.QueryResult(context.Background(), querySQL, scooterID)
+	rows, err := ordb.db.QueryResult(ctx, querySQL, scooterID)
 	if err != nil {
 		return orderList, err
 	}
@@ -153,14 +160,35 @@ func (ordb *OrderRepoDb) GetOrdersByScooterID(scooterID int) (models.OrderList,
 	return orderList, nil
 }
 
+//GetStatusInRentByID returns the scooter status at the start or at the end of the trip.
+func (ordb *OrderRepoDb) GetStatusInRentByID(ctx context.Context, statusID int) (models.ScooterStatusInRent, error) {
+	status := models.ScooterStatusInRent{}
+	var stationID *int
+
+	querySQL := `SELECT id, station_id, date_time, latitude, longitude 
+					FROM scooter_statuses_in_rent 
+					WHERE id=$1`
+
+	row := ordb.db.QueryResultRow(ctx, querySQL, statusID)
+	err := row.Scan(&status.ID, &stationID, &status.DateTime, &status.Location.Latitude, &status.Location.Longitude)
+	if err != nil {
+		return status, err
+	}
+	if stationID != nil {
+		status.StationID = *stationID
+	}
+
+	return status, nil
+}
+
 //GetScooterMileageByID returns total mileage for the chosen scooter.
-func (ordb *OrderRepoDb) GetScooterMileageByID(scooterID int) (float64, error) {
+func (ordb *OrderRepoDb) GetScooterMileageByID(ctx context.Context, scooterID int) (float64, error) {
 	var mileageKm float64
 	querySQL := `SELECT SUM(distance) 
 					FROM orders 
 					WHERE scooter_id=$1`
 
-	row := ordb.db.QueryResultRow(context.Background(), querySQL, scooterID)
+	row := ordb.db.QueryResultRow(ctx, querySQL, scooterID)
 	err := row.Scan(&mileageKm)
 	if err != nil {
 		return 0, err
@@ -172,13 +200,13 @@ func (ordb *OrderRepoDb) GetScooterMileageByID(scooterID int) (float64, error) {
 }
 
 //GetUserMileageByID returns total mileage for the chosen user.
-func (ordb *OrderRepoDb) GetUserMileageByID(userID int) (float64, error) {
+func (ordb *OrderRepoDb) GetUserMileageByID(ctx context.Context, userID int) (float64, error) {
 	var mileageKm float64
 	querySQL := `SELECT SUM(distance) 
 					FROM orders 
 					WHERE user_id=$1`
 
-	row := ordb.db.QueryResultRow(context.Background(), querySQL, userID)
+	row := ordb.db.QueryResultRow(ctx, querySQL, userID)
 	err := row.Scan(&mileageKm)
 	if err != nil {
 		return 0, err
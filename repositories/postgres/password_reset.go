@@ -0,0 +1,46 @@
+package postgres
+
+import (
+	"Dp218GO/models"
+	"Dp218GO/repositories"
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v4"
+)
+
+// PasswordResetRepoDB - struct representing password reset repository
+type PasswordResetRepoDB struct {
+	db repositories.AnyDatabase
+}
+
+// NewPasswordResetRepoDB - password reset repo initialization
+func NewPasswordResetRepoDB(db repositories.AnyDatabase) *PasswordResetRepoDB {
+	return &PasswordResetRepoDB{db}
+}
+
+// AddPasswordReset - create password reset record in the DB based on given entity
+func (pdb *PasswordResetRepoDB) AddPasswordReset(ctx context.Context, reset *models.PasswordReset) error {
+	querySQL := `INSERT INTO password_resets(user_id, token_hash, created_at, expires_at)
+		VALUES($1, $2, $3, $4)
+		RETURNING id;`
+	return pdb.db.QueryResultRow(ctx, querySQL, reset.UserID, reset.TokenHash, reset.CreatedAt, reset.ExpiresAt).
+		Scan(&reset.ID)
+}
+
+// UsePasswordReset - marks not used and not expired password reset as used, so the token works only once
+func (pdb *PasswordResetRepoDB) UsePasswordReset(ctx context.Context, tokenHash string, usedAt time.Time) (models.PasswordReset, error) {
+	reset := models.PasswordReset{}
+
+	querySQL := `UPDATE password_resets SET used_at = $2
+		WHERE token_hash = $1 AND used_at IS NULL AND expires_at > $2
+		RETURNING id, user_id, token_hash, created_at, expires_at;`
+	err := pdb.db.QueryResultRow(ctx, querySQL, tokenHash, usedAt).Scan(&reset.ID, &reset.UserID,
+		&reset.TokenHash, &reset.CreatedAt, &reset.ExpiresAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return reset, repositories.ErrNoPasswordReset
+	}
+
+	return reset, err
+}
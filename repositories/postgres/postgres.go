@@ -27,25 +27,64 @@ type Postgres struct {
 	Pool *pgxpool.Pool
 }
 
+// txKey - context key of the current DB transaction
+type txKey struct{}
+
+// querier - query functions which both the pool and the transaction have
+type querier interface {
+	Query(ctx context.Context, sql string, args ...interface{}) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...interface{}) pgx.Row
+	Exec(ctx context.Context, sql string, args ...interface{}) (pgconn.CommandTag, error)
+}
+
+// conn - returns the transaction from the context if there is one, otherwise the pool
+func (db *Postgres) conn(ctx context.Context) querier {
+	if tx, ok := ctx.Value(txKey{}).(pgx.Tx); ok {
+		return tx
+	}
+	return db.Pool
+}
+
 // QueryResult - execute query in the DB & get rows
 func (db *Postgres) QueryResult(ctx context.Context, query string, args ...interface{}) (pgx.Rows, error) {
 	ctxt, cancel := context.WithTimeout(ctx, defaultQueryTimeout)
 	defer cancel()
-	return db.Pool.Query(ctxt, query, args...)
+	return db.conn(ctx).Query(ctxt, query, args...)
 }
 
 // QueryResultRow - execute query in the DB & get one row
 func (db *Postgres) QueryResultRow(ctx context.Context, query string, args ...interface{}) pgx.Row {
 	ctxt, cancel := context.WithTimeout(ctx, defaultQueryTimeout)
 	defer cancel()
-	return db.Pool.QueryRow(ctxt, query, args...)
+	return db.conn(ctx).QueryRow(ctxt, query, args...)
 }
 
 // QueryExec - execute query in the DB
 func (db *Postgres) QueryExec(ctx context.Context, query string, args ...interface{}) (pgconn.CommandTag, error) {
 	ctxt, cancel := context.WithTimeout(ctx, defaultQueryTimeout)
 	defer cancel()
-	return db.Pool.Exec(ctxt, query, args...)
+	return db.conn(ctx).Exec(ctxt, query, args...)
+}
+
+// WithTx - execute fn in the DB transaction. Queries made with the context passed to fn are the part
+// of the transaction. The transaction is committed if fn returns nil and rolled back otherwise.
+// If ctx already keeps a transaction, fn joins it and the outer caller decides about commit
+func (db *Postgres) WithTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	if _, ok := ctx.Value(txKey{}).(pgx.Tx); ok {
+		return fn(ctx)
+	}
+
+	tx, err := db.Pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if err = fn(context.WithValue(ctx, txKey{}, tx)); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
 }
 
 // NewConnection - init new DB connection by given connection string
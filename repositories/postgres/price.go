@@ -0,0 +1,92 @@
+package postgres
+
+import (
+	"Dp218GO/models"
+	"Dp218GO/repositories"
+	"context"
+	"math"
+)
+
+// PriceRepoDB - struct for implementing trip price repository
+type PriceRepoDB struct {
+	db repositories.AnyDatabase
+}
+
+// NewPriceRepoDB - init of new trip price repo
+func NewPriceRepoDB(db repositories.AnyDatabase) *PriceRepoDB {
+	return &PriceRepoDB{db}
+}
+
+// GetTariffByScooterID - gets prices which the scooter's owner set for the scooter's model
+// and the owner's latest commission percent from the DB
+func (prdb *PriceRepoDB) GetTariffByScooterID(ctx context.Context, scooterID int) (models.Tariff, error) {
+	tariff := models.Tariff{}
+
+	querySQL := `SELECT s.owner_id, sm.payment_type_id, sp.price, sp.price_per_km, sp.unlock_fee, sp.minimum_charge,
+					COALESCE(sc.commission_percent, 0)
+					FROM scooters AS s
+					JOIN scooter_models AS sm ON s.model_id = sm.id
+					JOIN supplier_prices AS sp ON sp.payment_type_id = sm.payment_type_id AND sp.user_id = s.owner_id
+					LEFT JOIN LATERAL (
+						SELECT commission_percent FROM supplier_commissions
+						WHERE user_id = s.owner_id ORDER BY id DESC LIMIT 1
+					) AS sc ON true
+					WHERE s.id = $1;`
+	row := prdb.db.QueryResultRow(ctx, querySQL, scooterID)
+
+	var pricePerMinute, pricePerKm, unlockFee, minimumCharge float64
+	err := row.Scan(&tariff.SupplierID, &tariff.PaymentTypeID, &pricePerMinute, &pricePerKm, &unlockFee,
+		&minimumCharge, &tariff.CommissionPercent)
+	if err != nil {
+		return tariff, err
+	}
+
+	tariff.PricePerMinute = centsFromPrice(pricePerMinute)
+	tariff.PricePerKm = centsFromPrice(pricePerKm)
+	tariff.UnlockFee = centsFromPrice(unlockFee)
+	tariff.MinimumCharge = centsFromPrice(minimumCharge)
+
+	return tariff, nil
+}
+
+// AddPriceBreakdown - stores itemised trip price of the order in the DB
+func (prdb *PriceRepoDB) AddPriceBreakdown(ctx context.Context, breakdown *models.PriceBreakdown) error {
+	querySQL := `INSERT INTO order_prices(order_id, supplier_id, payment_type_id, minutes, kilometers, time_cents,
+					distance_cents, unlock_fee_cents, minimum_charge_cents, penalty_cents, total_cents, commission_cents,
+					supplier_cents)
+					VALUES($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+					ON CONFLICT (order_id) DO UPDATE SET supplier_id = EXCLUDED.supplier_id,
+					payment_type_id = EXCLUDED.payment_type_id, minutes = EXCLUDED.minutes,
+					kilometers = EXCLUDED.kilometers, time_cents = EXCLUDED.time_cents,
+					distance_cents = EXCLUDED.distance_cents, unlock_fee_cents = EXCLUDED.unlock_fee_cents,
+					minimum_charge_cents = EXCLUDED.minimum_charge_cents, penalty_cents = EXCLUDED.penalty_cents,
+					total_cents = EXCLUDED.total_cents, commission_cents = EXCLUDED.commission_cents,
+					supplier_cents = EXCLUDED.supplier_cents;`
+	_, err := prdb.db.QueryExec(ctx, querySQL, breakdown.OrderID, breakdown.SupplierID,
+		breakdown.PaymentTypeID, breakdown.Minutes, breakdown.Kilometers, breakdown.TimeCents,
+		breakdown.DistanceCents, breakdown.UnlockFeeCents, breakdown.MinimumChargeCents, breakdown.PenaltyCents,
+		breakdown.TotalCents, breakdown.CommissionCents, breakdown.SupplierCents)
+
+	return err
+}
+
+// GetPriceBreakdownByOrderID - gets itemised trip price of the order from the DB
+func (prdb *PriceRepoDB) GetPriceBreakdownByOrderID(ctx context.Context, orderID int) (models.PriceBreakdown, error) {
+	breakdown := models.PriceBreakdown{}
+
+	querySQL := `SELECT order_id, COALESCE(supplier_id, 0), COALESCE(payment_type_id, 0), minutes, kilometers,
+					time_cents, distance_cents, unlock_fee_cents, minimum_charge_cents, penalty_cents, total_cents,
+					commission_cents, supplier_cents
+					FROM order_prices WHERE order_id = $1;`
+	row := prdb.db.QueryResultRow(ctx, querySQL, orderID)
+	err := row.Scan(&breakdown.OrderID, &breakdown.SupplierID, &breakdown.PaymentTypeID, &breakdown.Minutes,
+		&breakdown.Kilometers, &breakdown.TimeCents, &breakdown.DistanceCents, &breakdown.UnlockFeeCents,
+		&breakdown.MinimumChargeCents, &breakdown.PenaltyCents, &breakdown.TotalCents, &breakdown.CommissionCents,
+		&breakdown.SupplierCents)
+
+	return breakdown, err
+}
+
+func centsFromPrice(price float64) int {
+	return int(math.Round(price * 100))
+}
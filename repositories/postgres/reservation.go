@@ -0,0 +1,174 @@
+package postgres
+
+import (
+	"Dp218GO/models"
+	"Dp218GO/repositories"
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v4"
+)
+
+//restoreRentSQL makes the scooters which are still held by the released reservations available for rent
+//if their battery allows it and returns the number of the released reservations
+const restoreRentSQL = `restored AS (
+						UPDATE scooter_statuses AS ss
+						SET can_be_rent = ss.battery_remain > sm.low_battery_level, reserved = false
+						FROM released
+						JOIN scooters AS s ON s.id = released.scooter_id
+						JOIN scooter_models AS sm ON s.model_id = sm.id
+						WHERE ss.scooter_id = released.scooter_id AND ss.reserved
+					)
+					SELECT COUNT(*) FROM released`
+
+// ReservationRepoDB - struct for implementing scooter reservation repository
+type ReservationRepoDB struct {
+	db repositories.AnyDatabase
+}
+
+// NewReservationRepoDB - init of new scooter reservation repo
+func NewReservationRepoDB(db repositories.AnyDatabase) *ReservationRepoDB {
+	return &ReservationRepoDB{db}
+}
+
+// AddReservation - holds the scooter which can be rented now and stores the reservation in the DB.
+// The scooter is marked as reserved, so only the reservation makes it available for rent again
+func (rdb *ReservationRepoDB) AddReservation(ctx context.Context, reservation *models.Reservation) error {
+	querySQL := `WITH reserved AS (
+						UPDATE scooter_statuses SET can_be_rent = false, reserved = true
+						WHERE scooter_id = $2 AND can_be_rent
+						RETURNING scooter_id
+					)
+					INSERT INTO scooter_reservations(user_id, scooter_id, created_at, expires_at)
+					SELECT $1, scooter_id, $3, $4 FROM reserved RETURNING id;`
+	err := rdb.db.QueryResultRow(ctx, querySQL, reservation.UserID, reservation.ScooterID, reservation.CreatedAt,
+		reservation.ExpiresAt).Scan(&reservation.ID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return repositories.ErrScooterNotAvailable
+	}
+
+	return err
+}
+
+// GetActiveReservationByUserID - gets the reservation of the user which isn't released yet from the DB
+func (rdb *ReservationRepoDB) GetActiveReservationByUserID(ctx context.Context, userID int) (models.Reservation, error) {
+	reservation := models.Reservation{}
+
+	var stationID *int
+	var latitude, longitude *float64
+	querySQL := `SELECT id, user_id, scooter_id, created_at, expires_at,
+					destination_station_id, destination_latitude, destination_longitude
+					FROM scooter_reservations
+					WHERE user_id = $1 AND released_at IS NULL;`
+	err := rdb.db.QueryResultRow(ctx, querySQL, userID).Scan(&reservation.ID, &reservation.UserID,
+		&reservation.ScooterID, &reservation.CreatedAt, &reservation.ExpiresAt, &stationID, &latitude, &longitude)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return reservation, repositories.ErrNoReservation
+	}
+	if err != nil {
+		return reservation, err
+	}
+
+	switch {
+	case stationID != nil:
+		reservation.Destination = &models.TripDestination{StationID: *stationID}
+	case latitude != nil && longitude != nil:
+		reservation.Destination = &models.TripDestination{
+			Location: models.Coordinate{Latitude: *latitude, Longitude: *longitude}}
+	}
+	return reservation, nil
+}
+
+// SetDestination - stores the station or the point (if the station is 0) where the trip of the active
+// reservation will be finished
+func (rdb *ReservationRepoDB) SetDestination(ctx context.Context, reservationID int,
+	destination models.TripDestination) error {
+	var latitude, longitude *float64
+	if destination.StationID == 0 {
+		latitude, longitude = &destination.Location.Latitude, &destination.Location.Longitude
+	}
+
+	querySQL := `UPDATE scooter_reservations
+					SET destination_station_id = NULLIF($2::int, 0), destination_latitude = $3, destination_longitude = $4
+					WHERE id = $1 AND released_at IS NULL;`
+	result, err := rdb.db.QueryExec(ctx, querySQL, reservationID, destination.StationID, latitude, longitude)
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return repositories.ErrNoReservation
+	}
+
+	return nil
+}
+
+// ExtendReservation - moves the expiry time of the active reservation
+func (rdb *ReservationRepoDB) ExtendReservation(ctx context.Context, reservationID int, expiresAt time.Time) error {
+	querySQL := `UPDATE scooter_reservations SET expires_at = $2 WHERE id = $1 AND released_at IS NULL;`
+	result, err := rdb.db.QueryExec(ctx, querySQL, reservationID, expiresAt)
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return repositories.ErrNoReservation
+	}
+
+	return nil
+}
+
+// ReleaseReservation - closes the active reservation and makes the scooter available for rent again
+func (rdb *ReservationRepoDB) ReleaseReservation(ctx context.Context, reservationID int, releasedAt time.Time) error {
+	querySQL := `WITH released AS (
+						UPDATE scooter_reservations SET released_at = $2
+						WHERE id = $1 AND released_at IS NULL
+						RETURNING scooter_id
+					), ` + restoreRentSQL + `;`
+	var released int64
+	err := rdb.db.QueryResultRow(ctx, querySQL, reservationID, releasedAt).Scan(&released)
+	if err != nil {
+		return err
+	}
+	if released == 0 {
+		return repositories.ErrNoReservation
+	}
+
+	return nil
+}
+
+// UseReservation - closes the active reservation because the trip on the scooter is started.
+// The scooter stays not available for rent, it is held by the trip now
+func (rdb *ReservationRepoDB) UseReservation(ctx context.Context, reservationID int, usedAt time.Time) error {
+	querySQL := `WITH used AS (
+						UPDATE scooter_reservations SET released_at = $2, used = true
+						WHERE id = $1 AND released_at IS NULL
+						RETURNING scooter_id
+					), taken AS (
+						UPDATE scooter_statuses AS ss SET reserved = false
+						FROM used
+						WHERE ss.scooter_id = used.scooter_id
+					)
+					SELECT COUNT(*) FROM used;`
+	var used int64
+	err := rdb.db.QueryResultRow(ctx, querySQL, reservationID, usedAt).Scan(&used)
+	if err != nil {
+		return err
+	}
+	if used == 0 {
+		return repositories.ErrNoReservation
+	}
+
+	return nil
+}
+
+// ExpireReservations - releases all the reservations which expired by the given time and returns their number
+func (rdb *ReservationRepoDB) ExpireReservations(ctx context.Context, now time.Time) (int64, error) {
+	querySQL := `WITH released AS (
+						UPDATE scooter_reservations SET released_at = expires_at
+						WHERE released_at IS NULL AND expires_at <= $1
+						RETURNING scooter_id
+					), ` + restoreRentSQL + `;`
+	var released int64
+	err := rdb.db.QueryResultRow(ctx, querySQL, now).Scan(&released)
+	return released, err
+}
@@ -18,7 +18,7 @@ func NewScooterRepoDB(db repositories.AnyDatabase) *ScooterRepoDB {
 }
 
 //GetAllScooters returns the list of all scooters in the database in ScooterDTO view.
-func (scdb *ScooterRepoDB) GetAllScooters() (*models.ScooterListDTO, error) {
+func (scdb *ScooterRepoDB) GetAllScooters(ctx context.Context) (*models.ScooterListDTO, error) {
 	scooterList := &models.ScooterListDTO{}
 
 	querySQL := `SELECT s.id, sm.max_weight, sm.model_name, ss.battery_remain, ss.can_be_rent
@@ -29,7 +29,7 @@ func (scdb *ScooterRepoDB) GetAllScooters() (*models.ScooterListDTO, error) {
 					ON s.id=ss.scooter_id 
 					ORDER BY s.id`
 
-	rows, err := scdb.db.QueryResult(context.Background(), querySQL)
+	rows, err := scdb.db.QueryResult(ctx, querySQL)
 	if err != nil {
 		return scooterList, err
 	}
@@ -46,7 +46,9 @@ func (scdb *ScooterRepoDB) GetAllScooters() (*models.ScooterListDTO, error) {
 	return scooterList, nil
 }
 
-func (scdb *ScooterRepoDB) GetAllScootersByStationID(stationID int) (*models.ScooterListDTO, error) {
+//GetAllScootersByStationID returns the scooters docked at the station, stationID 0 gives the scooters
+//which were left out of the stations.
+func (scdb *ScooterRepoDB) GetAllScootersByStationID(ctx context.Context, stationID int) (*models.ScooterListDTO, error) {
 	scooterList := &models.ScooterListDTO{}
 
 	querySQL := `SELECT s.id, sm.max_weight, sm.model_name, ss.battery_remain, ss.can_be_rent
@@ -55,10 +57,10 @@ func (scdb *ScooterRepoDB) GetAllScootersByStationID(stationID int) (*models.Sco
 					ON s.model_id=sm.id 
 					JOIN scooter_statuses as ss 
 					ON s.id=ss.scooter_id 
-					WHERE ss.station_id=$1
+					WHERE ss.station_id IS NOT DISTINCT FROM NULLIF($1::int, 0)
 					ORDER BY s.id`
 
-	rows, err := scdb.db.QueryResult(context.Background(), querySQL, stationID)
+	rows, err := scdb.db.QueryResult(ctx, querySQL, stationID)
 	if err != nil {
 		return scooterList, err
 	}
@@ -76,9 +78,10 @@ func (scdb *ScooterRepoDB) GetAllScootersByStationID(stationID int) (*models.Sco
 }
 
 //GetScooterById returns exact scooter by it's ID.
-func (scdb *ScooterRepoDB) GetScooterById(scooterId int) (models.ScooterDTO, error) {
+func (scdb *ScooterRepoDB) GetScooterById(ctx context.Context, scooterId int) (models.ScooterDTO, error) {
 	scooter := models.ScooterDTO{}
-	querySQL := `SELECT s.id, sm.max_weight, sm.model_name, ss.battery_remain, ss.can_be_rent
+	querySQL := `SELECT s.id, sm.max_weight, sm.speed, sm.model_name, ss.battery_remain, ss.can_be_rent,
+					sm.battery_capacity, sm.consumption_per_km, sm.low_battery_level
 					FROM scooters as s 
 					JOIN scooter_models as sm 
 					ON s.model_id=sm.id 
@@ -86,8 +89,9 @@ func (scdb *ScooterRepoDB) GetScooterById(scooterId int) (models.ScooterDTO, err
 					ON s.id=ss.scooter_id 
 					WHERE s.id=$1`
 
-	row := scdb.db.QueryResultRow(context.Background(), querySQL, scooterId)
-	err := row.Scan(&scooter.ID, &scooter.MaxWeight, &scooter.ScooterModel, &scooter.BatteryRemain, &scooter.CanBeRent)
+	row := scdb.db.QueryResultRow(ctx, querySQL, scooterId)
+	err := row.Scan(&scooter.ID, &scooter.MaxWeight, &scooter.Speed, &scooter.ScooterModel, &scooter.BatteryRemain,
+		&scooter.CanBeRent, &scooter.Battery.Capacity, &scooter.Battery.ConsumptionPerKm, &scooter.Battery.LowLevel)
 	if err != nil {
 		return scooter, err
 	}
@@ -96,9 +100,9 @@ func (scdb *ScooterRepoDB) GetScooterById(scooterId int) (models.ScooterDTO, err
 }
 
 //GetScooterStatus returns the ScooterStatus model of the chosen scooter by its ID.
-func (scdb *ScooterRepoDB) GetScooterStatus(scooterID int) (models.ScooterStatus, error) {
+func (scdb *ScooterRepoDB) GetScooterStatus(ctx context.Context, scooterID int) (models.ScooterStatus, error) {
 	var scooterStatus = models.ScooterStatus{}
-	scooter, err := scdb.GetScooterById(scooterID)
+	scooter, err := scdb.GetScooterById(ctx, scooterID)
 	if err != nil {
 		fmt.Println(err)
 		return models.ScooterStatus{}, err
@@ -109,7 +113,7 @@ func (scdb *ScooterRepoDB) GetScooterStatus(scooterID int) (models.ScooterStatus
 					FROM scooter_statuses
 					WHERE scooter_id=$1`
 
-	row := scdb.db.QueryResultRow(context.Background(), querySQL, scooterID)
+	row := scdb.db.QueryResultRow(ctx, querySQL, scooterID)
 	err = row.Scan(&scooterStatus.BatteryRemain,
 		&scooterStatus.Location.Latitude, &scooterStatus.Location.Longitude)
 	if err != nil {
@@ -121,9 +125,9 @@ func (scdb *ScooterRepoDB) GetScooterStatus(scooterID int) (models.ScooterStatus
 
 //CreateScooterStatusInRent creates a new record in ScooterStatusesInRent by scooter's ID and returns the
 //ScooterStatusInRent model.
-func (scdb *ScooterRepoDB) CreateScooterStatusInRent(scooterID int) (models.ScooterStatusInRent, error) {
+func (scdb *ScooterRepoDB) CreateScooterStatusInRent(ctx context.Context, scooterID int) (models.ScooterStatusInRent, error) {
 	var scooterStatusInRent models.ScooterStatusInRent
-	scooterStatus, err := scdb.GetScooterStatus(scooterID)
+	scooterStatus, err := scdb.GetScooterStatus(ctx, scooterID)
 	if err != nil {
 		fmt.Println(err)
 		return scooterStatusInRent, err
@@ -134,7 +138,7 @@ func (scdb *ScooterRepoDB) CreateScooterStatusInRent(scooterID int) (models.Scoo
 	querySQL := `INSERT INTO scooter_statuses_in_rent(date_time, latitude, longitude) 
 					VALUES(now(), $1, $2) RETURNING id, date_time`
 
-	err = scdb.db.QueryResultRow(context.Background(), querySQL, scooterStatus.Location.Latitude,
+	err = scdb.db.QueryResultRow(ctx, querySQL, scooterStatus.Location.Latitude,
 		scooterStatus.Location.Longitude).Scan(&scooterStatusInRent.ID, &scooterStatusInRent.DateTime)
 	if err != nil {
 		fmt.Println(err)
@@ -145,18 +149,39 @@ func (scdb *ScooterRepoDB) CreateScooterStatusInRent(scooterID int) (models.Scoo
 
 }
 
-//SendCurrentStatus updates ScooterStatus with given parameters.
-func (scdb *ScooterRepoDB) SendCurrentStatus(id, stationID int, lat, lon, battery float64) error {
-	var canBeRent bool
-	if battery > 10 {
-		canBeRent = true
-	}
-
-	querySQL := `UPDATE scooter_statuses 
-					SET latitude=$1, longitude=$2, battery_remain=$3, can_be_rent=$4, station_id=$5
-					WHERE scooter_id=$6`
+//SendCurrentStatus updates ScooterStatus with given parameters. The scooter can be rented again
+//if its battery is above the low level of its model. The scooter left out of the stations has stationID 0.
+//The scooter is docked only if the station still has a free slot, otherwise it's left free-floating at the
+//given location and ErrStationFull is returned. The station row is locked so concurrent trips can't overfill it.
+func (scdb *ScooterRepoDB) SendCurrentStatus(ctx context.Context, id, stationID int, lat, lon, battery float64) error {
+	var docked int
+	err := scdb.db.WithTx(ctx, func(ctx context.Context) error {
+		if stationID != 0 {
+			_, err := scdb.db.QueryExec(ctx, `SELECT id FROM scooter_stations WHERE id=$1 FOR UPDATE`, stationID)
+			if err != nil {
+				return err
+			}
+		}
 
-	row, err := scdb.db.QueryResult(context.Background(), querySQL, lat, lon, battery, canBeRent, stationID, id)
-	defer row.Close()
-	return err
+		querySQL := `UPDATE scooter_statuses AS ss
+					SET latitude=$1, longitude=$2, battery_remain=$3, can_be_rent=$3 > sm.low_battery_level,
+					station_id=CASE WHEN st.capacity > (SELECT COUNT(*) FROM scooter_statuses
+						WHERE station_id=st.id AND scooter_id<>ss.scooter_id) THEN st.id END
+					FROM scooters AS s
+					JOIN scooter_models AS sm
+					ON s.model_id=sm.id
+					LEFT JOIN scooter_stations AS st
+					ON st.id=NULLIF($4::int, 0) AND st.deleted_at IS NULL
+					WHERE ss.scooter_id=s.id AND ss.scooter_id=$5
+					RETURNING COALESCE(ss.station_id, 0)`
+
+		return scdb.db.QueryResultRow(ctx, querySQL, lat, lon, battery, stationID, id).Scan(&docked)
+	})
+	if err != nil {
+		return err
+	}
+	if docked != stationID {
+		return repositories.ErrStationFull
+	}
+	return nil
 }
@@ -18,11 +18,11 @@ func NewScooterInitRepoDB(db repositories.AnyDatabase) *ScooterInitRepoDB {
 	return &ScooterInitRepoDB{db}
 }
 
-// GetOwnersScooters - get list of all system scooters that related for current supplier from the DB
-func (si *ScooterInitRepoDB) GetOwnersScooters() (*models.SuppliersScooterList, error) {
+// GetOwnersScooters - get list of all system scooters that related for given supplier from the DB
+func (si *ScooterInitRepoDB) GetOwnersScooters(ctx context.Context, owner models.User) (*models.SuppliersScooterList, error) {
 	suppliersScooterList := &models.SuppliersScooterList{}
 
-	idFromStatuses, err := si.getScooterIDFromStatuses()
+	idFromStatuses, err := si.getScooterIDFromStatuses(ctx)
 	if err != nil {
 		return suppliersScooterList, err
 	}
@@ -31,7 +31,7 @@ func (si *ScooterInitRepoDB) GetOwnersScooters() (*models.SuppliersScooterList,
 		id, serial_number
 		FROM scooters WHERE owner_id = $1
 		ORDER BY id DESC;`
-	rows, err := si.db.QueryResult(context.Background(), querySQL, userId)
+	rows, err := si.db.QueryResult(ctx, querySQL, owner.ID)
 	if err != nil {
 		return suppliersScooterList, err
 	}
@@ -53,10 +53,10 @@ func (si *ScooterInitRepoDB) GetOwnersScooters() (*models.SuppliersScooterList,
 }
 
 //  getScooterIDFromStatuses - get scooter IDs from table of scooter statuses
-func (si *ScooterInitRepoDB) getScooterIDFromStatuses() (*models.ScooterIDsStatusesList, error) {
+func (si *ScooterInitRepoDB) getScooterIDFromStatuses(ctx context.Context) (*models.ScooterIDsStatusesList, error) {
 	list := &models.ScooterIDsStatusesList{}
 	querySQL := `SELECT scooter_id FROM scooter_statuses WHERE can_be_rent=true ;`
-	rows, err := si.db.QueryResult(context.Background(), querySQL)
+	rows, err := si.db.QueryResult(ctx, querySQL)
 	if err != nil {
 		return list, err
 	}
@@ -84,17 +84,17 @@ func (si *ScooterInitRepoDB) findStatusInTheList(scooterIds *models.ScooterIDsSt
 }
 
 // GetActiveStations - get all station data for stations that is active
-func (si *ScooterInitRepoDB) GetActiveStations() (*models.StationList, error) {
+func (si *ScooterInitRepoDB) GetActiveStations(ctx context.Context) (*models.StationList, error) {
 	list := &models.StationList{}
-	querySQL := `SELECT * FROM scooter_stations WHERE is_active=true ORDER BY id;`
-	rows, err := si.db.QueryResult(context.Background(), querySQL)
+	querySQL := stationSelectSQL + ` WHERE st.is_active=true ORDER BY st.id;`
+	rows, err := si.db.QueryResult(ctx, querySQL)
 	if err != nil {
 		return list, err
 	}
 	defer rows.Close()
 	for rows.Next() {
 		var station models.Station
-		err := rows.Scan(&station.ID, &station.Name, &station.IsActive, &station.Latitude, &station.Longitude)
+		err := scanStation(rows, &station)
 		if err != nil {
 			return list, err
 		}
@@ -104,10 +104,50 @@ func (si *ScooterInitRepoDB) GetActiveStations() (*models.StationList, error) {
 	return list, nil
 }
 
-//AddStatusesToScooters - add coordinates and statuses to scooter statuses
-func (si *ScooterInitRepoDB) AddStatusesToScooters(scooterIds []int, station models.Station) error {
+//AddStatusesToScooters - add coordinates and statuses to scooter statuses.
+//The scooters which already have statuses or belong to another supplier are rejected,
+//as well as the scooters which don't fit into the free slots of the station,
+//so either all the given scooters get statuses or none
+func (si *ScooterInitRepoDB) AddStatusesToScooters(ctx context.Context, owner models.User, scooterIds []int, station models.Station) error {
+	return si.db.WithTx(ctx, func(ctx context.Context) error {
+		return si.addStatusesToScooters(ctx, owner, scooterIds, station)
+	})
+}
+
+func (si *ScooterInitRepoDB) addStatusesToScooters(ctx context.Context, owner models.User, scooterIds []int, station models.Station) error {
 	batteryRemain := 100
 
+	var foreign int
+	querySQL := `SELECT COUNT(*) FROM scooters WHERE id = ANY($1) AND owner_id <> $2;`
+	if err := si.db.QueryResultRow(ctx, querySQL, scooterIds, owner.ID).Scan(&foreign); err != nil {
+		return err
+	}
+	if foreign > 0 {
+		return repositories.ErrNotOwner
+	}
+
+	var initialized int
+	querySQL = `SELECT COUNT(*) FROM scooter_statuses WHERE scooter_id = ANY($1);`
+	if err := si.db.QueryResultRow(ctx, querySQL, scooterIds).Scan(&initialized); err != nil {
+		return err
+	}
+	if initialized > 0 {
+		return fmt.Errorf("%d of the chosen scooters already have statuses", initialized)
+	}
+
+	var free int
+	querySQL = `SELECT st.capacity - (SELECT COUNT(*) FROM scooter_statuses WHERE station_id = st.id)
+		FROM scooter_stations AS st WHERE st.id = $1 AND st.deleted_at IS NULL FOR UPDATE;`
+	if err := si.db.QueryResultRow(ctx, querySQL, station.ID).Scan(&free); err != nil {
+		return err
+	}
+	if free < 0 {
+		free = 0
+	}
+	if free < len(scooterIds) {
+		return fmt.Errorf("%w: %d chosen, %d free", repositories.ErrStationFull, len(scooterIds), free)
+	}
+
 	valueStrings := make([]string, 0, len(scooterIds))
 	valueArgs := make([]interface{}, 0, len(scooterIds)*6)
 	for i, scooter := range scooterIds {
@@ -121,7 +161,7 @@ func (si *ScooterInitRepoDB) AddStatusesToScooters(scooterIds []int, station mod
 	}
 
 	stmt := fmt.Sprintf("INSERT INTO scooter_statuses(scooter_id, battery_remain, station_id, latitude, longitude, can_be_rent) VALUES %s", strings.Join(valueStrings, ","))
-	if _, err := si.db.QueryExec(context.Background(), stmt, valueArgs...); err != nil {
+	if _, err := si.db.QueryExec(ctx, stmt, valueArgs...); err != nil {
 		fmt.Println("Unable to insert due to: ", err)
 		return err
 	}
@@ -4,6 +4,10 @@ import (
 	"Dp218GO/models"
 	"Dp218GO/repositories"
 	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v4"
 )
 
 type StationRepoDB struct {
@@ -14,18 +18,43 @@ func NewStationRepoDB(db repositories.AnyDatabase) *StationRepoDB {
 	return &StationRepoDB{db}
 }
 
-func (pg *StationRepoDB) GetAllStations() (*models.StationList, error) {
+// stationSelectSQL - stations with their capacity and the number of scooters docked there,
+// the deleted stations are skipped
+const stationSelectSQL = `SELECT st.id, st.name, st.is_active, st.status, st.latitude, st.longitude, st.capacity,
+		COALESCE(occ.occupied, 0)
+		FROM scooter_stations AS st
+		LEFT JOIN (SELECT station_id, COUNT(*) AS occupied FROM scooter_statuses GROUP BY station_id) AS occ
+		ON occ.station_id = st.id
+		WHERE st.deleted_at IS NULL`
+
+// scanStation - read the row of stationSelectSQL followed by extra columns, free slots are never negative
+func scanStation(row interface{ Scan(dest ...interface{}) error }, station *models.Station, extra ...interface{}) error {
+	dest := append([]interface{}{&station.ID, &station.Name, &station.IsActive, &station.Status, &station.Latitude,
+		&station.Longitude, &station.Capacity, &station.Occupied}, extra...)
+	err := row.Scan(dest...)
+	if err != nil {
+		return err
+	}
+	station.FreeSlots = station.Capacity - station.Occupied
+	if station.FreeSlots < 0 {
+		station.FreeSlots = 0
+	}
+	return nil
+}
+
+func (pg *StationRepoDB) GetAllStations(ctx context.Context) (*models.StationList, error) {
 	list := &models.StationList{}
 
-	querySQL := `SELECT * FROM scooter_stations ORDER BY id;`
-	rows, err := pg.db.QueryResult(context.Background(), querySQL)
+	querySQL := stationSelectSQL + ` ORDER BY st.id;`
+	rows, err := pg.db.QueryResult(ctx, querySQL)
 	if err != nil {
 		return list, err
 	}
+	defer rows.Close()
 
 	for rows.Next() {
 		var station models.Station
-		err := rows.Scan(&station.ID, &station.Name, &station.IsActive, &station.Latitude, &station.Longitude)
+		err := scanStation(rows, &station)
 		if err != nil {
 			return list, err
 		}
@@ -35,44 +64,234 @@ func (pg *StationRepoDB) GetAllStations() (*models.StationList, error) {
 	return list, nil
 }
 
-func (pg *StationRepoDB) AddStation(station *models.Station) error {
+func (pg *StationRepoDB) AddStation(ctx context.Context, station *models.Station) error {
 	var id int
-	querySQL := `INSERT INTO scooter_stations(id, name, is_active, latitude, longitude) 
-		VALUES($1, $2, $3, $4, $5)
+	querySQL := `INSERT INTO scooter_stations(id, name, is_active, status, latitude, longitude, capacity) 
+		VALUES($1, $2, $3 = 'active', $3, $4, $5, $6)
 		RETURNING id;`
-	err := pg.db.QueryResultRow(context.Background(), querySQL, station.ID, station.Name, station.IsActive, &station.Latitude, &station.Longitude).Scan(&id)
+	err := pg.db.QueryResultRow(ctx, querySQL, station.ID, station.Name, station.Status, &station.Latitude,
+		&station.Longitude, station.Capacity).Scan(&id)
 	if err != nil {
 		return err
 	}
 	station.ID = id
+	station.FreeSlots = station.Capacity
 	return nil
 }
 
-func (pg *StationRepoDB) GetStationById(stationId int) (models.Station, error) {
+func (pg *StationRepoDB) GetStationById(ctx context.Context, stationId int) (models.Station, error) {
 	station := models.Station{}
 
-	querySQL := `SELECT * FROM scooter_stations WHERE id = $1;`
-	row := pg.db.QueryResultRow(context.Background(), querySQL, stationId)
-	err := row.Scan(&station.ID, &station.Name, &station.IsActive, &station.Latitude, &station.Longitude)
+	querySQL := stationSelectSQL + ` AND st.id = $1;`
+	row := pg.db.QueryResultRow(ctx, querySQL, stationId)
+	err := scanStation(row, &station)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return station, repositories.ErrNoStation
+	}
 
 	return station, err
 }
 
-func (pg *StationRepoDB) DeleteStation(stationId int) error {
-	querySQL := `DELETE FROM scooter_stations WHERE id = $1;`
-	_, err := pg.db.QueryExec(context.Background(), querySQL, stationId)
-	return err
+// DeleteStation - delete the station which has no scooters. The scooters are moved to the station moveTo
+// (if it's not 0 and has enough free slots) before the deletion. The station is only marked as deleted and closed,
+// so its status history is kept, the scheduled status changes of the station are cancelled
+func (pg *StationRepoDB) DeleteStation(ctx context.Context, stationId, moveTo int) error {
+	return pg.db.WithTx(ctx, func(ctx context.Context) error {
+		docked, err := pg.lockStation(ctx, stationId)
+		if err != nil {
+			return err
+		}
+
+		if docked > 0 {
+			if moveTo == 0 {
+				return repositories.ErrStationInUse
+			}
+			if _, err = pg.lockStation(ctx, moveTo); err != nil {
+				return err
+			}
+			target, err := pg.GetStationById(ctx, moveTo)
+			if err != nil {
+				return err
+			}
+			if target.FreeSlots < docked {
+				return repositories.ErrStationFull
+			}
+
+			querySQL := `UPDATE scooter_statuses AS ss
+				SET station_id = st.id, latitude = st.latitude, longitude = st.longitude
+				FROM scooter_stations AS st
+				WHERE st.id = $2 AND ss.station_id = $1;`
+			if _, err = pg.db.QueryExec(ctx, querySQL, stationId, moveTo); err != nil {
+				return err
+			}
+		}
+
+		querySQL := `UPDATE station_status_changes SET cancelled_at = now()
+			WHERE station_id = $1 AND applied_at IS NULL AND cancelled_at IS NULL;`
+		if _, err = pg.db.QueryExec(ctx, querySQL, stationId); err != nil {
+			return err
+		}
+
+		querySQL = `UPDATE scooter_stations SET deleted_at = now(), status = 'closed', is_active = false
+			WHERE id = $1;`
+		_, err = pg.db.QueryExec(ctx, querySQL, stationId)
+		return err
+	})
 }
 
-func (pg *StationRepoDB) UpdateStation(stationId int, stationData models.Station) (models.Station, error) {
-	station := models.Station{}
+// lockStation - locks the station till the end of the transaction and returns the number of scooters docked there
+func (pg *StationRepoDB) lockStation(ctx context.Context, stationId int) (int, error) {
+	var docked int
+	querySQL := `SELECT (SELECT COUNT(*) FROM scooter_statuses WHERE station_id = st.id)
+		FROM scooter_stations AS st
+		WHERE st.id = $1 AND st.deleted_at IS NULL
+		FOR UPDATE;`
+	err := pg.db.QueryResultRow(ctx, querySQL, stationId).Scan(&docked)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return 0, repositories.ErrNoStation
+	}
+	return docked, err
+}
+
+// UpdateStation - change name, location & capacity of the station, the status is changed by AddStatusChange
+func (pg *StationRepoDB) UpdateStation(ctx context.Context, stationId int, stationData models.Station) (models.Station, error) {
 	querySQL := `UPDATE scooter_stations 
-		SET is_active=$1, name=$2, latitude=$3, longitude=$4
-		WHERE id=$5
-		RETURNING id, is_active, name, latitude, longitude;`
-	err := pg.db.QueryResultRow(context.Background(), querySQL, stationData.IsActive, stationData.Name, stationData.Latitude, stationData.Longitude, stationId).Scan(&station.ID, &station.IsActive, &station.Name, &station.Latitude, &station.Longitude)
+		SET name=$1, latitude=$2, longitude=$3, capacity=$4
+		WHERE id=$5 AND deleted_at IS NULL;`
+	_, err := pg.db.QueryExec(ctx, querySQL, stationData.Name, stationData.Latitude, stationData.Longitude,
+		stationData.Capacity, stationId)
+	if err != nil {
+		return models.Station{}, err
+	}
+	return pg.GetStationById(ctx, stationId)
+}
+
+// HasRoom - whether the station can take the scooter, the scooter which is already docked there keeps its slot
+func (pg *StationRepoDB) HasRoom(ctx context.Context, stationId, scooterId int) (bool, error) {
+	var hasRoom bool
+	querySQL := `SELECT st.capacity > (SELECT COUNT(*) FROM scooter_statuses
+			WHERE station_id = st.id AND scooter_id <> $2)
+		FROM scooter_stations AS st
+		WHERE st.id = $1 AND st.deleted_at IS NULL;`
+	err := pg.db.QueryResultRow(ctx, querySQL, stationId, scooterId).Scan(&hasRoom)
+	return hasRoom, err
+}
+
+// FindActiveInBox - active stations within the box with the number of scooters which can be rented there,
+// the box is searched by the spatial index on the station location
+func (pg *StationRepoDB) FindActiveInBox(ctx context.Context, southWest, northEast models.Coordinate) ([]models.NearbyStation, error) {
+	var list []models.NearbyStation
+
+	querySQL := `SELECT st.id, st.name, st.is_active, st.status, st.latitude, st.longitude, st.capacity,
+		(SELECT COUNT(*) FROM scooter_statuses WHERE station_id = st.id),
+		(SELECT COUNT(*) FROM scooter_statuses WHERE station_id = st.id AND can_be_rent)
+		FROM scooter_stations AS st
+		WHERE st.is_active AND st.deleted_at IS NULL
+		AND point(st.longitude::float8, st.latitude::float8) <@ box(point($1, $2), point($3, $4));`
+	rows, err := pg.db.QueryResult(ctx, querySQL, southWest.Longitude, southWest.Latitude,
+		northEast.Longitude, northEast.Latitude)
 	if err != nil {
-		return station, err
+		return list, err
 	}
-	return station, nil
+	defer rows.Close()
+
+	for rows.Next() {
+		var nearby models.NearbyStation
+		if err := scanStation(rows, &nearby.Station, &nearby.RentableScooters); err != nil {
+			return list, err
+		}
+		list = append(list, nearby)
+	}
+	return list, rows.Err()
+}
+
+// AddStatusChange - store the status change of the station and apply all the changes which are due at now,
+// the change itself included if its time has come. The status of the deleted station can't be changed
+func (pg *StationRepoDB) AddStatusChange(ctx context.Context, change *models.StationStatusChange, now time.Time) error {
+	return pg.db.WithTx(ctx, func(ctx context.Context) error {
+		querySQL := `INSERT INTO station_status_changes(station_id, status, reason, changed_by, created_at, effective_at)
+			SELECT st.id, $2, $3, NULLIF($4::int, 0), $5, $6
+			FROM scooter_stations AS st
+			WHERE st.id = $1 AND st.deleted_at IS NULL
+			RETURNING id;`
+		err := pg.db.QueryResultRow(ctx, querySQL, change.StationID, change.Status, change.Reason, change.ChangedBy,
+			now, change.EffectiveAt).Scan(&change.ID)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return repositories.ErrNoStation
+		}
+		if err != nil {
+			return err
+		}
+		change.CreatedAt = now
+
+		if _, err = pg.ApplyStatusChanges(ctx, now); err != nil {
+			return err
+		}
+		if !change.EffectiveAt.After(now) {
+			change.AppliedAt = &now
+		}
+		return nil
+	})
+}
+
+// ApplyStatusChanges - set the statuses of the changes which are due at now and not applied yet,
+// the latest change of the station wins. Returns the number of the changed stations
+func (pg *StationRepoDB) ApplyStatusChanges(ctx context.Context, now time.Time) (int, error) {
+	querySQL := `WITH due AS (
+			UPDATE station_status_changes SET applied_at = $1
+			WHERE applied_at IS NULL AND cancelled_at IS NULL AND effective_at <= $1
+			RETURNING id, station_id, status, effective_at
+		), latest AS (
+			SELECT DISTINCT ON (station_id) station_id, status FROM due
+			ORDER BY station_id, effective_at DESC, id DESC
+		)
+		UPDATE scooter_stations AS st SET status = latest.status, is_active = latest.status = 'active'
+		FROM latest
+		WHERE st.id = latest.station_id AND st.deleted_at IS NULL;`
+	result, err := pg.db.QueryExec(ctx, querySQL, now)
+	if err != nil {
+		return 0, err
+	}
+	return int(result.RowsAffected()), nil
+}
+
+// GetStatusHistory - all the status changes of the station including the scheduled and cancelled ones
+func (pg *StationRepoDB) GetStatusHistory(ctx context.Context, stationId int) (*models.StationStatusHistory, error) {
+	history := &models.StationStatusHistory{}
+
+	querySQL := `SELECT id, station_id, status, reason, COALESCE(changed_by, 0), created_at, effective_at,
+		applied_at, cancelled_at
+		FROM station_status_changes
+		WHERE station_id = $1
+		ORDER BY effective_at DESC, id DESC;`
+	rows, err := pg.db.QueryResult(ctx, querySQL, stationId)
+	if err != nil {
+		return history, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var change models.StationStatusChange
+		err := rows.Scan(&change.ID, &change.StationID, &change.Status, &change.Reason, &change.ChangedBy,
+			&change.CreatedAt, &change.EffectiveAt, &change.AppliedAt, &change.CancelledAt)
+		if err != nil {
+			return history, err
+		}
+		history.Changes = append(history.Changes, change)
+	}
+	return history, rows.Err()
+}
+
+// CancelStatusChange - cancel the scheduled status change of the station which isn't applied yet
+func (pg *StationRepoDB) CancelStatusChange(ctx context.Context, stationId, changeId int, now time.Time) error {
+	querySQL := `UPDATE station_status_changes SET cancelled_at = $3
+		WHERE id = $1 AND station_id = $2 AND applied_at IS NULL AND cancelled_at IS NULL;`
+	result, err := pg.db.QueryExec(ctx, querySQL, changeId, stationId, now)
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return repositories.ErrNoStatusChange
+	}
+	return nil
 }
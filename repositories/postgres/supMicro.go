@@ -16,11 +16,11 @@ func NewSupMicroRepoDB(db repositories.AnyDatabase) *SupMicroRepoDB {
 	return &SupMicroRepoDB{db}
 }
 
-func (repo *SupMicroRepoDB) GetLocations() (*models.LocationList, error) {
+func (repo *SupMicroRepoDB) GetLocations(ctx context.Context) (*models.LocationList, error) {
 	list := &models.LocationList{}
 
 	querySQL := `SELECT * FROM locations ORDER BY id;`
-	rows, err := repo.db.QueryResult(context.Background(), querySQL)
+	rows, err := repo.db.QueryResult(ctx, querySQL)
 	if err != nil {
 		return list, err
 	}
@@ -37,9 +37,9 @@ func (repo *SupMicroRepoDB) GetLocations() (*models.LocationList, error) {
 	return list, nil
 }
 
-func (repo *SupMicroRepoDB) GetStations() (*models.StationList, error) {
+func (repo *SupMicroRepoDB) GetStations(ctx context.Context) (*models.StationList, error) {
 	query := `SELECT * FROM locations ORDER BY id;`
-	row, err := repo.db.QueryResult(context.Background(), query)
+	row, err := repo.db.QueryResult(ctx, query)
 
 	var result *models.StationList
 	if err != nil {
@@ -57,11 +57,11 @@ func (repo *SupMicroRepoDB) GetStations() (*models.StationList, error) {
 	return result, nil
 }
 
-func (repo *SupMicroRepoDB) CreateStationInLocation(station *models.Station, location *models.Location) error {
-	query := `INSERT INTO scooter_stations (name, is_active, latitude, longitude)
-	VALUES($1, $2, $3, $4)
+func (repo *SupMicroRepoDB) CreateStationInLocation(ctx context.Context, station *models.Station, location *models.Location) error {
+	query := `INSERT INTO scooter_stations (name, is_active, status, latitude, longitude)
+	VALUES($1, $2, CASE WHEN $2 THEN 'active' ELSE 'maintenance' END, $3, $4)
 	RETURNING id`
-	row := repo.db.QueryResultRow(context.Background(), query, station.Name, station.IsActive, location.Latitude, location.Longitude)
+	row := repo.db.QueryResultRow(ctx, query, station.Name, station.IsActive, location.Latitude, location.Longitude)
 	err := row.Scan(&station.ID)
 	if err != nil {
 		return err
@@ -70,11 +70,11 @@ func (repo *SupMicroRepoDB) CreateStationInLocation(station *models.Station, loc
 	return nil
 }
 
-func (repo *SupMicroRepoDB) CreateStation(station *models.Station) error {
+func (repo *SupMicroRepoDB) CreateStation(ctx context.Context, station *models.Station) error {
 	query := `INSERT INTO scooter_stations(name, is_active, latitude, longitude)
 	VALUES($1, $2, $3, $4) WHERE 
 	RETURNING id`
-	row := repo.db.QueryResultRow(context.Background(), query, station.Name, station.IsActive, station.Latitude, station.Longitude)
+	row := repo.db.QueryResultRow(ctx, query, station.Name, station.IsActive, station.Latitude, station.Longitude)
 	err := row.Scan(&station.ID)
 	if err != nil {
 		return err
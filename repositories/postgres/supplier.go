@@ -5,15 +5,15 @@ import (
 	"Dp218GO/repositories"
 	"context"
 	"encoding/csv"
+	"errors"
 	"fmt"
+	"github.com/jackc/pgx/v4"
 	"github.com/jszwec/csvutil"
 	"io"
 	"os"
 	"strings"
 )
 
-var userId = 9
-
 // SupplierRepoDB - struct representing supplier repository
 type SupplierRepoDB struct {
 	db repositories.AnyDatabase
@@ -24,18 +24,18 @@ func NewSupplierRepoDB(db repositories.AnyDatabase) *SupplierRepoDB {
 	return &SupplierRepoDB{db}
 }
 
-// GetModels - get list of all system scooter models with payment prices from the DB
-func (s *SupplierRepoDB) GetModels() (*models.ScooterModelDTOList, error) {
+// GetModels - get list of all system scooter models with payment prices and scooters of the owner from the DB
+func (s *SupplierRepoDB) GetModels(ctx context.Context, owner models.User) (*models.ScooterModelDTOList, error) {
 	modelsOdtList := &models.ScooterModelDTOList{}
 	pricesList := &models.SupplierPricesDTOList{}
 
-	pricesList, err := s.getPrices()
+	pricesList, err := s.getPrices(ctx, owner.ID)
 	if err != nil {
 		return modelsOdtList, err
 	}
 
-	querySQL := `SELECT * FROM scooter_models ORDER BY id DESC;`
-	rows, err := s.db.QueryResult(context.Background(), querySQL)
+	querySQL := `SELECT id, payment_type_id, model_name, max_weight, speed FROM scooter_models ORDER BY id DESC;`
+	rows, err := s.db.QueryResult(ctx, querySQL)
 	if err != nil {
 		return modelsOdtList, err
 	}
@@ -48,12 +48,9 @@ func (s *SupplierRepoDB) GetModels() (*models.ScooterModelDTOList, error) {
 			return modelsOdtList, err
 		}
 
-		model.Price, err = s.findSupplierPricesList(pricesList, paymentTypeID, userId)
-		if err != nil {
-			return modelsOdtList, err
-		}
+		model.Price = s.findSupplierPricesList(pricesList, paymentTypeID, owner.ID)
 
-		model.SuppliersScooters, err = s.getSuppliersScootersByModelId(model.ID)
+		model.SuppliersScooters, err = s.getSuppliersScootersByModelId(ctx, owner.ID, model.ID)
 		if err != nil {
 			return modelsOdtList, err
 		}
@@ -64,12 +61,12 @@ func (s *SupplierRepoDB) GetModels() (*models.ScooterModelDTOList, error) {
 	return modelsOdtList, nil
 }
 
-// SelectModel - get scooter model from the DB by given ID
-func (s *SupplierRepoDB) SelectModel(id int) (*models.ScooterModelDTO, error) {
+// SelectModel - get scooter model with the price of the owner from the DB by given ID
+func (s *SupplierRepoDB) SelectModel(ctx context.Context, owner models.User, id int) (*models.ScooterModelDTO, error) {
 	modelDTO := &models.ScooterModelDTO{}
 
 	querySQL := `SELECT id, payment_type_id, model_name, max_weight, speed  FROM scooter_models WHERE id = $1;`
-	row := s.db.QueryResultRow(context.Background(), querySQL, id)
+	row := s.db.QueryResultRow(ctx, querySQL, id)
 
 	var paymentTypeId int
 	err := row.Scan(&modelDTO.ID, &paymentTypeId, &modelDTO.ModelName, &modelDTO.MaxWeight, &modelDTO.Speed)
@@ -77,61 +74,64 @@ func (s *SupplierRepoDB) SelectModel(id int) (*models.ScooterModelDTO, error) {
 		return modelDTO, err
 	}
 
-	modelDTO.Price, err = s.getPrice(paymentTypeId, userId)
+	modelDTO.Price, err = s.getPrice(ctx, paymentTypeId, owner.ID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return modelDTO, nil
+	}
 
 	return modelDTO, err
 }
 
-// AddModel - create scooter model record in the DB based on given entity
-func (s *SupplierRepoDB) AddModel(modelData *models.ScooterModelDTO) error {
-
-	paymentTypeId, err := s.addPaymentTypeId(modelData.ModelName)
-	if err != nil {
-		return err
-	}
-	var modelId int
-	querySQL := `INSERT INTO scooter_models(payment_type_id, model_name, max_weight, speed)
+// AddModel - create scooter model record in the DB based on given entity.
+// Payment type, model and price of the owner are created in one transaction
+func (s *SupplierRepoDB) AddModel(ctx context.Context, owner models.User, modelData *models.ScooterModelDTO) error {
+	return s.db.WithTx(ctx, func(ctx context.Context) error {
+		paymentTypeId, err := s.addPaymentTypeId(ctx, modelData.ModelName)
+		if err != nil {
+			return err
+		}
+		var modelId int
+		querySQL := `INSERT INTO scooter_models(payment_type_id, model_name, max_weight, speed)
 	   		VALUES($1, $2, $3, $4)
 	   		RETURNING id;`
-	err = s.db.QueryResultRow(context.Background(), querySQL, &paymentTypeId, modelData.ModelName, modelData.MaxWeight, modelData.Speed).Scan(&modelId)
-	if err != nil {
-		return err
-	}
+		err = s.db.QueryResultRow(ctx, querySQL, &paymentTypeId, modelData.ModelName, modelData.MaxWeight, modelData.Speed).Scan(&modelId)
+		if err != nil {
+			return err
+		}
 
-	var priceId int
-	querySQL = `INSERT INTO supplier_prices(price, payment_type_id, user_id)
+		var priceId int
+		querySQL = `INSERT INTO supplier_prices(price, payment_type_id, user_id)
 	   		VALUES($1, $2, $3)
 	   		RETURNING id;`
-	err = s.db.QueryResultRow(context.Background(), querySQL, modelData.Price, paymentTypeId, userId).Scan(&priceId)
-	if err != nil {
-		return err
-	}
-	return nil
+		return s.db.QueryResultRow(ctx, querySQL, modelData.Price, paymentTypeId, owner.ID).Scan(&priceId)
+	})
 }
 
-//EditPrice - changes the price for the rental of a scooter which is associated with the model
-func (s *SupplierRepoDB) EditPrice(modelData *models.ScooterModelDTO) error {
+//EditPrice - changes the owner's price for the rental of a scooter which is associated with the model.
+//The price is created if the owner hasn't set it for the model yet
+func (s *SupplierRepoDB) EditPrice(ctx context.Context, owner models.User, modelData *models.ScooterModelDTO) error {
 	price := &models.ScooterModelDTO{}
-	paymentTypeId, err := s.getPaymentTypeByModelName(modelData.ModelName)
+	paymentTypeId, err := s.getPaymentTypeByModelName(ctx, modelData.ModelName)
 	if err != nil {
 		return err
 	}
 
 	querySQL := `UPDATE supplier_prices SET price=$1 WHERE payment_type_id = $2 AND user_id = $3 RETURNING price;`
-	err = s.db.QueryResultRow(context.Background(), querySQL, modelData.Price, paymentTypeId, userId).Scan(&price.Price)
-	if err != nil {
-		return err
+	err = s.db.QueryResultRow(ctx, querySQL, modelData.Price, paymentTypeId, owner.ID).Scan(&price.Price)
+	if errors.Is(err, pgx.ErrNoRows) {
+		querySQL = `INSERT INTO supplier_prices(price, payment_type_id, user_id) VALUES($1, $2, $3) RETURNING price;`
+		err = s.db.QueryResultRow(ctx, querySQL, modelData.Price, paymentTypeId, owner.ID).Scan(&price.Price)
 	}
 
-	return nil
+	return err
 }
 
-// getPrices - reads all data from the table supplier_prices
-func (s *SupplierRepoDB) getPrices() (*models.SupplierPricesDTOList, error) {
+// getPrices - reads prices of the supplier from the table supplier_prices
+func (s *SupplierRepoDB) getPrices(ctx context.Context, userId int) (*models.SupplierPricesDTOList, error) {
 	list := &models.SupplierPricesDTOList{}
 
-	querySQL := `SELECT * FROM supplier_prices ORDER BY id DESC;`
-	rows, err := s.db.QueryResult(context.Background(), querySQL)
+	querySQL := `SELECT id, price, payment_type_id, user_id FROM supplier_prices WHERE user_id = $1 ORDER BY id DESC;`
+	rows, err := s.db.QueryResult(ctx, querySQL, userId)
 	if err != nil {
 		return list, err
 	}
@@ -150,41 +150,42 @@ func (s *SupplierRepoDB) getPrices() (*models.SupplierPricesDTOList, error) {
 }
 
 //getPaymentTypeID - selects payment_type by scooter model id
-func (s *SupplierRepoDB) getPaymentTypeID(modelId int) (int, error) {
+func (s *SupplierRepoDB) getPaymentTypeID(ctx context.Context, modelId int) (int, error) {
 	model := &models.ScooterModel{}
 
 	querySQL := `SELECT payment_type_id FROM scooter_models WHERE id= $1;`
-	row := s.db.QueryResultRow(context.Background(), querySQL, modelId)
+	row := s.db.QueryResultRow(ctx, querySQL, modelId)
 	err := row.Scan(&model.PaymentType.ID)
 
 	return model.PaymentType.ID, err
 }
 
-// findSupplierPricesList - find price in given price list by paymentTypeId and user Id
-func (s *SupplierRepoDB) findSupplierPricesList(supplierPrice *models.SupplierPricesDTOList, paymentTypeId, userId int) (int, error) {
+// findSupplierPricesList - find price in given price list by paymentTypeId and user Id.
+// Zero is returned for the models which the supplier hasn't priced yet
+func (s *SupplierRepoDB) findSupplierPricesList(supplierPrice *models.SupplierPricesDTOList, paymentTypeId, userId int) int {
 	for _, v := range supplierPrice.SupplierPricesDTO {
 		if v.PaymentTypeID == paymentTypeId && v.UserId == userId {
-			return v.Price, nil
+			return v.Price
 		}
 	}
-	return 0, fmt.Errorf("not found paymentType id=%d", paymentTypeId)
+	return 0
 }
 
 // getPrice - selects a specific price by payment-type id and user id
-func (s *SupplierRepoDB) getPrice(paymentTypeId, userId int) (int, error) {
+func (s *SupplierRepoDB) getPrice(ctx context.Context, paymentTypeId, userId int) (int, error) {
 	price := models.ScooterModelDTO{}
 	querySQL := `SELECT price FROM supplier_prices WHERE payment_type_id = $1 AND user_id = $2;`
-	row := s.db.QueryResultRow(context.Background(), querySQL, paymentTypeId, userId)
+	row := s.db.QueryResultRow(ctx, querySQL, paymentTypeId, userId)
 	err := row.Scan(&price.Price)
 
 	return price.Price, err
 }
 
 // addPaymentTypeId - add payment type by model name
-func (s *SupplierRepoDB) addPaymentTypeId(modelName string) (int, error) {
+func (s *SupplierRepoDB) addPaymentTypeId(ctx context.Context, modelName string) (int, error) {
 	var paymentTypeId int
 	querySQL := `INSERT INTO payment_types (name) VALUES ($1) RETURNING id;`
-	err := s.db.QueryResultRow(context.Background(), querySQL, modelName).Scan(&paymentTypeId)
+	err := s.db.QueryResultRow(ctx, querySQL, modelName).Scan(&paymentTypeId)
 	if err != nil {
 		return 0, err
 	}
@@ -192,20 +193,20 @@ func (s *SupplierRepoDB) addPaymentTypeId(modelName string) (int, error) {
 }
 
 // getPaymentTypeByModelName - select payment type by model name
-func (s *SupplierRepoDB) getPaymentTypeByModelName(modelName string) (int, error) {
+func (s *SupplierRepoDB) getPaymentTypeByModelName(ctx context.Context, modelName string) (int, error) {
 	paymentType := models.PaymentType{}
 	querySQL := `SELECT * FROM payment_types WHERE name = $1;`
-	row := s.db.QueryResultRow(context.Background(), querySQL, modelName)
+	row := s.db.QueryResultRow(ctx, querySQL, modelName)
 	err := row.Scan(&paymentType.ID, &paymentType.Name)
 	return paymentType.ID, err
 }
 
-//getSuppliersScootersByModelId - get scooters related to scooter model
-func (s *SupplierRepoDB) getSuppliersScootersByModelId(modelId int) (models.SuppliersScooterList, error) {
+//getSuppliersScootersByModelId - get scooters of the supplier related to scooter model
+func (s *SupplierRepoDB) getSuppliersScootersByModelId(ctx context.Context, userId, modelId int) (models.SuppliersScooterList, error) {
 	list := models.SuppliersScooterList{}
 
-	querySQL := `SELECT id, serial_number FROM scooters WHERE model_id = $1 ORDER BY id DESC;`
-	rows, err := s.db.QueryResult(context.Background(), querySQL, modelId)
+	querySQL := `SELECT id, serial_number FROM scooters WHERE model_id = $1 AND owner_id = $2 ORDER BY id DESC;`
+	rows, err := s.db.QueryResult(ctx, querySQL, modelId, userId)
 	if err != nil {
 		return list, err
 	}
@@ -222,31 +223,46 @@ func (s *SupplierRepoDB) getSuppliersScootersByModelId(modelId int) (models.Supp
 	return list, nil
 }
 
-//AddSuppliersScooter - adds a scooter to the scooter table, its serial number will be displayed in the scooter list
-func (s *SupplierRepoDB) AddSuppliersScooter(modelId int, scooterSerial string) error {
-
-	// проверить если уже существует
+//AddSuppliersScooter - adds a scooter of the owner to the scooter table, its serial number will be displayed in the scooter list.
+//The scooter with the same serial number of another supplier is left untouched
+func (s *SupplierRepoDB) AddSuppliersScooter(ctx context.Context, owner models.User, modelId int, scooterSerial string) error {
 	var id int
 	querySQL := `INSERT INTO scooters(model_id, owner_id, serial_number) 
 	   		VALUES($1, $2, $3) 
 			ON CONFLICT (serial_number) DO UPDATE
 				SET model_id = $4
+				WHERE scooters.owner_id = excluded.owner_id
 				RETURNING id;`
-	err := s.db.QueryResultRow(context.Background(), querySQL, modelId, userId, scooterSerial, modelId).Scan(&id)
-	if err != nil {
-		return err
+	err := s.db.QueryResultRow(ctx, querySQL, modelId, owner.ID, scooterSerial, modelId).Scan(&id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return repositories.ErrNotOwner
 	}
-	return nil
+	return err
 }
 
-//DeleteSuppliersScooter - removes the scooter and its status from the list of scooters and from the database
-func (s *SupplierRepoDB) DeleteSuppliersScooter(id int) error {
-	querySQL := `DELETE FROM scooters WHERE id = $1;`
-	_, err := s.db.QueryExec(context.Background(), querySQL, id)
+//DeleteSuppliersScooter - removes the owner's scooter and its status from the list of scooters and from the database.
+//Both are removed in one transaction
+func (s *SupplierRepoDB) DeleteSuppliersScooter(ctx context.Context, owner models.User, id int) error {
+	return s.db.WithTx(ctx, func(ctx context.Context) error {
+		var ownerID int
+		querySQL := `SELECT owner_id FROM scooters WHERE id = $1 FOR UPDATE;`
+		if err := s.db.QueryResultRow(ctx, querySQL, id).Scan(&ownerID); err != nil {
+			return err
+		}
+		if ownerID != owner.ID {
+			return repositories.ErrNotOwner
+		}
 
-	querySQL = `DELETE FROM scooter_statuses WHERE scooter_id = $1;`
-	_, err = s.db.QueryExec(context.Background(), querySQL, id)
-	return err
+		querySQL = `DELETE FROM scooter_statuses WHERE scooter_id = $1;`
+		_, err := s.db.QueryExec(ctx, querySQL, id)
+		if err != nil {
+			return err
+		}
+
+		querySQL = `DELETE FROM scooters WHERE id = $1;`
+		_, err = s.db.QueryExec(ctx, querySQL, id)
+		return err
+	})
 }
 
 //ConvertToStruct - converting the received data from .csv into a structure for further work
@@ -270,19 +286,20 @@ func (s *SupplierRepoDB) ConvertToStruct(path string) []models.UploadedScooters
 	return fileData
 }
 
-// InsertToDb - enter the data received from the file into the database
-func (s *SupplierRepoDB) InsertToDb(modelId int, scooters []models.UploadedScooters) error {
+// InsertToDb - enter the data received from the file into the database as the owner's scooters.
+// Scooters with serial numbers of another supplier are skipped
+func (s *SupplierRepoDB) InsertToDb(ctx context.Context, owner models.User, modelId int, scooters []models.UploadedScooters) error {
 	valueStrings := make([]string, 0, len(scooters))
 	valueArgs := make([]interface{}, 0, len(scooters)*3)
 	for i, scooter := range scooters {
 		valueStrings = append(valueStrings, fmt.Sprintf("($%d, $%d, $%d)", i*3+1, i*3+2, i*3+3))
 		valueArgs = append(valueArgs, modelId)
-		valueArgs = append(valueArgs, userId)
+		valueArgs = append(valueArgs, owner.ID)
 		valueArgs = append(valueArgs, scooter.SerialNumber)
 	}
 
-	stmt := fmt.Sprintf("INSERT INTO scooters(model_id, owner_id, serial_number) VALUES %s ON CONFLICT (serial_number) DO UPDATE SET model_id = excluded.model_id", strings.Join(valueStrings, ","))
-	if _, err := s.db.QueryExec(context.Background(), stmt, valueArgs...); err != nil {
+	stmt := fmt.Sprintf("INSERT INTO scooters(model_id, owner_id, serial_number) VALUES %s ON CONFLICT (serial_number) DO UPDATE SET model_id = excluded.model_id WHERE scooters.owner_id = excluded.owner_id", strings.Join(valueStrings, ","))
+	if _, err := s.db.QueryExec(ctx, stmt, valueArgs...); err != nil {
 		fmt.Println("Unable to insert due to: ", err)
 		return err
 	}
@@ -0,0 +1,76 @@
+package postgres
+
+import (
+	"Dp218GO/models"
+	"Dp218GO/repositories"
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v4"
+)
+
+// TokenRepoDB - struct representing api token repository
+type TokenRepoDB struct {
+	db repositories.AnyDatabase
+}
+
+// NewTokenRepoDB - api token repo initialization
+func NewTokenRepoDB(db repositories.AnyDatabase) *TokenRepoDB {
+	return &TokenRepoDB{db}
+}
+
+// AddToken - create api token record in the DB based on given entity
+func (tdb *TokenRepoDB) AddToken(ctx context.Context, token *models.AuthToken) error {
+	querySQL := `INSERT INTO api_tokens(user_id, access_hash, refresh_hash, access_expires_at, refresh_expires_at)
+		VALUES($1, $2, $3, $4, $5)
+		RETURNING id;`
+	return tdb.db.QueryResultRow(ctx, querySQL, token.UserID, token.AccessHash, token.RefreshHash,
+		token.AccessExpiresAt, token.RefreshExpiresAt).Scan(&token.ID)
+}
+
+// GetTokenByAccessHash - get not revoked api token from the DB by the hash of access token
+func (tdb *TokenRepoDB) GetTokenByAccessHash(ctx context.Context, accessHash string) (models.AuthToken, error) {
+	querySQL := `SELECT id, user_id, access_hash, refresh_hash, access_expires_at, refresh_expires_at
+		FROM api_tokens WHERE access_hash = $1 AND revoked_at IS NULL;`
+	return tdb.getToken(ctx, querySQL, accessHash)
+}
+
+// GetTokenByRefreshHash - get not revoked api token from the DB by the hash of refresh token
+func (tdb *TokenRepoDB) GetTokenByRefreshHash(ctx context.Context, refreshHash string) (models.AuthToken, error) {
+	querySQL := `SELECT id, user_id, access_hash, refresh_hash, access_expires_at, refresh_expires_at
+		FROM api_tokens WHERE refresh_hash = $1 AND revoked_at IS NULL;`
+	return tdb.getToken(ctx, querySQL, refreshHash)
+}
+
+// RevokeToken - marks both tokens of the pair as revoked
+func (tdb *TokenRepoDB) RevokeToken(ctx context.Context, tokenID int, revokedAt time.Time) error {
+	querySQL := `UPDATE api_tokens SET revoked_at = $2 WHERE id = $1 AND revoked_at IS NULL;`
+	result, err := tdb.db.QueryExec(ctx, querySQL, tokenID, revokedAt)
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return repositories.ErrNoToken
+	}
+
+	return nil
+}
+
+// RevokeUserTokens - marks all not revoked tokens of the user as revoked
+func (tdb *TokenRepoDB) RevokeUserTokens(ctx context.Context, userID int, revokedAt time.Time) error {
+	querySQL := `UPDATE api_tokens SET revoked_at = $2 WHERE user_id = $1 AND revoked_at IS NULL;`
+	_, err := tdb.db.QueryExec(ctx, querySQL, userID, revokedAt)
+	return err
+}
+
+func (tdb *TokenRepoDB) getToken(ctx context.Context, querySQL string, hash string) (models.AuthToken, error) {
+	token := models.AuthToken{}
+	err := tdb.db.QueryResultRow(ctx, querySQL, hash).Scan(&token.ID, &token.UserID, &token.AccessHash,
+		&token.RefreshHash, &token.AccessExpiresAt, &token.RefreshExpiresAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return token, repositories.ErrNoToken
+	}
+
+	return token, err
+}
@@ -19,19 +19,20 @@ func NewUserRepoDB(db repositories.AnyDatabase) *UserRepoDB {
 }
 
 // GetAllUsers - get list of all system users from the DB
-func (urdb *UserRepoDB) GetAllUsers() (*models.UserList, error) {
+func (urdb *UserRepoDB) GetAllUsers(ctx context.Context) (*models.UserList, error) {
 	list := &models.UserList{}
 
-	roles, err := urdb.GetAllRoles()
+	roles, err := urdb.GetAllRoles(ctx)
 	if err != nil {
 		return list, err
 	}
 
 	querySQL := `SELECT 
-		id, login_email, is_blocked, user_name, user_surname, created_at, role_id 
+		id, login_email, is_blocked, user_name, user_surname, created_at, role_id, 
+		COALESCE(locked_until > now(), false) 
 		FROM users 
 		ORDER BY id DESC;`
-	rows, err := urdb.db.QueryResult(context.Background(), querySQL)
+	rows, err := urdb.db.QueryResult(ctx, querySQL)
 	if err != nil {
 		return list, err
 	}
@@ -40,7 +41,7 @@ func (urdb *UserRepoDB) GetAllUsers() (*models.UserList, error) {
 		var user models.User
 		var roleID int
 		err := rows.Scan(&user.ID, &user.LoginEmail, &user.IsBlocked,
-			&user.UserName, &user.UserSurname, &user.CreatedAt, &roleID)
+			&user.UserName, &user.UserSurname, &user.CreatedAt, &roleID, &user.IsLocked)
 		if err != nil {
 			return list, err
 		}
@@ -56,13 +57,13 @@ func (urdb *UserRepoDB) GetAllUsers() (*models.UserList, error) {
 }
 
 // AddUser - create user record in the DB based on given entity
-func (urdb *UserRepoDB) AddUser(user *models.User) error {
+func (urdb *UserRepoDB) AddUser(ctx context.Context, user *models.User) error {
 	var id int
 	var createdAt time.Time
 	querySQL := `INSERT INTO users(login_email, is_blocked, user_name, user_surname, role_id, password_hash) 
 		VALUES($1, $2, $3, $4, $5, $6)
 		RETURNING id, created_at;`
-	err := urdb.db.QueryResultRow(context.Background(), querySQL,
+	err := urdb.db.QueryResultRow(ctx, querySQL,
 		user.LoginEmail, user.IsBlocked, user.UserName, user.UserSurname, user.Role.ID, user.Password).
 		Scan(&id, &createdAt)
 	if err != nil {
@@ -74,71 +75,116 @@ func (urdb *UserRepoDB) AddUser(user *models.User) error {
 }
 
 // GetUserByID - get user entity from the DB by given user ID
-func (urdb *UserRepoDB) GetUserByID(userID int) (models.User, error) {
+func (urdb *UserRepoDB) GetUserByID(ctx context.Context, userID int) (models.User, error) {
 	user := models.User{}
 
 	querySQL := `SELECT 
-		id, login_email, is_blocked, user_name, user_surname, created_at, role_id
+		id, login_email, is_blocked, user_name, user_surname, created_at, role_id, activated_at IS NOT NULL,
+		COALESCE(locked_until > now(), false)
 		FROM users 
 		WHERE id = $1;`
-	row := urdb.db.QueryResultRow(context.Background(), querySQL, userID)
+	row := urdb.db.QueryResultRow(ctx, querySQL, userID)
 
 	var roleID int
 	err := row.Scan(&user.ID, &user.LoginEmail, &user.IsBlocked,
-		&user.UserName, &user.UserSurname, &user.CreatedAt, &roleID)
+		&user.UserName, &user.UserSurname, &user.CreatedAt, &roleID, &user.IsActivated, &user.IsLocked)
 	if err != nil {
 		return models.User{}, err
 	}
-	user.Role, err = urdb.GetRoleByID(roleID)
+	user.Role, err = urdb.GetRoleByID(ctx, roleID)
 
 	return user, err
 }
 
 // GetUserByEmail - get user entity from the DB by given user email
-func (urdb *UserRepoDB) GetUserByEmail(email string) (models.User, error) {
+func (urdb *UserRepoDB) GetUserByEmail(ctx context.Context, email string) (models.User, error) {
 	user := models.User{}
 
 	querySQL := `SELECT 
-		id, login_email, is_blocked, user_name, user_surname, created_at, role_id, password_hash 
+		id, login_email, is_blocked, user_name, user_surname, created_at, role_id, password_hash,
+		activated_at IS NOT NULL
 		FROM users 
 		WHERE login_email = $1;`
-	row := urdb.db.QueryResultRow(context.Background(), querySQL, email)
+	row := urdb.db.QueryResultRow(ctx, querySQL, email)
 
 	var roleID int
 	err := row.Scan(&user.ID, &user.LoginEmail, &user.IsBlocked,
-		&user.UserName, &user.UserSurname, &user.CreatedAt, &roleID, &user.Password)
+		&user.UserName, &user.UserSurname, &user.CreatedAt, &roleID, &user.Password, &user.IsActivated)
 
 	if err != nil {
 		return models.User{}, err
 	}
-	user.Role, err = urdb.GetRoleByID(roleID)
+	user.Role, err = urdb.GetRoleByID(ctx, roleID)
 
 	return user, err
 }
 
+// ActivateUser - unblocks the user which wasn't activated yet and marks it as activated,
+// so the users blocked by admin after the activation stay blocked
+func (urdb *UserRepoDB) ActivateUser(ctx context.Context, userID int, activatedAt time.Time) error {
+	querySQL := `UPDATE users SET is_blocked = false, activated_at = $2 WHERE id = $1 AND activated_at IS NULL;`
+	result, err := urdb.db.QueryExec(ctx, querySQL, userID, activatedAt)
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return repositories.ErrAlreadyActivated
+	}
+
+	return nil
+}
+
+// UpdatePassword - sets new password hash of the user and remembers when it was changed
+func (urdb *UserRepoDB) UpdatePassword(ctx context.Context, userID int, passwordHash string, changedAt time.Time) error {
+	querySQL := `UPDATE users SET password_hash = $2, password_changed_at = $3 WHERE id = $1;`
+	_, err := urdb.db.QueryExec(ctx, querySQL, userID, passwordHash, changedAt)
+	return err
+}
+
+// GetPasswordChangedAt - get time of the last password change of the user, zero time if it was never changed
+func (urdb *UserRepoDB) GetPasswordChangedAt(ctx context.Context, userID int) (time.Time, error) {
+	var changedAt *time.Time
+	querySQL := `SELECT password_changed_at FROM users WHERE id = $1;`
+	if err := urdb.db.QueryResultRow(ctx, querySQL, userID).Scan(&changedAt); err != nil {
+		return time.Time{}, err
+	}
+	if changedAt == nil {
+		return time.Time{}, nil
+	}
+
+	return *changedAt, nil
+}
+
+// UnlockUser - clears failed sign in attempts & lockout of the user with given ID
+func (urdb *UserRepoDB) UnlockUser(ctx context.Context, userID int) error {
+	querySQL := `UPDATE users SET failed_attempts = 0, last_failed_at = NULL, locked_until = NULL WHERE id = $1;`
+	_, err := urdb.db.QueryExec(ctx, querySQL, userID)
+	return err
+}
+
 // DeleteUser - delete user with given ID from the DB
-func (urdb *UserRepoDB) DeleteUser(userID int) error {
+func (urdb *UserRepoDB) DeleteUser(ctx context.Context, userID int) error {
 	querySQL := `DELETE FROM users WHERE id = $1;`
-	_, err := urdb.db.QueryExec(context.Background(), querySQL, userID)
+	_, err := urdb.db.QueryExec(ctx, querySQL, userID)
 	return err
 }
 
 // UpdateUser - update user with given ID in the DB based on given user entity
-func (urdb *UserRepoDB) UpdateUser(userID int, userData models.User) (models.User, error) {
+func (urdb *UserRepoDB) UpdateUser(ctx context.Context, userID int, userData models.User) (models.User, error) {
 	user := models.User{}
 	querySQL := `UPDATE users 
 		SET login_email=$1, is_blocked=$2, user_name=$3, user_surname=$4, role_id=$5 
 		WHERE id=$6 
 		RETURNING id, created_at, login_email, is_blocked, user_name, user_surname, role_id;`
 	var roleID int
-	err := urdb.db.QueryResultRow(context.Background(), querySQL,
+	err := urdb.db.QueryResultRow(ctx, querySQL,
 		userData.LoginEmail, userData.IsBlocked, userData.UserName,
 		userData.UserSurname, userData.Role.ID, userID).
 		Scan(&user.ID, &user.CreatedAt, &user.LoginEmail, &user.IsBlocked, &user.UserName, &user.UserSurname, &roleID)
 	if err != nil {
 		return user, err
 	}
-	user.Role, err = urdb.GetRoleByID(roleID)
+	user.Role, err = urdb.GetRoleByID(ctx, roleID)
 	if err != nil {
 		return user, err
 	}
@@ -146,20 +192,21 @@ func (urdb *UserRepoDB) UpdateUser(userID int, userData models.User) (models.Use
 }
 
 // FindUsersByLoginNameSurname - find list of users having whatToFind string in login, name or surname in the DB
-func (urdb *UserRepoDB) FindUsersByLoginNameSurname(whatToFind string) (*models.UserList, error) {
+func (urdb *UserRepoDB) FindUsersByLoginNameSurname(ctx context.Context, whatToFind string) (*models.UserList, error) {
 	list := &models.UserList{}
 
-	roles, err := urdb.GetAllRoles()
+	roles, err := urdb.GetAllRoles(ctx)
 	if err != nil {
 		return list, err
 	}
 
-	querySQL := `SELECT id, login_email, is_blocked, user_name, user_surname, created_at, role_id FROM users 
+	querySQL := `SELECT id, login_email, is_blocked, user_name, user_surname, created_at, role_id, 
+		COALESCE(locked_until > now(), false) FROM users 
 		WHERE LOWER(login_email) LIKE LOWER($1) 
 			OR LOWER(user_name) LIKE LOWER($1) 
 			OR LOWER(user_surname) LIKE LOWER($1) 
 		ORDER BY id DESC;`
-	rows, err := urdb.db.QueryResult(context.Background(), querySQL, whatToFind+"%")
+	rows, err := urdb.db.QueryResult(ctx, querySQL, whatToFind+"%")
 	if err != nil {
 		return list, err
 	}
@@ -168,7 +215,7 @@ func (urdb *UserRepoDB) FindUsersByLoginNameSurname(whatToFind string) (*models.
 		var user models.User
 		var roleID int
 		err := rows.Scan(&user.ID, &user.LoginEmail, &user.IsBlocked,
-			&user.UserName, &user.UserSurname, &user.CreatedAt, &roleID)
+			&user.UserName, &user.UserSurname, &user.CreatedAt, &roleID, &user.IsLocked)
 		if err != nil {
 			return list, err
 		}
@@ -184,10 +231,10 @@ func (urdb *UserRepoDB) FindUsersByLoginNameSurname(whatToFind string) (*models.
 }
 
 // GetAllRoles - get list of all roles from the DB
-func (urdb *UserRepoDB) GetAllRoles() (*models.RoleList, error) {
+func (urdb *UserRepoDB) GetAllRoles(ctx context.Context) (*models.RoleList, error) {
 	list := &models.RoleList{}
 	querySQL := `SELECT * FROM roles ORDER BY id DESC;`
-	rows, err := urdb.db.QueryResult(context.Background(), querySQL)
+	rows, err := urdb.db.QueryResult(ctx, querySQL)
 	if err != nil {
 		return list, err
 	}
@@ -203,10 +250,10 @@ func (urdb *UserRepoDB) GetAllRoles() (*models.RoleList, error) {
 }
 
 // GetRoleByID - get role from the DB by given role ID
-func (urdb *UserRepoDB) GetRoleByID(roleId int) (models.Role, error) {
+func (urdb *UserRepoDB) GetRoleByID(ctx context.Context, roleId int) (models.Role, error) {
 	role := models.Role{}
 	querySQL := `SELECT * FROM roles WHERE id = $1;`
-	row := urdb.db.QueryResultRow(context.Background(), querySQL, roleId)
+	row := urdb.db.QueryResultRow(ctx, querySQL, roleId)
 	err := row.Scan(&role.ID, &role.Name, &role.IsAdmin, &role.IsUser, &role.IsSupplier)
 	return role, err
 }
@@ -0,0 +1,154 @@
+package postgres
+
+import (
+	"Dp218GO/models"
+	"Dp218GO/repositories"
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/jackc/pgx/v4"
+)
+
+// zoneSelectSQL - zone columns, the area is read in the text form of the postgres polygon
+const zoneSelectSQL = `SELECT id, name, kind, area::text, COALESCE(speed_limit, 0), penalty_cents, is_active
+		FROM zones`
+
+// ZoneRepoDB - struct representing parking zones repository
+type ZoneRepoDB struct {
+	db repositories.AnyDatabase
+}
+
+// NewZoneRepoDB - zone repo initialization
+func NewZoneRepoDB(db repositories.AnyDatabase) *ZoneRepoDB {
+	return &ZoneRepoDB{db}
+}
+
+// GetAllZones - get list of all zones from the DB
+func (zrdb *ZoneRepoDB) GetAllZones(ctx context.Context) (*models.ZoneList, error) {
+	list := &models.ZoneList{}
+
+	rows, err := zrdb.db.QueryResult(ctx, zoneSelectSQL+` ORDER BY id;`)
+	if err != nil {
+		return list, err
+	}
+	defer rows.Close()
+
+	list.Zones, err = scanZones(rows)
+	return list, err
+}
+
+// GetZoneByID - get zone with given ID
+func (zrdb *ZoneRepoDB) GetZoneByID(ctx context.Context, zoneID int) (models.Zone, error) {
+	return scanZone(zrdb.db.QueryResultRow(ctx, zoneSelectSQL+` WHERE id = $1;`, zoneID))
+}
+
+// AddZone - create zone record in the DB based on given entity
+func (zrdb *ZoneRepoDB) AddZone(ctx context.Context, zone *models.Zone) error {
+	querySQL := `INSERT INTO zones(name, kind, area, speed_limit, penalty_cents, is_active)
+		VALUES($1, $2, $3::polygon, NULLIF($4::numeric, 0), $5, $6)
+		RETURNING id;`
+	return zrdb.db.QueryResultRow(ctx, querySQL, zone.Name, zone.Kind, polygonText(zone.Area), zone.SpeedLimit,
+		zone.PenaltyCents, zone.IsActive).Scan(&zone.ID)
+}
+
+// UpdateZone - change the zone with given ID and return its new state
+func (zrdb *ZoneRepoDB) UpdateZone(ctx context.Context, zoneID int, zone models.Zone) (models.Zone, error) {
+	querySQL := `UPDATE zones SET name = $2, kind = $3, area = $4::polygon, speed_limit = NULLIF($5::numeric, 0),
+		penalty_cents = $6, is_active = $7
+		WHERE id = $1;`
+	result, err := zrdb.db.QueryExec(ctx, querySQL, zoneID, zone.Name, zone.Kind, polygonText(zone.Area),
+		zone.SpeedLimit, zone.PenaltyCents, zone.IsActive)
+	if err != nil {
+		return models.Zone{}, err
+	}
+	if result.RowsAffected() == 0 {
+		return models.Zone{}, repositories.ErrNoZone
+	}
+
+	return zrdb.GetZoneByID(ctx, zoneID)
+}
+
+// DeleteZone - delete zone with given ID
+func (zrdb *ZoneRepoDB) DeleteZone(ctx context.Context, zoneID int) error {
+	result, err := zrdb.db.QueryExec(ctx, `DELETE FROM zones WHERE id = $1;`, zoneID)
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return repositories.ErrNoZone
+	}
+	return nil
+}
+
+// FindActiveAt - get active zones which contain the point, the points are stored as (longitude, latitude)
+func (zrdb *ZoneRepoDB) FindActiveAt(ctx context.Context, point models.Coordinate) ([]models.Zone, error) {
+	querySQL := zoneSelectSQL + ` WHERE is_active AND area @> point($1::float8, $2::float8) ORDER BY id;`
+	rows, err := zrdb.db.QueryResult(ctx, querySQL, point.Longitude, point.Latitude)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanZones(rows)
+}
+
+func scanZones(rows pgx.Rows) ([]models.Zone, error) {
+	var zones []models.Zone
+	for rows.Next() {
+		zone, err := scanZone(rows)
+		if err != nil {
+			return zones, err
+		}
+		zones = append(zones, zone)
+	}
+	return zones, rows.Err()
+}
+
+func scanZone(row pgx.Row) (models.Zone, error) {
+	var zone models.Zone
+	var area string
+	err := row.Scan(&zone.ID, &zone.Name, &zone.Kind, &area, &zone.SpeedLimit, &zone.PenaltyCents, &zone.IsActive)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return models.Zone{}, repositories.ErrNoZone
+	}
+	if err != nil {
+		return models.Zone{}, err
+	}
+
+	zone.Area, err = parsePolygon(area)
+	return zone, err
+}
+
+// polygonText - text form of the postgres polygon: ((lon1,lat1),(lon2,lat2),...)
+func polygonText(area []models.Coordinate) string {
+	points := make([]string, len(area))
+	for i, vertex := range area {
+		points[i] = fmt.Sprintf("(%v,%v)", vertex.Longitude, vertex.Latitude)
+	}
+	return "(" + strings.Join(points, ",") + ")"
+}
+
+// parsePolygon - reads the vertices from the text form of the postgres polygon
+func parsePolygon(text string) ([]models.Coordinate, error) {
+	text = strings.TrimSuffix(strings.TrimPrefix(text, "(("), "))")
+	var area []models.Coordinate
+	for _, point := range strings.Split(text, "),(") {
+		xy := strings.SplitN(point, ",", 2)
+		if len(xy) != 2 {
+			return nil, fmt.Errorf("malformed polygon point %q", point)
+		}
+		lon, err := strconv.ParseFloat(xy[0], 64)
+		if err != nil {
+			return nil, err
+		}
+		lat, err := strconv.ParseFloat(xy[1], 64)
+		if err != nil {
+			return nil, err
+		}
+		area = append(area, models.Coordinate{Latitude: lat, Longitude: lon})
+	}
+	return area, nil
+}
@@ -0,0 +1,14 @@
+//go:generate mockgen -source=price.go -destination=../repositories/mock/mock_price.go -package=mock
+package repositories
+
+import (
+	"Dp218GO/models"
+	"context"
+)
+
+// PriceRepo - interface for trip price repository
+type PriceRepo interface {
+	GetTariffByScooterID(ctx context.Context, scooterID int) (models.Tariff, error)
+	AddPriceBreakdown(ctx context.Context, breakdown *models.PriceBreakdown) error
+	GetPriceBreakdownByOrderID(ctx context.Context, orderID int) (models.PriceBreakdown, error)
+}
@@ -3,25 +3,26 @@ package repositories
 
 import (
 	"Dp218GO/models"
+	"context"
 	"time"
 )
 
 // ProblemRepo - interface for user problem repository
 type ProblemRepo interface {
-	AddNewProblem(problem *models.Problem) error
-	GetProblemByID(problemID int) (models.Problem, error)
-	GetProblemTypeByID(typeID int) (models.ProblemType, error)
-	GetProblemsByUserID(userID int) (*models.ProblemList, error)
-	GetProblemsByTypeID(typeID int) (*models.ProblemList, error)
-	GetProblemsByBeingSolved(solved bool) (*models.ProblemList, error)
-	GetProblemsByTimePeriod(start, end time.Time) (*models.ProblemList, error)
-	AddProblemComplexFields(problem *models.Problem, typeID, userID int)
-	MarkProblemAsSolved(problem *models.Problem) (models.Problem, error)
-	GetAllProblemTypes() ([]models.ProblemType, error)
+	AddNewProblem(ctx context.Context, problem *models.Problem) error
+	GetProblemByID(ctx context.Context, problemID int) (models.Problem, error)
+	GetProblemTypeByID(ctx context.Context, typeID int) (models.ProblemType, error)
+	GetProblemsByUserID(ctx context.Context, userID int) (*models.ProblemList, error)
+	GetProblemsByTypeID(ctx context.Context, typeID int) (*models.ProblemList, error)
+	GetProblemsByBeingSolved(ctx context.Context, solved bool) (*models.ProblemList, error)
+	GetProblemsByTimePeriod(ctx context.Context, start, end time.Time) (*models.ProblemList, error)
+	AddProblemComplexFields(ctx context.Context, problem *models.Problem, typeID, userID int)
+	MarkProblemAsSolved(ctx context.Context, problem *models.Problem) (models.Problem, error)
+	GetAllProblemTypes(ctx context.Context) ([]models.ProblemType, error)
 }
 
 // SolutionRepo - interface for solution repository
 type SolutionRepo interface {
-	AddProblemSolution(problemID int, solution *models.Solution) error
-	GetSolutionByProblem(problem models.Problem) (models.Solution, error)
+	AddProblemSolution(ctx context.Context, problemID int, solution *models.Solution) error
+	GetSolutionByProblem(ctx context.Context, problem models.Problem) (models.Solution, error)
 }
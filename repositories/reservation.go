@@ -0,0 +1,25 @@
+//go:generate mockgen -source=reservation.go -destination=../repositories/mock/mock_reservation.go -package=mock
+package repositories
+
+import (
+	"Dp218GO/models"
+	"context"
+	"errors"
+	"time"
+)
+
+var (
+	ErrScooterNotAvailable = errors.New("scooter is not available for rent")
+	ErrNoReservation       = errors.New("there is no active reservation")
+)
+
+//ReservationRepo the interface which implemented by functions which connect to the database.
+type ReservationRepo interface {
+	AddReservation(ctx context.Context, reservation *models.Reservation) error
+	GetActiveReservationByUserID(ctx context.Context, userID int) (models.Reservation, error)
+	ExtendReservation(ctx context.Context, reservationID int, expiresAt time.Time) error
+	SetDestination(ctx context.Context, reservationID int, destination models.TripDestination) error
+	ReleaseReservation(ctx context.Context, reservationID int, releasedAt time.Time) error
+	UseReservation(ctx context.Context, reservationID int, usedAt time.Time) error
+	ExpireReservations(ctx context.Context, now time.Time) (int64, error)
+}
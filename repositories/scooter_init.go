@@ -2,11 +2,14 @@
 
 package repositories
 
-import "Dp218GO/models"
+import (
+	"Dp218GO/models"
+	"context"
+)
 
 // ScooterInitRepoI - interface for adding scooters to stations
 type ScooterInitRepoI interface {
-	GetOwnersScooters() (*models.SuppliersScooterList, error)
-	GetActiveStations() (*models.StationList, error)
-	AddStatusesToScooters(scooterIds []int, station models.Station) error
+	GetOwnersScooters(ctx context.Context, owner models.User) (*models.SuppliersScooterList, error)
+	GetActiveStations(ctx context.Context) (*models.StationList, error)
+	AddStatusesToScooters(ctx context.Context, owner models.User, scooterIds []int, station models.Station) error
 }
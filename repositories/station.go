@@ -1,13 +1,35 @@
+//go:generate mockgen -source=station.go -destination=../repositories/mock/mock_station.go -package=mock
 package repositories
 
 import (
 	"Dp218GO/models"
+	"context"
+	"errors"
+	"time"
+)
+
+var (
+	// ErrStationFull - the station has no free slot for the scooter
+	ErrStationFull = errors.New("station has no free slots")
+	// ErrNoStation - error returned if station with given ID doesn't exist
+	ErrNoStation = errors.New("station is not found")
+	// ErrStationInUse - the station can't be deleted while scooters are assigned to it
+	ErrStationInUse = errors.New("scooters are still assigned to the station")
+	// ErrNoStatusChange - error returned if the station has no scheduled status change with given ID
+	ErrNoStatusChange = errors.New("scheduled status change is not found")
 )
 
 type StationRepo interface {
-	GetAllStations() (*models.StationList, error)
-	GetStationById(stationId int) (models.Station, error)
-	AddStation(station *models.Station) error
-	DeleteStation(stationId int) error
-	UpdateStation(stationId int, stationData models.Station) (models.Station, error)
+	GetAllStations(ctx context.Context) (*models.StationList, error)
+	GetStationById(ctx context.Context, stationId int) (models.Station, error)
+	AddStation(ctx context.Context, station *models.Station) error
+	DeleteStation(ctx context.Context, stationId, moveTo int) error
+	UpdateStation(ctx context.Context, stationId int, stationData models.Station) (models.Station, error)
+	HasRoom(ctx context.Context, stationId, scooterId int) (bool, error)
+	FindActiveInBox(ctx context.Context, southWest, northEast models.Coordinate) ([]models.NearbyStation, error)
+
+	AddStatusChange(ctx context.Context, change *models.StationStatusChange, now time.Time) error
+	ApplyStatusChanges(ctx context.Context, now time.Time) (int, error)
+	GetStatusHistory(ctx context.Context, stationId int) (*models.StationStatusHistory, error)
+	CancelStatusChange(ctx context.Context, stationId, changeId int, now time.Time) error
 }
@@ -1,11 +1,14 @@
 package repositories
 
-import "Dp218GO/models"
+import (
+	"Dp218GO/models"
+	"context"
+)
 
 // ScooterInitRepoI - interface for adding scooters to stations
 type SupMicroRepoI interface {
-	GetStations() (*models.StationList, error)
-	GetLocations() (*models.LocationList, error)
-	CreateStationInLocation(station *models.Station, location *models.Location) error
-	CreateStation(station *models.Station) error
+	GetStations(ctx context.Context) (*models.StationList, error)
+	GetLocations(ctx context.Context) (*models.LocationList, error)
+	CreateStationInLocation(ctx context.Context, station *models.Station, location *models.Location) error
+	CreateStation(ctx context.Context, station *models.Station) error
 }
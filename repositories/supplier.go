@@ -3,17 +3,22 @@ package repositories
 
 import (
 	"Dp218GO/models"
+	"context"
+	"errors"
 )
 
-// SupplierRepoI - interface for supplier repository
+// ErrNotOwner - error returned when supplier tries to change scooters of another supplier
+var ErrNotOwner = errors.New("scooter belongs to another supplier")
+
+// SupplierRepoI - interface for supplier repository, prices and scooters are scoped to the owner supplier
 type SupplierRepoI interface {
-	GetModels() (*models.ScooterModelDTOList, error)
-	SelectModel(id int) (*models.ScooterModelDTO, error)
-	AddModel(modelData *models.ScooterModelDTO) error
-	EditPrice(modelData *models.ScooterModelDTO) error
+	GetModels(ctx context.Context, owner models.User) (*models.ScooterModelDTOList, error)
+	SelectModel(ctx context.Context, owner models.User, id int) (*models.ScooterModelDTO, error)
+	AddModel(ctx context.Context, owner models.User, modelData *models.ScooterModelDTO) error
+	EditPrice(ctx context.Context, owner models.User, modelData *models.ScooterModelDTO) error
 
-	AddSuppliersScooter(modelId int, scooter string) error
-	DeleteSuppliersScooter(id int) error
+	AddSuppliersScooter(ctx context.Context, owner models.User, modelId int, scooter string) error
+	DeleteSuppliersScooter(ctx context.Context, owner models.User, id int) error
 	ConvertToStruct(path string) []models.UploadedScooters
-	InsertToDb(modelId int, scooters []models.UploadedScooters) error
+	InsertToDb(ctx context.Context, owner models.User, modelId int, scooters []models.UploadedScooters) error
 }
@@ -0,0 +1,21 @@
+//go:generate mockgen -source=token.go -destination=../repositories/mock/mock_token.go -package=mock
+package repositories
+
+import (
+	"Dp218GO/models"
+	"context"
+	"errors"
+	"time"
+)
+
+// ErrNoToken - error returned if there is no valid token with given hash
+var ErrNoToken = errors.New("token is not valid")
+
+// TokenRepo - interface for api token repository
+type TokenRepo interface {
+	AddToken(ctx context.Context, token *models.AuthToken) error
+	GetTokenByAccessHash(ctx context.Context, accessHash string) (models.AuthToken, error)
+	GetTokenByRefreshHash(ctx context.Context, refreshHash string) (models.AuthToken, error)
+	RevokeToken(ctx context.Context, tokenID int, revokedAt time.Time) error
+	RevokeUserTokens(ctx context.Context, userID int, revokedAt time.Time) error
+}
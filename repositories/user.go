@@ -4,23 +4,32 @@ package repositories
 import (
 	"Dp218GO/models"
 	"context"
+	"errors"
+	"time"
 )
 
+// ErrAlreadyActivated - error returned if user account is activated already
+var ErrAlreadyActivated = errors.New("user is already activated")
+
 // UserRepo - interface for user repository
 type UserRepo interface {
-	GetAllUsers() (*models.UserList, error)
-	GetUserByID(userID int) (models.User, error)
-	GetUserByEmail(email string) (models.User, error)
-	AddUser(user *models.User) error
-	UpdateUser(userID int, userData models.User) (models.User, error)
-	DeleteUser(userID int) error
-	FindUsersByLoginNameSurname(whatToFind string) (*models.UserList, error)
+	GetAllUsers(ctx context.Context) (*models.UserList, error)
+	GetUserByID(ctx context.Context, userID int) (models.User, error)
+	GetUserByEmail(ctx context.Context, email string) (models.User, error)
+	AddUser(ctx context.Context, user *models.User) error
+	UpdateUser(ctx context.Context, userID int, userData models.User) (models.User, error)
+	DeleteUser(ctx context.Context, userID int) error
+	FindUsersByLoginNameSurname(ctx context.Context, whatToFind string) (*models.UserList, error)
+	ActivateUser(ctx context.Context, userID int, activatedAt time.Time) error
+	UpdatePassword(ctx context.Context, userID int, passwordHash string, changedAt time.Time) error
+	GetPasswordChangedAt(ctx context.Context, userID int) (time.Time, error)
+	UnlockUser(ctx context.Context, userID int) error
 }
 
 // RoleRepo - interface for role repository
 type RoleRepo interface {
-	GetAllRoles() (*models.RoleList, error)
-	GetRoleByID(roleID int) (models.Role, error)
+	GetAllRoles(ctx context.Context) (*models.RoleList, error)
+	GetRoleByID(ctx context.Context, roleID int) (models.Role, error)
 }
 
 // AuthRepo - interface for authorization repository
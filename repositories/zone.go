@@ -0,0 +1,21 @@
+//go:generate mockgen -source=zone.go -destination=../repositories/mock/mock_zone.go -package=mock
+package repositories
+
+import (
+	"Dp218GO/models"
+	"context"
+	"errors"
+)
+
+// ErrNoZone - error returned if zone with given ID doesn't exist
+var ErrNoZone = errors.New("zone is not found")
+
+// ZoneRepo - interface for parking zones repository
+type ZoneRepo interface {
+	GetAllZones(ctx context.Context) (*models.ZoneList, error)
+	GetZoneByID(ctx context.Context, zoneID int) (models.Zone, error)
+	AddZone(ctx context.Context, zone *models.Zone) error
+	UpdateZone(ctx context.Context, zoneID int, zone models.Zone) (models.Zone, error)
+	DeleteZone(ctx context.Context, zoneID int) error
+	FindActiveAt(ctx context.Context, point models.Coordinate) ([]models.Zone, error)
+}
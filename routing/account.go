@@ -21,6 +21,7 @@ var keyAccountRoutes = []Route{
 		Uri:     `/accounts`,
 		Method:  http.MethodGet,
 		Handler: getAllAccounts,
+		Access:  AdminAccess,
 	},
 	{
 		Uri:     `/account/{` + accountIDKey + `}`,
@@ -48,11 +49,11 @@ var keyAccountRoutes = []Route{
 func AddAccountHandler(router *mux.Router, service *services.AccountService) {
 	accountService = service
 	accountRouter := router.NewRoute().Subrouter()
-	accountRouter.Use(FilterAuth(authenticationService))
+	accountRouter.Use(FilterAuthOrToken(authenticationService, tokenService))
 
 	for _, rt := range keyAccountRoutes {
-		accountRouter.Path(rt.Uri).HandlerFunc(rt.Handler).Methods(rt.Method)
-		accountRouter.Path(APIprefix + rt.Uri).HandlerFunc(rt.Handler).Methods(rt.Method)
+		accountRouter.Path(rt.Uri).Handler(rt.authorized()).Methods(rt.Method)
+		accountRouter.Path(APIprefix + rt.Uri).Handler(rt.authorized()).Methods(rt.Method)
 	}
 }
 
@@ -68,7 +69,7 @@ func getAllAccounts(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	accounts, err = accountService.GetAccountsByOwner(*user)
+	accounts, err = accountService.GetAccountsByOwner(r.Context(), *user)
 	if err != nil {
 		ServerErrorRender(format, w)
 		return
@@ -86,7 +87,17 @@ func getAccountInfo(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	accData, err := accountService.GetAccountOutputStructByID(accID)
+	account, err := accountService.GetAccountByID(r.Context(), accID)
+	if err != nil {
+		EncodeError(format, w, ErrorRendererDefault(err))
+		return
+	}
+	if !ownerOrAdmin(r, account.User.ID) {
+		forbiddenRender(w, r)
+		return
+	}
+
+	accData, err := accountService.GetAccountOutputStructByID(r.Context(), accID)
 	if err != nil {
 		EncodeError(format, w, ErrorRendererDefault(err))
 		return
@@ -103,11 +114,15 @@ func updateAccountInfo(w http.ResponseWriter, r *http.Request) {
 		EncodeError(format, w, ErrorRendererDefault(err))
 		return
 	}
-	account, err := accountService.GetAccountByID(accID)
+	account, err := accountService.GetAccountByID(r.Context(), accID)
 	if err != nil {
 		EncodeError(format, w, ErrorRendererDefault(err))
 		return
 	}
+	if !ownerOrAdmin(r, account.User.ID) {
+		forbiddenRender(w, r)
+		return
+	}
 	actionType, err := GetParameterFromRequest(r, "ActionType", utils.ConvertStringToString())
 	if err != nil {
 		EncodeError(format, w, ErrorRendererDefault(err))
@@ -121,7 +136,7 @@ func updateAccountInfo(w http.ResponseWriter, r *http.Request) {
 			EncodeError(format, w, ErrorRendererDefault(err))
 			return
 		}
-		err = accountService.AddMoneyToAccount(account, int(moneyAmount.(float64)*100))
+		err = accountService.AddMoneyToAccount(r.Context(), account, int(moneyAmount.(float64)*100))
 		if err != nil {
 			EncodeError(format, w, ErrorRendererDefault(err))
 			return
@@ -132,7 +147,7 @@ func updateAccountInfo(w http.ResponseWriter, r *http.Request) {
 			EncodeError(format, w, ErrorRendererDefault(err))
 			return
 		}
-		err = accountService.TakeMoneyFromAccount(account, int(moneyAmount.(float64)*100))
+		err = accountService.TakeMoneyFromAccount(r.Context(), account, int(moneyAmount.(float64)*100))
 		if err != nil {
 			EncodeError(format, w, ErrorRendererDefault(err))
 			return
@@ -177,10 +192,10 @@ func createAccount(w http.ResponseWriter, r *http.Request) {
 		User:   *user,
 	}
 
-	if err := accountService.AddAccount(&account); err != nil {
+	if err := accountService.AddAccount(r.Context(), &account); err != nil {
 		EncodeError(format, w, ErrorRendererDefault(err))
 		return
 	}
 
-	http.Redirect(w, r, "/accounts", http.StatusFound)
+	http.Redirect(w, r, "/account/"+strconv.Itoa(account.ID), http.StatusFound)
 }
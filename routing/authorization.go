@@ -0,0 +1,67 @@
+package routing
+
+import (
+	"Dp218GO/models"
+	"errors"
+	"net/http"
+)
+
+// Access - set of role flags for the route, user role must have at least one of them
+type Access uint8
+
+// available access flags, they match the flags of models.Role
+const (
+	AdminAccess Access = 1 << iota
+	UserAccess
+	SupplierAccess
+)
+
+// ErrForbidden - error returned to client if user role doesn't allow the request
+var ErrForbidden = errors.New("access denied")
+
+// Allows - checks if given role has any of the access flags, empty access allows every role
+func (a Access) Allows(role models.Role) bool {
+	if a == 0 {
+		return true
+	}
+
+	return a&AdminAccess != 0 && role.IsAdmin ||
+		a&UserAccess != 0 && role.IsUser ||
+		a&SupplierAccess != 0 && role.IsSupplier
+}
+
+// FilterAccess - middleware checks if user from context (put there by FilterAuth) has role allowed by access
+// shows forbidden error in request format if not allowed
+func FilterAccess(access Access) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			user := GetUserFromContext(r)
+			if user == nil || !access.Allows(user.Role) {
+				forbiddenRender(w, r)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// authorized - returns route handler wrapped with the check of route access and the request timeout,
+// the context of the request is cancelled when the time is over
+func (rt Route) authorized() http.Handler {
+	var handler http.Handler = http.HandlerFunc(rt.Handler)
+	if !rt.LongRunning && RequestTimeout > 0 {
+		handler = http.TimeoutHandler(handler, RequestTimeout, "request timed out")
+	}
+	return FilterAccess(rt.Access)(handler)
+}
+
+// ownerOrAdmin - checks if user from context is the owner with given id or an admin
+func ownerOrAdmin(r *http.Request, ownerID int) bool {
+	user := GetUserFromContext(r)
+	return user != nil && (user.ID == ownerID || user.Role.IsAdmin)
+}
+
+func forbiddenRender(w http.ResponseWriter, r *http.Request) {
+	EncodeError(GetFormatFromRequest(r), w, ErrorRenderer(ErrForbidden, "Forbidden", http.StatusForbidden))
+}
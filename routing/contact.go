@@ -0,0 +1,191 @@
+package routing
+
+import (
+	"Dp218GO/models"
+	"Dp218GO/repositories"
+	"Dp218GO/services"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+)
+
+var contactService *services.ContactService
+var contactIDKey = "contactID"
+
+var keyContactRoutes = []Route{
+	{
+		Uri:     `/contacts`,
+		Method:  http.MethodGet,
+		Handler: getContacts,
+	},
+	{
+		Uri:     `/contacts`,
+		Method:  http.MethodPost,
+		Handler: addContact,
+	},
+	{
+		Uri:     `/contact/{` + contactIDKey + `}`,
+		Method:  http.MethodPost,
+		Handler: updateContact,
+	},
+	{
+		Uri:     `/contact/{` + contactIDKey + `}`,
+		Method:  http.MethodDelete,
+		Handler: deleteContact,
+	},
+}
+
+// contactRequest - body of request for adding or changing user contact
+type contactRequest struct {
+	TypeID      int    `json:"type_id"`
+	ContactInfo string `json:"contact_info"`
+	IsPrimary   bool   `json:"is_primary"`
+}
+
+// contactsPage - contacts of user with the types which can be added
+type contactsPage struct {
+	*models.ContactList
+	*models.ContactTypeList
+}
+
+// AddContactHandler - add endpoints for managing contacts of current user to http router
+func AddContactHandler(router *mux.Router, service *services.ContactService) {
+	contactService = service
+	contactRouter := router.NewRoute().Subrouter()
+	contactRouter.Use(FilterAuthOrToken(authenticationService, tokenService))
+
+	for _, rt := range keyContactRoutes {
+		contactRouter.Path(rt.Uri).Handler(rt.authorized()).Methods(rt.Method)
+		contactRouter.Path(APIprefix + rt.Uri).Handler(rt.authorized()).Methods(rt.Method)
+	}
+}
+
+func getContacts(w http.ResponseWriter, r *http.Request) {
+	format := GetFormatFromRequest(r)
+	user := GetUserFromContext(r)
+
+	contacts, err := contactService.GetContacts(r.Context(), user.ID)
+	if err != nil {
+		ServerErrorRender(format, w)
+		return
+	}
+	if format == FormatJSON {
+		EncodeAnswer(format, w, contacts)
+		return
+	}
+
+	contactTypes, err := contactService.GetContactTypes(r.Context())
+	if err != nil {
+		ServerErrorRender(format, w)
+		return
+	}
+
+	EncodeAnswer(format, w, &contactsPage{contacts, contactTypes}, HTMLPath+"contacts.html")
+}
+
+func addContact(w http.ResponseWriter, r *http.Request) {
+	format := GetFormatFromRequest(r)
+	user := GetUserFromContext(r)
+
+	req, err := decodeContactRequest(format, r)
+	if err != nil {
+		EncodeError(format, w, ErrorRendererDefault(err))
+		return
+	}
+
+	contact, err := contactService.AddContact(r.Context(), user.ID, req.TypeID, req.ContactInfo, req.IsPrimary)
+	if err != nil {
+		contactErrorRender(format, w, err)
+		return
+	}
+
+	contactAnswer(format, w, r, contact)
+}
+
+func updateContact(w http.ResponseWriter, r *http.Request) {
+	format := GetFormatFromRequest(r)
+	user := GetUserFromContext(r)
+
+	contactID, err := strconv.Atoi(mux.Vars(r)[contactIDKey])
+	if err != nil {
+		EncodeError(format, w, ErrorRendererDefault(err))
+		return
+	}
+	req, err := decodeContactRequest(format, r)
+	if err != nil {
+		EncodeError(format, w, ErrorRendererDefault(err))
+		return
+	}
+
+	contact, err := contactService.UpdateContact(r.Context(), user.ID, contactID, req.ContactInfo, req.IsPrimary)
+	if err != nil {
+		contactErrorRender(format, w, err)
+		return
+	}
+
+	contactAnswer(format, w, r, contact)
+}
+
+func deleteContact(w http.ResponseWriter, r *http.Request) {
+	format := GetFormatFromRequest(r)
+	user := GetUserFromContext(r)
+
+	contactID, err := strconv.Atoi(mux.Vars(r)[contactIDKey])
+	if err != nil {
+		EncodeError(format, w, ErrorRendererDefault(err))
+		return
+	}
+
+	if err = contactService.DeleteContact(r.Context(), user.ID, contactID); err != nil {
+		contactErrorRender(format, w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// decodeContactRequest - takes contact from json body or from form of contacts page
+func decodeContactRequest(format int, r *http.Request) (contactRequest, error) {
+	req := contactRequest{}
+	if format == FormatJSON {
+		err := json.NewDecoder(r.Body).Decode(&req)
+		return req, err
+	}
+
+	if typeID := r.FormValue("TypeID"); typeID != "" {
+		var err error
+		if req.TypeID, err = strconv.Atoi(typeID); err != nil {
+			return req, err
+		}
+	}
+	req.ContactInfo = r.FormValue("ContactInfo")
+	req.IsPrimary, _ = strconv.ParseBool(r.FormValue("IsPrimary"))
+	return req, nil
+}
+
+// contactAnswer - returns changed contact in json or goes back to contacts page
+func contactAnswer(format int, w http.ResponseWriter, r *http.Request, contact models.Contact) {
+	if format == FormatJSON {
+		EncodeAnswer(format, w, contact)
+		return
+	}
+	http.Redirect(w, r, "/contacts", http.StatusFound)
+}
+
+// contactErrorRender - renders missing contact as 404, duplicate as 409,
+// validation errors as 400 and others as server error
+func contactErrorRender(format int, w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, repositories.ErrNoContact):
+		EncodeError(format, w, ErrorRenderer(err, "Not Found", http.StatusNotFound))
+	case errors.Is(err, repositories.ErrContactExists):
+		EncodeError(format, w, ErrorRenderer(err, "Conflict", http.StatusConflict))
+	case errors.Is(err, repositories.ErrNoContactType), errors.Is(err, services.ErrInvalidContact):
+		EncodeError(format, w, ErrorRendererDefault(err))
+	default:
+		ServerErrorRender(format, w)
+	}
+}
@@ -28,7 +28,7 @@ func AddCustomerHandler(router *mux.Router, service *services.CustomerService) {
 	custHandler := newCustomerHandler(service)
 
 	custRouter := router.PathPrefix("/customer").Subrouter()
-	custRouter.Use(FilterAuth(authenticationService), FilterCustomer)
+	custRouter.Use(FilterAuthOrToken(authenticationService, tokenService), FilterCustomer)
 
 	custRouter.Path("/map").HandlerFunc(custHandler.HomeHandler).Methods(http.MethodGet)
 	custRouter.Path("/station").HandlerFunc(custHandler.StationListHandler).Methods(http.MethodGet)
@@ -57,7 +57,7 @@ func (h *customerHandler) HomeHandler(w http.ResponseWriter, r *http.Request) {
 // returns json station list in response shows error if failed
 func (h *customerHandler) StationListHandler(w http.ResponseWriter, r *http.Request) {
 	// TODO show only not blocked
-	sts, err := h.custService.ListStations()
+	sts, err := h.custService.ListStations(r.Context())
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
@@ -68,13 +68,16 @@ func (h *customerHandler) StationListHandler(w http.ResponseWriter, r *http.Requ
 }
 
 //StationNearestHandler is handler that user customer service
-// takes user location and returns nearest
-// station in json format shows error if failed
+// takes user location and returns nearest active stations in json format
+// with distance, walking time and rentable scooters, shows error if failed.
+// Optional radius (meters) and limit narrow the search, available=true skips empty stations
 func (h *customerHandler) StationNearestHandler(w http.ResponseWriter, r *http.Request) {
 
 	valReq := validation.LocationRequest{
 		Latitude:  r.FormValue("x"),
 		Longitude: r.FormValue("y"),
+		Radius:    r.FormValue("radius"),
+		Limit:     r.FormValue("limit"),
 	}
 
 	if err := valReq.Validate(); err != nil {
@@ -94,14 +97,29 @@ func (h *customerHandler) StationNearestHandler(w http.ResponseWriter, r *http.R
 		return
 	}
 
-	nearest, err := h.custService.ShowNearestStation(x, y)
+	var radius float64
+	if valReq.Radius != "" {
+		radius, _ = strconv.ParseFloat(valReq.Radius, 64)
+	}
+	var limit int
+	if valReq.Limit != "" {
+		limit, _ = strconv.Atoi(valReq.Limit)
+	}
+	available, _ := strconv.ParseBool(r.FormValue("available"))
+
+	nearest, err := h.custService.NearestStations(r.Context(), models.Coordinate{Latitude: x, Longitude: y},
+		radius, limit, available)
+	if errors.Is(err, services.ErrInvalidGeoQuery) {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
 
 	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode([]*models.Station{nearest})
+	json.NewEncoder(w).Encode(nearest)
 }
 
 // StationInfoHandler is handler that shows general station info of station
@@ -115,7 +133,7 @@ func (h *customerHandler) StationInfoHandler(w http.ResponseWriter, r *http.Requ
 		return
 	}
 
-	station, err := h.custService.ShowStation(id)
+	station, err := h.custService.ShowStation(r.Context(), id)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
@@ -128,14 +146,5 @@ func (h *customerHandler) StationInfoHandler(w http.ResponseWriter, r *http.Requ
 // checks if user role is customer or admin
 // shows error if not allowed
 func FilterCustomer(next http.Handler) http.Handler {
-
-	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		user := GetUserFromContext(r)
-		if user == nil || !(user.Role.IsUser || user.Role.IsAdmin) {
-			http.Error(w, "only customers allowed", http.StatusForbidden)
-			return
-		}
-
-		next.ServeHTTP(w, r)
-	})
+	return FilterAccess(UserAccess | AdminAccess)(next)
 }
@@ -1,22 +1,100 @@
 package grpcserver
 
 import (
-	"fmt"
+	"context"
 	"google.golang.org/grpc"
 	"log"
 	"net"
+	"time"
 )
 
-//NewGrpcServer creates a new gRPC server on port 8080.
-func NewGrpcServer() *grpc.Server{
-	grpcServer := grpc.NewServer()
-	listener, err := net.Listen("tcp", ":8000")
+const (
+	defaultShutdownTimeout = 3 * time.Second
+	defaultAddr            = ":8000"
+)
+
+//Server is the gRPC server of the application. The services are registered before the server is started.
+type Server struct {
+	*grpc.Server
+	addr            string
+	notify          chan error
+	shutdownTimeout time.Duration
+}
+
+type Option func(*Server)
+
+//NewGrpcServer creates a new gRPC server, by default it listens on port 8000.
+func NewGrpcServer(opts ...Option) *Server {
+	server := &Server{
+		Server:          grpc.NewServer(),
+		addr:            defaultAddr,
+		notify:          make(chan error, 1),
+		shutdownTimeout: defaultShutdownTimeout,
+	}
+
+	for _, opt := range opts {
+		opt(server)
+	}
+
+	return server
+}
+
+//Start listens on the server's port and serves the registered services in the background.
+//The error of serving is sent to the Notify channel.
+func (s *Server) Start() {
+	listener, err := net.Listen("tcp", s.addr)
 	if err != nil {
-		log.Fatal(err)
+		s.notify <- err
+		close(s.notify)
+		return
 	}
+
 	go func() {
-		fmt.Println("grpc server started: 8000")
-		log.Fatal(grpcServer.Serve(listener))
+		log.Printf("grpc server started: %s", s.addr)
+		if err := s.Serve(listener); err != nil {
+			s.notify <- err
+		}
+		close(s.notify)
 	}()
-	return grpcServer
-}
\ No newline at end of file
+}
+
+func (s *Server) Notify() <-chan error {
+	return s.notify
+}
+
+//Shutdown stops accepting new streams and waits for the active ones to finish.
+//The streams which don't finish in time are closed.
+func (s *Server) Shutdown() error {
+	ctx, cancel := context.WithTimeout(context.Background(), s.shutdownTimeout)
+	defer cancel()
+
+	stopped := make(chan struct{})
+	go func() {
+		s.GracefulStop()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+		return nil
+	case <-ctx.Done():
+		s.Stop()
+		return ctx.Err()
+	}
+}
+
+//Port sets the port the server listens on, the default port is kept if the port is empty.
+func Port(port string) Option {
+	return func(s *Server) {
+		if port == "" {
+			return
+		}
+		s.addr = net.JoinHostPort("", port)
+	}
+}
+
+func ShutdownTimeout(timeout time.Duration) Option {
+	return func(s *Server) {
+		s.shutdownTimeout = timeout
+	}
+}
@@ -1,20 +1,25 @@
 package routing
 
 import (
-	"Dp218GO/configs"
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"html/template"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/gorilla/mux"
 )
 
+// Route - endpoint of the application, Access lists roles allowed to use it (any authenticated user if empty).
+// The handler is limited by RequestTimeout unless it is LongRunning
 type Route struct {
-	Uri     string
-	Method  string
-	Handler func(http.ResponseWriter, *http.Request)
+	Uri         string
+	Method      string
+	Handler     func(http.ResponseWriter, *http.Request)
+	Access      Access
+	LongRunning bool
 }
 
 // available formats of http response representation
@@ -24,25 +29,32 @@ const (
 )
 
 var (
-	// HTMLPath - path where html templates are stored
-	HTMLPath = configs.TEMPLATES_PATH + "html/"
+	// HTMLPath - path where html templates are stored, set by NewRouter
+	HTMLPath string
 	// MainPageHTML - path to main page template
-	MainPageHTML = HTMLPath + "main-page.html"
+	MainPageHTML string
 	// ErrorPageHTML - path to error page template
-	ErrorPageHTML = HTMLPath + "error.html"
+	ErrorPageHTML string
 	//APIprefix - endpoint prefix to receive result in API (json) format
 	APIprefix = "/api/v1"
+	// RequestTimeout - time given to the handler of the route to answer, set by NewRouter (0 - no limit)
+	RequestTimeout time.Duration
 )
 
-// NewRouter - initialize main http router of the application
-func NewRouter() *mux.Router {
+// NewRouter - initialize main http router of the application, templates are served from templatesPath,
+// the routes added afterwards answer within requestTimeout
+func NewRouter(templatesPath string, requestTimeout time.Duration) *mux.Router {
+	RequestTimeout = requestTimeout
+	HTMLPath = templatesPath + "html/"
+	MainPageHTML = HTMLPath + "main-page.html"
+	ErrorPageHTML = HTMLPath + "error.html"
 
 	router := mux.NewRouter()
 	router.MethodNotAllowedHandler = http.HandlerFunc(methodNotAllowedHandler)
 	router.NotFoundHandler = http.HandlerFunc(notFoundHandler)
 
 	router.PathPrefix("/templates/").Handler(http.StripPrefix("/templates/",
-		http.FileServer(http.Dir(configs.TEMPLATES_PATH))))
+		http.FileServer(http.Dir(templatesPath))))
 
 	router.HandleFunc("/", showHomePage)
 	router.HandleFunc("/login", showLoginPage)
@@ -78,17 +90,19 @@ func ServerErrorRender(format int, w http.ResponseWriter) {
 }
 
 // EncodeError - renders general error page with passed error info
+// body is rendered before writing so the status code is sent ahead of it
 func EncodeError(format int, w http.ResponseWriter, respErr *ResponseStatus) {
+	var body bytes.Buffer
 	var err error
 	switch format {
 	case FormatJSON:
 		w.Header().Set("Content-Type", "application/json")
-		err = json.NewEncoder(w).Encode(respErr)
+		err = json.NewEncoder(&body).Encode(respErr)
 	case FormatHTML:
 		w.Header().Set("Content-Type", "text/html")
 		var tmpl *template.Template
 		if tmpl, err = template.ParseFiles(ErrorPageHTML); err == nil {
-			err = tmpl.Execute(w, respErr)
+			err = tmpl.Execute(&body, respErr)
 		}
 	default:
 		err = fmt.Errorf("format error")
@@ -100,6 +114,7 @@ func EncodeError(format int, w http.ResponseWriter, respErr *ResponseStatus) {
 	}
 
 	w.WriteHeader(respErr.StatusCode)
+	body.WriteTo(w)
 }
 
 // EncodeAnswer - renders given answer structure into given htmlTemplate using given format
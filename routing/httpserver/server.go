@@ -1,31 +1,45 @@
 package httpserver
 
 import (
+	"Dp218GO/models"
 	"Dp218GO/protos"
-	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"io"
+	"log"
 	"net"
 	"net/http"
+	"sync"
 	"time"
 )
 
 const (
-	defaultReadTimeout     = 5 * time.Second
-	defaultWriteTimeout    = 5 * time.Second
+	defaultReadTimeout = 5 * time.Second
+	//defaultWriteTimeout is 0 because the event streams and the trip requests last longer than any write timeout.
+	//The ordinary requests are limited by their handlers.
+	defaultWriteTimeout    = 0
 	defaultIdleTimeout     = 30 * time.Second
-	defaultShutdownTimeout = 3 * time.Second
+	defaultShutdownTimeout = 15 * time.Second
 	defaultAddr            = ":8080"
 )
 
 //Client is a struct a client who connects to the "scooter-run" page.
 type Client struct {
-	w    io.Writer
-	done chan struct{}
+	w     io.Writer
+	sub   Subscription
+	queue chan event
+	done  <-chan struct{}
+	//revoked checks if the client has lost the access to the watched scooter.
+	revoked func(ctx context.Context) bool
+}
+
+//BatteryWarner makes the warning for the rider whose scooter reported the low battery.
+type BatteryWarner interface {
+	BatteryWarning(ctx context.Context, msg *protos.ClientMessage) (*models.BatteryWarning, error)
 }
 
 //Server is a struct of the http-server which has a channel for gRPC connection.
@@ -33,7 +47,14 @@ type Server struct {
 	server          *http.Server
 	notify          chan error
 	shutdownTimeout time.Duration
-	client          map[int]*Client
+	done            chan struct{}
+	closeDone       sync.Once
+	stop            context.CancelFunc
+	clients         *subscribers
+	trips           TripResolver
+	scooterTrips    ScooterTripResolver
+	users           UserResolver
+	warner          BatteryWarner
 	taken           map[int]bool
 	codes           map[int]int
 	in              chan *protos.ClientMessage
@@ -42,21 +63,28 @@ type Server struct {
 
 type Option func(*Server)
 
-//New creates and starts the http-server
+//New creates the http-server and starts delivering the scooter messages, Start serves the http requests.
+//The contexts of the requests are cancelled if they don't finish in time on shutdown.
 func New(handler http.Handler, opts ...Option) *Server {
+	base, stop := context.WithCancel(context.Background())
 	httpServer := &http.Server{
 		Handler:      handler,
 		ReadTimeout:  defaultReadTimeout,
 		WriteTimeout: defaultWriteTimeout,
 		IdleTimeout:  defaultIdleTimeout,
 		Addr:         defaultAddr,
+		BaseContext: func(net.Listener) context.Context {
+			return base
+		},
 	}
 
 	server := &Server{
 		server:          httpServer,
 		notify:          make(chan error, 1),
 		shutdownTimeout: defaultShutdownTimeout,
-		client:          make(map[int]*Client),
+		done:            make(chan struct{}),
+		stop:            stop,
+		clients:         &subscribers{clients: make(map[*Client]struct{})},
 		taken:           make(map[int]bool),
 		codes:           make(map[int]int),
 		in:              make(chan *protos.ClientMessage),
@@ -71,43 +99,97 @@ func New(handler http.Handler, opts ...Option) *Server {
 	return server
 }
 
+//Start serves the http requests in the background, the error of serving is sent to the Notify channel.
+func (s *Server) Start() {
+	go func() {
+		log.Printf("http server started: %s", s.server.Addr)
+		if err := s.server.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
+			s.notify <- err
+		}
+		close(s.notify)
+	}()
+}
+
 func (s *Server) Notify() <-chan error {
 	return s.notify
 }
 
+//Shutdown ends the event streams of the connected clients and waits for the other requests to finish.
+//The requests which are still running when the shutdown timeout is over (the trips) are cancelled.
 func (s *Server) Shutdown() error {
+	defer s.stop()
+
 	ctx, cancel := context.WithTimeout(context.Background(), s.shutdownTimeout)
 	defer cancel()
 
-	return s.server.Shutdown(ctx)
+	s.closeDone.Do(func() {
+		close(s.done)
+	})
+	if err := s.clients.wait(ctx); err != nil {
+		log.Printf("event streams are not closed: %v", err)
+	}
+
+	err := s.server.Shutdown(ctx)
+	if err != nil {
+		s.stop()
+		if closeErr := s.server.Close(); closeErr != nil {
+			log.Println(closeErr)
+		}
+	}
+	return err
 }
 
+//Port sets the port the server listens on, the default port is kept if the port is empty.
 func Port(port string) Option {
 	return func(s *Server) {
+		if port == "" {
+			return
+		}
 		s.server.Addr = net.JoinHostPort("", port)
 	}
 }
 
 //ScooterHandler is a special handler which adds a new stream client to the server.
+//The client is subscribed to the scooter or to its own active trip given in the query.
+//The scooter in the trip can be watched only by its rider.
 func (s *Server) ScooterHandler(w http.ResponseWriter, r *http.Request) {
-	fmt.Println("new client connected")
+	sub, err := s.subscriptionFromRequest(r)
+	if err != nil {
+		http.Error(w, err.Error(), subscriptionError(err))
+		return
+	}
+
+	fmt.Printf("new client connected to scooter %v\n", sub.ScooterID)
 	w.Header().Set("Content-Type", "text/event-stream")
 	w.Header().Set("Cache-control", "no-cache")
 	w.Header().Set("Connection", "keep-alive")
 
 	client := &Client{
-		w:    w,
-		done: make(chan struct{}),
+		w:     w,
+		sub:   sub,
+		queue: make(chan event, clientBuffer),
+		done:  s.done,
+		revoked: func(ctx context.Context) bool {
+			return s.revoked(ctx, sub)
+		},
 	}
 	s.AddClient(client)
+	defer s.RemoveClient(client)
 
-	<-client.done
+	if err = client.serve(r.Context()); err != nil {
+		fmt.Println(err)
+	}
 	fmt.Println("connection closed")
 }
 
 //AddClient is a Server's function for adding attached Client.
 func (s *Server) AddClient(c *Client) {
-	s.client[1] = c
+	s.clients.add(c)
+}
+
+//RemoveClient is a Server's function for removing disconnected Client.
+func (s *Server) RemoveClient(c *Client) {
+	s.clients.remove(c)
 }
 
 //Register is a function for implementing gRPC-service.
@@ -134,36 +216,42 @@ func (s *Server) Receive(stream protos.ScooterService_ReceiveServer) error {
 	return err
 }
 
-//run runs the Server and wait for messages into the channel. Then encode them and print to the console.
+//run runs the Server and wait for messages into the channel. Then encode them and send to the subscribed clients.
 func (s *Server) run() {
 	go func() {
-		for {
-			select {
-			case msg := <-s.in:
-				var buf bytes.Buffer
-				json.NewEncoder(&buf).Encode(msg)
-
-				for _, client := range s.client {
-
-					go func(c *Client) {
-						if _, err := fmt.Fprintf(c.w, "data: %v\n\n", buf.String()); err != nil {
-							fmt.Println(err)
-							delete(s.client, 1)
-							close(c.done)
-							return
-						}
-
-						if f, ok := c.w.(http.Flusher); ok {
-							f.Flush()
-						}
-						fmt.Printf("data: %v\n", buf.String())
-					}(client)
-				}
+		for msg := range s.in {
+			data, err := json.Marshal(msg)
+			if err != nil {
+				log.Println(err)
+				continue
+			}
+
+			s.clients.publish(msg.Id, event{data: data})
+
+			if msg.LowBattery && s.warner != nil {
+				go s.warn(msg)
 			}
 		}
 	}()
 }
 
+//warn sends the low battery warning to the clients which watch the scooter.
+func (s *Server) warn(msg *protos.ClientMessage) {
+	warning, err := s.warner.BatteryWarning(context.Background(), msg)
+	if err != nil {
+		log.Println(err)
+		return
+	}
+
+	data, err := json.Marshal(warning)
+	if err != nil {
+		log.Println(err)
+		return
+	}
+
+	s.clients.publish(msg.Id, event{name: "low-battery", data: data})
+}
+
 func ReadTimeout(timeout time.Duration) Option {
 	return func(s *Server) {
 		s.server.ReadTimeout = timeout
@@ -187,3 +275,20 @@ func ShutdownTimeout(timeout time.Duration) Option {
 		s.shutdownTimeout = timeout
 	}
 }
+
+//Warnings sets the BatteryWarner which makes low battery warnings for the clients.
+func Warnings(warner BatteryWarner) Option {
+	return func(s *Server) {
+		s.warner = warner
+	}
+}
+
+//Trips sets the resolvers which allow clients to watch their trips and the scooters which are not
+//in the trips of other users.
+func Trips(trips TripResolver, scooterTrips ScooterTripResolver, users UserResolver) Option {
+	return func(s *Server) {
+		s.trips = trips
+		s.scooterTrips = scooterTrips
+		s.users = users
+	}
+}
@@ -0,0 +1,286 @@
+package httpserver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	//clientBuffer is the number of messages which are kept for the slow client. When the buffer is full,
+	//the oldest message is dropped, so the client always gets the latest scooter position.
+	clientBuffer      = 16
+	heartbeatInterval = 15 * time.Second
+	//tripCheckInterval is how often the access to the watched scooter is checked, the stream is closed soon
+	//after the watched trip ends or the watched scooter is taken for the trip of another user.
+	tripCheckInterval = 5 * time.Second
+	scooterIDKey      = "scooterId"
+	tripIDKey         = "tripId"
+)
+
+var (
+	ErrNoSubscription = errors.New("scooterId or tripId must be given")
+	ErrTripForbidden  = errors.New("the trip can be watched only by its rider")
+	ErrNoTrips        = errors.New("trips can't be checked")
+	ErrTripEnded      = errors.New("the trip has ended")
+)
+
+//Trip is the rider and the scooter of the trip. The trip is active until the scooter is returned.
+type Trip struct {
+	UserID    int
+	ScooterID uint64
+	Active    bool
+}
+
+//TripResolver returns the trip by its ID.
+type TripResolver func(ctx context.Context, tripID uint64) (Trip, error)
+
+//ScooterTripResolver returns the active trip of the scooter, the trip isn't Active if the scooter is free.
+type ScooterTripResolver func(ctx context.Context, scooterID uint64) (Trip, error)
+
+//UserResolver returns the ID of the signed in user who sent the request.
+type UserResolver func(r *http.Request) (int, bool)
+
+//event is the server-sent event. The event without the name is the scooter position.
+type event struct {
+	name string
+	data []byte
+}
+
+//Subscription is what the client watches on the "scooter-run" page and the user who watches it.
+type Subscription struct {
+	ScooterID uint64
+	TripID    uint64
+	UserID    int
+}
+
+//subscribers keeps the connected clients. It is safe for concurrent use.
+type subscribers struct {
+	mu      sync.Mutex
+	clients map[*Client]struct{}
+	active  sync.WaitGroup
+}
+
+func (ss *subscribers) add(c *Client) {
+	ss.mu.Lock()
+	defer ss.mu.Unlock()
+	ss.clients[c] = struct{}{}
+	ss.active.Add(1)
+}
+
+func (ss *subscribers) remove(c *Client) {
+	ss.mu.Lock()
+	defer ss.mu.Unlock()
+	if _, ok := ss.clients[c]; ok {
+		delete(ss.clients, c)
+		ss.active.Done()
+	}
+}
+
+//wait blocks until all the clients are removed or ctx is done.
+func (ss *subscribers) wait(ctx context.Context) error {
+	removed := make(chan struct{})
+	go func() {
+		ss.active.Wait()
+		close(removed)
+	}()
+
+	select {
+	case <-removed:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+//publish puts the message to the queues of the clients which are subscribed to the scooter.
+func (ss *subscribers) publish(scooterID uint64, e event) {
+	ss.mu.Lock()
+	defer ss.mu.Unlock()
+
+	for c := range ss.clients {
+		if c.sub.ScooterID == scooterID {
+			c.enqueue(e)
+		}
+	}
+}
+
+//enqueue never blocks: if the client doesn't read its messages, the oldest one is dropped.
+func (c *Client) enqueue(e event) {
+	for {
+		select {
+		case c.queue <- e:
+			return
+		default:
+		}
+
+		select {
+		case <-c.queue:
+		default:
+		}
+	}
+}
+
+//subscriptionFromRequest reads the scooter or the trip ID from the request query. The trip is watched
+//by the scooter which is used in it, only the rider can watch the trip and only while it is active.
+//The scooter which is in a trip can be watched only by the rider too.
+func (s *Server) subscriptionFromRequest(r *http.Request) (Subscription, error) {
+	sub := Subscription{}
+	var err error
+
+	if s.trips == nil || s.scooterTrips == nil || s.users == nil {
+		return sub, ErrNoTrips
+	}
+	userID, ok := s.users(r)
+	if !ok {
+		return sub, ErrTripForbidden
+	}
+	sub.UserID = userID
+
+	if value := r.URL.Query().Get(scooterIDKey); value != "" {
+		sub.ScooterID, err = strconv.ParseUint(value, 10, 64)
+		if err != nil {
+			return sub, err
+		}
+		return sub, s.checkScooter(r.Context(), sub)
+	}
+
+	value := r.URL.Query().Get(tripIDKey)
+	if value == "" {
+		return sub, ErrNoSubscription
+	}
+	sub.TripID, err = strconv.ParseUint(value, 10, 64)
+	if err != nil {
+		return sub, err
+	}
+
+	trip, err := s.trips(r.Context(), sub.TripID)
+	if err != nil {
+		return sub, err
+	}
+	if trip.UserID != userID {
+		return sub, ErrTripForbidden
+	}
+	if !trip.Active {
+		return sub, ErrTripEnded
+	}
+
+	sub.ScooterID = trip.ScooterID
+	return sub, nil
+}
+
+//checkScooter returns ErrTripForbidden if the watched scooter is in the trip of another user.
+func (s *Server) checkScooter(ctx context.Context, sub Subscription) error {
+	trip, err := s.scooterTrips(ctx, sub.ScooterID)
+	if err != nil {
+		return err
+	}
+	if trip.Active && trip.UserID != sub.UserID {
+		return ErrTripForbidden
+	}
+	return nil
+}
+
+//revoked reports whether the client can't watch the scooter any more: the watched trip is over or the watched
+//scooter is in the trip of another user. The access which can't be checked is treated as revoked,
+//so the client never gets the positions of someone else's trip.
+func (s *Server) revoked(ctx context.Context, sub Subscription) bool {
+	if sub.TripID == 0 {
+		err := s.checkScooter(ctx, sub)
+		if err != nil && !errors.Is(err, ErrTripForbidden) {
+			log.Println(err)
+		}
+		return err != nil
+	}
+
+	trip, err := s.trips(ctx, sub.TripID)
+	if err != nil {
+		log.Println(err)
+		return true
+	}
+	return !trip.Active || trip.ScooterID != sub.ScooterID
+}
+
+//subscriptionError maps the subscription errors to http statuses.
+func subscriptionError(err error) int {
+	switch {
+	case errors.Is(err, ErrTripForbidden):
+		return http.StatusForbidden
+	case errors.Is(err, ErrTripEnded):
+		return http.StatusGone
+	default:
+		return http.StatusBadRequest
+	}
+}
+
+//serve writes the client's messages and the heartbeat events to the stream until the client disconnects
+//or the server is shut down. The access to the scooter is checked periodically and before the first message
+//after a pause, so the positions of the scooter's next trip are never sent to someone who may not see them.
+//The stream of the trip is closed with the "trip-end" event when the trip ends.
+func (c *Client) serve(ctx context.Context) error {
+	heartbeat := time.NewTicker(heartbeatInterval)
+	defer heartbeat.Stop()
+
+	var accessCheck <-chan time.Time
+	if c.revoked != nil {
+		ticker := time.NewTicker(tripCheckInterval)
+		defer ticker.Stop()
+		accessCheck = ticker.C
+	}
+
+	var lastMessage time.Time
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-c.done:
+			return nil
+		case <-accessCheck:
+			if c.revoked(ctx) {
+				return c.end()
+			}
+			continue
+		case e := <-c.queue:
+			if c.revoked != nil && time.Since(lastMessage) >= tripCheckInterval && c.revoked(ctx) {
+				return c.end()
+			}
+			lastMessage = time.Now()
+
+			if e.name != "" {
+				if _, err := fmt.Fprintf(c.w, "event: %s\n", e.name); err != nil {
+					return err
+				}
+			}
+			if _, err := fmt.Fprintf(c.w, "data: %s\n\n", e.data); err != nil {
+				return err
+			}
+		case <-heartbeat.C:
+			if _, err := fmt.Fprintf(c.w, "event: heartbeat\ndata: %d\n\n", time.Now().Unix()); err != nil {
+				return err
+			}
+		}
+
+		if f, ok := c.w.(http.Flusher); ok {
+			f.Flush()
+		}
+	}
+}
+
+//end closes the stream of the client which has lost the access to the scooter. The client which watched
+//the trip gets the "trip-end" event.
+func (c *Client) end() error {
+	if c.sub.TripID == 0 {
+		return nil
+	}
+
+	_, err := fmt.Fprintf(c.w, "event: trip-end\ndata: %d\n\n", c.sub.TripID)
+	if f, ok := c.w.(http.Flusher); ok && err == nil {
+		f.Flush()
+	}
+	return err
+}
@@ -5,27 +5,40 @@ import (
 	"fmt"
 	"github.com/gorilla/mux"
 	"net/http"
+	"strconv"
 )
 
+var orderIDKey = "orderId"
+
 var keyOrderRoutes = []Route{
 	{
 		Uri:     `/orders`,
 		Method:  http.MethodGet,
 		Handler: getAllOrders,
+		Access:  AdminAccess,
+	},
+	{
+		Uri:     `/order/{` + orderIDKey + `}/price`,
+		Method:  http.MethodGet,
+		Handler: getOrderPrice,
+		Access:  UserAccess | AdminAccess,
 	},
 }
 
 //AddOrderHandler adds routes to the router from the list of routes.
 func AddOrderHandler(router *mux.Router, order *services.OrderService) {
 	orderService = order
+	orderRouter := router.NewRoute().Subrouter()
+	orderRouter.Use(FilterAuthOrToken(authenticationService, tokenService))
+
 	for _, rt := range keyOrderRoutes {
-		router.Path(rt.Uri).HandlerFunc(rt.Handler).Methods(rt.Method)
-		router.Path(APIprefix + rt.Uri).HandlerFunc(rt.Handler).Methods(rt.Method)
+		orderRouter.Path(rt.Uri).Handler(rt.authorized()).Methods(rt.Method)
+		orderRouter.Path(APIprefix + rt.Uri).Handler(rt.authorized()).Methods(rt.Method)
 	}
 }
 
 func getAllOrders(w http.ResponseWriter, r *http.Request) {
-	orders, err := orderService.GetAllOrders()
+	orders, err := orderService.GetAllOrders(r.Context())
 	if err != nil {
 		ServerErrorRender(FormatJSON, w)
 		fmt.Println(err)
@@ -34,3 +47,29 @@ func getAllOrders(w http.ResponseWriter, r *http.Request) {
 
 	EncodeAnswer(FormatJSON, w, orders)
 }
+
+func getOrderPrice(w http.ResponseWriter, r *http.Request) {
+	orderID, err := strconv.Atoi(mux.Vars(r)[orderIDKey])
+	if err != nil {
+		EncodeError(FormatJSON, w, ErrorRendererDefault(err))
+		return
+	}
+
+	order, err := orderService.GetOrderByID(r.Context(), orderID)
+	if err != nil {
+		EncodeError(FormatJSON, w, ErrorRendererDefault(err))
+		return
+	}
+	if !ownerOrAdmin(r, order.UserID) {
+		forbiddenRender(w, r)
+		return
+	}
+
+	price, err := orderService.GetOrderPrice(r.Context(), orderID)
+	if err != nil {
+		EncodeError(FormatJSON, w, ErrorRendererDefault(err))
+		return
+	}
+
+	EncodeAnswer(FormatJSON, w, price)
+}
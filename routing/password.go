@@ -0,0 +1,134 @@
+package routing
+
+import (
+	"Dp218GO/internal/validation"
+	"Dp218GO/repositories"
+	"Dp218GO/services"
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/gorilla/mux"
+)
+
+var passwordService *services.PasswordService
+
+var keyPasswordRoutes = []Route{
+	{
+		Uri:     `/password/change`,
+		Method:  http.MethodPost,
+		Handler: changePassword,
+	},
+}
+
+// AddPasswordHandler - add endpoints for password reset & change to http router,
+// reset endpoints are available without authentication
+func AddPasswordHandler(router *mux.Router, service *services.PasswordService) {
+	passwordService = service
+	for _, prefix := range []string{"", APIprefix} {
+		router.Path(prefix + "/password/forgot").HandlerFunc(forgotPassword).Methods(http.MethodPost)
+		router.Path(prefix + "/password/reset").HandlerFunc(showPasswordReset).Methods(http.MethodGet)
+		router.Path(prefix + "/password/reset").HandlerFunc(resetPassword).Methods(http.MethodPost)
+	}
+
+	passwordRouter := router.NewRoute().Subrouter()
+	passwordRouter.Use(FilterAuthOrToken(authenticationService, tokenService))
+
+	for _, rt := range keyPasswordRoutes {
+		passwordRouter.Path(rt.Uri).Handler(rt.authorized()).Methods(rt.Method)
+		passwordRouter.Path(APIprefix + rt.Uri).Handler(rt.authorized()).Methods(rt.Method)
+	}
+}
+
+// forgotPassword - sends reset link, the answer doesn't reveal if the email is registered
+func forgotPassword(w http.ResponseWriter, r *http.Request) {
+	format := GetFormatFromRequest(r)
+
+	valReq := validation.ForgotPasswordRequest{LoginEmail: r.FormValue("email")}
+	if err := valReq.Validate(); err != nil {
+		EncodeError(format, w, ErrorRendererDefault(err))
+		return
+	}
+
+	if err := passwordService.RequestReset(r.Context(), valReq.LoginEmail); err != nil {
+		log.Printf("password reset link for %s is not sent: %v", valReq.LoginEmail, err)
+	}
+
+	if format == FormatJSON {
+		w.WriteHeader(http.StatusAccepted)
+		return
+	}
+	http.Redirect(w, r, "/login", http.StatusFound)
+}
+
+func showPasswordReset(w http.ResponseWriter, r *http.Request) {
+	EncodeAnswer(FormatHTML, w, struct{ Token string }{r.URL.Query().Get("token")}, HTMLPath+"password-reset.html")
+}
+
+func resetPassword(w http.ResponseWriter, r *http.Request) {
+	format := GetFormatFromRequest(r)
+
+	valReq := validation.ResetPasswordRequest{
+		Token:    r.FormValue("token"),
+		Password: r.FormValue("password"),
+	}
+	if err := valReq.Validate(); err != nil {
+		EncodeError(format, w, ErrorRendererDefault(err))
+		return
+	}
+
+	err := passwordService.Reset(r.Context(), valReq.Token, valReq.Password)
+	if errors.Is(err, repositories.ErrNoPasswordReset) {
+		EncodeError(format, w, ErrorRenderer(err, "Link expired, request the new one", http.StatusGone))
+		return
+	}
+	if err != nil {
+		ServerErrorRender(format, w)
+		return
+	}
+
+	if format == FormatJSON {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	http.Redirect(w, r, "/login", http.StatusFound)
+}
+
+// changePassword - changes password of current user, other sessions & all api tokens of the user
+// become invalid, the session of this request is renewed
+func changePassword(w http.ResponseWriter, r *http.Request) {
+	format := GetFormatFromRequest(r)
+	user := GetUserFromContext(r)
+
+	valReq := validation.ChangePasswordRequest{
+		OldPassword: r.FormValue("old_password"),
+		NewPassword: r.FormValue("new_password"),
+	}
+	if err := valReq.Validate(); err != nil {
+		EncodeError(format, w, ErrorRendererDefault(err))
+		return
+	}
+
+	err := passwordService.Change(r.Context(), user.LoginEmail, valReq.OldPassword, valReq.NewPassword)
+	if errors.Is(err, services.ErrWrongPassword) {
+		EncodeError(format, w, ErrorRenderer(err, "Forbidden", http.StatusForbidden))
+		return
+	}
+	if err != nil {
+		ServerErrorRender(format, w)
+		return
+	}
+
+	if _, ok := bearerToken(r); !ok {
+		if err = authenticationService.RefreshSession(w, r, user); err != nil {
+			ServerErrorRender(format, w)
+			return
+		}
+	}
+
+	if format == FormatJSON {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	http.Redirect(w, r, "/home", http.StatusFound)
+}
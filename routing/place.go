@@ -0,0 +1,128 @@
+package routing
+
+import (
+	"Dp218GO/services"
+	"encoding/json"
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+)
+
+// maxImportSize - limit of the imported places file
+const maxImportSize = 10 << 20
+
+var placeService *services.PlaceService
+
+var keyPlaceRoutes = []Route{
+	{
+		Uri:     `/stations.geojson`,
+		Method:  http.MethodGet,
+		Handler: getStationsFeed,
+	},
+	{
+		Uri:     `/places/export`,
+		Method:  http.MethodGet,
+		Handler: exportPlaces,
+		Access:  AdminAccess | SupplierAccess,
+	},
+	{
+		Uri:     `/places/import`,
+		Method:  http.MethodPost,
+		Handler: importPlaces,
+		Access:  AdminAccess,
+	},
+}
+
+// AddPlaceHandler - add endpoints for bulk import & export of stations and locations to http router
+func AddPlaceHandler(router *mux.Router, service *services.PlaceService) {
+	placeService = service
+	placeRouter := router.NewRoute().Subrouter()
+	placeRouter.Use(FilterAuthOrToken(authenticationService, tokenService))
+
+	for _, rt := range keyPlaceRoutes {
+		placeRouter.Path(rt.Uri).Handler(rt.authorized()).Methods(rt.Method)
+		placeRouter.Path(APIprefix + rt.Uri).Handler(rt.authorized()).Methods(rt.Method)
+	}
+}
+
+// getStationsFeed - all stations as GeoJSON points which the map can show as they are
+func getStationsFeed(w http.ResponseWriter, r *http.Request) {
+	feed, err := placeService.StationsFeed(r.Context())
+	if err != nil {
+		ServerErrorRender(FormatJSON, w)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/geo+json")
+	json.NewEncoder(w).Encode(feed)
+}
+
+// exportPlaces - file with all stations and locations, geojson (default) or csv by format parameter
+func exportPlaces(w http.ResponseWriter, r *http.Request) {
+	format := r.FormValue("format")
+	if format == "" {
+		format = services.PlacesGeoJSON
+	}
+	if format != services.PlacesGeoJSON && format != services.PlacesCSV {
+		EncodeError(FormatJSON, w, ErrorRenderer(services.ErrPlacesFormat, "Bad request", http.StatusBadRequest))
+		return
+	}
+
+	contentType := "application/geo+json"
+	if format == services.PlacesCSV {
+		contentType = "text/csv"
+	}
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Disposition", "attachment; filename=places."+format)
+
+	if err := placeService.ExportPlaces(r.Context(), format, w); err != nil {
+		ServerErrorRender(FormatJSON, w)
+	}
+}
+
+// importPlaces - validates and stores the places from the request body or from the uploaded "file".
+// The format is taken from the format parameter or the content type, dry_run=true only reports the changes
+func importPlaces(w http.ResponseWriter, r *http.Request) {
+	r.Body = http.MaxBytesReader(w, r.Body, maxImportSize)
+	dryRun, _ := strconv.ParseBool(r.URL.Query().Get("dry_run"))
+	format := r.URL.Query().Get("format")
+
+	var body io.Reader = r.Body
+	contentType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if contentType == "multipart/form-data" {
+		file, header, err := r.FormFile("file")
+		if err != nil {
+			EncodeError(FormatJSON, w, ErrorRenderer(err, "Bad request", http.StatusBadRequest))
+			return
+		}
+		defer file.Close()
+		body = file
+		contentType = header.Header.Get("Content-Type")
+	}
+	if format == "" {
+		format = services.PlacesGeoJSON
+		if contentType == "text/csv" {
+			format = services.PlacesCSV
+		}
+	}
+
+	report, err := placeService.ImportPlaces(r.Context(), format, body, dryRun, currentUserID(r))
+	if errors.Is(err, services.ErrPlacesFormat) || errors.Is(err, services.ErrPlacesFile) {
+		EncodeError(FormatJSON, w, ErrorRenderer(err, "Bad request", http.StatusBadRequest))
+		return
+	}
+	if err != nil {
+		ServerErrorRender(FormatJSON, w)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if len(report.Errors) > 0 {
+		w.WriteHeader(http.StatusUnprocessableEntity)
+	}
+	json.NewEncoder(w).Encode(report)
+}
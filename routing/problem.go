@@ -39,6 +39,7 @@ var keyProblemRoutes = []Route{
 		Uri:     `/problem/{` + problemIDKey + `}/solution`,
 		Method:  http.MethodPost,
 		Handler: addProblemSolution,
+		Access:  AdminAccess,
 	},
 	{
 		Uri:     `/problem/{` + problemIDKey + `}/solution`,
@@ -73,11 +74,11 @@ func (pu *problemsForTemplate) DistinctProblemTypes() map[int]models.ProblemType
 func AddProblemHandler(router *mux.Router, problserv *services.ProblemService) {
 	problemService = problserv
 	problemRouter := router.NewRoute().Subrouter()
-	problemRouter.Use(FilterAuth(authenticationService))
+	problemRouter.Use(FilterAuthOrToken(authenticationService, tokenService))
 
 	for _, rt := range keyProblemRoutes {
-		problemRouter.Path(rt.Uri).HandlerFunc(rt.Handler).Methods(rt.Method)
-		problemRouter.Path(APIprefix + rt.Uri).HandlerFunc(rt.Handler).Methods(rt.Method)
+		problemRouter.Path(rt.Uri).Handler(rt.authorized()).Methods(rt.Method)
+		problemRouter.Path(APIprefix + rt.Uri).Handler(rt.authorized()).Methods(rt.Method)
 	}
 }
 
@@ -90,7 +91,7 @@ func getAllProblems(w http.ResponseWriter, r *http.Request) {
 
 	userID, err = GetParameterFromRequest(r, "UserID", utils.ConvertStringToInt())
 	if err == nil {
-		problems, err = problemService.GetProblemsByUserID(userID.(int))
+		problems, err = problemService.GetProblemsByUserID(r.Context(), userID.(int))
 		if err != nil {
 			ServerErrorRender(format, w)
 			return
@@ -100,7 +101,7 @@ func getAllProblems(w http.ResponseWriter, r *http.Request) {
 	if err != nil {
 		typeID, err = GetParameterFromRequest(r, "TypeID", utils.ConvertStringToInt())
 		if err == nil {
-			problems, err = problemService.GetProblemsByTypeID(typeID.(int))
+			problems, err = problemService.GetProblemsByTypeID(r.Context(), typeID.(int))
 			if err != nil {
 				ServerErrorRender(format, w)
 				return
@@ -113,7 +114,7 @@ func getAllProblems(w http.ResponseWriter, r *http.Request) {
 		if err == nil {
 			dateTo, err = GetParameterFromRequest(r, "DateTo", utils.ConvertStringToTime())
 			if err == nil {
-				problems, err = problemService.GetProblemsByTimePeriod(dateFrom.(time.Time), dateTo.(time.Time))
+				problems, err = problemService.GetProblemsByTimePeriod(r.Context(), dateFrom.(time.Time), dateTo.(time.Time))
 				if err != nil {
 					ServerErrorRender(format, w)
 					return
@@ -125,7 +126,7 @@ func getAllProblems(w http.ResponseWriter, r *http.Request) {
 	if err != nil {
 		isSolvedFilter, err = GetParameterFromRequest(r, "SolvedFilter", utils.ConvertStringToBool())
 		if err == nil {
-			problems, err = problemService.GetProblemsByBeingSolved(isSolvedFilter.(bool))
+			problems, err = problemService.GetProblemsByBeingSolved(r.Context(), isSolvedFilter.(bool))
 			if err != nil {
 				ServerErrorRender(format, w)
 				return
@@ -134,7 +135,7 @@ func getAllProblems(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if err != nil {
-		problems, err = problemService.GetProblemsByTimePeriod(time.Unix(0, 0), time.Now())
+		problems, err = problemService.GetProblemsByTimePeriod(r.Context(), time.Unix(0, 0), time.Now())
 		if err != nil {
 			ServerErrorRender(format, w)
 			return
@@ -151,7 +152,7 @@ func newProblem(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	problemTypes, err := problemService.GetAllProblemTypes()
+	problemTypes, err := problemService.GetAllProblemTypes(r.Context())
 	if err != nil {
 		EncodeError(FormatHTML, w, ErrorRendererDefault(err))
 		return
@@ -175,7 +176,7 @@ func getProblemInfo(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	problem, err := problemService.GetProblemByID(problemID)
+	problem, err := problemService.GetProblemByID(r.Context(), problemID)
 	if err != nil {
 		EncodeError(FormatHTML, w, ErrorRendererDefault(err))
 		return
@@ -189,7 +190,7 @@ func addProblem(w http.ResponseWriter, r *http.Request) {
 
 	problemData := models.Problem{}
 	DecodeRequest(format, w, r, &problemData, decodeProblemAddRequest)
-	err := problemService.AddNewProblem(&problemData)
+	err := problemService.AddNewProblem(r.Context(), &problemData)
 	if err != nil {
 		ServerErrorRender(format, w)
 		return
@@ -220,7 +221,7 @@ func decodeProblemAddRequest(r *http.Request, data interface{}) error {
 
 	problemData.Description = description.(string)
 	problemData.IsSolved = false
-	problemService.AddProblemComplexFields(problemData, typeID.(int), userID.(int))
+	problemService.AddProblemComplexFields(r.Context(), problemData, typeID.(int), userID.(int))
 
 	return err
 }
@@ -234,13 +235,13 @@ func getProblemSolution(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	problem, err := problemService.GetProblemByID(problemID)
+	problem, err := problemService.GetProblemByID(r.Context(), problemID)
 	if err != nil {
 		EncodeError(FormatHTML, w, ErrorRendererDefault(err))
 		return
 	}
 
-	solution, err := problemService.GetSolutionByProblem(problem)
+	solution, err := problemService.GetSolutionByProblem(r.Context(), problem)
 	if err != nil {
 		EncodeError(FormatHTML, w, ErrorRendererDefault(err))
 		return
@@ -261,7 +262,7 @@ func addProblemSolution(w http.ResponseWriter, r *http.Request) {
 	solutionData := models.Solution{}
 	solutionData.Problem = models.Problem{ID: problemID}
 	DecodeRequest(format, w, r, &solutionData, decodeSolutionAddRequest)
-	err = problemService.AddProblemSolution(solutionData.Problem.ID, &solutionData)
+	err = problemService.AddProblemSolution(r.Context(), solutionData.Problem.ID, &solutionData)
 	if err != nil {
 		ServerErrorRender(format, w)
 		return
@@ -285,11 +286,11 @@ func decodeSolutionAddRequest(r *http.Request, data interface{}) error {
 	if err != nil {
 		return err
 	}
-	problem, err := problemService.GetProblemByID(problemID.(int))
+	problem, err := problemService.GetProblemByID(r.Context(), problemID.(int))
 	if err != nil {
 		return err
 	}
 	solutionData.Problem = problem
 
-	return problemService.AddProblemSolution(problemID.(int), solutionData)
+	return problemService.AddProblemSolution(r.Context(), problemID.(int), solutionData)
 }
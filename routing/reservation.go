@@ -0,0 +1,116 @@
+package routing
+
+import (
+	"Dp218GO/repositories"
+	"Dp218GO/services"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+)
+
+var reservationService *services.ReservationService
+
+var keyReservationRoutes = []Route{
+	{
+		Uri:     `/reservation`,
+		Method:  http.MethodGet,
+		Handler: getReservation,
+		Access:  UserAccess | AdminAccess,
+	},
+	{
+		Uri:     `/reservation`,
+		Method:  http.MethodPost,
+		Handler: reserveScooter,
+		Access:  UserAccess | AdminAccess,
+	},
+	{
+		Uri:     `/reservation/extend`,
+		Method:  http.MethodPost,
+		Handler: extendReservation,
+		Access:  UserAccess | AdminAccess,
+	},
+	{
+		Uri:     `/reservation`,
+		Method:  http.MethodDelete,
+		Handler: releaseReservation,
+		Access:  UserAccess | AdminAccess,
+	},
+}
+
+// AddReservationHandler - add endpoints for scooter reservations to http router
+func AddReservationHandler(router *mux.Router, service *services.ReservationService) {
+	reservationService = service
+	reservationRouter := router.NewRoute().Subrouter()
+	reservationRouter.Use(FilterAuthOrToken(authenticationService, tokenService))
+
+	for _, rt := range keyReservationRoutes {
+		reservationRouter.Path(rt.Uri).Handler(rt.authorized()).Methods(rt.Method)
+		reservationRouter.Path(APIprefix + rt.Uri).Handler(rt.authorized()).Methods(rt.Method)
+	}
+}
+
+func getReservation(w http.ResponseWriter, r *http.Request) {
+	user := GetUserFromContext(r)
+
+	reservation, err := reservationService.GetReservation(r.Context(), user.ID)
+	if err != nil {
+		EncodeError(FormatJSON, w, reservationErrorRenderer(err))
+		return
+	}
+
+	EncodeAnswer(FormatJSON, w, reservation)
+}
+
+func reserveScooter(w http.ResponseWriter, r *http.Request) {
+	user := GetUserFromContext(r)
+
+	scooterID, err := strconv.Atoi(r.FormValue(scooterIDKey))
+	if err != nil {
+		EncodeError(FormatJSON, w, ErrorRendererDefault(err))
+		return
+	}
+
+	reservation, err := reservationService.Reserve(r.Context(), user.ID, scooterID)
+	if err != nil {
+		EncodeError(FormatJSON, w, reservationErrorRenderer(err))
+		return
+	}
+
+	EncodeAnswer(FormatJSON, w, reservation)
+}
+
+func extendReservation(w http.ResponseWriter, r *http.Request) {
+	user := GetUserFromContext(r)
+
+	reservation, err := reservationService.Extend(r.Context(), user.ID)
+	if err != nil {
+		EncodeError(FormatJSON, w, reservationErrorRenderer(err))
+		return
+	}
+
+	EncodeAnswer(FormatJSON, w, reservation)
+}
+
+func releaseReservation(w http.ResponseWriter, r *http.Request) {
+	user := GetUserFromContext(r)
+
+	err := reservationService.Release(r.Context(), user.ID)
+	if err != nil {
+		EncodeError(FormatJSON, w, reservationErrorRenderer(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+func reservationErrorRenderer(err error) *ResponseStatus {
+	switch {
+	case errors.Is(err, repositories.ErrNoReservation):
+		return ErrorRenderer(err, "Not found", http.StatusNotFound)
+	case errors.Is(err, repositories.ErrScooterNotAvailable), errors.Is(err, services.ErrReservationExists):
+		return ErrorRenderer(err, "Conflict", http.StatusConflict)
+	}
+	return ErrorRenderer(err, "Internal server error", http.StatusInternalServerError)
+}
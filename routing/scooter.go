@@ -2,25 +2,31 @@ package routing
 
 import (
 	"Dp218GO/models"
+	"Dp218GO/repositories"
 	"Dp218GO/services"
+	"context"
+	"errors"
 	"fmt"
 	"github.com/gorilla/mux"
 	"net/http"
 	"strconv"
+	"time"
 )
 
+// closeTripTimeout - time limit to close the trip after the scooter has stopped
+const closeTripTimeout = 5 * time.Second
+
 var scooterService *services.ScooterService
 var scooterGrpcService *services.GrpcScooterService
 var orderService *services.OrderService
 var scooterIDKey = "scooterId"
 
-var chosenScooterID, chosenStationID int
-
 var scooterRoutes = []Route{
 	{
 		Uri:     `/scooters`,
 		Method:  http.MethodGet,
 		Handler: getAllScooters,
+		Access:  AdminAccess | SupplierAccess,
 	},
 	{
 		Uri:     `/scooter/{` + scooterIDKey + `}`,
@@ -31,21 +37,32 @@ var scooterRoutes = []Route{
 		Uri:     `/start-trip/{` + stationIDKey + `}`,
 		Method:  http.MethodGet,
 		Handler: showTripPage,
+		Access:  UserAccess | AdminAccess,
 	},
 	{
-		Uri:     `/run`,
-		Method:  http.MethodGet,
-		Handler: startScooterTrip,
+		Uri:         `/run`,
+		Method:      http.MethodGet,
+		Handler:     startScooterTrip,
+		Access:      UserAccess | AdminAccess,
+		LongRunning: true,
 	},
 	{
 		Uri:     `/choose-scooter`,
 		Method:  http.MethodPost,
 		Handler: ChooseScooter,
+		Access:  UserAccess | AdminAccess,
 	},
 	{
 		Uri:     `/choose-station`,
 		Method:  http.MethodPost,
 		Handler: ChooseStation,
+		Access:  UserAccess | AdminAccess,
+	},
+	{
+		Uri:     `/choose-destination`,
+		Method:  http.MethodPost,
+		Handler: ChooseDestination,
+		Access:  UserAccess | AdminAccess,
 	},
 }
 
@@ -58,11 +75,11 @@ type combineForTemplate struct {
 func AddScooterHandler(router *mux.Router, service *services.ScooterService) {
 	scooterService = service
 	scooterRouter := router.NewRoute().Subrouter()
-	scooterRouter.Use(FilterAuth(authenticationService))
+	scooterRouter.Use(FilterAuthOrToken(authenticationService, tokenService))
 
 	for _, rt := range scooterRoutes {
-		scooterRouter.Path(rt.Uri).HandlerFunc(rt.Handler).Methods(rt.Method)
-		scooterRouter.Path(APIprefix + rt.Uri).HandlerFunc(rt.Handler).Methods(rt.Method)
+		scooterRouter.Path(rt.Uri).Handler(rt.authorized()).Methods(rt.Method)
+		scooterRouter.Path(APIprefix + rt.Uri).Handler(rt.authorized()).Methods(rt.Method)
 	}
 }
 
@@ -70,16 +87,16 @@ func AddScooterHandler(router *mux.Router, service *services.ScooterService) {
 func AddGrpcScooterHandler(router *mux.Router, service *services.GrpcScooterService) {
 	scooterGrpcService = service
 	scooterGrpcRouter := router.NewRoute().Subrouter()
-	scooterGrpcRouter.Use(FilterAuth(authenticationService))
+	scooterGrpcRouter.Use(FilterAuthOrToken(authenticationService, tokenService))
 
 	for _, rt := range scooterRoutes {
-		router.Path(rt.Uri).HandlerFunc(rt.Handler).Methods(rt.Method)
-		router.Path(APIprefix + rt.Uri).HandlerFunc(rt.Handler).Methods(rt.Method)
+		scooterGrpcRouter.Path(rt.Uri).Handler(rt.authorized()).Methods(rt.Method)
+		scooterGrpcRouter.Path(APIprefix + rt.Uri).Handler(rt.authorized()).Methods(rt.Method)
 	}
 }
 
 func getAllScooters(w http.ResponseWriter, r *http.Request) {
-	scooters, err := scooterService.GetAllScooters()
+	scooters, err := scooterService.GetAllScooters(r.Context())
 
 	if err != nil {
 		ServerErrorRender(FormatJSON, w)
@@ -97,7 +114,7 @@ func getScooterById(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	scooter, err := scooterService.GetScooterById(scooterID)
+	scooter, err := scooterService.GetScooterById(r.Context(), scooterID)
 	if err != nil {
 		EncodeError(FormatJSON, w, ErrorRendererDefault(err))
 		return
@@ -109,36 +126,89 @@ func getScooterById(w http.ResponseWriter, r *http.Request) {
 func startScooterTrip(w http.ResponseWriter, r *http.Request) {
 	userFromRequest := GetUserFromContext(r)
 
-	statusStart, err := scooterService.CreateScooterStatusInRent(chosenScooterID)
+	err := accountService.CheckTripDeposit(r.Context(), *userFromRequest)
+	if err != nil {
+		EncodeError(FormatJSON, w, ErrorRenderer(err, "Payment required", http.StatusPaymentRequired))
+		return
+	}
+
+	reservation, err := reservationService.GetReservation(r.Context(), userFromRequest.ID)
+	if err != nil {
+		EncodeError(FormatJSON, w, ErrorRenderer(fmt.Errorf("choose the scooter first: %w", err), "Conflict",
+			http.StatusConflict))
+		return
+	}
+	scooterID := reservation.ScooterID
+	if reservation.Destination == nil {
+		EncodeError(FormatJSON, w, ErrorRenderer(errors.New("choose the destination first"), "Conflict",
+			http.StatusConflict))
+		return
+	}
+
+	statusStart, err := scooterService.CreateScooterStatusInRent(r.Context(), scooterID)
 	if err != nil {
 		fmt.Println(err)
+		ServerErrorRender(FormatJSON, w)
+		return
 	}
 
-	err = scooterGrpcService.InitAndRun(chosenScooterID, chosenStationID)
+	order, err := orderService.StartOrder(r.Context(), *userFromRequest, scooterID, statusStart.ID)
 	if err != nil {
 		fmt.Println(err)
-		EncodeError(FormatJSON, w, ErrorRendererDefault(err))
+		ServerErrorRender(FormatJSON, w)
+		return
 	}
 
-	statusEnd, err := scooterService.CreateScooterStatusInRent(chosenScooterID)
+	// the trip is closed even if the rider has gone, so the request context isn't used for it
+	closeCtx, cancel := context.WithTimeout(context.Background(), closeTripTimeout)
+	defer cancel()
 
-	distance := statusEnd.Location.Distance(statusStart.Location)
+	tripEnd, err := scooterGrpcService.InitAndRun(r.Context(), userFromRequest.ID, scooterID,
+		*reservation.Destination)
+	if err != nil {
+		// the scooter is parked where the run has stopped, the failed trip is not charged
+		if deleteErr := orderService.DeleteOrder(closeCtx, order.ID); deleteErr != nil {
+			fmt.Println(deleteErr)
+		}
+		fmt.Println(err)
+		switch {
+		case errors.Is(err, repositories.ErrStationFull):
+			EncodeError(FormatJSON, w, stationErrorRenderer(err))
+		case errors.Is(err, services.ErrParkingNotAllowed):
+			EncodeError(FormatJSON, w, zoneErrorRenderer(err))
+		default:
+			EncodeError(FormatJSON, w, ErrorRendererDefault(err))
+		}
+		return
+	}
 
-	_, err = orderService.CreateOrder(*userFromRequest, chosenScooterID, statusStart.ID, statusEnd.ID, distance)
+	statusEnd, err := scooterService.CreateScooterStatusInRent(closeCtx, scooterID)
 	if err != nil {
 		fmt.Println(err)
+		ServerErrorRender(FormatJSON, w)
+		return
 	}
+
+	order.StatusEndID = statusEnd.ID
+	err = orderService.CompleteOrder(closeCtx, &order, tripEnd.PenaltyCents)
+	if err != nil {
+		fmt.Println(err)
+		ServerErrorRender(FormatJSON, w)
+		return
+	}
+
+	EncodeAnswer(FormatJSON, w, tripEnd)
 }
 
 func showTripPage(w http.ResponseWriter, r *http.Request) {
 	stationID, err := strconv.Atoi(mux.Vars(r)[stationIDKey])
 
-	scooterList, err := scooterService.GetAllScootersByStationID(stationID)
+	scooterList, err := scooterService.GetAllScootersByStationID(r.Context(), stationID)
 	if err != nil {
 		fmt.Println(err)
 	}
 
-	stationList, err := stationService.GetAllStations()
+	stationList, err := stationService.GetAllStations(r.Context())
 	if err != nil {
 		fmt.Println(err)
 	}
@@ -146,6 +216,8 @@ func showTripPage(w http.ResponseWriter, r *http.Request) {
 	EncodeAnswer(FormatHTML, w, &combineForTemplate{*scooterList, *stationList}, HTMLPath+"scooter-run.html")
 }
 
+//ChooseScooter reserves the chosen scooter for the user. The scooter which the user held before is released,
+//the destination chosen for it is kept.
 func ChooseScooter(w http.ResponseWriter, r *http.Request) {
 	err := r.ParseForm()
 	if err != nil {
@@ -154,15 +226,42 @@ func ChooseScooter(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	chosenScooterID, err = strconv.Atoi(r.Form.Get("id"))
+	scooterID, err := strconv.Atoi(r.Form.Get("id"))
 	if err != nil {
 		w.WriteHeader(http.StatusBadRequest)
 		fmt.Println(err)
 		return
 	}
-	w.WriteHeader(http.StatusOK)
+
+	user := GetUserFromContext(r)
+	previous, err := reservationService.GetReservation(r.Context(), user.ID)
+	if err != nil && !errors.Is(err, repositories.ErrNoReservation) {
+		EncodeError(FormatJSON, w, reservationErrorRenderer(err))
+		return
+	}
+	err = reservationService.Release(r.Context(), user.ID)
+	if err != nil && !errors.Is(err, repositories.ErrNoReservation) {
+		EncodeError(FormatJSON, w, reservationErrorRenderer(err))
+		return
+	}
+
+	reservation, err := reservationService.Reserve(r.Context(), user.ID, scooterID)
+	if err != nil {
+		EncodeError(FormatJSON, w, reservationErrorRenderer(err))
+		return
+	}
+	if previous.Destination != nil {
+		reservation, err = reservationService.ChooseDestination(r.Context(), user.ID, *previous.Destination)
+		if err != nil {
+			EncodeError(FormatJSON, w, reservationErrorRenderer(err))
+			return
+		}
+	}
+
+	EncodeAnswer(FormatJSON, w, reservation)
 }
 
+//ChooseStation sets the station where the trip on the reserved scooter will be finished.
 func ChooseStation(w http.ResponseWriter, r *http.Request) {
 	err := r.ParseForm()
 	if err != nil {
@@ -171,11 +270,47 @@ func ChooseStation(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	chosenStationID, err = strconv.Atoi(r.Form.Get("id"))
+	stationID, err := strconv.Atoi(r.Form.Get("id"))
 	if err != nil {
 		w.WriteHeader(http.StatusBadRequest)
 		fmt.Println(err)
 		return
 	}
-	w.WriteHeader(http.StatusOK)
+	chooseDestination(w, r, models.TripDestination{StationID: stationID})
+}
+
+//ChooseDestination sets the point out of the stations where the trip on the reserved scooter will be finished.
+//The point is refused if the parking isn't allowed there.
+func ChooseDestination(w http.ResponseWriter, r *http.Request) {
+	point, err := decodePoint(r)
+	if err != nil {
+		EncodeError(FormatJSON, w, ErrorRenderer(err, "Bad request", http.StatusBadRequest))
+		return
+	}
+
+	err = zoneService.CheckParking(r.Context(), point)
+	if err != nil {
+		EncodeError(FormatJSON, w, zoneErrorRenderer(err))
+		return
+	}
+
+	chooseDestination(w, r, models.TripDestination{Location: point})
+}
+
+//chooseDestination stores the destination on the reservation of the user, so the trips of different users
+//don't mix their destinations.
+func chooseDestination(w http.ResponseWriter, r *http.Request, destination models.TripDestination) {
+	user := GetUserFromContext(r)
+	reservation, err := reservationService.ChooseDestination(r.Context(), user.ID, destination)
+	if errors.Is(err, repositories.ErrNoReservation) {
+		EncodeError(FormatJSON, w, ErrorRenderer(fmt.Errorf("choose the scooter first: %w", err), "Conflict",
+			http.StatusConflict))
+		return
+	}
+	if err != nil {
+		EncodeError(FormatJSON, w, reservationErrorRenderer(err))
+		return
+	}
+
+	EncodeAnswer(FormatJSON, w, reservation)
 }
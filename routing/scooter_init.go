@@ -16,11 +16,13 @@ var scooterInitRoutes = []Route{
 		Uri:     `/init`,
 		Method:  http.MethodGet,
 		Handler: getAllocationData,
+		Access:  SupplierAccess | AdminAccess,
 	},
 	{
 		Uri:     `/transfer`,
 		Method:  http.MethodPost,
 		Handler: addStatusesToScooters,
+		Access:  SupplierAccess | AdminAccess,
 	},
 }
 
@@ -28,11 +30,11 @@ var scooterInitRoutes = []Route{
 func AddScooterInitHandler(router *mux.Router, service *services.ScooterInitService) {
 	scooterInitService = service
 	scooterInitRouter := router.NewRoute().Subrouter()
-	scooterInitRouter.Use(FilterAuth(authenticationService))
+	scooterInitRouter.Use(FilterAuthOrToken(authenticationService, tokenService))
 
 	for _, rt := range scooterInitRoutes {
-		scooterInitRouter.Path(rt.Uri).HandlerFunc(rt.Handler).Methods(rt.Method)
-		scooterInitRouter.Path(APIprefix + rt.Uri).HandlerFunc(rt.Handler).Methods(rt.Method)
+		scooterInitRouter.Path(rt.Uri).Handler(rt.authorized()).Methods(rt.Method)
+		scooterInitRouter.Path(APIprefix + rt.Uri).Handler(rt.authorized()).Methods(rt.Method)
 	}
 }
 
@@ -46,7 +48,7 @@ func getAllocationData(w http.ResponseWriter, r *http.Request) {
 		fmt.Println(err)
 		return
 	}
-	dataAllocation = scooterInitService.ConvertForTemplateStruct()
+	dataAllocation = scooterInitService.ConvertForTemplateStruct(r.Context(), *GetUserFromContext(r))
 
 	EncodeAnswer(format, w, dataAllocation, HTMLPath+"scooter-init.html")
 }
@@ -73,10 +75,11 @@ func addStatusesToScooters(w http.ResponseWriter, r *http.Request) {
 		intScooterIds = append(intScooterIds, intId)
 	}
 
-	stationData, err := stationService.GetStationById(intStationId)
+	stationData, err := stationService.GetStationById(r.Context(), intStationId)
 
-	err = scooterInitService.AddStatusesToScooters(intScooterIds, stationData)
+	err = scooterInitService.AddStatusesToScooters(r.Context(), *GetUserFromContext(r), intScooterIds, stationData)
 	if err != nil {
+		EncodeError(GetFormatFromRequest(r), w, supplierErrorRenderer(err))
 		return
 	}
 
@@ -0,0 +1,138 @@
+package routing
+
+import (
+	"Dp218GO/repositories"
+	"Dp218GO/services"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+)
+
+var loginAuditService *services.LoginAuditService
+var sessionIDKey = "sessionID"
+
+// ErrCurrentSession - error returned if user tries to revoke the session of the request itself
+var ErrCurrentSession = errors.New("current session can be closed by sign out only")
+
+var keySessionRoutes = []Route{
+	{
+		Uri:     `/sessions`,
+		Method:  http.MethodGet,
+		Handler: getSessions,
+	},
+	{
+		Uri:     `/sessions/revoke-others`,
+		Method:  http.MethodPost,
+		Handler: revokeOtherSessions,
+	},
+	{
+		Uri:     `/session/{` + sessionIDKey + `}`,
+		Method:  http.MethodDelete,
+		Handler: revokeSession,
+	},
+	{
+		Uri:     `/user/{` + userIDKey + `}/logins`,
+		Method:  http.MethodGet,
+		Handler: getUserLogins,
+		Access:  AdminAccess,
+	},
+}
+
+// AddSessionHandler - add endpoints for user sessions & login history to http router
+func AddSessionHandler(router *mux.Router, service *services.LoginAuditService) {
+	loginAuditService = service
+	sessionRouter := router.NewRoute().Subrouter()
+	sessionRouter.Use(FilterAuthOrToken(authenticationService, tokenService))
+
+	for _, rt := range keySessionRoutes {
+		sessionRouter.Path(rt.Uri).Handler(rt.authorized()).Methods(rt.Method)
+		sessionRouter.Path(APIprefix + rt.Uri).Handler(rt.authorized()).Methods(rt.Method)
+	}
+}
+
+// getSessions - shows active sessions of current user, the session of this request is marked
+func getSessions(w http.ResponseWriter, r *http.Request) {
+	format := GetFormatFromRequest(r)
+	user := GetUserFromContext(r)
+
+	sessions, err := loginAuditService.Sessions(r.Context(), user.ID, authenticationService.CurrentSessionID(r))
+	if err != nil {
+		ServerErrorRender(format, w)
+		return
+	}
+
+	EncodeAnswer(format, w, sessions, HTMLPath+"sessions.html")
+}
+
+// revokeOtherSessions - signs out current user everywhere except the session of this request
+func revokeOtherSessions(w http.ResponseWriter, r *http.Request) {
+	format := GetFormatFromRequest(r)
+	user := GetUserFromContext(r)
+
+	err := loginAuditService.RevokeOtherSessions(r.Context(), user.ID, authenticationService.CurrentSessionID(r))
+	if err != nil {
+		ServerErrorRender(format, w)
+		return
+	}
+
+	if format == FormatJSON {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	http.Redirect(w, r, "/sessions", http.StatusFound)
+}
+
+// revokeSession - signs out one of the other sessions of current user
+func revokeSession(w http.ResponseWriter, r *http.Request) {
+	format := GetFormatFromRequest(r)
+	user := GetUserFromContext(r)
+
+	sessionID, err := strconv.Atoi(mux.Vars(r)[sessionIDKey])
+	if err != nil {
+		EncodeError(format, w, ErrorRendererDefault(err))
+		return
+	}
+	if sessionID == authenticationService.CurrentSessionID(r) {
+		EncodeError(format, w, ErrorRendererDefault(ErrCurrentSession))
+		return
+	}
+
+	err = loginAuditService.RevokeSession(r.Context(), user.ID, sessionID)
+	if errors.Is(err, repositories.ErrNoSession) {
+		EncodeError(format, w, ErrorRenderer(err, "Not Found", http.StatusNotFound))
+		return
+	}
+	if err != nil {
+		ServerErrorRender(format, w)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// getUserLogins - login history of the user for admin, the number of events is set by limit parameter
+func getUserLogins(w http.ResponseWriter, r *http.Request) {
+	userID, err := strconv.Atoi(mux.Vars(r)[userIDKey])
+	if err != nil {
+		EncodeError(FormatJSON, w, ErrorRendererDefault(err))
+		return
+	}
+
+	var limit int
+	if limitParam := r.URL.Query().Get("limit"); limitParam != "" {
+		if limit, err = strconv.Atoi(limitParam); err != nil {
+			EncodeError(FormatJSON, w, ErrorRendererDefault(err))
+			return
+		}
+	}
+
+	events, err := loginAuditService.History(r.Context(), userID, limit)
+	if err != nil {
+		ServerErrorRender(FormatJSON, w)
+		return
+	}
+
+	EncodeAnswer(FormatJSON, w, events)
+}
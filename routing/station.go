@@ -2,9 +2,13 @@ package routing
 
 import (
 	"Dp218GO/models"
+	"Dp218GO/repositories"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"strconv"
+	"time"
 
 	"Dp218GO/services"
 	"github.com/gorilla/mux"
@@ -12,6 +16,10 @@ import (
 
 var stationService *services.StationService
 var stationIDKey = "stationID"
+var statusChangeIDKey = "changeID"
+
+// statusFormLayout - layout of the datetime-local input of the status change form
+const statusFormLayout = "2006-01-02T15:04"
 
 var keyRoutesStation = []Route{
 	{
@@ -28,29 +36,60 @@ var keyRoutesStation = []Route{
 		Uri:     `/station`,
 		Method:  http.MethodPost,
 		Handler: createStation,
+		Access:  AdminAccess,
 	},
 	{
 		Uri:     `/station/{` + stationIDKey + `}`,
 		Method:  http.MethodDelete,
 		Handler: deleteStation,
+		Access:  AdminAccess,
 	},
 	{
 		Uri:     `/stations`,
 		Method:  http.MethodPost,
 		Handler: allStationsOperation,
+		Access:  AdminAccess,
 	},
 	{
 		Uri:     `/station/{` + stationIDKey + `}`,
 		Method:  http.MethodPost,
 		Handler: UpdateStation,
+		Access:  AdminAccess,
+	},
+	{
+		Uri:     `/station/{` + stationIDKey + `}/status`,
+		Method:  http.MethodPost,
+		Handler: changeStationStatus,
+		Access:  AdminAccess,
+	},
+	{
+		Uri:     `/station/{` + stationIDKey + `}/history`,
+		Method:  http.MethodGet,
+		Handler: getStationHistory,
+		Access:  AdminAccess,
+	},
+	{
+		Uri:     `/station/{` + stationIDKey + `}/status/{` + statusChangeIDKey + `}`,
+		Method:  http.MethodDelete,
+		Handler: cancelStationStatusChange,
+		Access:  AdminAccess,
 	},
 }
 
+// stationPage - station with its status history shown on the edit page
+type stationPage struct {
+	models.Station
+	History []models.StationStatusChange `json:"history"`
+}
+
 func AddStationHandler(router *mux.Router, service *services.StationService) {
 	stationService = service
+	stationRouter := router.NewRoute().Subrouter()
+	stationRouter.Use(FilterAuthOrToken(authenticationService, tokenService))
+
 	for _, rt := range keyRoutesStation {
-		router.Path(rt.Uri).HandlerFunc(rt.Handler).Methods(rt.Method)
-		router.Path(APIprefix + rt.Uri).HandlerFunc(rt.Handler).Methods(rt.Method)
+		stationRouter.Path(rt.Uri).Handler(rt.authorized()).Methods(rt.Method)
+		stationRouter.Path(APIprefix + rt.Uri).Handler(rt.authorized()).Methods(rt.Method)
 	}
 }
 
@@ -60,8 +99,8 @@ func createStation(w http.ResponseWriter, r *http.Request) {
 	station := &models.Station{}
 	DecodeRequest(format, w, r, station, nil)
 
-	if err := stationService.AddStation(station); err != nil {
-		EncodeError(format, w, ErrorRendererDefault(err))
+	if err := stationService.AddStation(r.Context(), station); err != nil {
+		EncodeError(format, w, stationErrorRenderer(err))
 		return
 	}
 
@@ -73,7 +112,7 @@ func GetAllStations(w http.ResponseWriter, r *http.Request) {
 	var err error
 	format := GetFormatFromRequest(r)
 
-	station, err = stationService.GetAllStations()
+	station, err = stationService.GetAllStations(r.Context())
 	if err != nil {
 		ServerErrorRender(format, w)
 		return
@@ -90,13 +129,28 @@ func getStation(w http.ResponseWriter, r *http.Request) {
 		EncodeError(format, w, ErrorRendererDefault(err))
 		return
 	}
-	station, err := stationService.GetStationById(stationId)
+	station, err := stationService.GetStationById(r.Context(), stationId)
 	if err != nil {
-		EncodeError(format, w, ErrorRendererDefault(err))
+		EncodeError(format, w, stationErrorRenderer(err))
+		return
+	}
+
+	renderStation(format, w, r, station)
+}
+
+// renderStation - the station as is for JSON, the edit page with the status history for HTML
+func renderStation(format int, w http.ResponseWriter, r *http.Request, station models.Station) {
+	if format == FormatJSON {
+		EncodeAnswer(format, w, station)
 		return
 	}
 
-	EncodeAnswer(format, w, station, HTMLPath+"station-edit.html")
+	history, err := stationService.StatusHistory(r.Context(), station.ID)
+	if err != nil {
+		ServerErrorRender(format, w)
+		return
+	}
+	EncodeAnswer(format, w, stationPage{Station: station, History: history.Changes}, HTMLPath+"station-edit.html")
 }
 
 func deleteStation(w http.ResponseWriter, r *http.Request) {
@@ -107,9 +161,17 @@ func deleteStation(w http.ResponseWriter, r *http.Request) {
 		EncodeError(format, w, ErrorRendererDefault(err))
 		return
 	}
-	err = stationService.DeleteStation(stationId)
+	var moveTo int
+	if value := r.URL.Query().Get("move_to"); value != "" {
+		if moveTo, err = strconv.Atoi(value); err != nil {
+			EncodeError(format, w, ErrorRendererDefault(err))
+			return
+		}
+	}
+
+	err = stationService.DeleteStation(r.Context(), stationId, moveTo)
 	if err != nil {
-		ServerErrorRender(format, w)
+		EncodeError(format, w, stationErrorRenderer(err))
 		return
 	}
 	EncodeAnswer(format, w, ErrorRenderer(fmt.Errorf(""), "success", http.StatusOK))
@@ -125,17 +187,22 @@ func allStationsOperation(w http.ResponseWriter, r *http.Request) {
 	}
 	actionType := r.FormValue("ActionType")
 	switch actionType {
-	case "BlockStation":
+	case "ChangeStatus":
 		stationId, err := strconv.Atoi(r.FormValue("stationID"))
 		if err != nil {
 			EncodeError(format, w, ErrorRendererDefault(err))
 			return
 		}
-		err = stationService.ChangeStationBlockStatus(stationId)
-		if err != nil {
+		change := &models.StationStatusChange{StationID: stationId}
+		if err = decodeStatusChangeForm(r, change); err != nil {
 			EncodeError(format, w, ErrorRendererDefault(err))
 			return
 		}
+		err = stationService.ChangeStatus(r.Context(), change)
+		if err != nil {
+			EncodeError(format, w, stationErrorRenderer(err))
+			return
+		}
 	default:
 		EncodeError(format, w, ErrorRendererDefault(fmt.Errorf("unknown users operation")))
 	}
@@ -150,27 +217,122 @@ func UpdateStation(w http.ResponseWriter, r *http.Request) {
 		EncodeError(format, w, ErrorRendererDefault(err))
 		return
 	}
-	stationData, err := stationService.GetStationById(stationId)
+	stationData, err := stationService.GetStationById(r.Context(), stationId)
 	if err != nil {
-		EncodeError(format, w, ErrorRendererDefault(err))
+		EncodeError(format, w, stationErrorRenderer(err))
 		return
 	}
 	DecodeRequest(format, w, r, &stationData, DecodeStationUpdateRequest)
-	stationData, err = stationService.UpdateStation(stationId, stationData)
+	stationData, err = stationService.UpdateStation(r.Context(), stationId, stationData)
+	if errors.Is(err, services.ErrInvalidCapacity) {
+		EncodeError(format, w, stationErrorRenderer(err))
+		return
+	}
 	if err != nil {
 		ServerErrorRender(format, w)
 		return
 	}
 
-	EncodeAnswer(format, w, stationData, HTMLPath+"station-edit.html")
+	renderStation(format, w, r, stationData)
+}
+
+// changeStationStatus - changes the status of the station at once or at effective_at, the change is made by
+// the current user
+func changeStationStatus(w http.ResponseWriter, r *http.Request) {
+	format := GetFormatFromRequest(r)
+
+	stationId, err := strconv.Atoi(mux.Vars(r)[stationIDKey])
+	if err != nil {
+		EncodeError(format, w, ErrorRendererDefault(err))
+		return
+	}
+
+	change := &models.StationStatusChange{}
+	if format == FormatJSON {
+		err = json.NewDecoder(r.Body).Decode(change)
+	} else {
+		err = decodeStatusChangeForm(r, change)
+	}
+	if err != nil {
+		EncodeError(format, w, ErrorRenderer(err, "Bad request", http.StatusBadRequest))
+		return
+	}
+	change.StationID, change.ChangedBy = stationId, currentUserID(r)
+
+	if err = stationService.ChangeStatus(r.Context(), change); err != nil {
+		EncodeError(format, w, stationErrorRenderer(err))
+		return
+	}
+
+	if format == FormatJSON {
+		EncodeAnswer(format, w, change)
+		return
+	}
+	http.Redirect(w, r, fmt.Sprintf("/station/%d", stationId), http.StatusSeeOther)
+}
+
+func getStationHistory(w http.ResponseWriter, r *http.Request) {
+	stationId, err := strconv.Atoi(mux.Vars(r)[stationIDKey])
+	if err != nil {
+		EncodeError(FormatJSON, w, ErrorRendererDefault(err))
+		return
+	}
+
+	history, err := stationService.StatusHistory(r.Context(), stationId)
+	if err != nil {
+		ServerErrorRender(FormatJSON, w)
+		return
+	}
+
+	EncodeAnswer(FormatJSON, w, history)
+}
+
+func cancelStationStatusChange(w http.ResponseWriter, r *http.Request) {
+	stationId, err := strconv.Atoi(mux.Vars(r)[stationIDKey])
+	if err != nil {
+		EncodeError(FormatJSON, w, ErrorRendererDefault(err))
+		return
+	}
+	changeId, err := strconv.Atoi(mux.Vars(r)[statusChangeIDKey])
+	if err != nil {
+		EncodeError(FormatJSON, w, ErrorRendererDefault(err))
+		return
+	}
+
+	if err = stationService.CancelStatusChange(r.Context(), stationId, changeId); err != nil {
+		EncodeError(FormatJSON, w, stationErrorRenderer(err))
+		return
+	}
+
+	EncodeAnswer(FormatJSON, w, ErrorRenderer(fmt.Errorf(""), "success", http.StatusOK))
+}
+
+// currentUserID - ID of the user making the request, 0 if it's unknown
+func currentUserID(r *http.Request) int {
+	if user := GetUserFromContext(r); user != nil {
+		return user.ID
+	}
+	return 0
+}
+
+// decodeStatusChangeForm - reads Status, Reason & optional EffectiveAt of the form, the author is the current user
+func decodeStatusChangeForm(r *http.Request, change *models.StationStatusChange) error {
+	change.Status = r.FormValue("Status")
+	change.Reason = r.FormValue("Reason")
+	change.ChangedBy = currentUserID(r)
+
+	effectiveAt := r.FormValue("EffectiveAt")
+	if effectiveAt == "" {
+		return nil
+	}
+	var err error
+	change.EffectiveAt, err = time.ParseInLocation(statusFormLayout, effectiveAt, time.Local)
+	return err
 }
 
 func DecodeStationUpdateRequest(r *http.Request, data interface{}) error {
 	r.ParseForm()
 	stationData := data.(*models.Station)
-	if _, ok := r.Form["IsActive"]; ok {
-		stationData.IsActive, _ = strconv.ParseBool(r.FormValue("IsActive"))
-	}
 	if _, ok := r.Form["Name"]; ok {
 		stationData.Name = r.FormValue("Name")
 	}
@@ -180,5 +342,27 @@ func DecodeStationUpdateRequest(r *http.Request, data interface{}) error {
 	if _, ok := r.Form["Longitude"]; ok {
 		stationData.Longitude, _ = strconv.ParseFloat(r.FormValue("Longitude"), 64)
 	}
+	if _, ok := r.Form["Capacity"]; ok {
+		capacity, err := strconv.Atoi(r.FormValue("Capacity"))
+		if err != nil {
+			return err
+		}
+		stationData.Capacity = capacity
+	}
 	return nil
 }
+
+// stationErrorRenderer - full station and station with scooters are reported as conflict,
+// unknown station or status change as not found and wrong station data as bad request
+func stationErrorRenderer(err error) *ResponseStatus {
+	switch {
+	case errors.Is(err, repositories.ErrStationFull), errors.Is(err, repositories.ErrStationInUse):
+		return ErrorRenderer(err, "Conflict", http.StatusConflict)
+	case errors.Is(err, repositories.ErrNoStation), errors.Is(err, repositories.ErrNoStatusChange):
+		return ErrorRenderer(err, "Not found", http.StatusNotFound)
+	case errors.Is(err, services.ErrInvalidCapacity), errors.Is(err, services.ErrInvalidStatus),
+		errors.Is(err, services.ErrReasonRequired), errors.Is(err, services.ErrInvalidMove):
+		return ErrorRenderer(err, "Bad request", http.StatusBadRequest)
+	}
+	return ErrorRendererDefault(err)
+}
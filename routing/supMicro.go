@@ -16,16 +16,19 @@ var keySupMicroRoutes = []Route{
 		Uri:     `/mStations`,
 		Method:  http.MethodGet,
 		Handler: getTempData,
+		Access:  SupplierAccess | AdminAccess,
 	},
 	{
 		Uri:     `/microAddStation`,
 		Method:  http.MethodPost,
 		Handler: createMicroStation,
+		Access:  SupplierAccess | AdminAccess,
 	},
 	{
 		Uri:     `/microASl`,
 		Method:  http.MethodPost,
 		Handler: createMicroStationInLocation,
+		Access:  SupplierAccess | AdminAccess,
 	},
 }
 
@@ -33,10 +36,11 @@ var keySupMicroRoutes = []Route{
 func AddSupMicroHandler(router *mux.Router, supserv *services.SupMicroService) {
 	supMicroService = supserv
 	supMicroRouter := router.NewRoute().Subrouter()
+	supMicroRouter.Use(FilterAuthOrToken(authenticationService, tokenService))
 
 	for _, rt := range keySupMicroRoutes {
-		supMicroRouter.Path(rt.Uri).HandlerFunc(rt.Handler).Methods(rt.Method)
-		supMicroRouter.Path(APIprefix + rt.Uri).HandlerFunc(rt.Handler).Methods(rt.Method)
+		supMicroRouter.Path(rt.Uri).Handler(rt.authorized()).Methods(rt.Method)
+		supMicroRouter.Path(APIprefix + rt.Uri).Handler(rt.authorized()).Methods(rt.Method)
 	}
 }
 
@@ -47,13 +51,13 @@ func getTempData(w http.ResponseWriter, r *http.Request) {
 	var location = &models.LocationList{}
 	format := GetFormatFromRequest(r)
 
-	station, err = stationService.GetAllStations()
+	station, err = stationService.GetAllStations(r.Context())
 	if err != nil {
 		ServerErrorRender(format, w)
 		return
 	}
 
-	location, err = supMicroService.GetLocations()
+	location, err = supMicroService.GetLocations(r.Context())
 	if err != nil {
 		ServerErrorRender(format, w)
 		return
@@ -71,7 +75,7 @@ func createMicroStationInLocation(w http.ResponseWriter, r *http.Request) {
 	format := GetFormatFromRequest(r)
 	stationData := models.Station{}
 	locationData := models.Location{}
-	err := supplierMicroService.CreateStationInLocation(&locationData, &stationData)
+	err := supplierMicroService.CreateStationInLocation(r.Context(), &locationData, &stationData)
 	if err != nil {
 		ServerErrorRender(format, w)
 		return
@@ -102,7 +106,7 @@ func createMicroStation(w http.ResponseWriter, r *http.Request) {
 	stationData.Latitude = fLatitude
 	stationData.Longitude = fLongitude
 
-	if err := supplierMicroService.AddNewStation(&stationData); err != nil {
+	if err := supplierMicroService.AddNewStation(r.Context(), &stationData); err != nil {
 		EncodeError(format, w, ErrorRendererDefault(err))
 		return
 	}
@@ -2,7 +2,9 @@ package routing
 
 import (
 	"Dp218GO/models"
+	"Dp218GO/repositories"
 	"Dp218GO/services"
+	"errors"
 	"fmt"
 	"github.com/gorilla/mux"
 	"io"
@@ -20,31 +22,37 @@ var supplierKeyRoutes = []Route{
 		Uri:     `/models`,
 		Method:  http.MethodGet,
 		Handler: getModels,
+		Access:  SupplierAccess | AdminAccess,
 	},
 	{
 		Uri:     `/models`,
 		Method:  http.MethodPost,
 		Handler: createModel,
+		Access:  SupplierAccess | AdminAccess,
 	},
 	{
 		Uri:     `/price/{id}`,
 		Method:  http.MethodPost,
 		Handler: editPrice,
+		Access:  SupplierAccess | AdminAccess,
 	},
 	{
 		Uri:     `/upload/{id}`,
 		Method:  http.MethodPost,
 		Handler: uploadFile,
+		Access:  SupplierAccess | AdminAccess,
 	},
 	{
 		Uri:     `/model/{id}`,
 		Method:  http.MethodPost,
 		Handler: addSuppliersScooter,
+		Access:  SupplierAccess | AdminAccess,
 	},
 	{
 		Uri:     `/delete/{id}`,
 		Method:  http.MethodPost,
 		Handler: deleteSuppliersScooter,
+		Access:  SupplierAccess | AdminAccess,
 	},
 }
 
@@ -58,11 +66,11 @@ type FileHeader struct {
 func AddSupplierHandler(router *mux.Router, service *services.SupplierService) {
 	supplierService = service
 	supplierRouter := router.NewRoute().Subrouter()
-	supplierRouter.Use(FilterAuth(authenticationService))
+	supplierRouter.Use(FilterAuthOrToken(authenticationService, tokenService))
 
 	for _, rt := range supplierKeyRoutes {
-		supplierRouter.Path(rt.Uri).HandlerFunc(rt.Handler).Methods(rt.Method)
-		supplierRouter.Path(APIprefix + rt.Uri).HandlerFunc(rt.Handler).Methods(rt.Method)
+		supplierRouter.Path(rt.Uri).Handler(rt.authorized()).Methods(rt.Method)
+		supplierRouter.Path(APIprefix + rt.Uri).Handler(rt.authorized()).Methods(rt.Method)
 	}
 }
 
@@ -76,7 +84,7 @@ func getModels(w http.ResponseWriter, r *http.Request) {
 		fmt.Println(err)
 		return
 	}
-	modelList, err = supplierService.GetModels()
+	modelList, err = supplierService.GetModels(r.Context(), *GetUserFromContext(r))
 	if err != nil {
 		ServerErrorRender(format, w)
 		return
@@ -116,7 +124,7 @@ func createModel(w http.ResponseWriter, r *http.Request) {
 	model.Speed = intSpeed
 	model.Price = intPrice
 
-	if err := supplierService.AddModel(model); err != nil {
+	if err := supplierService.AddModel(r.Context(), *GetUserFromContext(r), model); err != nil {
 		EncodeError(format, w, ErrorRendererDefault(err))
 		return
 	}
@@ -143,7 +151,8 @@ func editPrice(w http.ResponseWriter, r *http.Request) {
 		EncodeError(format, w, ErrorRendererDefault(err))
 		return
 	}
-	modelData, err := supplierService.SelectModel(modelId)
+	owner := GetUserFromContext(r)
+	modelData, err := supplierService.SelectModel(r.Context(), *owner, modelId)
 	if err != nil {
 		EncodeError(format, w, ErrorRendererDefault(err))
 		return
@@ -152,7 +161,7 @@ func editPrice(w http.ResponseWriter, r *http.Request) {
 	model = modelData
 	model.Price = intPrice
 
-	if err := supplierService.ChangePrice(model); err != nil {
+	if err := supplierService.ChangePrice(r.Context(), *owner, model); err != nil {
 		EncodeError(format, w, ErrorRendererDefault(err))
 		return
 	}
@@ -169,8 +178,8 @@ func addSuppliersScooter(w http.ResponseWriter, r *http.Request) {
 	}
 	scooterSerial := r.FormValue("newScooter")
 
-	if err := supplierService.AddSuppliersScooter(modelId, scooterSerial); err != nil {
-		EncodeError(format, w, ErrorRendererDefault(err))
+	if err := supplierService.AddSuppliersScooter(r.Context(), *GetUserFromContext(r), modelId, scooterSerial); err != nil {
+		EncodeError(format, w, supplierErrorRenderer(err))
 		return
 	}
 
@@ -185,7 +194,11 @@ func deleteSuppliersScooter(w http.ResponseWriter, r *http.Request) {
 		EncodeError(format, w, ErrorRendererDefault(err))
 		return
 	}
-	err = supplierService.DeleteSuppliersScooter(scooterId)
+	err = supplierService.DeleteSuppliersScooter(r.Context(), *GetUserFromContext(r), scooterId)
+	if errors.Is(err, repositories.ErrNotOwner) {
+		EncodeError(format, w, supplierErrorRenderer(err))
+		return
+	}
 	if err != nil {
 		ServerErrorRender(format, w)
 		return
@@ -221,7 +234,15 @@ func uploadFile(w http.ResponseWriter, r *http.Request) {
 		EncodeError(format, w, ErrorRendererDefault(err))
 		return
 	}
-	supplierService.InsertScootersToDb(modelId, filepath)
+	supplierService.InsertScootersToDb(r.Context(), *GetUserFromContext(r), modelId, filepath)
 
 	http.Redirect(w, r, "http://localhost:8080/models", http.StatusFound)
 }
+
+// supplierErrorRenderer - renders forbidden error if supplier touches scooters of another supplier, bad request otherwise
+func supplierErrorRenderer(err error) *ResponseStatus {
+	if errors.Is(err, repositories.ErrNotOwner) {
+		return ErrorRenderer(err, "Forbidden", http.StatusForbidden)
+	}
+	return stationErrorRenderer(err)
+}
@@ -14,20 +14,24 @@ var keySupplierMicroRoutes = []Route{
 		Uri:     `/render`,
 		Method:  http.MethodGet,
 		Handler: getTemplateData,
+		Access:  SupplierAccess | AdminAccess,
 	}, {
 		Uri:     `/locations`,
 		Method:  http.MethodGet,
 		Handler: getLocations,
+		Access:  SupplierAccess | AdminAccess,
 	},
 	{
 		Uri:     `/addStation`,
 		Method:  http.MethodPost,
 		Handler: addStation,
+		Access:  SupplierAccess | AdminAccess,
 	},
 	{
 		Uri:     `/ASl`,
 		Method:  http.MethodPost,
 		Handler: addStationInLocation,
+		Access:  SupplierAccess | AdminAccess,
 	},
 }
 
@@ -35,11 +39,11 @@ var keySupplierMicroRoutes = []Route{
 func AddSupplierMicroHandler(router *mux.Router, supserv *services.SupplierMicroService) {
 	supplierMicroService = supserv
 	supplierMicroRouter := router.NewRoute().Subrouter()
-	//	supplierMicroRouter.Use(FilterAuth(authenticationService))
+	supplierMicroRouter.Use(FilterAuthOrToken(authenticationService, tokenService))
 
 	for _, rt := range keySupplierMicroRoutes {
-		supplierMicroRouter.Path(rt.Uri).HandlerFunc(rt.Handler).Methods(rt.Method)
-		supplierMicroRouter.Path(APIprefix + rt.Uri).HandlerFunc(rt.Handler).Methods(rt.Method)
+		supplierMicroRouter.Path(rt.Uri).Handler(rt.authorized()).Methods(rt.Method)
+		supplierMicroRouter.Path(APIprefix + rt.Uri).Handler(rt.authorized()).Methods(rt.Method)
 	}
 }
 
@@ -48,13 +52,13 @@ func getTemplateData(w http.ResponseWriter, r *http.Request) {
 	var locationData *models.LocationList
 	var stationsData models.StationList
 	format := GetFormatFromRequest(r)
-	locationData, err = supplierMicroService.GetAllLocations()
+	locationData, err = supplierMicroService.GetAllLocations(r.Context())
 	if err != nil {
 		ServerErrorRender(format, w)
 		return
 	}
 
-	stationsData, err = supplierMicroService.GetAllLStations()
+	stationsData, err = supplierMicroService.GetAllLStations(r.Context())
 	if err != nil {
 		EncodeError(FormatHTML, w, ErrorRendererDefault(err))
 		return
@@ -72,7 +76,7 @@ func addStationInLocation(w http.ResponseWriter, r *http.Request) {
 	format := GetFormatFromRequest(r)
 	stationData := models.Station{}
 	locationData := models.Location{}
-	err := supplierMicroService.CreateStationInLocation(&locationData, &stationData)
+	err := supplierMicroService.CreateStationInLocation(r.Context(), &locationData, &stationData)
 	if err != nil {
 		ServerErrorRender(format, w)
 		return
@@ -86,7 +90,7 @@ func addStation(w http.ResponseWriter, r *http.Request) {
 
 	stationData := models.Station{}
 	DecodeRequest(format, w, r, &stationData, decodeProblemAddRequest)
-	err := supplierMicroService.AddNewStation(&stationData)
+	err := supplierMicroService.AddNewStation(r.Context(), &stationData)
 	if err != nil {
 		ServerErrorRender(format, w)
 		return
@@ -103,7 +107,7 @@ func getLocations(w http.ResponseWriter, r *http.Request) {
 	//	format := GetFormatFromRequest(r)
 	var locationData *models.LocationList
 
-	locationData, err := supplierMicroService.GetAllLocations()
+	locationData, err := supplierMicroService.GetAllLocations(r.Context())
 	if err != nil {
 		EncodeError(FormatHTML, w, ErrorRendererDefault(err))
 		return
@@ -2,6 +2,7 @@ package routing
 
 import (
 	"Dp218GO/models"
+	"context"
 	"errors"
 	"fmt"
 	"net/http"
@@ -25,41 +26,48 @@ var keyUserRoutes = []Route{
 		Uri:     `/users`,
 		Method:  http.MethodGet,
 		Handler: getAllUsers,
+		Access:  AdminAccess,
 	},
 	{
 		Uri:     `/users`,
 		Method:  http.MethodPost,
 		Handler: allUsersOperation,
+		Access:  AdminAccess,
 	},
 	{
 		Uri:     `/user/{` + userIDKey + `}`,
 		Method:  http.MethodGet,
 		Handler: getUser,
+		Access:  AdminAccess,
 	},
 	{
 		Uri:     `/user`,
 		Method:  http.MethodPost,
 		Handler: createUser,
+		Access:  AdminAccess,
 	},
 	{
 		Uri:     `/user/{` + userIDKey + `}`,
 		Method:  http.MethodPost,
 		Handler: updateUser,
+		Access:  AdminAccess,
 	},
 	{
 		Uri:     `/user/{` + userIDKey + `}`,
 		Method:  http.MethodDelete,
 		Handler: deleteUser,
+		Access:  AdminAccess,
 	},
 }
 
 type userWithRoleList struct {
 	models.User
+	ctx context.Context
 }
 
 // ListOfRoles - returns slice of all roles to render them on template
 func (ur *userWithRoleList) ListOfRoles() []models.Role {
-	roles, _ := userService.GetAllRoles()
+	roles, _ := userService.GetAllRoles(ur.ctx)
 	return roles.Roles
 }
 
@@ -67,11 +75,11 @@ func (ur *userWithRoleList) ListOfRoles() []models.Role {
 func AddUserHandler(router *mux.Router, service *services.UserService) {
 	userService = service
 	userRouter := router.NewRoute().Subrouter()
-	userRouter.Use(FilterAuth(authenticationService))
+	userRouter.Use(FilterAuthOrToken(authenticationService, tokenService))
 
 	for _, rt := range keyUserRoutes {
-		userRouter.Path(rt.Uri).HandlerFunc(rt.Handler).Methods(rt.Method)
-		userRouter.Path(APIprefix + rt.Uri).HandlerFunc(rt.Handler).Methods(rt.Method)
+		userRouter.Path(rt.Uri).Handler(rt.authorized()).Methods(rt.Method)
+		userRouter.Path(APIprefix + rt.Uri).Handler(rt.authorized()).Methods(rt.Method)
 	}
 }
 
@@ -81,7 +89,7 @@ func createUser(w http.ResponseWriter, r *http.Request) {
 	user := &models.User{}
 	DecodeRequest(FormatJSON, w, r, user, nil)
 
-	if err := userService.AddUser(user); err != nil {
+	if err := userService.AddUser(r.Context(), user); err != nil {
 		EncodeError(format, w, ErrorRendererDefault(err))
 		return
 	}
@@ -99,9 +107,9 @@ func getAllUsers(w http.ResponseWriter, r *http.Request) {
 	searchData := r.FormValue("SearchData")
 
 	if len(searchData) == 0 {
-		users, err = userService.GetAllUsers()
+		users, err = userService.GetAllUsers(r.Context())
 	} else {
-		users, err = userService.FindUsersByLoginNameSurname(searchData)
+		users, err = userService.FindUsersByLoginNameSurname(r.Context(), searchData)
 	}
 	if err != nil {
 		ServerErrorRender(format, w)
@@ -130,13 +138,13 @@ func getUser(w http.ResponseWriter, r *http.Request) {
 		EncodeError(format, w, ErrorRendererDefault(err))
 		return
 	}
-	user, err := userService.GetUserByID(userID)
+	user, err := userService.GetUserByID(r.Context(), userID)
 	if err != nil {
 		EncodeError(format, w, ErrorRendererDefault(err))
 		return
 	}
 
-	EncodeAnswer(format, w, &userWithRoleList{user}, HTMLPath+"user-edit.html")
+	EncodeAnswer(format, w, &userWithRoleList{user, r.Context()}, HTMLPath+"user-edit.html")
 }
 
 func deleteUser(w http.ResponseWriter, r *http.Request) {
@@ -147,7 +155,7 @@ func deleteUser(w http.ResponseWriter, r *http.Request) {
 		EncodeError(format, w, ErrorRendererDefault(err))
 		return
 	}
-	err = userService.DeleteUser(userID)
+	err = userService.DeleteUser(r.Context(), userID)
 	if err != nil {
 		ServerErrorRender(format, w)
 		return
@@ -163,19 +171,19 @@ func updateUser(w http.ResponseWriter, r *http.Request) {
 		EncodeError(format, w, ErrorRendererDefault(err))
 		return
 	}
-	userData, err := userService.GetUserByID(userID)
+	userData, err := userService.GetUserByID(r.Context(), userID)
 	if err != nil {
 		EncodeError(format, w, ErrorRendererDefault(err))
 		return
 	}
 	DecodeRequest(format, w, r, &userData, decodeUserUpdateRequest)
-	userData, err = userService.UpdateUser(userID, userData)
+	userData, err = userService.UpdateUser(r.Context(), userID, userData)
 	if err != nil {
 		ServerErrorRender(format, w)
 		return
 	}
 
-	EncodeAnswer(format, w, &userWithRoleList{userData}, HTMLPath+"user-edit.html")
+	EncodeAnswer(format, w, &userWithRoleList{userData, r.Context()}, HTMLPath+"user-edit.html")
 }
 
 func allUsersOperation(w http.ResponseWriter, r *http.Request) {
@@ -194,7 +202,18 @@ func allUsersOperation(w http.ResponseWriter, r *http.Request) {
 			EncodeError(format, w, ErrorRendererDefault(err))
 			return
 		}
-		err = userService.ChangeUsersBlockStatus(userID)
+		err = userService.ChangeUsersBlockStatus(r.Context(), userID)
+		if err != nil {
+			EncodeError(format, w, ErrorRendererDefault(err))
+			return
+		}
+	case "UnlockUser":
+		userID, err := strconv.Atoi(r.FormValue("UserID"))
+		if err != nil {
+			EncodeError(format, w, ErrorRendererDefault(err))
+			return
+		}
+		err = userService.UnlockUser(r.Context(), userID)
 		if err != nil {
 			EncodeError(format, w, ErrorRendererDefault(err))
 			return
@@ -228,7 +247,7 @@ func decodeUserUpdateRequest(r *http.Request, data interface{}) error {
 		if err != nil {
 			return err
 		}
-		userData.Role, err = userService.GetRoleByID(roleID)
+		userData.Role, err = userService.GetRoleByID(r.Context(), roleID)
 		if err != nil {
 			return err
 		}
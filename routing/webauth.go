@@ -3,10 +3,17 @@ package routing
 import (
 	"Dp218GO/internal/validation"
 	"Dp218GO/models"
+	"Dp218GO/repositories"
 	"Dp218GO/services"
 	"context"
+	"encoding/json"
 	"errors"
+	"log"
+	"math"
 	"net/http"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/gorilla/mux"
 )
@@ -16,12 +23,32 @@ type userKey string
 var (
 	ukey                  userKey = "user"
 	authenticationService *services.AuthService
+	tokenService          *services.TokenService
+	activationService     *services.ActivationService
 	// ErrSignUp error returned to client if registering failed
 	ErrSignUp = errors.New("signup error")
 	// ErrSignIn error returned to client if authentication failed
 	ErrSignIn = errors.New("signin error")
+	// ErrGrantType error returned to client if token is requested with unknown grant type
+	ErrGrantType = errors.New("unsupported grant type")
 )
 
+// available grant types of the token endpoint
+const (
+	grantPassword     = "password"
+	grantRefreshToken = "refresh_token"
+	bearerPrefix      = "Bearer "
+)
+
+// tokenRequest - body of the token endpoint request, email & password are used with password grant,
+// refresh token is used with refresh_token grant
+type tokenRequest struct {
+	GrantType    string `json:"grant_type"`
+	Email        string `json:"email"`
+	Password     string `json:"password"`
+	RefreshToken string `json:"refresh_token"`
+}
+
 //AddAuthHandler registeres endpoints for authentication
 func AddAuthHandler(router *mux.Router, service *services.AuthService) {
 	authenticationService = service
@@ -30,6 +57,22 @@ func AddAuthHandler(router *mux.Router, service *services.AuthService) {
 	router.Path("/signout").HandlerFunc(SignOut(authenticationService)).Methods(http.MethodGet)
 }
 
+//AddActivationHandler registeres endpoints for account activation
+func AddActivationHandler(router *mux.Router, service *services.ActivationService) {
+	activationService = service
+	for _, prefix := range []string{"", APIprefix} {
+		router.Path(prefix + "/activate").HandlerFunc(Activate(activationService)).Methods(http.MethodGet)
+		router.Path(prefix + "/activate/resend").HandlerFunc(ResendActivation(activationService)).Methods(http.MethodPost)
+	}
+}
+
+//AddTokenHandler registeres api endpoints for issuing & revoking bearer tokens
+func AddTokenHandler(router *mux.Router, service *services.TokenService) {
+	tokenService = service
+	router.Path(APIprefix + "/token").HandlerFunc(IssueToken(authenticationService, tokenService)).Methods(http.MethodPost)
+	router.Path(APIprefix + "/token").HandlerFunc(RevokeToken(tokenService)).Methods(http.MethodDelete)
+}
+
 //SignUp is handler for signup authentication service method
 func SignUp(sv *services.AuthService) http.HandlerFunc {
 
@@ -55,11 +98,18 @@ func SignUp(sv *services.AuthService) http.HandlerFunc {
 			Password:    valReq.Password,
 		}
 
-		if err := sv.SignUp(user); err != nil {
+		if err := sv.SignUp(r.Context(), user); err != nil {
 
 			http.Error(w, ErrSignUp.Error(), http.StatusInternalServerError)
 			return
 		}
+
+		// user is created already, the link can be requested again if sending failed
+		if activationService != nil {
+			if err := activationService.SendActivation(r.Context(), *user); err != nil {
+				log.Printf("unable to send activation link to %s: %v", user.LoginEmail, err)
+			}
+		}
 		http.Redirect(w, r, "/login", http.StatusFound)
 	}
 }
@@ -83,6 +133,9 @@ func SignIn(sv *services.AuthService) http.HandlerFunc {
 		}
 
 		if err := sv.SignIn(w, r, req); err != nil {
+			if lockoutRender(FormatHTML, w, err) {
+				return
+			}
 
 			EncodeError(FormatHTML, w, &ResponseStatus{
 				Err:        ErrSignIn,
@@ -101,6 +154,13 @@ func SignIn(sv *services.AuthService) http.HandlerFunc {
 func SignOut(sv *services.AuthService) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 
+		if token, ok := bearerToken(r); ok && tokenService != nil {
+			if err := revokeRecorded(r, tokenService, token); err != nil {
+				EncodeError(FormatJSON, w, ErrorRenderer(err, "Unauthorized", http.StatusUnauthorized))
+				return
+			}
+		}
+
 		err := sv.SignOut(w, r)
 		if err != nil {
 			http.Error(w, err.Error(), http.StatusInternalServerError)
@@ -111,6 +171,125 @@ func SignOut(sv *services.AuthService) http.HandlerFunc {
 	}
 }
 
+//Activate is handler which activates the user account by the token from activation link
+func Activate(sv *services.ActivationService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		format := GetFormatFromRequest(r)
+
+		err := sv.Activate(r.Context(), r.URL.Query().Get("token"))
+		switch {
+		case errors.Is(err, services.ErrActivationExpired):
+			EncodeError(format, w, ErrorRenderer(err, "Link expired, request the new one", http.StatusGone))
+			return
+		case errors.Is(err, repositories.ErrAlreadyActivated):
+			EncodeError(format, w, ErrorRenderer(err, "Already activated", http.StatusConflict))
+			return
+		case errors.Is(err, services.ErrActivationInvalid):
+			EncodeError(format, w, ErrorRendererDefault(err))
+			return
+		case err != nil:
+			ServerErrorRender(format, w)
+			return
+		}
+
+		if format == FormatJSON {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		http.Redirect(w, r, "/login", http.StatusFound)
+	}
+}
+
+//ResendActivation is handler which sends the new activation link to the email from request.
+//The answer is the same whether the link was sent or not, so it doesn't reveal registered emails
+func ResendActivation(sv *services.ActivationService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		format := GetFormatFromRequest(r)
+
+		email := r.FormValue("email")
+		if err := sv.Resend(r.Context(), email); err != nil {
+			log.Printf("activation link for %s is not sent: %v", email, err)
+		}
+
+		if format == FormatJSON {
+			w.WriteHeader(http.StatusAccepted)
+			return
+		}
+		http.Redirect(w, r, "/login", http.StatusFound)
+	}
+}
+
+//IssueToken is handler for token endpoint, returns new token pair
+//for user credentials or refresh token in json format
+func IssueToken(sv *services.AuthService, ts *services.TokenService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		req := tokenRequest{}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			EncodeError(FormatJSON, w, ErrorRendererDefault(err))
+			return
+		}
+
+		var pair models.TokenPair
+		var err error
+		switch req.GrantType {
+		case grantPassword:
+			valReq := validation.SignInUserRequest{
+				LoginEmail: req.Email,
+				Password:   req.Password,
+			}
+			if err = valReq.Validate(); err != nil {
+				EncodeError(FormatJSON, w, ErrorRendererDefault(err))
+				return
+			}
+
+			var user models.User
+			user, err = sv.Authenticate(r, &services.AuthRequest{Email: valReq.LoginEmail, Password: valReq.Password})
+			if lockoutRender(FormatJSON, w, err) {
+				return
+			}
+			if err != nil {
+				EncodeError(FormatJSON, w, ErrorRenderer(ErrSignIn, "Unauthorized", http.StatusUnauthorized))
+				return
+			}
+			pair, err = ts.Issue(r.Context(), user.ID)
+		case grantRefreshToken:
+			pair, err = ts.Refresh(r.Context(), req.RefreshToken)
+			if errors.Is(err, repositories.ErrNoToken) || errors.Is(err, services.ErrUserBlocked) {
+				EncodeError(FormatJSON, w, ErrorRenderer(err, "Unauthorized", http.StatusUnauthorized))
+				return
+			}
+		default:
+			EncodeError(FormatJSON, w, ErrorRendererDefault(ErrGrantType))
+			return
+		}
+
+		if err != nil {
+			ServerErrorRender(FormatJSON, w)
+			return
+		}
+
+		EncodeAnswer(FormatJSON, w, pair)
+	}
+}
+
+//RevokeToken is handler which revokes the bearer token of the request together with its refresh token
+func RevokeToken(ts *services.TokenService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		token, ok := bearerToken(r)
+		if !ok {
+			EncodeError(FormatJSON, w, ErrorRenderer(repositories.ErrNoToken, "Unauthorized", http.StatusUnauthorized))
+			return
+		}
+
+		if err := revokeRecorded(r, ts, token); err != nil {
+			EncodeError(FormatJSON, w, ErrorRenderer(err, "Unauthorized", http.StatusUnauthorized))
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
 // FilterAuth  is middleware checks if user is authenticated
 // writes user to context for retrieving if chaining middleware is present
 // shows error if user is not authenticated
@@ -119,7 +298,7 @@ func FilterAuth(sv *services.AuthService) func(http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			user, err := sv.GetUserFromRequest(r)
 			if err != nil {
-				http.Error(w, err.Error(), http.StatusForbidden)
+				EncodeError(GetFormatFromRequest(r), w, ErrorRenderer(err, "Forbidden", http.StatusForbidden))
 				return
 			}
 			newReq := r.WithContext(context.WithValue(r.Context(), ukey, user))
@@ -139,3 +318,81 @@ func GetUserFromContext(r *http.Request) *models.User {
 	}
 	return nil
 }
+
+// FilterAuthOrToken is FilterAuth variant which also accepts bearer access token
+// from Authorization header, the user of the token is written to context the same way
+// request with invalid token is rejected without falling back to session
+func FilterAuthOrToken(sv *services.AuthService, ts *services.TokenService) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		session := FilterAuth(sv)(next)
+
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			token, ok := bearerToken(r)
+			if !ok || ts == nil {
+				session.ServeHTTP(w, r)
+				return
+			}
+
+			user, err := ts.Authenticate(r.Context(), token)
+			if err != nil {
+				w.Header().Set("WWW-Authenticate", "Bearer")
+				EncodeError(GetFormatFromRequest(r), w, ErrorRenderer(err, "Unauthorized", http.StatusUnauthorized))
+				return
+			}
+			newReq := r.WithContext(context.WithValue(r.Context(), ukey, user))
+
+			next.ServeHTTP(w, newReq)
+		})
+	}
+}
+
+// lockoutRender renders refused sign in as 423 for locked account or 429 for too frequent attempts,
+// Retry-After header tells when to try again. Returns false for other errors
+func lockoutRender(format int, w http.ResponseWriter, err error) bool {
+	var lockErr *services.LockoutError
+	if !errors.As(err, &lockErr) {
+		return false
+	}
+
+	retryAfter := int(math.Ceil(time.Until(lockErr.Until).Seconds()))
+	if retryAfter < 1 {
+		retryAfter = 1
+	}
+	w.Header().Set("Retry-After", strconv.Itoa(retryAfter))
+
+	if errors.Is(err, services.ErrAccountLocked) {
+		EncodeError(format, w, ErrorRenderer(err, "Account locked", http.StatusLocked))
+		return true
+	}
+	EncodeError(format, w, ErrorRenderer(err, "Too many attempts", http.StatusTooManyRequests))
+	return true
+}
+
+// revokeRecorded revokes the bearer token and writes sign out of its user to login history
+func revokeRecorded(r *http.Request, ts *services.TokenService, token string) error {
+	user, err := ts.Authenticate(r.Context(), token)
+	if err != nil {
+		return err
+	}
+	if err = ts.Revoke(r.Context(), token); err != nil {
+		return err
+	}
+
+	if loginAuditService != nil {
+		if err = loginAuditService.EndSession(r.Context(), *user, 0, r); err != nil {
+			log.Printf("sign out of user %d is not recorded: %v", user.ID, err)
+		}
+	}
+	return nil
+}
+
+// bearerToken retrieves token from Authorization header of the request
+func bearerToken(r *http.Request) (string, bool) {
+	header := r.Header.Get("Authorization")
+	if !strings.HasPrefix(header, bearerPrefix) {
+		return "", false
+	}
+
+	token := strings.TrimSpace(strings.TrimPrefix(header, bearerPrefix))
+	return token, token != ""
+}
@@ -0,0 +1,213 @@
+package routing
+
+import (
+	"Dp218GO/internal/validation"
+	"Dp218GO/models"
+	"Dp218GO/repositories"
+	"Dp218GO/services"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+)
+
+var zoneService *services.ZoneService
+var zoneIDKey = "zoneID"
+
+var keyZoneRoutes = []Route{
+	{
+		Uri:     `/zones`,
+		Method:  http.MethodGet,
+		Handler: getZones,
+	},
+	{
+		Uri:     `/zones/at`,
+		Method:  http.MethodGet,
+		Handler: getZonesAt,
+	},
+	{
+		Uri:     `/zone/{` + zoneIDKey + `}`,
+		Method:  http.MethodGet,
+		Handler: getZone,
+	},
+	{
+		Uri:     `/zones`,
+		Method:  http.MethodPost,
+		Handler: createZone,
+		Access:  AdminAccess,
+	},
+	{
+		Uri:     `/zone/{` + zoneIDKey + `}`,
+		Method:  http.MethodPost,
+		Handler: updateZone,
+		Access:  AdminAccess,
+	},
+	{
+		Uri:     `/zone/{` + zoneIDKey + `}`,
+		Method:  http.MethodDelete,
+		Handler: deleteZone,
+		Access:  AdminAccess,
+	},
+}
+
+// zonesAtPoint - zones which contain the point and whether the trip can be finished there
+type zonesAtPoint struct {
+	Location       models.Coordinate `json:"location"`
+	Zones          []models.Zone     `json:"zones"`
+	ParkingAllowed bool              `json:"parking_allowed"`
+	Reason         string            `json:"reason,omitempty"`
+}
+
+// AddZoneHandler - add endpoints for viewing zones and managing them by admin to http router
+func AddZoneHandler(router *mux.Router, service *services.ZoneService) {
+	zoneService = service
+	zoneRouter := router.NewRoute().Subrouter()
+	zoneRouter.Use(FilterAuthOrToken(authenticationService, tokenService))
+
+	for _, rt := range keyZoneRoutes {
+		zoneRouter.Path(rt.Uri).Handler(rt.authorized()).Methods(rt.Method)
+		zoneRouter.Path(APIprefix + rt.Uri).Handler(rt.authorized()).Methods(rt.Method)
+	}
+}
+
+func getZones(w http.ResponseWriter, r *http.Request) {
+	zones, err := zoneService.GetAllZones(r.Context())
+	if err != nil {
+		ServerErrorRender(FormatJSON, w)
+		return
+	}
+
+	EncodeAnswer(FormatJSON, w, zones)
+}
+
+func getZonesAt(w http.ResponseWriter, r *http.Request) {
+	point, err := decodePoint(r)
+	if err != nil {
+		EncodeError(FormatJSON, w, ErrorRenderer(err, "Bad request", http.StatusBadRequest))
+		return
+	}
+
+	zones, err := zoneService.ZonesAt(r.Context(), point)
+	if err != nil {
+		ServerErrorRender(FormatJSON, w)
+		return
+	}
+
+	answer := &zonesAtPoint{Location: point, Zones: zones, ParkingAllowed: true}
+	err = zoneService.CheckParking(r.Context(), point)
+	if errors.Is(err, services.ErrParkingNotAllowed) {
+		answer.ParkingAllowed, answer.Reason = false, err.Error()
+	} else if err != nil {
+		ServerErrorRender(FormatJSON, w)
+		return
+	}
+
+	EncodeAnswer(FormatJSON, w, answer)
+}
+
+func getZone(w http.ResponseWriter, r *http.Request) {
+	zoneID, err := strconv.Atoi(mux.Vars(r)[zoneIDKey])
+	if err != nil {
+		EncodeError(FormatJSON, w, ErrorRendererDefault(err))
+		return
+	}
+
+	zone, err := zoneService.GetZoneByID(r.Context(), zoneID)
+	if err != nil {
+		EncodeError(FormatJSON, w, zoneErrorRenderer(err))
+		return
+	}
+
+	EncodeAnswer(FormatJSON, w, zone)
+}
+
+func createZone(w http.ResponseWriter, r *http.Request) {
+	zone := &models.Zone{IsActive: true}
+	if err := json.NewDecoder(r.Body).Decode(zone); err != nil {
+		EncodeError(FormatJSON, w, ErrorRenderer(err, "Bad request", http.StatusBadRequest))
+		return
+	}
+
+	if err := zoneService.AddZone(r.Context(), zone); err != nil {
+		EncodeError(FormatJSON, w, zoneErrorRenderer(err))
+		return
+	}
+
+	EncodeAnswer(FormatJSON, w, zone)
+}
+
+func updateZone(w http.ResponseWriter, r *http.Request) {
+	zoneID, err := strconv.Atoi(mux.Vars(r)[zoneIDKey])
+	if err != nil {
+		EncodeError(FormatJSON, w, ErrorRendererDefault(err))
+		return
+	}
+
+	zone, err := zoneService.GetZoneByID(r.Context(), zoneID)
+	if err != nil {
+		EncodeError(FormatJSON, w, zoneErrorRenderer(err))
+		return
+	}
+	if err = json.NewDecoder(r.Body).Decode(&zone); err != nil {
+		EncodeError(FormatJSON, w, ErrorRenderer(err, "Bad request", http.StatusBadRequest))
+		return
+	}
+
+	zone, err = zoneService.UpdateZone(r.Context(), zoneID, zone)
+	if err != nil {
+		EncodeError(FormatJSON, w, zoneErrorRenderer(err))
+		return
+	}
+
+	EncodeAnswer(FormatJSON, w, zone)
+}
+
+func deleteZone(w http.ResponseWriter, r *http.Request) {
+	zoneID, err := strconv.Atoi(mux.Vars(r)[zoneIDKey])
+	if err != nil {
+		EncodeError(FormatJSON, w, ErrorRendererDefault(err))
+		return
+	}
+
+	if err = zoneService.DeleteZone(r.Context(), zoneID); err != nil {
+		EncodeError(FormatJSON, w, zoneErrorRenderer(err))
+		return
+	}
+
+	EncodeAnswer(FormatJSON, w, ErrorRenderer(fmt.Errorf(""), "success", http.StatusOK))
+}
+
+// decodePoint - reads the point from latitude & longitude parameters of the request
+func decodePoint(r *http.Request) (models.Coordinate, error) {
+	valReq := validation.LocationRequest{
+		Latitude:  r.FormValue("latitude"),
+		Longitude: r.FormValue("longitude"),
+	}
+	if err := valReq.Validate(); err != nil {
+		return models.Coordinate{}, err
+	}
+
+	var point models.Coordinate
+	var err error
+	if point.Latitude, err = strconv.ParseFloat(valReq.Latitude, 64); err != nil {
+		return point, err
+	}
+	point.Longitude, err = strconv.ParseFloat(valReq.Longitude, 64)
+	return point, err
+}
+
+// zoneErrorRenderer - maps errors of zones and of the trips which break the zone rules to http statuses
+func zoneErrorRenderer(err error) *ResponseStatus {
+	switch {
+	case errors.Is(err, repositories.ErrNoZone):
+		return ErrorRenderer(err, "Not found", http.StatusNotFound)
+	case errors.Is(err, services.ErrInvalidZone):
+		return ErrorRenderer(err, "Bad request", http.StatusBadRequest)
+	case errors.Is(err, services.ErrParkingNotAllowed):
+		return ErrorRenderer(err, "Conflict", http.StatusConflict)
+	}
+	return ErrorRendererDefault(err)
+}
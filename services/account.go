@@ -3,17 +3,22 @@ package services
 import (
 	"Dp218GO/models"
 	"Dp218GO/repositories"
+	"context"
 	"errors"
 	"time"
 )
 
-// constants for income & outcome payment types
+// constants for commission, income & outcome payment types
 const (
-	PayIncomeTypeID  = 2
-	PayOutcomeTypeID = 3
+	PayCommissionTypeID = 1
+	PayIncomeTypeID     = 2
+	PayOutcomeTypeID    = 3
 )
 
 var ErrNotEnoughMoneyToTake = errors.New("can't take more money than you have")
+var ErrNotEnoughMoneyForTrip = errors.New("not enough money on the account to start the trip")
+var ErrNoAccount = errors.New("user has no account")
+var ErrOrderAlreadySettled = repositories.ErrOrderAlreadySettled
 
 // AccountService - structure for implementing accounting service
 type AccountService struct {
@@ -21,84 +26,90 @@ type AccountService struct {
 	repoAccountTransaction repositories.AccountTransactionRepo
 	repoPaymentType        repositories.PaymentTypeRepo
 	clock                  Clock
+	platformAccountNumber  string
+	tripDepositCents       int
 }
 
+const platformAccountName = "Platform account"
+
 type transactionsWithIncome struct {
 	Transaction models.AccountTransaction
 	IsIncome    bool
 }
 
-// NewAccountService - initialization of AccountService
+// NewAccountService - initialization of AccountService. Trip commissions are paid to the account with
+// platformAccountNumber, trip can be started only with tripDepositCents on the user's account
 func NewAccountService(repoAccount repositories.AccountRepo,
-	repoAccountTransaction repositories.AccountTransactionRepo, repoPaymentType repositories.PaymentTypeRepo, clock Clock) *AccountService {
+	repoAccountTransaction repositories.AccountTransactionRepo, repoPaymentType repositories.PaymentTypeRepo, clock Clock,
+	platformAccountNumber string, tripDepositCents int) *AccountService {
 
 	return &AccountService{repoAccount, repoAccountTransaction,
-		repoPaymentType, clock}
+		repoPaymentType, clock, platformAccountNumber, tripDepositCents}
 }
 
 // GetAccountsByOwner - get user accounts list by user
-func (accserv *AccountService) GetAccountsByOwner(user models.User) (*models.AccountList, error) {
-	return accserv.repoAccount.GetAccountsByOwner(user)
+func (accserv *AccountService) GetAccountsByOwner(ctx context.Context, user models.User) (*models.AccountList, error) {
+	return accserv.repoAccount.GetAccountsByOwner(ctx, user)
 }
 
 // GetAccountByID - get account by ID
-func (accserv *AccountService) GetAccountByID(accountID int) (models.Account, error) {
-	return accserv.repoAccount.GetAccountByID(accountID)
+func (accserv *AccountService) GetAccountByID(ctx context.Context, accountID int) (models.Account, error) {
+	return accserv.repoAccount.GetAccountByID(ctx, accountID)
 }
 
 // GetAccountByNumber - get account by its number
-func (accserv *AccountService) GetAccountByNumber(number string) (models.Account, error) {
-	return accserv.repoAccount.GetAccountByNumber(number)
+func (accserv *AccountService) GetAccountByNumber(ctx context.Context, number string) (models.Account, error) {
+	return accserv.repoAccount.GetAccountByNumber(ctx, number)
 }
 
 // AddAccount - add account record
-func (accserv *AccountService) AddAccount(account *models.Account) error {
-	return accserv.repoAccount.AddAccount(account)
+func (accserv *AccountService) AddAccount(ctx context.Context, account *models.Account) error {
+	return accserv.repoAccount.AddAccount(ctx, account)
 }
 
 // UpdateAccount - update account record
-func (accserv *AccountService) UpdateAccount(accountID int, accountData models.Account) (models.Account, error) {
-	return accserv.repoAccount.UpdateAccount(accountID, accountData)
+func (accserv *AccountService) UpdateAccount(ctx context.Context, accountID int, accountData models.Account) (models.Account, error) {
+	return accserv.repoAccount.UpdateAccount(ctx, accountID, accountData)
 }
 
 // GetAccountTransactionByID - get money transaction info by its ID
-func (accserv *AccountService) GetAccountTransactionByID(transId int) (models.AccountTransaction, error) {
-	return accserv.repoAccountTransaction.GetAccountTransactionByID(transId)
+func (accserv *AccountService) GetAccountTransactionByID(ctx context.Context, transId int) (models.AccountTransaction, error) {
+	return accserv.repoAccountTransaction.GetAccountTransactionByID(ctx, transId)
 }
 
 // AddAccountTransaction - add money transaction record
-func (accserv *AccountService) AddAccountTransaction(accountTransaction *models.AccountTransaction) error {
-	return accserv.repoAccountTransaction.AddAccountTransaction(accountTransaction)
+func (accserv *AccountService) AddAccountTransaction(ctx context.Context, accountTransaction *models.AccountTransaction) error {
+	return accserv.repoAccountTransaction.AddAccountTransaction(ctx, accountTransaction)
 }
 
 // GetAccountTransactions - get money transactions for given accounts
-func (accserv *AccountService) GetAccountTransactions(accounts ...models.Account) (*models.AccountTransactionList, error) { //nolint:lll
-	return accserv.repoAccountTransaction.GetAccountTransactions(accounts...)
+func (accserv *AccountService) GetAccountTransactions(ctx context.Context, accounts ...models.Account) (*models.AccountTransactionList, error) { //nolint:lll
+	return accserv.repoAccountTransaction.GetAccountTransactions(ctx, accounts...)
 }
 
 // GetAccountTransactionsInTimePeriod - get money transactions for given accounts from start to end time
-func (accserv *AccountService) GetAccountTransactionsInTimePeriod(start time.Time, end time.Time, accounts ...models.Account) (*models.AccountTransactionList, error) { //nolint:lll
-	return accserv.repoAccountTransaction.GetAccountTransactionsInTimePeriod(start, end, accounts...)
+func (accserv *AccountService) GetAccountTransactionsInTimePeriod(ctx context.Context, start time.Time, end time.Time, accounts ...models.Account) (*models.AccountTransactionList, error) { //nolint:lll
+	return accserv.repoAccountTransaction.GetAccountTransactionsInTimePeriod(ctx, start, end, accounts...)
 }
 
 // GetAccountTransactionsByOrder - get money transactions for given order
-func (accserv *AccountService) GetAccountTransactionsByOrder(order models.Order) (*models.AccountTransactionList, error) { //nolint:lll
-	return accserv.repoAccountTransaction.GetAccountTransactionsByOrder(order)
+func (accserv *AccountService) GetAccountTransactionsByOrder(ctx context.Context, order models.Order) (*models.AccountTransactionList, error) { //nolint:lll
+	return accserv.repoAccountTransaction.GetAccountTransactionsByOrder(ctx, order)
 }
 
 // GetAccountTransactionsByPaymentType - get money transactions for given accounts & given payment type
-func (accserv *AccountService) GetAccountTransactionsByPaymentType(paymentType models.PaymentType, accounts ...models.Account) (*models.AccountTransactionList, error) { //nolint:lll
-	return accserv.repoAccountTransaction.GetAccountTransactionsByPaymentType(paymentType, accounts...)
+func (accserv *AccountService) GetAccountTransactionsByPaymentType(ctx context.Context, paymentType models.PaymentType, accounts ...models.Account) (*models.AccountTransactionList, error) { //nolint:lll
+	return accserv.repoAccountTransaction.GetAccountTransactionsByPaymentType(ctx, paymentType, accounts...)
 }
 
 // GetPaymentTypeByID - get payment type by its ID
-func (accserv *AccountService) GetPaymentTypeByID(paymentTypeId int) (models.PaymentType, error) {
-	return accserv.repoPaymentType.GetPaymentTypeById(paymentTypeId)
+func (accserv *AccountService) GetPaymentTypeByID(ctx context.Context, paymentTypeId int) (models.PaymentType, error) {
+	return accserv.repoPaymentType.GetPaymentTypeById(ctx, paymentTypeId)
 }
 
 // CalculateMoneyAmountByDate - count money total for given account by given time
-func (accserv *AccountService) CalculateMoneyAmountByDate(account models.Account, byTime time.Time) (models.Money, error) {
-	transactionsUpToDate, err := accserv.repoAccountTransaction.GetAccountTransactionsInTimePeriod(time.UnixMilli(0), byTime, account)
+func (accserv *AccountService) CalculateMoneyAmountByDate(ctx context.Context, account models.Account, byTime time.Time) (models.Money, error) {
+	transactionsUpToDate, err := accserv.repoAccountTransaction.GetAccountTransactionsInTimePeriod(ctx, time.UnixMilli(0), byTime, account)
 	if err != nil {
 		return models.Money{}, err
 	}
@@ -115,8 +126,8 @@ func (accserv *AccountService) CalculateMoneyAmountByDate(account models.Account
 }
 
 // CalculateProfitForPeriod - count profit for given period from start to end time
-func (accserv *AccountService) CalculateProfitForPeriod(account models.Account, start, end time.Time) (models.Money, error) { //nolint:lll
-	transactionsUpToDate, err := accserv.repoAccountTransaction.GetAccountTransactionsInTimePeriod(start, end, account)
+func (accserv *AccountService) CalculateProfitForPeriod(ctx context.Context, account models.Account, start, end time.Time) (models.Money, error) { //nolint:lll
+	transactionsUpToDate, err := accserv.repoAccountTransaction.GetAccountTransactionsInTimePeriod(ctx, start, end, account)
 	if err != nil {
 		return models.Money{}, err
 	}
@@ -130,8 +141,8 @@ func (accserv *AccountService) CalculateProfitForPeriod(account models.Account,
 }
 
 // CalculateLossForPeriod - count loss for given period from start to end time
-func (accserv *AccountService) CalculateLossForPeriod(account models.Account, start, end time.Time) (models.Money, error) { //nolint:lll
-	transactionsUpToDate, err := accserv.repoAccountTransaction.GetAccountTransactionsInTimePeriod(start, end, account)
+func (accserv *AccountService) CalculateLossForPeriod(ctx context.Context, account models.Account, start, end time.Time) (models.Money, error) { //nolint:lll
+	transactionsUpToDate, err := accserv.repoAccountTransaction.GetAccountTransactionsInTimePeriod(ctx, start, end, account)
 	if err != nil {
 		return models.Money{}, err
 	}
@@ -145,8 +156,8 @@ func (accserv *AccountService) CalculateLossForPeriod(account models.Account, st
 }
 
 // AddMoneyToAccount - new transaction record to add money to given account
-func (accserv *AccountService) AddMoneyToAccount(account models.Account, amountCents int) error {
-	paymentType, err := accserv.repoPaymentType.GetPaymentTypeById(PayIncomeTypeID)
+func (accserv *AccountService) AddMoneyToAccount(ctx context.Context, account models.Account, amountCents int) error {
+	paymentType, err := accserv.repoPaymentType.GetPaymentTypeById(ctx, PayIncomeTypeID)
 	if err != nil {
 		return err
 	}
@@ -161,17 +172,17 @@ func (accserv *AccountService) AddMoneyToAccount(account models.Account, amountC
 		Order:       models.Order{},
 		AmountCents: amountCents}
 
-	return accserv.repoAccountTransaction.AddAccountTransaction(accTransaction)
+	return accserv.repoAccountTransaction.AddAccountTransaction(ctx, accTransaction)
 }
 
 // TakeMoneyFromAccount - new transaction record to get money from given account
-func (accserv *AccountService) TakeMoneyFromAccount(account models.Account, amountCents int) error {
-	paymentType, err := accserv.repoPaymentType.GetPaymentTypeById(PayOutcomeTypeID)
+func (accserv *AccountService) TakeMoneyFromAccount(ctx context.Context, account models.Account, amountCents int) error {
+	paymentType, err := accserv.repoPaymentType.GetPaymentTypeById(ctx, PayOutcomeTypeID)
 	if err != nil {
 		return err
 	}
 	currentTime := accserv.clock.Now()
-	totalMoney, err := accserv.CalculateMoneyAmountByDate(account, currentTime)
+	totalMoney, err := accserv.CalculateMoneyAmountByDate(ctx, account, currentTime)
 	if err != nil {
 		return err
 	}
@@ -187,7 +198,115 @@ func (accserv *AccountService) TakeMoneyFromAccount(account models.Account, amou
 		Order:       models.Order{},
 		AmountCents: amountCents}
 
-	return accserv.repoAccountTransaction.AddAccountTransaction(accTransaction)
+	return accserv.repoAccountTransaction.AddAccountTransaction(ctx, accTransaction)
+}
+
+// CheckTripDeposit - check that user's main account has enough money to start the trip
+func (accserv *AccountService) CheckTripDeposit(ctx context.Context, user models.User) error {
+	account, err := accserv.getMainAccount(ctx, user)
+	if err != nil {
+		return err
+	}
+
+	totalMoney, err := accserv.CalculateMoneyAmountByDate(ctx, account, accserv.clock.Now())
+	if err != nil {
+		return err
+	}
+	if accserv.CentsFromMoney(totalMoney) < accserv.tripDepositCents {
+		return ErrNotEnoughMoneyForTrip
+	}
+
+	return nil
+}
+
+// SettleOrder - pay for the finished trip: rider's main account is debited with the trip price,
+// supplier's main account is credited with its part and the commission goes to the platform account.
+// All the money transactions of the order are stored at once
+func (accserv *AccountService) SettleOrder(ctx context.Context, order models.Order, price models.PriceBreakdown) error {
+	orderTransactions, err := accserv.repoAccountTransaction.GetAccountTransactionsByOrder(ctx, order)
+	if err != nil {
+		return err
+	}
+	if len(orderTransactions.AccountTransactions) > 0 {
+		return ErrOrderAlreadySettled
+	}
+
+	riderAccount, err := accserv.getMainAccount(ctx, models.User{ID: order.UserID})
+	if err != nil {
+		return err
+	}
+	supplierAccount, err := accserv.getMainAccount(ctx, models.User{ID: price.SupplierID})
+	if err != nil {
+		return err
+	}
+	tripPaymentType, err := accserv.repoPaymentType.GetPaymentTypeById(ctx, price.PaymentTypeID)
+	if err != nil {
+		return err
+	}
+
+	currentTime := accserv.clock.Now()
+	transactions := []*models.AccountTransaction{{
+		DateTime:    currentTime,
+		PaymentType: tripPaymentType,
+		AccountFrom: riderAccount,
+		AccountTo:   supplierAccount,
+		Order:       order,
+		AmountCents: price.SupplierCents}}
+
+	if price.CommissionCents > 0 {
+		platformAccount, err := accserv.repoAccount.GetAccountByNumber(ctx, accserv.platformAccountNumber)
+		if err != nil {
+			return err
+		}
+		commissionPaymentType, err := accserv.repoPaymentType.GetPaymentTypeById(ctx, PayCommissionTypeID)
+		if err != nil {
+			return err
+		}
+
+		transactions = append(transactions, &models.AccountTransaction{
+			DateTime:    currentTime,
+			PaymentType: commissionPaymentType,
+			AccountFrom: riderAccount,
+			AccountTo:   platformAccount,
+			Order:       order,
+			AmountCents: price.CommissionCents})
+	}
+
+	return accserv.repoAccountTransaction.AddAccountTransactions(ctx, transactions...)
+}
+
+// EnsurePlatformAccount - creates the account for trip commissions with the configured number
+// if it doesn't exist yet. The account is owned by the user with ownerID
+func (accserv *AccountService) EnsurePlatformAccount(ctx context.Context, ownerID int) (models.Account, error) {
+	account, err := accserv.repoAccount.GetAccountByNumber(ctx, accserv.platformAccountNumber)
+	if !errors.Is(err, repositories.ErrNoAccount) {
+		return account, err
+	}
+
+	account = models.Account{Name: platformAccountName, Number: accserv.platformAccountNumber,
+		User: models.User{ID: ownerID}}
+	if err = accserv.repoAccount.AddAccount(ctx, &account); err != nil {
+		// the account could be created by another instance of the app in the meantime
+		if created, errGet := accserv.repoAccount.GetAccountByNumber(ctx, accserv.platformAccountNumber); errGet == nil {
+			return created, nil
+		}
+		return models.Account{}, err
+	}
+
+	return account, nil
+}
+
+// getMainAccount - the first account of the user is used for trip payments
+func (accserv *AccountService) getMainAccount(ctx context.Context, user models.User) (models.Account, error) {
+	accounts, err := accserv.repoAccount.GetAccountsByOwner(ctx, user)
+	if err != nil {
+		return models.Account{}, err
+	}
+	if len(accounts.Accounts) == 0 {
+		return models.Account{}, ErrNoAccount
+	}
+
+	return accounts.Accounts[0], nil
 }
 
 // MoneyFromCents - convert cents to Money struct (dollars, cents)
@@ -208,29 +327,29 @@ func (accserv *AccountService) CentsFromMoney(money models.Money) int {
 }
 
 // GetAccountOutputStructByID - get more convenient structure for given account by its ID
-func (accserv *AccountService) GetAccountOutputStructByID(accId int) (interface{}, error) {
-	account, err := accserv.GetAccountByID(accId)
+func (accserv *AccountService) GetAccountOutputStructByID(ctx context.Context, accId int) (interface{}, error) {
+	account, err := accserv.GetAccountByID(ctx, accId)
 	if err != nil {
 		return nil, err
 	}
 
 	now := time.Now()
 
-	moneyTotal, err := accserv.CalculateMoneyAmountByDate(account, now)
+	moneyTotal, err := accserv.CalculateMoneyAmountByDate(ctx, account, now)
 	if err != nil {
 		return nil, err
 	}
 
 	monthStart := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())
-	monthIncome, err := accserv.CalculateProfitForPeriod(account, monthStart, now)
+	monthIncome, err := accserv.CalculateProfitForPeriod(ctx, account, monthStart, now)
 	if err != nil {
 		return nil, err
 	}
-	monthOutcome, err := accserv.CalculateLossForPeriod(account, monthStart, now)
+	monthOutcome, err := accserv.CalculateLossForPeriod(ctx, account, monthStart, now)
 	if err != nil {
 		return nil, err
 	}
-	monthTransactions, err := accserv.GetAccountTransactionsInTimePeriod(monthStart, now, account)
+	monthTransactions, err := accserv.GetAccountTransactionsInTimePeriod(ctx, monthStart, now, account)
 	if err != nil {
 		return nil, err
 	}
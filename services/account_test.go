@@ -2,7 +2,10 @@ package services
 
 import (
 	"Dp218GO/models"
+	"Dp218GO/repositories"
+	repomock "Dp218GO/repositories/mock"
 	"Dp218GO/services/mock"
+	"context"
 	"errors"
 	"github.com/golang/mock/gomock"
 	assert "github.com/stretchr/testify/require"
@@ -13,9 +16,9 @@ import (
 // UseCasesMock is a struct which exists of repositories which are mocked and our service.
 type accountUseCasesMock struct {
 	AccountServiceUC       *AccountService
-	RepoPaymentType        *mock.MockPaymentTypeRepo
-	RepoAccountTransaction *mock.MockAccountTransactionRepo
-	RepoAccount            *mock.MockAccountRepo
+	RepoPaymentType        *repomock.MockPaymentTypeRepo
+	RepoAccountTransaction *repomock.MockAccountTransactionRepo
+	RepoAccount            *repomock.MockAccountRepo
 	Clock                  *mock.MockClock
 }
 
@@ -46,14 +49,15 @@ func runTestCases(t *testing.T, testCases []accountTestCase) {
 }
 
 func newAccountUseCasesMock(ctrl *gomock.Controller) *accountUseCasesMock {
-	repoAccount := mock.NewMockAccountRepo(ctrl)
-	repoAccountTransaction := mock.NewMockAccountTransactionRepo(ctrl)
-	repoPaymentType := mock.NewMockPaymentTypeRepo(ctrl)
+	repoAccount := repomock.NewMockAccountRepo(ctrl)
+	repoAccountTransaction := repomock.NewMockAccountTransactionRepo(ctrl)
+	repoPaymentType := repomock.NewMockPaymentTypeRepo(ctrl)
 	clock := mock.NewMockClock(ctrl)
 
 	// We created 'clock' for mocking 'time.Now()'
 	// Transfer 'clock' here just because it doesn't work in any other way.
-	accountServiceUC := NewAccountService(repoAccount, repoAccountTransaction, repoPaymentType, clock)
+	accountServiceUC := NewAccountService(repoAccount, repoAccountTransaction, repoPaymentType, clock,
+		"000000000001", 5000)
 
 	return &accountUseCasesMock{
 		AccountServiceUC:       accountServiceUC,
@@ -78,7 +82,7 @@ func Test_Account_AddMoneyToAccount(t *testing.T) {
 				// The next we call the function we need ex:'GetPaymentTypeByID'
 				// 'Return' let us set the values which will be returned. We can also return an error.
 				// With 'Times' we set how many times the function will be called.
-				mock.RepoPaymentType.EXPECT().GetPaymentTypeById(2).
+				mock.RepoPaymentType.EXPECT().GetPaymentTypeById(gomock.Any(), 2).
 					Return(models.PaymentType{}, nil).Times(1)
 
 				// Here we are mocking the time of our 'Clock' which is a wrapper of the system service 'Time'
@@ -96,11 +100,11 @@ func Test_Account_AddMoneyToAccount(t *testing.T) {
 					AmountCents: 50}
 
 				//We call this func like in the order of calls into the real 'AddMoneyToAccount'.
-				mock.RepoAccountTransaction.EXPECT().AddAccountTransaction(accTransaction).
+				mock.RepoAccountTransaction.EXPECT().AddAccountTransaction(gomock.Any(), accTransaction).
 					Return(nil).Times(1)
 
 				// In this case we expect that function will be called without any errors.
-				err := mock.AccountServiceUC.AddMoneyToAccount(accTransaction.AccountTo, 50)
+				err := mock.AccountServiceUC.AddMoneyToAccount(context.Background(), accTransaction.AccountTo, 50)
 
 				// Compare that expected value of error is nil.
 				assert.Equal(t, nil, err)
@@ -113,7 +117,7 @@ func Test_Account_AddMoneyToAccount(t *testing.T) {
 				expectedError := errors.New("expectedError")
 
 				// Call 'GetPaymentTypeById' and return here our 'expectedError'.
-				mock.RepoPaymentType.EXPECT().GetPaymentTypeById(2).
+				mock.RepoPaymentType.EXPECT().GetPaymentTypeById(gomock.Any(), 2).
 					Return(models.PaymentType{}, expectedError).Times(1)
 
 				accTransaction := &models.AccountTransaction{
@@ -125,7 +129,7 @@ func Test_Account_AddMoneyToAccount(t *testing.T) {
 					AmountCents: 50}
 
 				// Calling 'AddMoneyToAccount' will return us the error, because we had the error into the func before.
-				err := mock.AccountServiceUC.AddMoneyToAccount(accTransaction.AccountTo, 50)
+				err := mock.AccountServiceUC.AddMoneyToAccount(context.Background(), accTransaction.AccountTo, 50)
 
 				assert.Error(t, err)
 				assert.Equal(t, expectedError, err)
@@ -156,19 +160,19 @@ func Test_Account_TakeMoneyFromAccount(t *testing.T) {
 			name: "Correct",
 			test: func(t *testing.T, mock *accountUseCasesMock) {
 
-				mock.RepoPaymentType.EXPECT().GetPaymentTypeById(3).
+				mock.RepoPaymentType.EXPECT().GetPaymentTypeById(gomock.Any(), 3).
 					Return(models.PaymentType{}, nil).Times(1)
 
 				mock.Clock.EXPECT().Now().Return(currentTime).Times(1)
 
 				mock.RepoAccountTransaction.EXPECT().
-					GetAccountTransactionsInTimePeriod(time.UnixMilli(0), currentTime, accTransaction.AccountFrom).
+					GetAccountTransactionsInTimePeriod(gomock.Any(), time.UnixMilli(0), currentTime, accTransaction.AccountFrom).
 					Return(accTransList, nil).Times(1)
 
-				mock.RepoAccountTransaction.EXPECT().AddAccountTransaction(accTransaction).
+				mock.RepoAccountTransaction.EXPECT().AddAccountTransaction(gomock.Any(), accTransaction).
 					Return(nil).Times(1)
 
-				err := mock.AccountServiceUC.TakeMoneyFromAccount(accTransaction.AccountFrom, 100)
+				err := mock.AccountServiceUC.TakeMoneyFromAccount(context.Background(), accTransaction.AccountFrom, 100)
 
 				assert.Equal(t, nil, err)
 			},
@@ -177,16 +181,16 @@ func Test_Account_TakeMoneyFromAccount(t *testing.T) {
 			name: "Incorrect, not enough money",
 			test: func(t *testing.T, mock *accountUseCasesMock) {
 
-				mock.RepoPaymentType.EXPECT().GetPaymentTypeById(3).
+				mock.RepoPaymentType.EXPECT().GetPaymentTypeById(gomock.Any(), 3).
 					Return(models.PaymentType{}, nil).Times(1)
 
 				mock.Clock.EXPECT().Now().Return(currentTime).Times(1)
 
 				mock.RepoAccountTransaction.EXPECT().
-					GetAccountTransactionsInTimePeriod(time.UnixMilli(0), currentTime, accTransaction.AccountFrom).
+					GetAccountTransactionsInTimePeriod(gomock.Any(), time.UnixMilli(0), currentTime, accTransaction.AccountFrom).
 					Return(accTransList, nil).Times(1)
 
-				err := mock.AccountServiceUC.TakeMoneyFromAccount(accTransaction.AccountFrom, 200)
+				err := mock.AccountServiceUC.TakeMoneyFromAccount(context.Background(), accTransaction.AccountFrom, 200)
 
 				assert.Error(t, err)
 				assert.Equal(t, ErrNotEnoughMoneyToTake, err)
@@ -194,3 +198,153 @@ func Test_Account_TakeMoneyFromAccount(t *testing.T) {
 		},
 	})
 }
+
+func Test_Account_CheckTripDeposit(t *testing.T) {
+	var currentTime = time.Date(2021, 12, 19, 12, 21, 00, 00, time.UTC)
+	user := models.User{ID: 5}
+	account := models.Account{ID: 3, User: user}
+
+	runTestCases(t, []accountTestCase{
+		{
+			name: "Correct",
+			test: func(t *testing.T, mock *accountUseCasesMock) {
+				mock.RepoAccount.EXPECT().GetAccountsByOwner(gomock.Any(), user).
+					Return(&models.AccountList{Accounts: []models.Account{account}}, nil).Times(1)
+				mock.Clock.EXPECT().Now().Return(currentTime).Times(1)
+				mock.RepoAccountTransaction.EXPECT().
+					GetAccountTransactionsInTimePeriod(gomock.Any(), time.UnixMilli(0), currentTime, account).
+					Return(&models.AccountTransactionList{AccountTransactions: []models.AccountTransaction{
+						{AccountTo: account, AmountCents: 5000}}}, nil).Times(1)
+
+				err := mock.AccountServiceUC.CheckTripDeposit(context.Background(), user)
+				assert.Equal(t, nil, err)
+			},
+		},
+		{
+			name: "Incorrect, not enough money",
+			test: func(t *testing.T, mock *accountUseCasesMock) {
+				mock.RepoAccount.EXPECT().GetAccountsByOwner(gomock.Any(), user).
+					Return(&models.AccountList{Accounts: []models.Account{account}}, nil).Times(1)
+				mock.Clock.EXPECT().Now().Return(currentTime).Times(1)
+				mock.RepoAccountTransaction.EXPECT().
+					GetAccountTransactionsInTimePeriod(gomock.Any(), time.UnixMilli(0), currentTime, account).
+					Return(&models.AccountTransactionList{AccountTransactions: []models.AccountTransaction{
+						{AccountTo: account, AmountCents: 5000}, {AccountFrom: account, AmountCents: 1}}}, nil).Times(1)
+
+				err := mock.AccountServiceUC.CheckTripDeposit(context.Background(), user)
+				assert.Equal(t, ErrNotEnoughMoneyForTrip, err)
+			},
+		},
+		{
+			name: "Incorrect, no account",
+			test: func(t *testing.T, mock *accountUseCasesMock) {
+				mock.RepoAccount.EXPECT().GetAccountsByOwner(gomock.Any(), user).
+					Return(&models.AccountList{}, nil).Times(1)
+
+				err := mock.AccountServiceUC.CheckTripDeposit(context.Background(), user)
+				assert.Equal(t, ErrNoAccount, err)
+			},
+		},
+	})
+}
+
+func Test_Account_SettleOrder(t *testing.T) {
+	var currentTime = time.Date(2021, 12, 19, 12, 21, 00, 00, time.UTC)
+	order := models.Order{ID: 1, UserID: 5, Amount: 1500}
+	price := models.PriceBreakdown{OrderID: 1, SupplierID: 9, PaymentTypeID: 4, TotalCents: 1500,
+		CommissionCents: 150, SupplierCents: 1350}
+	riderAccount := models.Account{ID: 3}
+	supplierAccount := models.Account{ID: 1}
+	platformAccount := models.Account{ID: 10}
+
+	runTestCases(t, []accountTestCase{
+		{
+			name: "Correct",
+			test: func(t *testing.T, mock *accountUseCasesMock) {
+				mock.RepoAccountTransaction.EXPECT().GetAccountTransactionsByOrder(gomock.Any(), order).
+					Return(&models.AccountTransactionList{}, nil).Times(1)
+				mock.RepoAccount.EXPECT().GetAccountsByOwner(gomock.Any(), models.User{ID: 5}).
+					Return(&models.AccountList{Accounts: []models.Account{riderAccount}}, nil).Times(1)
+				mock.RepoAccount.EXPECT().GetAccountsByOwner(gomock.Any(), models.User{ID: 9}).
+					Return(&models.AccountList{Accounts: []models.Account{supplierAccount}}, nil).Times(1)
+				mock.RepoPaymentType.EXPECT().GetPaymentTypeById(gomock.Any(), 4).
+					Return(models.PaymentType{ID: 4}, nil).Times(1)
+				mock.Clock.EXPECT().Now().Return(currentTime).Times(1)
+				mock.RepoAccount.EXPECT().GetAccountByNumber(gomock.Any(), "000000000001").
+					Return(platformAccount, nil).Times(1)
+				mock.RepoPaymentType.EXPECT().GetPaymentTypeById(gomock.Any(), PayCommissionTypeID).
+					Return(models.PaymentType{ID: PayCommissionTypeID}, nil).Times(1)
+
+				mock.RepoAccountTransaction.EXPECT().AddAccountTransactions(gomock.Any(),
+					&models.AccountTransaction{DateTime: currentTime, PaymentType: models.PaymentType{ID: 4},
+						AccountFrom: riderAccount, AccountTo: supplierAccount, Order: order, AmountCents: 1350},
+					&models.AccountTransaction{DateTime: currentTime,
+						PaymentType: models.PaymentType{ID: PayCommissionTypeID}, AccountFrom: riderAccount,
+						AccountTo: platformAccount, Order: order, AmountCents: 150}).
+					Return(nil).Times(1)
+
+				err := mock.AccountServiceUC.SettleOrder(context.Background(), order, price)
+				assert.Equal(t, nil, err)
+			},
+		},
+		{
+			name: "Incorrect, already settled",
+			test: func(t *testing.T, mock *accountUseCasesMock) {
+				mock.RepoAccountTransaction.EXPECT().GetAccountTransactionsByOrder(gomock.Any(), order).
+					Return(&models.AccountTransactionList{AccountTransactions: []models.AccountTransaction{{ID: 1}}},
+						nil).Times(1)
+
+				err := mock.AccountServiceUC.SettleOrder(context.Background(), order, price)
+				assert.Equal(t, ErrOrderAlreadySettled, err)
+			},
+		},
+	})
+}
+
+func Test_Account_EnsurePlatformAccount(t *testing.T) {
+	platformAccount := models.Account{ID: 9, Name: "Platform account", Number: "000000000001", User: models.User{ID: 1}}
+
+	runTestCases(t, []accountTestCase{
+		{
+			name: "Correct, account exists",
+			test: func(t *testing.T, mock *accountUseCasesMock) {
+				mock.RepoAccount.EXPECT().GetAccountByNumber(gomock.Any(), "000000000001").
+					Return(platformAccount, nil).Times(1)
+				mock.RepoAccount.EXPECT().AddAccount(gomock.Any(), gomock.Any()).Times(0)
+
+				account, err := mock.AccountServiceUC.EnsurePlatformAccount(context.Background(), 1)
+				assert.Equal(t, nil, err)
+				assert.Equal(t, platformAccount, account)
+			},
+		},
+		{
+			name: "Correct, account is created",
+			test: func(t *testing.T, mock *accountUseCasesMock) {
+				mock.RepoAccount.EXPECT().GetAccountByNumber(gomock.Any(), "000000000001").
+					Return(models.Account{}, repositories.ErrNoAccount).Times(1)
+				mock.RepoAccount.EXPECT().AddAccount(gomock.Any(),
+					&models.Account{Name: "Platform account", Number: "000000000001", User: models.User{ID: 1}}).
+					DoAndReturn(func(ctx context.Context, account *models.Account) error {
+						account.ID = 9
+						return nil
+					}).Times(1)
+
+				account, err := mock.AccountServiceUC.EnsurePlatformAccount(context.Background(), 1)
+				assert.Equal(t, nil, err)
+				assert.Equal(t, platformAccount, account)
+			},
+		},
+		{
+			name: "Incorrect, owner doesn't exist",
+			test: func(t *testing.T, mock *accountUseCasesMock) {
+				errOwner := errors.New("violates foreign key constraint")
+				mock.RepoAccount.EXPECT().GetAccountByNumber(gomock.Any(), "000000000001").
+					Return(models.Account{}, repositories.ErrNoAccount).Times(2)
+				mock.RepoAccount.EXPECT().AddAccount(gomock.Any(), gomock.Any()).Return(errOwner).Times(1)
+
+				_, err := mock.AccountServiceUC.EnsurePlatformAccount(context.Background(), 1)
+				assert.Equal(t, errOwner, err)
+			},
+		},
+	})
+}
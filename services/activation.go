@@ -0,0 +1,137 @@
+package services
+
+import (
+	"Dp218GO/models"
+	"Dp218GO/repositories"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+//defaultActivationTTL is the lifetime of the activation link if it isn't configured.
+const defaultActivationTTL = 24 * time.Hour
+
+var (
+	ErrActivationInvalid = errors.New("activation link is not valid")
+	ErrActivationExpired = errors.New("activation link has expired")
+)
+
+//ActivationService sends the activation links to the signed up users and activates their accounts.
+//The link token is signed with the secret and carries the user ID and the expiry time,
+//the signature also covers the user email, so the token is bound to the email it was sent to.
+type ActivationService struct {
+	repoUser repositories.UserRepo
+	mailer   Mailer
+	clock    Clock
+	secret   []byte
+	ttl      time.Duration
+	baseURL  string
+}
+
+//NewActivationService creates the new ActivationService. baseURL is the address of the application
+//which is used for the activation links.
+func NewActivationService(userRepo repositories.UserRepo, mailer Mailer, clock Clock, secret string,
+	ttl time.Duration, baseURL string) *ActivationService {
+	if ttl <= 0 {
+		ttl = defaultActivationTTL
+	}
+	return &ActivationService{repoUser: userRepo, mailer: mailer, clock: clock, secret: []byte(secret),
+		ttl: ttl, baseURL: strings.TrimRight(baseURL, "/")}
+}
+
+//SendActivation sends the activation link to the email of the user.
+func (as *ActivationService) SendActivation(ctx context.Context, user models.User) error {
+	if user.IsActivated {
+		return repositories.ErrAlreadyActivated
+	}
+
+	token := as.token(user, as.clock.Now().Add(as.ttl))
+	link := as.baseURL + "/activate?token=" + url.QueryEscape(token)
+	body := fmt.Sprintf("Hello, %s!\n\nTo activate your account open the link below:\n%s\n\nThe link is valid for %s.",
+		user.UserName, link, as.ttl)
+
+	return as.mailer.Send(ctx, user.LoginEmail, "Account activation", body)
+}
+
+//Resend sends the new activation link to the user with the given email.
+func (as *ActivationService) Resend(ctx context.Context, email string) error {
+	user, err := as.repoUser.GetUserByEmail(ctx, email)
+	if err != nil {
+		return err
+	}
+
+	return as.SendActivation(ctx, user)
+}
+
+//Activate checks the token from the activation link and unblocks its user.
+func (as *ActivationService) Activate(ctx context.Context, token string) error {
+	userID, expiresAt, signature, err := parseActivationToken(token)
+	if err != nil {
+		return err
+	}
+
+	user, err := as.repoUser.GetUserByID(ctx, userID)
+	if err != nil {
+		return ErrActivationInvalid
+	}
+	if !hmac.Equal(signature, as.sign(user, expiresAt)) {
+		return ErrActivationInvalid
+	}
+
+	now := as.clock.Now()
+	if !now.Before(expiresAt) {
+		return ErrActivationExpired
+	}
+
+	return as.repoUser.ActivateUser(ctx, userID, now)
+}
+
+func (as *ActivationService) token(user models.User, expiresAt time.Time) string {
+	payload := fmt.Sprintf("%d:%d", user.ID, expiresAt.Unix())
+	return base64.RawURLEncoding.EncodeToString([]byte(payload)) + "." +
+		base64.RawURLEncoding.EncodeToString(as.sign(user, expiresAt))
+}
+
+func (as *ActivationService) sign(user models.User, expiresAt time.Time) []byte {
+	mac := hmac.New(sha256.New, as.secret)
+	fmt.Fprintf(mac, "%d:%d:%s", user.ID, expiresAt.Unix(), user.LoginEmail)
+	return mac.Sum(nil)
+}
+
+func parseActivationToken(token string) (int, time.Time, []byte, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 2 {
+		return 0, time.Time{}, nil, ErrActivationInvalid
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil {
+		return 0, time.Time{}, nil, ErrActivationInvalid
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return 0, time.Time{}, nil, ErrActivationInvalid
+	}
+
+	fields := strings.Split(string(payload), ":")
+	if len(fields) != 2 {
+		return 0, time.Time{}, nil, ErrActivationInvalid
+	}
+	userID, err := strconv.Atoi(fields[0])
+	if err != nil {
+		return 0, time.Time{}, nil, ErrActivationInvalid
+	}
+	expires, err := strconv.ParseInt(fields[1], 10, 64)
+	if err != nil {
+		return 0, time.Time{}, nil, ErrActivationInvalid
+	}
+
+	return userID, time.Unix(expires, 0), signature, nil
+}
@@ -0,0 +1,159 @@
+package services
+
+import (
+	"Dp218GO/models"
+	"Dp218GO/repositories"
+	repomock "Dp218GO/repositories/mock"
+	"Dp218GO/services/mock"
+	"context"
+	"github.com/golang/mock/gomock"
+	assert "github.com/stretchr/testify/require"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+const testActivationTTL = time.Hour
+
+var activationTime = time.Date(2021, 12, 20, 10, 0, 0, 0, time.UTC)
+
+//testMailer keeps the last sent email instead of sending it.
+type testMailer struct {
+	to   string
+	body string
+	//sent keeps the last email body of every address.
+	sent map[string]string
+}
+
+func (m *testMailer) Send(ctx context.Context, to, subject, body string) error {
+	m.to, m.body = to, body
+	if m.sent == nil {
+		m.sent = make(map[string]string)
+	}
+	m.sent[to] = body
+	return nil
+}
+
+//token returns the token of the activation link from the last email.
+func (m *testMailer) token() string {
+	start := strings.Index(m.body, "token=") + len("token=")
+	token, _ := url.QueryUnescape(strings.Fields(m.body[start:])[0])
+	return token
+}
+
+type activationUseCasesMock struct {
+	ActivationServiceUC *ActivationService
+	RepoUser            *repomock.MockUserRepo
+	Clock               *mock.MockClock
+	Mailer              *testMailer
+}
+
+type activationTestCase struct {
+	name string
+	test func(t *testing.T, mock *activationUseCasesMock)
+}
+
+func runActivationTestCases(t *testing.T, testCases []activationTestCase) {
+	for _, tc := range testCases {
+		t.Run(tc.name, func(tt *testing.T) {
+			defer func() {
+				if err := recover(); err != nil {
+					tt.Error(err)
+				}
+			}()
+
+			ctrl := gomock.NewController(tt)
+			defer ctrl.Finish()
+
+			mock := newActivationUseCasesMock(ctrl)
+
+			tc.test(tt, mock)
+		})
+	}
+}
+
+func newActivationUseCasesMock(ctrl *gomock.Controller) *activationUseCasesMock {
+	repoUser := repomock.NewMockUserRepo(ctrl)
+	clock := mock.NewMockClock(ctrl)
+	mailer := &testMailer{}
+
+	return &activationUseCasesMock{
+		ActivationServiceUC: NewActivationService(repoUser, mailer, clock, "secret", testActivationTTL,
+			"http://localhost:8080/"),
+		RepoUser: repoUser,
+		Clock:    clock,
+		Mailer:   mailer,
+	}
+}
+
+func Test_Activation_Activate(t *testing.T) {
+	user := models.User{ID: 5, LoginEmail: "new@mail.com", UserName: "New", IsBlocked: true}
+
+	runActivationTestCases(t, []activationTestCase{
+		{
+			name: "Correct",
+			test: func(t *testing.T, mock *activationUseCasesMock) {
+				now := activationTime.Add(time.Minute)
+				mock.Clock.EXPECT().Now().Return(activationTime).Times(1)
+				mock.Clock.EXPECT().Now().Return(now).Times(1)
+				mock.RepoUser.EXPECT().GetUserByID(gomock.Any(), 5).Return(user, nil).Times(1)
+				mock.RepoUser.EXPECT().ActivateUser(gomock.Any(), 5, now).Return(nil).Times(1)
+
+				assert.Nil(t, mock.ActivationServiceUC.SendActivation(context.Background(), user))
+				assert.Equal(t, user.LoginEmail, mock.Mailer.to)
+				assert.Contains(t, mock.Mailer.body, "http://localhost:8080/activate?token=")
+
+				assert.Nil(t, mock.ActivationServiceUC.Activate(context.Background(), mock.Mailer.token()))
+			},
+		},
+		{
+			name: "Expired",
+			test: func(t *testing.T, mock *activationUseCasesMock) {
+				mock.Clock.EXPECT().Now().Return(activationTime).Times(1)
+				mock.Clock.EXPECT().Now().Return(activationTime.Add(testActivationTTL)).Times(1)
+				mock.RepoUser.EXPECT().GetUserByID(gomock.Any(), 5).Return(user, nil).Times(1)
+
+				assert.Nil(t, mock.ActivationServiceUC.SendActivation(context.Background(), user))
+				err := mock.ActivationServiceUC.Activate(context.Background(), mock.Mailer.token())
+				assert.ErrorIs(t, err, ErrActivationExpired)
+			},
+		},
+		{
+			name: "EmailChanged",
+			test: func(t *testing.T, mock *activationUseCasesMock) {
+				changed := user
+				changed.LoginEmail = "other@mail.com"
+				mock.Clock.EXPECT().Now().Return(activationTime).Times(1)
+				mock.RepoUser.EXPECT().GetUserByID(gomock.Any(), 5).Return(changed, nil).Times(1)
+
+				assert.Nil(t, mock.ActivationServiceUC.SendActivation(context.Background(), user))
+				err := mock.ActivationServiceUC.Activate(context.Background(), mock.Mailer.token())
+				assert.ErrorIs(t, err, ErrActivationInvalid)
+			},
+		},
+		{
+			name: "Malformed",
+			test: func(t *testing.T, mock *activationUseCasesMock) {
+				err := mock.ActivationServiceUC.Activate(context.Background(), "not-a-token")
+				assert.ErrorIs(t, err, ErrActivationInvalid)
+			},
+		},
+	})
+}
+
+func Test_Activation_Resend(t *testing.T) {
+	runActivationTestCases(t, []activationTestCase{
+		{
+			name: "AlreadyActivated",
+			test: func(t *testing.T, mock *activationUseCasesMock) {
+				mock.RepoUser.EXPECT().GetUserByEmail(gomock.Any(), "user@mail.com").
+					Return(models.User{ID: 6, LoginEmail: "user@mail.com", IsActivated: true}, nil).Times(1)
+
+				err := mock.ActivationServiceUC.Resend(context.Background(), "user@mail.com")
+				assert.ErrorIs(t, err, repositories.ErrAlreadyActivated)
+				assert.Empty(t, mock.Mailer.to)
+			},
+		},
+	})
+}
@@ -0,0 +1,108 @@
+package services
+
+import (
+	"Dp218GO/internal/validation"
+	"Dp218GO/models"
+	"Dp218GO/repositories"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+//ErrInvalidContact is returned if the contact value doesn't follow the rules of its type.
+var ErrInvalidContact = errors.New("invalid contact")
+
+//ContactService manages the phone numbers, secondary emails and other contacts of the users.
+type ContactService struct {
+	repoContact repositories.ContactRepo
+}
+
+//NewContactService creates the new ContactService.
+func NewContactService(contactRepo repositories.ContactRepo) *ContactService {
+	return &ContactService{repoContact: contactRepo}
+}
+
+//GetContactTypes returns all the kinds of contacts the users can add.
+func (cs *ContactService) GetContactTypes(ctx context.Context) (*models.ContactTypeList, error) {
+	return cs.repoContact.GetContactTypes(ctx)
+}
+
+//GetContacts returns the contacts of the user.
+func (cs *ContactService) GetContacts(ctx context.Context, userID int) (*models.ContactList, error) {
+	return cs.repoContact.GetContactsByUserID(ctx, userID)
+}
+
+//AddContact validates the contact by the rules of its type and adds it to the user.
+func (cs *ContactService) AddContact(ctx context.Context, userID, typeID int, info string,
+	primary bool) (models.Contact, error) {
+	contactType, err := cs.repoContact.GetContactTypeByID(ctx, typeID)
+	if err != nil {
+		return models.Contact{}, err
+	}
+
+	contact := models.Contact{
+		UserID:      userID,
+		Type:        contactType,
+		ContactInfo: strings.TrimSpace(info),
+		IsPrimary:   primary,
+	}
+	if err = validateContact(contact); err != nil {
+		return models.Contact{}, err
+	}
+
+	err = cs.repoContact.AddContact(ctx, &contact)
+	return contact, err
+}
+
+//UpdateContact changes the value and the primary flag of the contact of the user.
+func (cs *ContactService) UpdateContact(ctx context.Context, userID, contactID int, info string,
+	primary bool) (models.Contact, error) {
+	contact, err := cs.repoContact.GetContactByID(ctx, userID, contactID)
+	if err != nil {
+		return models.Contact{}, err
+	}
+
+	contact.ContactInfo = strings.TrimSpace(info)
+	contact.IsPrimary = primary
+	if err = validateContact(contact); err != nil {
+		return models.Contact{}, err
+	}
+
+	err = cs.repoContact.UpdateContact(ctx, contact)
+	return contact, err
+}
+
+//DeleteContact removes the contact of the user.
+func (cs *ContactService) DeleteContact(ctx context.Context, userID, contactID int) error {
+	return cs.repoContact.DeleteContact(ctx, userID, contactID)
+}
+
+func validateContact(contact models.Contact) error {
+	err := validation.ContactRequest{
+		TypeCode:    contact.Type.Code,
+		ContactInfo: contact.ContactInfo,
+	}.Validate()
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidContact, err)
+	}
+	return nil
+}
+
+//secondaryEmail returns the primary secondary email of the user which gets the notifications besides
+//the login email. The secondary emails are not verified, so they must never get the links which grant
+//access to the account.
+func secondaryEmail(ctx context.Context, contactRepo repositories.ContactRepo, user models.User) (string, bool, error) {
+	contact, err := contactRepo.GetPrimaryContact(ctx, user.ID, models.ContactTypeEmail)
+	if errors.Is(err, repositories.ErrNoContact) {
+		return "", false, nil
+	}
+	if err != nil {
+		return "", false, err
+	}
+
+	if strings.EqualFold(contact.ContactInfo, user.LoginEmail) {
+		return "", false, nil
+	}
+	return contact.ContactInfo, true, nil
+}
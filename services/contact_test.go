@@ -0,0 +1,131 @@
+package services
+
+import (
+	"Dp218GO/models"
+	"Dp218GO/repositories"
+	repomock "Dp218GO/repositories/mock"
+	"context"
+	"github.com/golang/mock/gomock"
+	assert "github.com/stretchr/testify/require"
+	"testing"
+)
+
+var (
+	phoneType = models.ContactType{ID: 1, Code: models.ContactTypePhone, Name: "Phone number"}
+	emailType = models.ContactType{ID: 2, Code: models.ContactTypeEmail, Name: "Secondary email"}
+)
+
+type contactUseCasesMock struct {
+	ContactServiceUC *ContactService
+	RepoContact      *repomock.MockContactRepo
+}
+
+type contactTestCase struct {
+	name string
+	test func(t *testing.T, mock *contactUseCasesMock)
+}
+
+func runContactTestCases(t *testing.T, testCases []contactTestCase) {
+	for _, tc := range testCases {
+		t.Run(tc.name, func(tt *testing.T) {
+			defer func() {
+				if err := recover(); err != nil {
+					tt.Error(err)
+				}
+			}()
+
+			ctrl := gomock.NewController(tt)
+			defer ctrl.Finish()
+
+			mock := newContactUseCasesMock(ctrl)
+
+			tc.test(tt, mock)
+		})
+	}
+}
+
+func newContactUseCasesMock(ctrl *gomock.Controller) *contactUseCasesMock {
+	repoContact := repomock.NewMockContactRepo(ctrl)
+
+	return &contactUseCasesMock{
+		ContactServiceUC: NewContactService(repoContact),
+		RepoContact:      repoContact,
+	}
+}
+
+func Test_Contact_AddContact(t *testing.T) {
+	runContactTestCases(t, []contactTestCase{
+		{
+			name: "Phone",
+			test: func(t *testing.T, mock *contactUseCasesMock) {
+				mock.RepoContact.EXPECT().GetContactTypeByID(gomock.Any(), 1).Return(phoneType, nil).Times(1)
+				mock.RepoContact.EXPECT().AddContact(gomock.Any(), &models.Contact{UserID: 5, Type: phoneType,
+					ContactInfo: "+380501234567", IsPrimary: true}).
+					DoAndReturn(func(ctx context.Context, contact *models.Contact) error {
+						contact.ID = 9
+						return nil
+					}).Times(1)
+
+				contact, err := mock.ContactServiceUC.AddContact(context.Background(), 5, 1, " +380501234567 ", true)
+				assert.Nil(t, err)
+				assert.Equal(t, 9, contact.ID)
+			},
+		},
+		{
+			name: "InvalidPhone",
+			test: func(t *testing.T, mock *contactUseCasesMock) {
+				mock.RepoContact.EXPECT().GetContactTypeByID(gomock.Any(), 1).Return(phoneType, nil).Times(1)
+
+				_, err := mock.ContactServiceUC.AddContact(context.Background(), 5, 1, "050-123-45-67", false)
+				assert.ErrorIs(t, err, ErrInvalidContact)
+			},
+		},
+		{
+			name: "InvalidEmail",
+			test: func(t *testing.T, mock *contactUseCasesMock) {
+				mock.RepoContact.EXPECT().GetContactTypeByID(gomock.Any(), 2).Return(emailType, nil).Times(1)
+
+				_, err := mock.ContactServiceUC.AddContact(context.Background(), 5, 2, "not an email", false)
+				assert.ErrorIs(t, err, ErrInvalidContact)
+			},
+		},
+		{
+			name: "UnknownType",
+			test: func(t *testing.T, mock *contactUseCasesMock) {
+				mock.RepoContact.EXPECT().GetContactTypeByID(gomock.Any(), 7).
+					Return(models.ContactType{}, repositories.ErrNoContactType).Times(1)
+
+				_, err := mock.ContactServiceUC.AddContact(context.Background(), 5, 7, "value", false)
+				assert.ErrorIs(t, err, repositories.ErrNoContactType)
+			},
+		},
+	})
+}
+
+func Test_Contact_UpdateContact(t *testing.T) {
+	runContactTestCases(t, []contactTestCase{
+		{
+			name: "Correct",
+			test: func(t *testing.T, mock *contactUseCasesMock) {
+				mock.RepoContact.EXPECT().GetContactByID(gomock.Any(), 5, 3).
+					Return(models.Contact{ID: 3, UserID: 5, Type: emailType, ContactInfo: "old@mail.com"}, nil).Times(1)
+				mock.RepoContact.EXPECT().UpdateContact(gomock.Any(), models.Contact{ID: 3, UserID: 5, Type: emailType,
+					ContactInfo: "new@mail.com", IsPrimary: true}).Return(nil).Times(1)
+
+				contact, err := mock.ContactServiceUC.UpdateContact(context.Background(), 5, 3, "new@mail.com", true)
+				assert.Nil(t, err)
+				assert.True(t, contact.IsPrimary)
+			},
+		},
+		{
+			name: "AnotherUser",
+			test: func(t *testing.T, mock *contactUseCasesMock) {
+				mock.RepoContact.EXPECT().GetContactByID(gomock.Any(), 6, 3).
+					Return(models.Contact{}, repositories.ErrNoContact).Times(1)
+
+				_, err := mock.ContactServiceUC.UpdateContact(context.Background(), 6, 3, "new@mail.com", true)
+				assert.ErrorIs(t, err, repositories.ErrNoContact)
+			},
+		},
+	})
+}
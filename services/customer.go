@@ -3,9 +3,27 @@ package services
 import (
 	"Dp218GO/models"
 	"Dp218GO/repositories"
+	"context"
+	"errors"
+	"fmt"
 	"math"
+	"sort"
 )
 
+// limits of the nearest stations search, distances are in meters
+const (
+	DefaultSearchRadius = 2000
+	MaxSearchRadius     = 50000
+	DefaultNearestLimit = 5
+	MaxNearestLimit     = 50
+)
+
+// walkingSpeed - average walking speed in meters per second (5 km/h)
+const walkingSpeed = 5000.0 / 3600
+
+// ErrInvalidGeoQuery is returned when the nearest stations search has wrong parameters
+var ErrInvalidGeoQuery = errors.New("invalid station search")
+
 // CustomerService takes repostation interface
 // serves as main service for customer interaction with system
 type CustomerService struct {
@@ -20,47 +38,72 @@ func NewCustomerService(repo repositories.StationRepo) *CustomerService {
 }
 
 // ListStations returns station list from db or error if failed
-func (cs *CustomerService) ListStations() (*models.StationList, error) {
-	return cs.repoStation.GetAllStations()
+func (cs *CustomerService) ListStations(ctx context.Context) (*models.StationList, error) {
+	return cs.repoStation.GetAllStations(ctx)
 }
 
 // ShowStation returns station from db by id or error if failed
-func (cs *CustomerService) ShowStation(id int) (*models.Station, error) {
-	station, err := cs.repoStation.GetStationById(id)
+func (cs *CustomerService) ShowStation(ctx context.Context, id int) (*models.Station, error) {
+	station, err := cs.repoStation.GetStationById(ctx, id)
 	if err != nil {
 		return nil, err
 	}
 	return &station, nil
 }
 
-// ShowNearestStation takes user location and returns nearest station or error if failed
-func (cs *CustomerService) ShowNearestStation(x, y float64) (*models.Station, error) {
+// NearestStations returns up to limit active stations within radius (in meters) of the point,
+// nearest first; with onlyAvailable set the stations without rentable scooters are skipped.
+// Zero radius and limit are replaced by the defaults
+func (cs *CustomerService) NearestStations(ctx context.Context, point models.Coordinate, radius float64, limit int,
+	onlyAvailable bool) ([]models.NearbyStation, error) {
+	if radius == 0 {
+		radius = DefaultSearchRadius
+	}
+	if limit == 0 {
+		limit = DefaultNearestLimit
+	}
+	if err := validateNearestQuery(point, radius, limit); err != nil {
+		return nil, err
+	}
 
-	stations, err := cs.repoStation.GetAllStations()
+	southWest, northEast := point.BoundingBox(radius)
+	candidates, err := cs.repoStation.FindActiveInBox(ctx, southWest, northEast)
 	if err != nil {
 		return nil, err
 	}
 
-	nearest := calcNearest(x, y, stations.Station)
+	nearest := make([]models.NearbyStation, 0, len(candidates))
+	for _, st := range candidates {
+		st.Distance = point.Distance(models.Coordinate{Latitude: st.Latitude, Longitude: st.Longitude})
+		if st.Distance > radius || onlyAvailable && st.RentableScooters == 0 {
+			continue
+		}
+		st.WalkingMinutes = walkingMinutes(st.Distance)
+		nearest = append(nearest, st)
+	}
+
+	sort.SliceStable(nearest, func(i, j int) bool {
+		return nearest[i].Distance < nearest[j].Distance
+	})
+	if len(nearest) > limit {
+		nearest = nearest[:limit]
+	}
 	return nearest, nil
 }
 
-func calcNearest(x, y float64, sts []models.Station) *models.Station {
-
-	min := math.MaxFloat64
-	var nearest models.Station
-
-	for _, v := range sts {
-		dis := calcDistance(x, y, v.Latitude, v.Longitude)
-		if dis < min {
-			min = dis
-			nearest = v
-		}
+func validateNearestQuery(point models.Coordinate, radius float64, limit int) error {
+	switch {
+	case point.Latitude < -90 || point.Latitude > 90 || point.Longitude < -180 || point.Longitude > 180:
+		return fmt.Errorf("%w: coordinates are out of range", ErrInvalidGeoQuery)
+	case radius < 0 || radius > MaxSearchRadius:
+		return fmt.Errorf("%w: radius must be between 0 and %v meters", ErrInvalidGeoQuery, MaxSearchRadius)
+	case limit < 0 || limit > MaxNearestLimit:
+		return fmt.Errorf("%w: limit must be between 0 and %d", ErrInvalidGeoQuery, MaxNearestLimit)
 	}
-	return &nearest
+	return nil
 }
 
-func calcDistance(x1, y1, x2, y2 float64) float64 {
-	z := math.Pow(math.Abs(x1-x2), 2) + math.Pow(math.Abs(y1-y2), 2)
-	return math.Sqrt(z)
+// walkingMinutes - time to walk the distance (in meters), rounded up to whole minutes
+func walkingMinutes(distance float64) int {
+	return int(math.Ceil(distance / walkingSpeed / 60))
 }
@@ -0,0 +1,144 @@
+package services
+
+import (
+	"Dp218GO/models"
+	repomock "Dp218GO/repositories/mock"
+	"context"
+	"errors"
+	"github.com/golang/mock/gomock"
+	assert "github.com/stretchr/testify/require"
+	"testing"
+)
+
+// user is at Pobeda3 station, Private Sector is ~1.7 km and Dafi Mall ~1.8 km far from it
+var (
+	userPoint   = models.Coordinate{Latitude: 48.42367, Longitude: 35.04436}
+	pobeda      = models.NearbyStation{Station: models.Station{ID: 1, Name: "Pobeda3", IsActive: true, Latitude: 48.42367, Longitude: 35.04436}}
+	dafi        = models.NearbyStation{Station: models.Station{ID: 2, Name: "Dafi Mall", IsActive: true, Latitude: 48.4221, Longitude: 35.0196}, RentableScooters: 3}
+	privateSect = models.NearbyStation{Station: models.Station{ID: 3, Name: "Private Sector", IsActive: true, Latitude: 48.42543, Longitude: 35.02183}, RentableScooters: 1}
+)
+
+type customerUseCasesMock struct {
+	CustomerServiceUC *CustomerService
+	RepoStation       *repomock.MockStationRepo
+}
+
+type customerTestCase struct {
+	name string
+	test func(t *testing.T, mock *customerUseCasesMock)
+}
+
+func runCustomerTestCases(t *testing.T, testCases []customerTestCase) {
+	for _, tc := range testCases {
+		t.Run(tc.name, func(tt *testing.T) {
+			defer func() {
+				if err := recover(); err != nil {
+					tt.Error(err)
+				}
+			}()
+
+			ctrl := gomock.NewController(tt)
+			defer ctrl.Finish()
+
+			mock := newCustomerUseCasesMock(ctrl)
+
+			tc.test(tt, mock)
+		})
+	}
+}
+
+func newCustomerUseCasesMock(ctrl *gomock.Controller) *customerUseCasesMock {
+	repoStation := repomock.NewMockStationRepo(ctrl)
+
+	return &customerUseCasesMock{
+		CustomerServiceUC: NewCustomerService(repoStation),
+		RepoStation:       repoStation,
+	}
+}
+
+func Test_Customer_NearestStations(t *testing.T) {
+	runCustomerTestCases(t, []customerTestCase{
+		{
+			name: "SortedByDistance",
+			test: func(t *testing.T, mock *customerUseCasesMock) {
+				sw, ne := userPoint.BoundingBox(DefaultSearchRadius)
+				mock.RepoStation.EXPECT().FindActiveInBox(gomock.Any(), sw, ne).
+					Return([]models.NearbyStation{privateSect, dafi, pobeda}, nil).Times(1)
+
+				stations, err := mock.CustomerServiceUC.NearestStations(context.Background(), userPoint, 0, 0, false)
+				assert.Nil(t, err)
+				assert.Len(t, stations, 3)
+				assert.Equal(t, 1, stations[0].ID)
+				assert.Equal(t, 0.0, stations[0].Distance)
+				assert.Equal(t, 0, stations[0].WalkingMinutes)
+				assert.Equal(t, 3, stations[1].ID)
+				assert.Equal(t, 2, stations[2].ID)
+				assert.InDelta(t, 1837, stations[2].Distance, 1)
+				assert.Equal(t, 23, stations[2].WalkingMinutes)
+			},
+		},
+		{
+			name: "RadiusAndLimit",
+			test: func(t *testing.T, mock *customerUseCasesMock) {
+				mock.RepoStation.EXPECT().FindActiveInBox(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return([]models.NearbyStation{privateSect, dafi, pobeda}, nil).Times(1)
+
+				stations, err := mock.CustomerServiceUC.NearestStations(context.Background(), userPoint, 1800, 1, false)
+				assert.Nil(t, err)
+				assert.Len(t, stations, 1)
+				assert.Equal(t, 1, stations[0].ID)
+			},
+		},
+		{
+			name: "OnlyAvailable",
+			test: func(t *testing.T, mock *customerUseCasesMock) {
+				mock.RepoStation.EXPECT().FindActiveInBox(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return([]models.NearbyStation{privateSect, dafi, pobeda}, nil).Times(1)
+
+				stations, err := mock.CustomerServiceUC.NearestStations(context.Background(), userPoint, 0, 0, true)
+				assert.Nil(t, err)
+				assert.Len(t, stations, 2)
+				assert.Equal(t, 3, stations[0].ID)
+			},
+		},
+		{
+			name: "InvalidQuery",
+			test: func(t *testing.T, mock *customerUseCasesMock) {
+				_, err := mock.CustomerServiceUC.NearestStations(context.Background(),
+					models.Coordinate{Latitude: 95, Longitude: 35}, 0, 0, false)
+				assert.ErrorIs(t, err, ErrInvalidGeoQuery)
+
+				_, err = mock.CustomerServiceUC.NearestStations(context.Background(), userPoint, MaxSearchRadius+1, 0, false)
+				assert.ErrorIs(t, err, ErrInvalidGeoQuery)
+
+				_, err = mock.CustomerServiceUC.NearestStations(context.Background(), userPoint, 0, MaxNearestLimit+1, false)
+				assert.ErrorIs(t, err, ErrInvalidGeoQuery)
+			},
+		},
+		{
+			name: "RepoError",
+			test: func(t *testing.T, mock *customerUseCasesMock) {
+				repoErr := errors.New("db is down")
+				mock.RepoStation.EXPECT().FindActiveInBox(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil, repoErr).Times(1)
+
+				_, err := mock.CustomerServiceUC.NearestStations(context.Background(), userPoint, 0, 0, false)
+				assert.ErrorIs(t, err, repoErr)
+			},
+		},
+	})
+}
+
+func Test_Customer_BoundingBox(t *testing.T) {
+	for _, st := range []models.NearbyStation{pobeda, dafi, privateSect} {
+		point := models.Coordinate{Latitude: st.Latitude, Longitude: st.Longitude}
+		sw, ne := userPoint.BoundingBox(userPoint.Distance(point) + 1)
+		assert.True(t, point.Latitude >= sw.Latitude && point.Latitude <= ne.Latitude)
+		assert.True(t, point.Longitude >= sw.Longitude && point.Longitude <= ne.Longitude)
+	}
+
+	sw, ne := models.Coordinate{Latitude: 89.99, Longitude: 10}.BoundingBox(5000)
+	assert.Equal(t, -180.0, sw.Longitude)
+	assert.Equal(t, 180.0, ne.Longitude)
+	assert.Equal(t, 90.0, ne.Latitude)
+}
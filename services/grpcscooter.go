@@ -5,21 +5,29 @@ import (
 	"Dp218GO/protos"
 	"Dp218GO/repositories"
 	"context"
+	"errors"
 	"fmt"
 	"google.golang.org/grpc"
+	"math"
+	"shared.micro/geo"
 	"time"
 )
 
 const (
-	step          = 0.0001
-	dischargeStep = 0.1
-	interval      = 450
+	//defaultTick is the interval between two scooter positions if the tick isn't configured.
+	defaultTick = 450 * time.Millisecond
+	//parkTimeout is the time given to save where the scooter has stopped if the trip was interrupted.
+	parkTimeout = 5 * time.Second
 )
 
 //GrpcScooterService is a service which responsible for gRPC scooter.
 type GrpcScooterService struct {
 	repositories.ScooterRepo
 	*StationService
+	reservations *ReservationService
+	zones        *ZoneService
+	grpcAddress  string
+	tick         time.Duration
 }
 
 //GrpcScooterClient is a struct with parameters which will be translated by the gRPC connection.
@@ -27,24 +35,43 @@ type GrpcScooterClient struct {
 	ID            uint64
 	coordinate    models.Coordinate
 	batteryRemain float64
+	battery       models.BatteryModel
+	speed         float64
+	tick          time.Duration
 	stream        protos.ScooterService_ReceiveClient
+	zones         []models.Zone
+	violations    []models.Zone
 }
 
-//NewGrpcScooterService creates a new GrpcScooterService.
-func NewGrpcScooterService(repoScooter repositories.ScooterRepo, stationService *StationService) *GrpcScooterService {
+//NewGrpcScooterService creates a new GrpcScooterService. The scooter positions are sent to the gRPC server
+//at grpcAddress every tick, the rules of the zones are applied to the moving scooters.
+func NewGrpcScooterService(repoScooter repositories.ScooterRepo, stationService *StationService,
+	reservations *ReservationService, zones *ZoneService, grpcAddress string, tick time.Duration) *GrpcScooterService {
 	return &GrpcScooterService{
 		repoScooter,
 		stationService,
+		reservations,
+		zones,
+		grpcAddress,
+		tick,
 	}
 }
 
-//NewGrpcScooterClient creates a new GrpcScooterClient with given parameters.
-func NewGrpcScooterClient(id uint64, coordinate models.Coordinate, battery float64,
-	stream protos.ScooterService_ReceiveClient) *GrpcScooterClient {
+//NewGrpcScooterClient creates a new GrpcScooterClient with given parameters. The speed is given in km/h,
+//the positions are sent every tick.
+func NewGrpcScooterClient(id uint64, coordinate models.Coordinate, batteryRemain, speed float64,
+	battery models.BatteryModel, tick time.Duration, stream protos.ScooterService_ReceiveClient) *GrpcScooterClient {
+	if tick <= 0 {
+		tick = defaultTick
+	}
+
 	return &GrpcScooterClient{
 		ID:            id,
 		coordinate:    coordinate,
-		batteryRemain: battery,
+		batteryRemain: batteryRemain,
+		battery:       battery,
+		speed:         speed,
+		tick:          tick,
 		stream:        stream,
 	}
 }
@@ -53,29 +80,54 @@ func NewGrpcScooterClient(id uint64, coordinate models.Coordinate, battery float
 //If they satisfy the conditions, function creates connection to the gRPC server, creates gRPC client,
 //calls 'run' function which moves the scooter to the destination point.
 //After finished moves it sends the current scooter status to the database.
-func (gss *GrpcScooterService) InitAndRun(scooterID int, chosenStationID int) error {
-	scooter, err := gss.GetScooterById(scooterID)
+//The scooter reserved by the user is taken for the trip, the scooter reserved by someone else can't be used.
+//The trip can't end at the station without free slots or out of the stations where the parking isn't allowed.
+//If the station is filled up during the trip, the scooter is left next to it and the parking rules apply.
+//The returned TripEnd tells where the scooter has stopped and which zone rules were broken on the way.
+func (gss *GrpcScooterService) InitAndRun(ctx context.Context, userID, scooterID int,
+	destination models.TripDestination) (models.TripEnd, error) {
+	if destination.StationID != 0 {
+		if err := gss.CheckRoom(ctx, destination.StationID, scooterID); err != nil {
+			return models.TripEnd{}, err
+		}
+	} else if err := gss.zones.CheckParking(ctx, destination.Location); err != nil {
+		return models.TripEnd{}, err
+	}
+
+	scooter, err := gss.GetScooterById(ctx, scooterID)
 	if err != nil {
 		fmt.Println(err)
-		return err
+		return models.TripEnd{}, err
 	}
 
-	scooterStatus, err := gss.GetScooterStatus(scooterID)
+	scooterStatus, err := gss.GetScooterStatus(ctx, scooterID)
 	if err != nil {
 		fmt.Println(err)
-		return err
+		return models.TripEnd{}, err
+	}
+
+	reserved, err := gss.reservations.UseForTrip(ctx, userID, scooterID)
+	if err != nil {
+		return models.TripEnd{}, err
 	}
 
-	if scooter.CanBeRent {
-		var coordinate models.Coordinate
-		station, err := gss.GetStationById(chosenStationID)
+	if scooter.CanBeRent || reserved {
+		coordinate := destination.Location
+		if destination.StationID != 0 {
+			station, err := gss.GetStationById(ctx, destination.StationID)
+			if err != nil {
+				return models.TripEnd{}, err
+			}
+			coordinate.Latitude = station.Latitude
+			coordinate.Longitude = station.Longitude
+		}
+
+		zones, err := gss.zones.ActiveZones(ctx)
 		if err != nil {
-			return err
+			return models.TripEnd{}, err
 		}
-		coordinate.Latitude = station.Latitude
-		coordinate.Longitude = station.Longitude
 
-		conn, err := grpc.DialContext(context.Background(), ":8000", grpc.WithInsecure())
+		conn, err := grpc.DialContext(ctx, gss.grpcAddress, grpc.WithInsecure())
 
 		if err != nil {
 			panic(err)
@@ -83,122 +135,167 @@ func (gss *GrpcScooterService) InitAndRun(scooterID int, chosenStationID int) er
 		defer conn.Close()
 
 		sClient := protos.NewScooterServiceClient(conn)
-		stream, err := sClient.Receive(context.Background())
+		stream, err := sClient.Receive(ctx)
 		if err != nil {
 			panic(err)
 		}
 
 		client := NewGrpcScooterClient(uint64(scooterID),
-			scooterStatus.Location, scooter.BatteryRemain, stream)
-		err = client.run(coordinate)
+			scooterStatus.Location, scooter.BatteryRemain, float64(scooter.Speed),
+			scooter.Battery, gss.tick, stream)
+		client.zones = zones
+		arrived, err := client.run(ctx, coordinate)
 		if err != nil {
 			fmt.Println(err)
 		}
 
-		err = gss.SendCurrentStatus(int(client.ID), chosenStationID, client.coordinate.Latitude,
-			client.coordinate.Longitude,
-			client.batteryRemain)
-		if err != nil {
-			fmt.Println(err)
+		end := models.TripEnd{Location: client.coordinate, Violations: client.violations}
+		if arrived {
+			end.StationID = destination.StationID
+		}
+		parkCtx := ctx
+		if ctx.Err() != nil {
+			var cancel context.CancelFunc
+			parkCtx, cancel = context.WithTimeout(context.Background(), parkTimeout)
+			defer cancel()
 		}
+		end = gss.park(parkCtx, int(client.ID), client.batteryRemain, zones, end)
 
 		if client.batteryRemain <= 0 {
 			err = fmt.Errorf("scooter battery discharged. Trip is over")
-			return err
+			return end, err
 		}
-		return nil
+		return end, nil
 	}
 
 	err = fmt.Errorf("you can't use this scooter. Choose another one")
 	fmt.Println(err.Error())
-	return err
+	return models.TripEnd{}, err
 }
 
-//grpcScooterMessage sends the message be gRPC stream in a format which defined in the *proto file.
-func (s *GrpcScooterClient) grpcScooterMessage() {
-	intPol := time.Duration(interval) * time.Millisecond
+//park saves where the scooter has stopped and applies the parking rules. The scooter which found
+//the destination station full is left free-floating next to it.
+func (gss *GrpcScooterService) park(ctx context.Context, scooterID int, batteryRemain float64,
+	zones []models.Zone, end models.TripEnd) models.TripEnd {
+	err := gss.SendCurrentStatus(ctx, scooterID, end.StationID, end.Location.Latitude, end.Location.Longitude,
+		batteryRemain)
+	if errors.Is(err, repositories.ErrStationFull) {
+		end.StationID = 0
+		end.StationFull = true
+	} else if err != nil {
+		fmt.Println(err)
+	}
+	return finishTrip(zones, end)
+}
+
+//BatteryWarning makes the warning for the rider whose scooter reported the low battery. The nearest active
+//station is suggested to finish the trip, it is marked as reachable if the scooter has enough charge to get there.
+func (gss *GrpcScooterService) BatteryWarning(ctx context.Context, msg *protos.ClientMessage) (*models.BatteryWarning, error) {
+	scooter, err := gss.GetScooterById(ctx, int(msg.Id))
+	if err != nil {
+		return nil, err
+	}
+
+	stations, err := gss.GetAllStations(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	position := models.Coordinate{Latitude: msg.Latitude, Longitude: msg.Longitude}
+	station, distance, ok := nearestStation(stations.Station, position)
+	if !ok {
+		return nil, fmt.Errorf("there is no active station")
+	}
+
+	warning := &models.BatteryWarning{
+		ScooterID:     msg.Id,
+		BatteryRemain: msg.BatteryRemain,
+		Range:         scooter.Battery.Range(msg.BatteryRemain),
+		Station:       station,
+		Distance:      distance,
+	}
+	warning.Reachable = warning.Range >= warning.Distance
 
+	return warning, nil
+}
+
+//nearestStation returns the active station which is the closest to the point and the distance to it.
+func nearestStation(stations []models.Station, point models.Coordinate) (models.Station, float64, bool) {
+	var nearest models.Station
+	minDistance := math.Inf(1)
+
+	for _, station := range stations {
+		if !station.IsActive {
+			continue
+		}
+
+		distance := point.Distance(models.Coordinate{Latitude: station.Latitude, Longitude: station.Longitude})
+		if distance < minDistance {
+			nearest, minDistance = station, distance
+		}
+	}
+
+	return nearest, minDistance, !math.IsInf(minDistance, 1)
+}
+
+//grpcScooterMessage sends the message be gRPC stream in a format which defined in the *proto file.
+//lowBattery tells that the battery has just gone below the low level of the scooter model.
+func (s *GrpcScooterClient) grpcScooterMessage(lowBattery bool) {
 	fmt.Println("executing run in client")
 	msg := &protos.ClientMessage{
-		Id:        s.ID,
-		Latitude:  s.coordinate.Latitude,
-		Longitude: s.coordinate.Longitude,
+		Id:            s.ID,
+		Latitude:      s.coordinate.Latitude,
+		Longitude:     s.coordinate.Longitude,
+		BatteryRemain: s.batteryRemain,
+		LowBattery:    lowBattery,
 	}
 	err := s.stream.Send(msg)
 	if err != nil {
 		fmt.Println(err)
 	}
-	time.Sleep(intPol)
 }
 
 //run is responsible for scooter's movements from his current position to the destination point.
-//Run also is responsible for scooter's discharge. Every step battery charge decrease by the constant discharge value.
-func (s *GrpcScooterClient) run(station models.Coordinate) error {
-
-	switch {
-	case s.coordinate.Latitude <= station.Latitude && s.coordinate.Longitude <= station.Longitude:
-		for ; s.coordinate.Latitude <= station.Latitude && s.coordinate.Longitude <= station.Longitude && s.
-			batteryRemain > 0; s.
-			coordinate.Latitude,
-			s.coordinate.Longitude, s.batteryRemain = s.coordinate.Latitude+step, s.coordinate.Longitude+step,
-			s.batteryRemain-dischargeStep {
-			s.grpcScooterMessage()
-		}
-		fallthrough
-	case s.coordinate.Latitude >= station.Latitude && s.coordinate.Longitude <= station.Longitude:
-		for ; s.coordinate.Latitude >= station.Latitude && s.coordinate.Longitude <= station.Longitude && s.
-			batteryRemain > 0; s.coordinate.
-			Latitude,
-			s.coordinate.Longitude, s.batteryRemain = s.coordinate.Latitude-step, s.coordinate.Longitude+step,
-			s.batteryRemain-dischargeStep {
-			s.grpcScooterMessage()
-		}
-		fallthrough
-	case s.coordinate.Latitude >= station.Latitude && s.coordinate.Longitude >= station.Longitude:
-		for ; s.coordinate.Latitude >= station.Latitude && s.coordinate.Longitude >= station.Longitude && s.
-			batteryRemain > 0; s.coordinate.
-			Latitude,
-			s.coordinate.Longitude, s.batteryRemain = s.coordinate.Latitude-step, s.coordinate.Longitude-step,
-			s.batteryRemain-dischargeStep {
-			s.grpcScooterMessage()
-		}
-		fallthrough
-	case s.coordinate.Latitude <= station.Latitude && s.coordinate.Longitude >= station.Longitude:
-		for ; s.coordinate.Latitude <= station.Latitude && s.coordinate.Longitude >= station.Longitude && s.
-			batteryRemain > 0; s.coordinate.
-			Latitude,
-			s.coordinate.Longitude, s.batteryRemain = s.coordinate.Latitude+step, s.coordinate.Longitude-step,
-			s.batteryRemain-dischargeStep {
-			s.grpcScooterMessage()
-		}
-		fallthrough
-	case s.coordinate.Latitude <= station.Latitude:
-		for ; s.coordinate.Latitude <= station.Latitude && s.
-			batteryRemain > 0; s.coordinate.Latitude, s.batteryRemain = s.coordinate.Latitude+step, s.batteryRemain-dischargeStep {
-			s.grpcScooterMessage()
-		}
-		fallthrough
-	case s.coordinate.Latitude >= station.Latitude:
-		for ; s.coordinate.Latitude >= station.Latitude && s.
-			batteryRemain > 0; s.coordinate.Latitude, s.batteryRemain = s.coordinate.Latitude-step, s.batteryRemain-dischargeStep {
-			s.grpcScooterMessage()
-		}
-		fallthrough
-	case s.coordinate.Longitude >= station.Longitude:
-		for ; s.coordinate.Longitude >= station.Longitude && s.
-			batteryRemain > 0; s.coordinate.Longitude, s.batteryRemain = s.coordinate.Longitude-step,
-			s.batteryRemain-dischargeStep {
-			s.grpcScooterMessage()
+//The scooter goes along the great-circle route at its model's speed and its position is sent every tick.
+//In a slow zone the speed is limited by the zone, the scooter which is going to enter an out-of-service zone
+//is stopped at its border and the zone is counted as violated.
+//Run also is responsible for scooter's discharge: the battery charge decreases for every passed kilometer
+//by the consumption of the scooter model. Run reports whether the scooter has reached the destination.
+//The scooter stops where it is when ctx is done.
+func (s *GrpcScooterClient) run(ctx context.Context, station models.Coordinate) (bool, error) {
+	if s.speed <= 0 {
+		return false, fmt.Errorf("scooter %v has unknown speed", s.ID)
+	}
+
+	route := geo.NewRoute(geo.Point(s.coordinate), geo.Point(station))
+
+	ticker := time.NewTicker(s.tick)
+	defer ticker.Stop()
+
+	passed := 0.0
+	for passed < route.Distance() && s.batteryRemain > 0 {
+		select {
+		case <-ctx.Done():
+			return false, ctx.Err()
+		case <-ticker.C:
 		}
-		fallthrough
-	case s.coordinate.Longitude <= station.Longitude:
-		for ; s.coordinate.Longitude <= station.Longitude && s.
-			batteryRemain > 0; s.coordinate.Longitude, s.batteryRemain = s.coordinate.Longitude+step,
-			s.batteryRemain-dischargeStep {
-			s.grpcScooterMessage()
+
+		step := geo.StepLength(speedLimit(s.zones, s.coordinate, s.speed), s.tick)
+		move := math.Min(step, route.Distance()-passed)
+		next := models.Coordinate(route.PointAt(passed + move))
+		if zone, ok := zoneOfKind(zonesAt(s.zones, next), models.ZoneOutOfService); ok {
+			s.violations = append(s.violations, zone)
+			return false, fmt.Errorf("scooter %v is stopped at the border of %q zone", s.ID, zone.Name)
 		}
-	default:
-		return fmt.Errorf("error happened")
+
+		passed += move
+		s.coordinate = next
+
+		wasLow := s.batteryRemain <= s.battery.LowLevel
+		s.batteryRemain = math.Max(s.batteryRemain-move/1000*s.battery.DischargePerKm(), 0)
+
+		s.grpcScooterMessage(!wasLow && s.batteryRemain <= s.battery.LowLevel)
 	}
-	return nil
+
+	return passed >= route.Distance(), nil
 }
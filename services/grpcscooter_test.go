@@ -0,0 +1,128 @@
+package services
+
+import (
+	"Dp218GO/models"
+	"Dp218GO/repositories"
+	"Dp218GO/repositories/mock"
+	"context"
+	"github.com/golang/mock/gomock"
+	assert "github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestGrpcScooter_NearestStation(t *testing.T) {
+	stations := []models.Station{
+		{ID: 1, Name: "Pobeda3", IsActive: true, Latitude: 48.42367, Longitude: 35.04436},
+		{ID: 2, Name: "Dafi Mall", IsActive: true, Latitude: 48.4221, Longitude: 35.0196},
+		{ID: 3, Name: "Private Sector", IsActive: false, Latitude: 48.42543, Longitude: 35.02183},
+	}
+
+	testCases := []struct {
+		name     string
+		stations []models.Station
+		point    models.Coordinate
+		expected int
+		found    bool
+	}{
+		{
+			name:     "Nearest",
+			stations: stations,
+			point:    models.Coordinate{Latitude: 48.4230, Longitude: 35.0400},
+			expected: 1,
+			found:    true,
+		},
+		{
+			name:     "SkipsInactive",
+			stations: stations,
+			point:    models.Coordinate{Latitude: 48.4254, Longitude: 35.0218},
+			expected: 2,
+			found:    true,
+		},
+		{
+			name:     "NoActive",
+			stations: stations[2:],
+			point:    models.Coordinate{Latitude: 48.4254, Longitude: 35.0218},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(tt *testing.T) {
+			station, distance, ok := nearestStation(tc.stations, tc.point)
+			assert.Equal(tt, tc.found, ok)
+			if !tc.found {
+				return
+			}
+
+			assert.Equal(tt, tc.expected, station.ID)
+			assert.InDelta(tt, tc.point.Distance(models.Coordinate{Latitude: station.Latitude,
+				Longitude: station.Longitude}), distance, 0.001)
+		})
+	}
+}
+
+func TestGrpcScooter_BatteryModel(t *testing.T) {
+	battery := models.BatteryModel{Capacity: 280, ConsumptionPerKm: 14, LowLevel: 10}
+
+	assert.InDelta(t, 5.0, battery.DischargePerKm(), 0.000001)
+	assert.InDelta(t, 2000.0, battery.Range(10), 0.000001)
+	assert.Equal(t, 0.0, battery.Range(0))
+	assert.Equal(t, 0.0, models.BatteryModel{}.DischargePerKm())
+}
+
+func TestGrpcScooter_Park(t *testing.T) {
+	zones := []models.Zone{parkZone, squareZone}
+
+	testCases := []struct {
+		name     string
+		end      models.TripEnd
+		dockErr  error
+		expected models.TripEnd
+	}{
+		{
+			name:     "Docked",
+			end:      models.TripEnd{StationID: 1, Location: squarePoint},
+			expected: models.TripEnd{StationID: 1, Location: squarePoint},
+		},
+		{
+			name:    "StationFilledUp",
+			end:     models.TripEnd{StationID: 1, Location: squarePoint},
+			dockErr: repositories.ErrStationFull,
+			expected: models.TripEnd{Location: squarePoint, StationFull: true,
+				Violations: []models.Zone{squareZone}, PenaltyCents: squareZone.PenaltyCents},
+		},
+		{
+			name:     "FreeFloating",
+			end:      models.TripEnd{Location: parkPoint},
+			expected: models.TripEnd{Location: parkPoint},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(tt *testing.T) {
+			ctrl := gomock.NewController(tt)
+			defer ctrl.Finish()
+
+			repoScooter := mock.NewMockScooterRepo(ctrl)
+			repoScooter.EXPECT().SendCurrentStatus(gomock.Any(), 7, tc.end.StationID, tc.end.Location.Latitude,
+				tc.end.Location.Longitude, 55.0).Return(tc.dockErr).Times(1)
+
+			gss := &GrpcScooterService{ScooterRepo: repoScooter}
+			end := gss.park(context.Background(), 7, 55.0, zones, tc.end)
+			assert.Equal(tt, tc.expected, end)
+		})
+	}
+}
+
+func TestGrpcScooter_RunStopsOnCancel(t *testing.T) {
+	start := models.Coordinate{Latitude: 48.4223, Longitude: 35.0234}
+	client := NewGrpcScooterClient(1, start, 100, 25, models.BatteryModel{}, time.Hour, nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	arrived, err := client.run(ctx, models.Coordinate{Latitude: 48.4647, Longitude: 35.0462})
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.False(t, arrived)
+	assert.Equal(t, start, client.coordinate)
+}
@@ -0,0 +1,141 @@
+package services
+
+import (
+	"Dp218GO/repositories"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+//default limits of failed sign in attempts if they aren't configured.
+const (
+	defaultLoginMaxAttempts   = 5
+	defaultLoginLockout       = 15 * time.Minute
+	defaultLoginBackoff       = time.Second
+	defaultLoginIPMaxAttempts = 20
+	defaultLoginIPWindow      = 15 * time.Minute
+)
+
+var (
+	//ErrAccountLocked is returned on sign in to the account locked after too many failed attempts.
+	ErrAccountLocked = errors.New("account is temporarily locked after too many failed sign in attempts")
+	//ErrTooManyAttempts is returned on sign in which is made too soon after the failed one.
+	ErrTooManyAttempts = errors.New("too many failed sign in attempts")
+)
+
+//LockoutError is returned if the sign in is refused without checking the password.
+//Err is ErrAccountLocked or ErrTooManyAttempts, Until is the time the next attempt is allowed.
+type LockoutError struct {
+	Err   error
+	Until time.Time
+}
+
+func (e *LockoutError) Error() string {
+	return fmt.Sprintf("%v, try again after %s", e.Err, e.Until.Format("15:04:05 MST"))
+}
+
+func (e *LockoutError) Unwrap() error {
+	return e.Err
+}
+
+//LockoutPolicy sets the limits of failed sign in attempts.
+//Every failed attempt of the account doubles the delay before the next one starting from Backoff,
+//MaxAttempts failures in a row lock the account for Lockout.
+//IPMaxAttempts failures from one address during IPWindow block the address till the window passes.
+type LockoutPolicy struct {
+	MaxAttempts   int
+	Lockout       time.Duration
+	Backoff       time.Duration
+	IPMaxAttempts int
+	IPWindow      time.Duration
+}
+
+//LockoutService protects the sign in from password guessing by account and by IP address.
+type LockoutService struct {
+	repoLockout repositories.LockoutRepo
+	clock       Clock
+	policy      LockoutPolicy
+}
+
+//NewLockoutService creates the new LockoutService, zero limits of the policy are replaced by defaults.
+func NewLockoutService(lockoutRepo repositories.LockoutRepo, clock Clock, policy LockoutPolicy) *LockoutService {
+	if policy.MaxAttempts <= 0 {
+		policy.MaxAttempts = defaultLoginMaxAttempts
+	}
+	if policy.Lockout <= 0 {
+		policy.Lockout = defaultLoginLockout
+	}
+	if policy.Backoff <= 0 {
+		policy.Backoff = defaultLoginBackoff
+	}
+	if policy.IPMaxAttempts <= 0 {
+		policy.IPMaxAttempts = defaultLoginIPMaxAttempts
+	}
+	if policy.IPWindow <= 0 {
+		policy.IPWindow = defaultLoginIPWindow
+	}
+	return &LockoutService{repoLockout: lockoutRepo, clock: clock, policy: policy}
+}
+
+//Check returns LockoutError if the sign in with the email from the address isn't allowed now.
+func (ls *LockoutService) Check(ctx context.Context, email, ipAddress string) error {
+	now := ls.clock.Now()
+
+	failures, err := ls.repoLockout.GetLoginFailures(ctx, email)
+	if err != nil {
+		return err
+	}
+	if now.Before(failures.LockedUntil) {
+		return &LockoutError{Err: ErrAccountLocked, Until: failures.LockedUntil}
+	}
+	if failures.FailedAttempts > 0 {
+		next := failures.LastFailedAt.Add(ls.backoff(failures.FailedAttempts))
+		if now.Before(next) {
+			return &LockoutError{Err: ErrTooManyAttempts, Until: next}
+		}
+	}
+
+	count, err := ls.repoLockout.CountFailedByIP(ctx, ipAddress, now.Add(-ls.policy.IPWindow))
+	if err != nil {
+		return err
+	}
+	if count >= ls.policy.IPMaxAttempts {
+		return &LockoutError{Err: ErrTooManyAttempts, Until: now.Add(ls.policy.IPWindow)}
+	}
+
+	return nil
+}
+
+//Failed counts the failed attempt of the account and locks it when the limit is reached.
+//The attempts from the address are counted by the login history.
+func (ls *LockoutService) Failed(ctx context.Context, email string) error {
+	now := ls.clock.Now()
+
+	attempts, err := ls.repoLockout.AddLoginFailure(ctx, email, now)
+	if err != nil {
+		return err
+	}
+	if attempts < ls.policy.MaxAttempts {
+		return nil
+	}
+
+	return ls.repoLockout.LockUser(ctx, email, now.Add(ls.policy.Lockout))
+}
+
+//Succeeded forgets the failed attempts of the account.
+func (ls *LockoutService) Succeeded(ctx context.Context, email string) error {
+	return ls.repoLockout.ResetLoginFailures(ctx, email)
+}
+
+//backoff returns the delay after the given number of failed attempts, it never exceeds the lockout.
+func (ls *LockoutService) backoff(attempts int) time.Duration {
+	delay := ls.policy.Backoff
+	for i := 1; i < attempts && delay < ls.policy.Lockout; i++ {
+		delay *= 2
+	}
+	if delay > ls.policy.Lockout {
+		delay = ls.policy.Lockout
+	}
+	return delay
+}
@@ -0,0 +1,158 @@
+package services
+
+import (
+	"Dp218GO/models"
+	repomock "Dp218GO/repositories/mock"
+	"Dp218GO/services/mock"
+	"context"
+	"github.com/golang/mock/gomock"
+	assert "github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+var lockoutTime = time.Date(2021, 12, 20, 10, 0, 0, 0, time.UTC)
+
+var testLockoutPolicy = LockoutPolicy{
+	MaxAttempts:   3,
+	Lockout:       10 * time.Minute,
+	Backoff:       time.Second,
+	IPMaxAttempts: 10,
+	IPWindow:      15 * time.Minute,
+}
+
+type lockoutUseCasesMock struct {
+	LockoutServiceUC *LockoutService
+	RepoLockout      *repomock.MockLockoutRepo
+	Clock            *mock.MockClock
+}
+
+type lockoutTestCase struct {
+	name string
+	test func(t *testing.T, mock *lockoutUseCasesMock)
+}
+
+func runLockoutTestCases(t *testing.T, testCases []lockoutTestCase) {
+	for _, tc := range testCases {
+		t.Run(tc.name, func(tt *testing.T) {
+			defer func() {
+				if err := recover(); err != nil {
+					tt.Error(err)
+				}
+			}()
+
+			ctrl := gomock.NewController(tt)
+			defer ctrl.Finish()
+
+			mock := newLockoutUseCasesMock(ctrl)
+
+			tc.test(tt, mock)
+		})
+	}
+}
+
+func newLockoutUseCasesMock(ctrl *gomock.Controller) *lockoutUseCasesMock {
+	repoLockout := repomock.NewMockLockoutRepo(ctrl)
+	clock := mock.NewMockClock(ctrl)
+
+	return &lockoutUseCasesMock{
+		LockoutServiceUC: NewLockoutService(repoLockout, clock, testLockoutPolicy),
+		RepoLockout:      repoLockout,
+		Clock:            clock,
+	}
+}
+
+func Test_Lockout_Check(t *testing.T) {
+	runLockoutTestCases(t, []lockoutTestCase{
+		{
+			name: "Allowed",
+			test: func(t *testing.T, mock *lockoutUseCasesMock) {
+				mock.Clock.EXPECT().Now().Return(lockoutTime).Times(1)
+				mock.RepoLockout.EXPECT().GetLoginFailures(gomock.Any(), "test@mail.com").
+					Return(models.LoginFailures{FailedAttempts: 2, LastFailedAt: lockoutTime.Add(-3 * time.Second)}, nil).
+					Times(1)
+				mock.RepoLockout.EXPECT().CountFailedByIP(gomock.Any(), "10.0.0.1", lockoutTime.Add(-15*time.Minute)).
+					Return(2, nil).Times(1)
+
+				err := mock.LockoutServiceUC.Check(context.Background(), "test@mail.com", "10.0.0.1")
+				assert.Nil(t, err)
+			},
+		},
+		{
+			name: "Locked",
+			test: func(t *testing.T, mock *lockoutUseCasesMock) {
+				mock.Clock.EXPECT().Now().Return(lockoutTime).Times(1)
+				mock.RepoLockout.EXPECT().GetLoginFailures(gomock.Any(), "test@mail.com").
+					Return(models.LoginFailures{LockedUntil: lockoutTime.Add(time.Minute)}, nil).Times(1)
+
+				err := mock.LockoutServiceUC.Check(context.Background(), "test@mail.com", "10.0.0.1")
+				assert.ErrorIs(t, err, ErrAccountLocked)
+				assert.Equal(t, lockoutTime.Add(time.Minute), err.(*LockoutError).Until)
+			},
+		},
+		{
+			name: "Backoff",
+			test: func(t *testing.T, mock *lockoutUseCasesMock) {
+				mock.Clock.EXPECT().Now().Return(lockoutTime).Times(1)
+				mock.RepoLockout.EXPECT().GetLoginFailures(gomock.Any(), "test@mail.com").
+					Return(models.LoginFailures{FailedAttempts: 2, LastFailedAt: lockoutTime.Add(-time.Second)}, nil).
+					Times(1)
+
+				err := mock.LockoutServiceUC.Check(context.Background(), "test@mail.com", "10.0.0.1")
+				assert.ErrorIs(t, err, ErrTooManyAttempts)
+				assert.Equal(t, lockoutTime.Add(time.Second), err.(*LockoutError).Until)
+			},
+		},
+		{
+			name: "TooManyFromIP",
+			test: func(t *testing.T, mock *lockoutUseCasesMock) {
+				mock.Clock.EXPECT().Now().Return(lockoutTime).Times(1)
+				mock.RepoLockout.EXPECT().GetLoginFailures(gomock.Any(), "other@mail.com").
+					Return(models.LoginFailures{}, nil).Times(1)
+				mock.RepoLockout.EXPECT().CountFailedByIP(gomock.Any(), "10.0.0.1", gomock.Any()).
+					Return(10, nil).Times(1)
+
+				err := mock.LockoutServiceUC.Check(context.Background(), "other@mail.com", "10.0.0.1")
+				assert.ErrorIs(t, err, ErrTooManyAttempts)
+			},
+		},
+	})
+}
+
+func Test_Lockout_Failed(t *testing.T) {
+	runLockoutTestCases(t, []lockoutTestCase{
+		{
+			name: "BelowLimit",
+			test: func(t *testing.T, mock *lockoutUseCasesMock) {
+				mock.Clock.EXPECT().Now().Return(lockoutTime).Times(1)
+				mock.RepoLockout.EXPECT().AddLoginFailure(gomock.Any(), "test@mail.com", lockoutTime).
+					Return(2, nil).Times(1)
+
+				err := mock.LockoutServiceUC.Failed(context.Background(), "test@mail.com")
+				assert.Nil(t, err)
+			},
+		},
+		{
+			name: "LocksAccount",
+			test: func(t *testing.T, mock *lockoutUseCasesMock) {
+				mock.Clock.EXPECT().Now().Return(lockoutTime).Times(1)
+				mock.RepoLockout.EXPECT().AddLoginFailure(gomock.Any(), "test@mail.com", lockoutTime).
+					Return(3, nil).Times(1)
+				mock.RepoLockout.EXPECT().LockUser(gomock.Any(), "test@mail.com", lockoutTime.Add(10*time.Minute)).
+					Return(nil).Times(1)
+
+				err := mock.LockoutServiceUC.Failed(context.Background(), "test@mail.com")
+				assert.Nil(t, err)
+			},
+		},
+	})
+}
+
+func TestLockout_Backoff(t *testing.T) {
+	ls := NewLockoutService(nil, nil, testLockoutPolicy)
+
+	assert.Equal(t, time.Second, ls.backoff(1))
+	assert.Equal(t, 2*time.Second, ls.backoff(2))
+	assert.Equal(t, 8*time.Second, ls.backoff(4))
+	assert.Equal(t, 10*time.Minute, ls.backoff(20))
+}
@@ -0,0 +1,121 @@
+package services
+
+import (
+	"Dp218GO/models"
+	"Dp218GO/repositories"
+	"context"
+	"errors"
+	"net"
+	"net/http"
+)
+
+//defaultLoginHistoryLimit is the number of login events returned if the limit isn't given.
+const defaultLoginHistoryLimit = 50
+
+//LoginAuditService records the sign ins, sign outs and failed attempts of the users
+//and keeps the sessions which the users can revoke.
+type LoginAuditService struct {
+	repoAudit repositories.LoginAuditRepo
+	clock     Clock
+}
+
+//NewLoginAuditService creates the new LoginAuditService.
+func NewLoginAuditService(auditRepo repositories.LoginAuditRepo, clock Clock) *LoginAuditService {
+	return &LoginAuditService{repoAudit: auditRepo, clock: clock}
+}
+
+//StartSession creates the session of the signed in user and records the sign in event.
+//The sessionless sign in (api tokens) is recorded with zero session.
+func (la *LoginAuditService) StartSession(ctx context.Context, user models.User, r *http.Request,
+	withSession bool) (int, error) {
+	now := la.clock.Now()
+
+	session := &models.UserSession{
+		UserID:    user.ID,
+		IPAddress: requestIP(r),
+		UserAgent: r.UserAgent(),
+		CreatedAt: now,
+	}
+	if withSession {
+		if err := la.repoAudit.AddSession(ctx, session); err != nil {
+			return 0, err
+		}
+	}
+
+	return session.ID, la.record(ctx, user.ID, user.LoginEmail, models.LoginEventSignIn, session.ID, r)
+}
+
+//EndSession revokes the session and records the sign out event.
+func (la *LoginAuditService) EndSession(ctx context.Context, user models.User, sessionID int, r *http.Request) error {
+	if sessionID != 0 {
+		err := la.repoAudit.RevokeSession(ctx, user.ID, sessionID, la.clock.Now())
+		if err != nil && !errors.Is(err, repositories.ErrNoSession) {
+			return err
+		}
+	}
+
+	return la.record(ctx, user.ID, user.LoginEmail, models.LoginEventSignOut, sessionID, r)
+}
+
+//RecordFailed records the failed sign in attempt with the given email.
+func (la *LoginAuditService) RecordFailed(ctx context.Context, email string, r *http.Request) error {
+	return la.record(ctx, 0, email, models.LoginEventFailed, 0, r)
+}
+
+//IsSessionActive checks if the session wasn't revoked.
+func (la *LoginAuditService) IsSessionActive(ctx context.Context, sessionID int) (bool, error) {
+	return la.repoAudit.IsSessionActive(ctx, sessionID)
+}
+
+//History returns the latest login events of the user.
+func (la *LoginAuditService) History(ctx context.Context, userID, limit int) (*models.LoginEventList, error) {
+	if limit <= 0 {
+		limit = defaultLoginHistoryLimit
+	}
+	return la.repoAudit.GetLoginEventsByUserID(ctx, userID, limit)
+}
+
+//Sessions returns the active sessions of the user, the current session is marked.
+func (la *LoginAuditService) Sessions(ctx context.Context, userID, currentSessionID int) (*models.UserSessionList, error) {
+	sessions, err := la.repoAudit.GetActiveSessionsByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range sessions.Sessions {
+		sessions.Sessions[i].Current = sessions.Sessions[i].ID == currentSessionID
+	}
+	return sessions, nil
+}
+
+//RevokeSession revokes the session of the user.
+func (la *LoginAuditService) RevokeSession(ctx context.Context, userID, sessionID int) error {
+	return la.repoAudit.RevokeSession(ctx, userID, sessionID, la.clock.Now())
+}
+
+//RevokeOtherSessions revokes all the sessions of the user except the current one.
+func (la *LoginAuditService) RevokeOtherSessions(ctx context.Context, userID, currentSessionID int) error {
+	return la.repoAudit.RevokeOtherSessions(ctx, userID, currentSessionID, la.clock.Now())
+}
+
+func (la *LoginAuditService) record(ctx context.Context, userID int, email, event string, sessionID int,
+	r *http.Request) error {
+	return la.repoAudit.AddLoginEvent(ctx, &models.LoginEvent{
+		UserID:     userID,
+		LoginEmail: email,
+		Event:      event,
+		SessionID:  sessionID,
+		IPAddress:  requestIP(r),
+		UserAgent:  r.UserAgent(),
+		CreatedAt:  la.clock.Now(),
+	})
+}
+
+//requestIP returns the address of the client which sent the request.
+func requestIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
@@ -0,0 +1,205 @@
+package services
+
+import (
+	"Dp218GO/models"
+	"Dp218GO/repositories"
+	repomock "Dp218GO/repositories/mock"
+	"Dp218GO/services/mock"
+	"context"
+	"github.com/golang/mock/gomock"
+	assert "github.com/stretchr/testify/require"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+var auditTime = time.Date(2021, 12, 20, 10, 0, 0, 0, time.UTC)
+
+type loginAuditUseCasesMock struct {
+	LoginAuditServiceUC *LoginAuditService
+	RepoAudit           *repomock.MockLoginAuditRepo
+	Clock               *mock.MockClock
+}
+
+type loginAuditTestCase struct {
+	name string
+	test func(t *testing.T, mock *loginAuditUseCasesMock)
+}
+
+func runLoginAuditTestCases(t *testing.T, testCases []loginAuditTestCase) {
+	for _, tc := range testCases {
+		t.Run(tc.name, func(tt *testing.T) {
+			defer func() {
+				if err := recover(); err != nil {
+					tt.Error(err)
+				}
+			}()
+
+			ctrl := gomock.NewController(tt)
+			defer ctrl.Finish()
+
+			mock := newLoginAuditUseCasesMock(ctrl)
+
+			tc.test(tt, mock)
+		})
+	}
+}
+
+func newLoginAuditUseCasesMock(ctrl *gomock.Controller) *loginAuditUseCasesMock {
+	repoAudit := repomock.NewMockLoginAuditRepo(ctrl)
+	clock := mock.NewMockClock(ctrl)
+
+	return &loginAuditUseCasesMock{
+		LoginAuditServiceUC: NewLoginAuditService(repoAudit, clock),
+		RepoAudit:           repoAudit,
+		Clock:               clock,
+	}
+}
+
+func Test_LoginAudit_StartSession(t *testing.T) {
+	user := models.User{ID: 1, LoginEmail: "test@mail.com"}
+
+	runLoginAuditTestCases(t, []loginAuditTestCase{
+		{
+			name: "WithSession",
+			test: func(t *testing.T, mock *loginAuditUseCasesMock) {
+				r := httptest.NewRequest("POST", "/signin", nil)
+				r.RemoteAddr = "10.0.0.1:51000"
+				r.Header.Set("User-Agent", "test-agent")
+
+				mock.Clock.EXPECT().Now().Return(auditTime).Times(2)
+				mock.RepoAudit.EXPECT().AddSession(gomock.Any(), &models.UserSession{UserID: 1,
+					IPAddress: "10.0.0.1", UserAgent: "test-agent", CreatedAt: auditTime}).
+					DoAndReturn(func(ctx context.Context, session *models.UserSession) error {
+						session.ID = 5
+						return nil
+					}).Times(1)
+				mock.RepoAudit.EXPECT().AddLoginEvent(gomock.Any(), &models.LoginEvent{UserID: 1,
+					LoginEmail: "test@mail.com", Event: models.LoginEventSignIn, SessionID: 5,
+					IPAddress: "10.0.0.1", UserAgent: "test-agent", CreatedAt: auditTime}).
+					Return(nil).Times(1)
+
+				sessionID, err := mock.LoginAuditServiceUC.StartSession(context.Background(), user, r, true)
+				assert.Nil(t, err)
+				assert.Equal(t, 5, sessionID)
+			},
+		},
+		{
+			name: "WithoutSession",
+			test: func(t *testing.T, mock *loginAuditUseCasesMock) {
+				r := httptest.NewRequest("POST", "/api/v1/token", nil)
+
+				mock.Clock.EXPECT().Now().Return(auditTime).Times(2)
+				mock.RepoAudit.EXPECT().AddLoginEvent(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, event *models.LoginEvent) error {
+						assert.Equal(t, models.LoginEventSignIn, event.Event)
+						assert.Equal(t, 0, event.SessionID)
+						return nil
+					}).Times(1)
+
+				sessionID, err := mock.LoginAuditServiceUC.StartSession(context.Background(), user, r, false)
+				assert.Nil(t, err)
+				assert.Equal(t, 0, sessionID)
+			},
+		},
+	})
+}
+
+func Test_LoginAudit_EndSession(t *testing.T) {
+	user := models.User{ID: 1, LoginEmail: "test@mail.com"}
+
+	runLoginAuditTestCases(t, []loginAuditTestCase{
+		{
+			name: "Correct",
+			test: func(t *testing.T, mock *loginAuditUseCasesMock) {
+				mock.Clock.EXPECT().Now().Return(auditTime).Times(2)
+				mock.RepoAudit.EXPECT().RevokeSession(gomock.Any(), 1, 5, auditTime).Return(nil).Times(1)
+				mock.RepoAudit.EXPECT().AddLoginEvent(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, event *models.LoginEvent) error {
+						assert.Equal(t, models.LoginEventSignOut, event.Event)
+						assert.Equal(t, 5, event.SessionID)
+						return nil
+					}).Times(1)
+
+				err := mock.LoginAuditServiceUC.EndSession(context.Background(), user, 5,
+					httptest.NewRequest("GET", "/signout", nil))
+				assert.Nil(t, err)
+			},
+		},
+		{
+			name: "AlreadyRevoked",
+			test: func(t *testing.T, mock *loginAuditUseCasesMock) {
+				mock.Clock.EXPECT().Now().Return(auditTime).Times(2)
+				mock.RepoAudit.EXPECT().RevokeSession(gomock.Any(), 1, 5, auditTime).
+					Return(repositories.ErrNoSession).Times(1)
+				mock.RepoAudit.EXPECT().AddLoginEvent(gomock.Any(), gomock.Any()).Return(nil).Times(1)
+
+				err := mock.LoginAuditServiceUC.EndSession(context.Background(), user, 5,
+					httptest.NewRequest("GET", "/signout", nil))
+				assert.Nil(t, err)
+			},
+		},
+	})
+}
+
+func Test_LoginAudit_RecordFailed(t *testing.T) {
+	runLoginAuditTestCases(t, []loginAuditTestCase{
+		{
+			name: "Correct",
+			test: func(t *testing.T, mock *loginAuditUseCasesMock) {
+				r := httptest.NewRequest("POST", "/signin", nil)
+				r.RemoteAddr = "[::1]:51000"
+
+				mock.Clock.EXPECT().Now().Return(auditTime).Times(1)
+				mock.RepoAudit.EXPECT().AddLoginEvent(gomock.Any(), &models.LoginEvent{
+					LoginEmail: "unknown@mail.com", Event: models.LoginEventFailed, IPAddress: "::1",
+					CreatedAt: auditTime}).Return(nil).Times(1)
+
+				err := mock.LoginAuditServiceUC.RecordFailed(context.Background(), "unknown@mail.com", r)
+				assert.Nil(t, err)
+			},
+		},
+	})
+}
+
+func Test_LoginAudit_History(t *testing.T) {
+	runLoginAuditTestCases(t, []loginAuditTestCase{
+		{
+			name: "DefaultLimit",
+			test: func(t *testing.T, mock *loginAuditUseCasesMock) {
+				mock.RepoAudit.EXPECT().GetLoginEventsByUserID(gomock.Any(), 1, defaultLoginHistoryLimit).
+					Return(&models.LoginEventList{}, nil).Times(1)
+
+				_, err := mock.LoginAuditServiceUC.History(context.Background(), 1, 0)
+				assert.Nil(t, err)
+			},
+		},
+		{
+			name: "GivenLimit",
+			test: func(t *testing.T, mock *loginAuditUseCasesMock) {
+				mock.RepoAudit.EXPECT().GetLoginEventsByUserID(gomock.Any(), 1, 10).
+					Return(&models.LoginEventList{}, nil).Times(1)
+
+				_, err := mock.LoginAuditServiceUC.History(context.Background(), 1, 10)
+				assert.Nil(t, err)
+			},
+		},
+	})
+}
+
+func Test_LoginAudit_Sessions(t *testing.T) {
+	runLoginAuditTestCases(t, []loginAuditTestCase{
+		{
+			name: "MarksCurrent",
+			test: func(t *testing.T, mock *loginAuditUseCasesMock) {
+				mock.RepoAudit.EXPECT().GetActiveSessionsByUserID(gomock.Any(), 1).
+					Return(&models.UserSessionList{Sessions: []models.UserSession{{ID: 4}, {ID: 5}}}, nil).Times(1)
+
+				sessions, err := mock.LoginAuditServiceUC.Sessions(context.Background(), 1, 5)
+				assert.Nil(t, err)
+				assert.False(t, sessions.Sessions[0].Current)
+				assert.True(t, sessions.Sessions[1].Current)
+			},
+		},
+	})
+}
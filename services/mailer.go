@@ -0,0 +1,71 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"net/smtp"
+	"strings"
+)
+
+//Mailer sends the plain text emails to the users.
+type Mailer interface {
+	Send(ctx context.Context, to, subject, body string) error
+}
+
+//SMTPMailer is the Mailer which sends emails through the SMTP server.
+type SMTPMailer struct {
+	addr string
+	auth smtp.Auth
+	from string
+}
+
+//NewSMTPMailer creates the SMTPMailer. Plain auth is used only if the user is given.
+func NewSMTPMailer(host, port, user, password, from string) *SMTPMailer {
+	var auth smtp.Auth
+	if user != "" {
+		auth = smtp.PlainAuth("", user, password, host)
+	}
+	return &SMTPMailer{addr: net.JoinHostPort(host, port), auth: auth, from: from}
+}
+
+//Send sends the email, the context is checked only before the sending since net/smtp doesn't support it.
+func (m *SMTPMailer) Send(ctx context.Context, to, subject, body string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return smtp.SendMail(m.addr, m.auth, m.from, []string{to}, composeMail(m.from, to, subject, body))
+}
+
+//LogMailer is the Mailer for the local runs, it writes the emails to the log output instead of sending them.
+type LogMailer struct {
+	logger *log.Logger
+	from   string
+}
+
+//NewLogMailer creates the LogMailer which writes the emails to w (a file or stdout).
+func NewLogMailer(w io.Writer, from string) *LogMailer {
+	return &LogMailer{logger: log.New(w, "mail: ", log.LstdFlags), from: from}
+}
+
+//Send writes the email to the log.
+func (m *LogMailer) Send(ctx context.Context, to, subject, body string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	m.logger.Printf("\n%s", composeMail(m.from, to, subject, body))
+	return nil
+}
+
+func composeMail(from, to, subject, body string) []byte {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "From: %s\r\n", from)
+	fmt.Fprintf(&sb, "To: %s\r\n", to)
+	fmt.Fprintf(&sb, "Subject: %s\r\n", subject)
+	sb.WriteString("MIME-Version: 1.0\r\nContent-Type: text/plain; charset=\"utf-8\"\r\n\r\n")
+	sb.WriteString(body)
+	sb.WriteString("\r\n")
+	return []byte(sb.String())
+}
@@ -3,60 +3,171 @@ package services
 import (
 	"Dp218GO/models"
 	"Dp218GO/repositories"
+	"context"
+	"math"
 )
 
-//OrderService is the service which gives access to the OrderRepo repository.
+//OrderService is the service which gives access to the OrderRepo repository and counts the trip price.
 type OrderService struct {
 	repoOrder repositories.OrderRepo
+	pricing   *PricingService
+	accounts  *AccountService
+	tx        repositories.TxManager
 }
 
 //NewOrderService creates the new OrderService.
-func NewOrderService(orderRepo repositories.OrderRepo) *OrderService {
-	return &OrderService{repoOrder: orderRepo}
+func NewOrderService(orderRepo repositories.OrderRepo, pricing *PricingService,
+	accounts *AccountService, tx repositories.TxManager) *OrderService {
+	return &OrderService{repoOrder: orderRepo, pricing: pricing, accounts: accounts, tx: tx}
 }
 
 //CreateOrder gives the access to the OrderRepo.CreateOrder function.
-func (ors *OrderService) CreateOrder(user models.User, scooterID, startID, endID int,
+func (ors *OrderService) CreateOrder(ctx context.Context, user models.User, scooterID, startID, endID int,
 	distance float64) (models.Order, error) {
-	return ors.repoOrder.CreateOrder(user, scooterID, startID, endID, distance)
+	return ors.repoOrder.CreateOrder(ctx, user, scooterID, startID, endID, distance)
+}
+
+//StartOrder opens the order of the trip which is starting. The order has no end status until the trip ends,
+//its ID is the ID of the trip which the rider watches.
+func (ors *OrderService) StartOrder(ctx context.Context, user models.User, scooterID, startID int) (models.Order,
+	error) {
+	return ors.repoOrder.CreateOrder(ctx, user, scooterID, startID, 0, 0)
+}
+
+//GetActiveOrderByScooterID returns the order of the trip which the scooter is in now, ok is false
+//if the scooter is not in a trip.
+func (ors *OrderService) GetActiveOrderByScooterID(ctx context.Context, scooterID int) (models.Order, bool, error) {
+	orders, err := ors.repoOrder.GetOrdersByScooterID(ctx, scooterID)
+	if err != nil {
+		return models.Order{}, false, err
+	}
+
+	for _, order := range orders.Orders {
+		if order.StatusEndID == 0 {
+			return order, true, nil
+		}
+	}
+	return models.Order{}, false, nil
 }
 
 //GetAllOrders gives the access to the OrderRepo.GetAllOrders function.
-func (ors *OrderService) GetAllOrders() (*models.OrderList, error) {
-	return ors.repoOrder.GetAllOrders()
+func (ors *OrderService) GetAllOrders(ctx context.Context) (*models.OrderList, error) {
+	return ors.repoOrder.GetAllOrders(ctx)
 }
 
 //GetOrderByID gives the access to the OrderRepo.GetOrderByID function.
-func (ors *OrderService) GetOrderByID(orderID int) (models.Order, error) {
-	return ors.repoOrder.GetOrderByID(orderID)
+func (ors *OrderService) GetOrderByID(ctx context.Context, orderID int) (models.Order, error) {
+	return ors.repoOrder.GetOrderByID(ctx, orderID)
 }
 
 //GetOrdersByUserID gives the access to the OrderRepo.GetOrdersByUserID function.
-func (ors *OrderService) GetOrdersByUserID(userID int) (models.OrderList, error) {
-	return ors.repoOrder.GetOrdersByUserID(userID)
+func (ors *OrderService) GetOrdersByUserID(ctx context.Context, userID int) (models.OrderList, error) {
+	return ors.repoOrder.GetOrdersByUserID(ctx, userID)
 }
 
 //GetOrdersByScooterID gives the access to the OrderRepo.GetOrdersByScooterID function.
-func (ors *OrderService) GetOrdersByScooterID(scooterID int) (models.OrderList, error) {
-	return ors.repoOrder.GetOrdersByScooterID(scooterID)
+func (ors *OrderService) GetOrdersByScooterID(ctx context.Context, scooterID int) (models.OrderList, error) {
+	return ors.repoOrder.GetOrdersByScooterID(ctx, scooterID)
 }
 
 //GetScooterMileageByID gives the access to the OrderRepo.GetScooterMileageByID function.
-func (ors *OrderService) GetScooterMileageByID(scooterID int) (float64, error) {
-	return ors.repoOrder.GetScooterMileageByID(scooterID)
+func (ors *OrderService) GetScooterMileageByID(ctx context.Context, scooterID int) (float64, error) {
+	return ors.repoOrder.GetScooterMileageByID(ctx, scooterID)
 }
 
 //GetUserMileageByID gives the access to the OrderRepo.GetUserMileageByID function.
-func (ors *OrderService) GetUserMileageByID(userID int) (float64, error) {
-	return ors.repoOrder.GetUserMileageByID(userID)
+func (ors *OrderService) GetUserMileageByID(ctx context.Context, userID int) (float64, error) {
+	return ors.repoOrder.GetUserMileageByID(ctx, userID)
 }
 
 //UpdateOrder gives the access to the OrderRepo.UpdateOrder function.
-func (ors *OrderService) UpdateOrder(orderID int, orderData models.Order) (models.Order, error) {
-	return ors.repoOrder.UpdateOrder(orderID, orderData)
+func (ors *OrderService) UpdateOrder(ctx context.Context, orderID int, orderData models.Order) (models.Order, error) {
+	return ors.repoOrder.UpdateOrder(ctx, orderID, orderData)
 }
 
 //DeleteOrder ives the access to the OrderRepo.DeleteOrder function.
-func (ors *OrderService) DeleteOrder(orderID int) error {
-	return ors.repoOrder.DeleteOrder(orderID)
+func (ors *OrderService) DeleteOrder(ctx context.Context, orderID int) error {
+	return ors.repoOrder.DeleteOrder(ctx, orderID)
+}
+
+//CountTripDistance returns the distance (in meters) between the start and the end points of the trip.
+func (ors *OrderService) CountTripDistance(ctx context.Context, order models.Order) (int, error) {
+	start, end, err := ors.getTripStatuses(ctx, order)
+	if err != nil {
+		return 0, err
+	}
+
+	return int(math.Round(start.Location.Distance(end.Location))), nil
+}
+
+//CountTripAmountMoney returns the total price of the trip in cents.
+func (ors *OrderService) CountTripAmountMoney(ctx context.Context, order models.Order) (int, error) {
+	breakdown, err := ors.CountTripPrice(ctx, order)
+	if err != nil {
+		return 0, err
+	}
+
+	return breakdown.TotalCents, nil
+}
+
+//CountTripPrice returns the itemised price of the trip.
+func (ors *OrderService) CountTripPrice(ctx context.Context, order models.Order) (models.PriceBreakdown, error) {
+	start, end, err := ors.getTripStatuses(ctx, order)
+	if err != nil {
+		return models.PriceBreakdown{}, err
+	}
+
+	return ors.pricing.CountTripPrice(ctx, order, end.DateTime.Sub(start.DateTime))
+}
+
+//CompleteOrder counts the distance and the price of the finished trip, stores them with the order,
+//keeps the itemised price and pays for the trip from the user's account. The order is either completed
+//and paid or left as it was. The penalty for the broken zone rules is added to the price and goes
+//to the platform.
+func (ors *OrderService) CompleteOrder(ctx context.Context, order *models.Order, penaltyCents int) error {
+	start, end, err := ors.getTripStatuses(ctx, *order)
+	if err != nil {
+		return err
+	}
+	order.Distance = start.Location.Distance(end.Location)
+
+	breakdown, err := ors.pricing.CountTripPrice(ctx, *order, end.DateTime.Sub(start.DateTime))
+	if err != nil {
+		return err
+	}
+	breakdown.PenaltyCents = penaltyCents
+	breakdown.TotalCents += penaltyCents
+	breakdown.CommissionCents += penaltyCents
+	order.Amount = breakdown.TotalCents
+
+	return ors.tx.WithTx(ctx, func(ctx context.Context) error {
+		updated, err := ors.repoOrder.UpdateOrder(ctx, order.ID, *order)
+		if err != nil {
+			return err
+		}
+
+		err = ors.pricing.SavePriceBreakdown(ctx, &breakdown)
+		if err != nil {
+			return err
+		}
+
+		*order = updated
+		return ors.accounts.SettleOrder(ctx, updated, breakdown)
+	})
+}
+
+//GetOrderPrice returns the itemised price which was stored with the order.
+func (ors *OrderService) GetOrderPrice(ctx context.Context, orderID int) (models.PriceBreakdown, error) {
+	return ors.pricing.GetPriceBreakdown(ctx, orderID)
+}
+
+func (ors *OrderService) getTripStatuses(ctx context.Context, order models.Order) (models.ScooterStatusInRent, models.ScooterStatusInRent,
+	error) {
+	start, err := ors.repoOrder.GetStatusInRentByID(ctx, order.StatusStartID)
+	if err != nil {
+		return start, models.ScooterStatusInRent{}, err
+	}
+
+	end, err := ors.repoOrder.GetStatusInRentByID(ctx, order.StatusEndID)
+	return start, end, err
 }
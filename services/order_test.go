@@ -3,15 +3,24 @@ package services
 import (
 	"Dp218GO/models"
 	"Dp218GO/repositories/mock"
+	servicemock "Dp218GO/services/mock"
+	"context"
 	"errors"
 	"github.com/golang/mock/gomock"
 	assert "github.com/stretchr/testify/require"
 	"testing"
+	"time"
 )
 
 type OrderMock struct {
 	OrderService *OrderService
 	RepoOrder    *mock.MockOrderRepo
+	RepoPrice    *mock.MockPriceRepo
+	RepoAccount  *mock.MockAccountRepo
+	RepoTrans    *mock.MockAccountTransactionRepo
+	RepoPayment  *mock.MockPaymentTypeRepo
+	Clock        *servicemock.MockClock
+	Tx           *mock.MockTxManager
 }
 
 type orderTestCase struct {
@@ -40,12 +49,25 @@ func runOrderTestCases(t *testing.T, testCases []orderTestCase) {
 
 func NewOrderMock(ctrl *gomock.Controller) *OrderMock {
 	repoOrder := mock.NewMockOrderRepo(ctrl)
+	repoPrice := mock.NewMockPriceRepo(ctrl)
+	repoAccount := mock.NewMockAccountRepo(ctrl)
+	repoTrans := mock.NewMockAccountTransactionRepo(ctrl)
+	repoPayment := mock.NewMockPaymentTypeRepo(ctrl)
+	clock := servicemock.NewMockClock(ctrl)
+	tx := mock.NewMockTxManager(ctrl)
 
-	orderService := NewOrderService(repoOrder)
+	accountService := NewAccountService(repoAccount, repoTrans, repoPayment, clock, "000000000001", 0)
+	orderService := NewOrderService(repoOrder, NewPricingService(repoPrice), accountService, tx)
 
 	return &OrderMock{
 		OrderService: orderService,
 		RepoOrder:    repoOrder,
+		RepoPrice:    repoPrice,
+		RepoAccount:  repoAccount,
+		RepoTrans:    repoTrans,
+		RepoPayment:  repoPayment,
+		Clock:        clock,
+		Tx:           tx,
 	}
 }
 
@@ -54,20 +76,20 @@ func TestOrderService_CreateOrder(t *testing.T) {
 		{
 			name: "Correct",
 			test: func(t *testing.T, mock *OrderMock) {
-				mock.RepoOrder.EXPECT().CreateOrder(models.User{}, 1, 2, 3, 1000.50).Return(models.Order{},
+				mock.RepoOrder.EXPECT().CreateOrder(gomock.Any(), models.User{}, 1, 2, 3, 1000.50).Return(models.Order{},
 					nil).Times(1)
 
-				_, err := mock.OrderService.CreateOrder(models.User{}, 1, 2, 3, 1000.50)
+				_, err := mock.OrderService.CreateOrder(context.Background(), models.User{}, 1, 2, 3, 1000.50)
 				assert.Equal(t, nil, err)
 			},
 		}, {
 			name: "Incorrect",
 			test: func(t *testing.T, mock *OrderMock) {
 				expectedError := errors.New("expectedError")
-				mock.RepoOrder.EXPECT().CreateOrder(models.User{}, 1, 2, 3, 1000.50).Return(models.Order{},
+				mock.RepoOrder.EXPECT().CreateOrder(gomock.Any(), models.User{}, 1, 2, 3, 1000.50).Return(models.Order{},
 					expectedError).Times(1)
 
-				_, err := mock.RepoOrder.CreateOrder(models.User{}, 1, 2, 3, 1000.50)
+				_, err := mock.RepoOrder.CreateOrder(context.Background(), models.User{}, 1, 2, 3, 1000.50)
 				assert.Error(t, err)
 				assert.Equal(t, expectedError, err)
 			},
@@ -80,20 +102,20 @@ func TestOrderService_GetAllOrders(t *testing.T) {
 		{
 			name: "Correct",
 			test: func(t *testing.T, mock *OrderMock) {
-				mock.RepoOrder.EXPECT().GetAllOrders().Return(&models.OrderList{},
+				mock.RepoOrder.EXPECT().GetAllOrders(gomock.Any()).Return(&models.OrderList{},
 					nil).Times(1)
 
-				_, err := mock.OrderService.GetAllOrders()
+				_, err := mock.OrderService.GetAllOrders(context.Background())
 				assert.Equal(t, nil, err)
 			},
 		}, {
 			name: "Incorrect",
 			test: func(t *testing.T, mock *OrderMock) {
 				expectedError := errors.New("expectedError")
-				mock.RepoOrder.EXPECT().GetAllOrders().Return(&models.OrderList{},
+				mock.RepoOrder.EXPECT().GetAllOrders(gomock.Any()).Return(&models.OrderList{},
 					expectedError).Times(1)
 
-				_, err := mock.RepoOrder.GetAllOrders()
+				_, err := mock.RepoOrder.GetAllOrders(context.Background())
 				assert.Error(t, err)
 				assert.Equal(t, expectedError, err)
 			},
@@ -106,20 +128,20 @@ func TestOrderService_GetOrderByID(t *testing.T) {
 		{
 			name: "Correct",
 			test: func(t *testing.T, mock *OrderMock) {
-				mock.RepoOrder.EXPECT().GetOrderByID(1).Return(models.Order{},
+				mock.RepoOrder.EXPECT().GetOrderByID(gomock.Any(), 1).Return(models.Order{},
 					nil).Times(1)
 
-				_, err := mock.OrderService.GetOrderByID(1)
+				_, err := mock.OrderService.GetOrderByID(context.Background(), 1)
 				assert.Equal(t, nil, err)
 			},
 		}, {
 			name: "Incorrect",
 			test: func(t *testing.T, mock *OrderMock) {
 				expectedError := errors.New("expectedError")
-				mock.RepoOrder.EXPECT().GetOrderByID(1).Return(models.Order{},
+				mock.RepoOrder.EXPECT().GetOrderByID(gomock.Any(), 1).Return(models.Order{},
 					expectedError).Times(1)
 
-				_, err := mock.RepoOrder.GetOrderByID(1)
+				_, err := mock.RepoOrder.GetOrderByID(context.Background(), 1)
 				assert.Error(t, err)
 				assert.Equal(t, expectedError, err)
 			},
@@ -132,20 +154,20 @@ func TestOrderService_GetOrdersByUserID(t *testing.T) {
 		{
 			name: "Correct",
 			test: func(t *testing.T, mock *OrderMock) {
-				mock.RepoOrder.EXPECT().GetOrdersByUserID(1).Return(models.OrderList{},
+				mock.RepoOrder.EXPECT().GetOrdersByUserID(gomock.Any(), 1).Return(models.OrderList{},
 					nil).Times(1)
 
-				_, err := mock.OrderService.GetOrdersByUserID(1)
+				_, err := mock.OrderService.GetOrdersByUserID(context.Background(), 1)
 				assert.Equal(t, nil, err)
 			},
 		}, {
 			name: "Incorrect",
 			test: func(t *testing.T, mock *OrderMock) {
 				expectedError := errors.New("expectedError")
-				mock.RepoOrder.EXPECT().GetOrdersByUserID(1).Return(models.OrderList{},
+				mock.RepoOrder.EXPECT().GetOrdersByUserID(gomock.Any(), 1).Return(models.OrderList{},
 					expectedError).Times(1)
 
-				_, err := mock.RepoOrder.GetOrdersByUserID(1)
+				_, err := mock.RepoOrder.GetOrdersByUserID(context.Background(), 1)
 				assert.Error(t, err)
 				assert.Equal(t, expectedError, err)
 			},
@@ -158,20 +180,20 @@ func TestOrderService_GetOrdersByScooterID(t *testing.T) {
 		{
 			name: "Correct",
 			test: func(t *testing.T, mock *OrderMock) {
-				mock.RepoOrder.EXPECT().GetOrdersByScooterID(1).Return(models.OrderList{},
+				mock.RepoOrder.EXPECT().GetOrdersByScooterID(gomock.Any(), 1).Return(models.OrderList{},
 					nil).Times(1)
 
-				_, err := mock.OrderService.GetOrdersByScooterID(1)
+				_, err := mock.OrderService.GetOrdersByScooterID(context.Background(), 1)
 				assert.Equal(t, nil, err)
 			},
 		}, {
 			name: "Incorrect",
 			test: func(t *testing.T, mock *OrderMock) {
 				expectedError := errors.New("expectedError")
-				mock.RepoOrder.EXPECT().GetOrdersByScooterID(1).Return(models.OrderList{},
+				mock.RepoOrder.EXPECT().GetOrdersByScooterID(gomock.Any(), 1).Return(models.OrderList{},
 					expectedError).Times(1)
 
-				_, err := mock.RepoOrder.GetOrdersByScooterID(1)
+				_, err := mock.RepoOrder.GetOrdersByScooterID(context.Background(), 1)
 				assert.Error(t, err)
 				assert.Equal(t, expectedError, err)
 			},
@@ -179,25 +201,54 @@ func TestOrderService_GetOrdersByScooterID(t *testing.T) {
 	})
 }
 
+func TestOrderService_GetActiveOrderByScooterID(t *testing.T) {
+	runOrderTestCases(t, []orderTestCase{
+		{
+			name: "InTrip",
+			test: func(t *testing.T, mock *OrderMock) {
+				mock.RepoOrder.EXPECT().GetOrdersByScooterID(gomock.Any(), 1).Return(models.OrderList{
+					Orders: []models.Order{{ID: 3, ScooterID: 1, StatusEndID: 5}, {ID: 4, ScooterID: 1, UserID: 2}},
+				}, nil).Times(1)
+
+				order, ok, err := mock.OrderService.GetActiveOrderByScooterID(context.Background(), 1)
+				assert.Nil(t, err)
+				assert.True(t, ok)
+				assert.Equal(t, 4, order.ID)
+			},
+		}, {
+			name: "NotInTrip",
+			test: func(t *testing.T, mock *OrderMock) {
+				mock.RepoOrder.EXPECT().GetOrdersByScooterID(gomock.Any(), 1).Return(models.OrderList{
+					Orders: []models.Order{{ID: 3, ScooterID: 1, StatusEndID: 5}},
+				}, nil).Times(1)
+
+				_, ok, err := mock.OrderService.GetActiveOrderByScooterID(context.Background(), 1)
+				assert.Nil(t, err)
+				assert.False(t, ok)
+			},
+		},
+	})
+}
+
 func TestOrderService_GetScooterMileageByID(t *testing.T) {
 	runOrderTestCases(t, []orderTestCase{
 		{
 			name: "Correct",
 			test: func(t *testing.T, mock *OrderMock) {
-				mock.RepoOrder.EXPECT().GetScooterMileageByID(1).Return(100.500,
+				mock.RepoOrder.EXPECT().GetScooterMileageByID(gomock.Any(), 1).Return(100.500,
 					nil).Times(1)
 
-				_, err := mock.OrderService.GetScooterMileageByID(1)
+				_, err := mock.OrderService.GetScooterMileageByID(context.Background(), 1)
 				assert.Equal(t, nil, err)
 			},
 		}, {
 			name: "Incorrect",
 			test: func(t *testing.T, mock *OrderMock) {
 				expectedError := errors.New("expectedError")
-				mock.RepoOrder.EXPECT().GetScooterMileageByID(1).Return(100.500,
+				mock.RepoOrder.EXPECT().GetScooterMileageByID(gomock.Any(), 1).Return(100.500,
 					expectedError).Times(1)
 
-				_, err := mock.RepoOrder.GetScooterMileageByID(1)
+				_, err := mock.RepoOrder.GetScooterMileageByID(context.Background(), 1)
 				assert.Error(t, err)
 				assert.Equal(t, expectedError, err)
 			},
@@ -210,20 +261,20 @@ func TestOrderService_GetUserMileageByID(t *testing.T) {
 		{
 			name: "Correct",
 			test: func(t *testing.T, mock *OrderMock) {
-				mock.RepoOrder.EXPECT().GetUserMileageByID(1).Return(100.500,
+				mock.RepoOrder.EXPECT().GetUserMileageByID(gomock.Any(), 1).Return(100.500,
 					nil).Times(1)
 
-				_, err := mock.OrderService.GetUserMileageByID(1)
+				_, err := mock.OrderService.GetUserMileageByID(context.Background(), 1)
 				assert.Equal(t, nil, err)
 			},
 		}, {
 			name: "Incorrect",
 			test: func(t *testing.T, mock *OrderMock) {
 				expectedError := errors.New("expectedError")
-				mock.RepoOrder.EXPECT().GetUserMileageByID(1).Return(100.500,
+				mock.RepoOrder.EXPECT().GetUserMileageByID(gomock.Any(), 1).Return(100.500,
 					expectedError).Times(1)
 
-				_, err := mock.RepoOrder.GetUserMileageByID(1)
+				_, err := mock.RepoOrder.GetUserMileageByID(context.Background(), 1)
 				assert.Error(t, err)
 				assert.Equal(t, expectedError, err)
 			},
@@ -236,20 +287,20 @@ func TestOrderService_UpdateOrder(t *testing.T) {
 		{
 			name: "Correct",
 			test: func(t *testing.T, mock *OrderMock) {
-				mock.RepoOrder.EXPECT().UpdateOrder(1, models.Order{}).Return(models.Order{},
+				mock.RepoOrder.EXPECT().UpdateOrder(context.Background(), 1, models.Order{}).Return(models.Order{},
 					nil).Times(1)
 
-				_, err := mock.OrderService.UpdateOrder(1, models.Order{})
+				_, err := mock.OrderService.UpdateOrder(context.Background(), 1, models.Order{})
 				assert.Equal(t, nil, err)
 			},
 		}, {
 			name: "Incorrect",
 			test: func(t *testing.T, mock *OrderMock) {
 				expectedError := errors.New("expectedError")
-				mock.RepoOrder.EXPECT().UpdateOrder(1, models.Order{}).Return(models.Order{},
+				mock.RepoOrder.EXPECT().UpdateOrder(context.Background(), 1, models.Order{}).Return(models.Order{},
 					expectedError).Times(1)
 
-				_, err := mock.RepoOrder.UpdateOrder(1, models.Order{})
+				_, err := mock.RepoOrder.UpdateOrder(context.Background(), 1, models.Order{})
 				assert.Error(t, err)
 				assert.Equal(t, expectedError, err)
 			},
@@ -262,20 +313,165 @@ func TestOrderService_DeleteOrder(t *testing.T) {
 		{
 			name: "Correct",
 			test: func(t *testing.T, mock *OrderMock) {
-				mock.RepoOrder.EXPECT().DeleteOrder(1).
+				mock.RepoOrder.EXPECT().DeleteOrder(gomock.Any(), 1).
 					Return(nil).Times(1)
 
-				err := mock.OrderService.DeleteOrder(1)
+				err := mock.OrderService.DeleteOrder(context.Background(), 1)
 				assert.Equal(t, nil, err)
 			},
 		}, {
 			name: "Incorrect",
 			test: func(t *testing.T, mock *OrderMock) {
 				expectedError := errors.New("expectedError")
-				mock.RepoOrder.EXPECT().DeleteOrder(1).Return(
+				mock.RepoOrder.EXPECT().DeleteOrder(gomock.Any(), 1).Return(
 					expectedError).Times(1)
 
-				err := mock.RepoOrder.DeleteOrder(1)
+				err := mock.RepoOrder.DeleteOrder(context.Background(), 1)
+				assert.Error(t, err)
+				assert.Equal(t, expectedError, err)
+			},
+		},
+	})
+}
+
+func TestOrderService_CompleteOrder(t *testing.T) {
+	start := time.Date(2021, 12, 1, 10, 0, 0, 0, time.UTC)
+	startStatus := models.ScooterStatusInRent{ID: 2, DateTime: start,
+		Location: models.Coordinate{Latitude: 48.42367, Longitude: 35.04436}}
+	endStatus := models.ScooterStatusInRent{ID: 3, DateTime: start.Add(10 * time.Minute),
+		Location: models.Coordinate{Latitude: 48.42210, Longitude: 35.01960}}
+	tariff := models.Tariff{SupplierID: 9, PaymentTypeID: 4, PricePerMinute: 100, UnlockFee: 500,
+		CommissionPercent: 10}
+
+	runOrderTestCases(t, []orderTestCase{
+		{
+			name: "Correct",
+			test: func(t *testing.T, mock *OrderMock) {
+				order := models.Order{ID: 1, UserID: 5, ScooterID: 4, StatusStartID: 2, StatusEndID: 3}
+				mock.RepoOrder.EXPECT().GetStatusInRentByID(gomock.Any(), 2).Return(startStatus, nil).Times(1)
+				mock.RepoOrder.EXPECT().GetStatusInRentByID(gomock.Any(), 3).Return(endStatus, nil).Times(1)
+				mock.RepoPrice.EXPECT().GetTariffByScooterID(gomock.Any(), 4).Return(tariff, nil).Times(1)
+				mock.Tx.EXPECT().WithTx(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).Times(1)
+				mock.RepoOrder.EXPECT().UpdateOrder(gomock.Any(), 1, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, orderID int, orderData models.Order) (models.Order, error) {
+						return orderData, nil
+					}).Times(1)
+				mock.RepoPrice.EXPECT().AddPriceBreakdown(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, breakdown *models.PriceBreakdown) error {
+						assert.Equal(t, 1, breakdown.OrderID)
+						assert.Equal(t, 1500, breakdown.TotalCents)
+						assert.Equal(t, 150, breakdown.CommissionCents)
+						return nil
+					}).Times(1)
+				mock.RepoTrans.EXPECT().GetAccountTransactionsByOrder(gomock.Any(), gomock.Any()).
+					Return(&models.AccountTransactionList{}, nil).Times(1)
+				mock.RepoAccount.EXPECT().GetAccountsByOwner(gomock.Any(), models.User{ID: 5}).
+					Return(&models.AccountList{Accounts: []models.Account{{ID: 3}}}, nil).Times(1)
+				mock.RepoAccount.EXPECT().GetAccountsByOwner(gomock.Any(), models.User{ID: 9}).
+					Return(&models.AccountList{Accounts: []models.Account{{ID: 1}}}, nil).Times(1)
+				mock.RepoPayment.EXPECT().GetPaymentTypeById(gomock.Any(), 4).Return(models.PaymentType{ID: 4}, nil).Times(1)
+				mock.Clock.EXPECT().Now().Return(endStatus.DateTime).Times(1)
+				mock.RepoAccount.EXPECT().GetAccountByNumber(gomock.Any(), "000000000001").
+					Return(models.Account{ID: 10}, nil).Times(1)
+				mock.RepoPayment.EXPECT().GetPaymentTypeById(gomock.Any(), PayCommissionTypeID).
+					Return(models.PaymentType{ID: PayCommissionTypeID}, nil).Times(1)
+				mock.RepoTrans.EXPECT().AddAccountTransactions(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).Times(1)
+
+				err := mock.OrderService.CompleteOrder(context.Background(), &order, 0)
+				assert.Equal(t, nil, err)
+				assert.Equal(t, 1500, order.Amount)
+				assert.InDelta(t, endStatus.Location.Distance(startStatus.Location), order.Distance, 0.001)
+			},
+		}, {
+			name: "WithPenalty",
+			test: func(t *testing.T, mock *OrderMock) {
+				order := models.Order{ID: 1, UserID: 5, ScooterID: 4, StatusStartID: 2, StatusEndID: 3}
+				mock.RepoOrder.EXPECT().GetStatusInRentByID(gomock.Any(), 2).Return(startStatus, nil).Times(1)
+				mock.RepoOrder.EXPECT().GetStatusInRentByID(gomock.Any(), 3).Return(endStatus, nil).Times(1)
+				mock.RepoPrice.EXPECT().GetTariffByScooterID(gomock.Any(), 4).Return(tariff, nil).Times(1)
+				mock.Tx.EXPECT().WithTx(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).Times(1)
+				mock.RepoOrder.EXPECT().UpdateOrder(gomock.Any(), 1, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, orderID int, orderData models.Order) (models.Order, error) {
+						return orderData, nil
+					}).Times(1)
+				mock.RepoPrice.EXPECT().AddPriceBreakdown(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, breakdown *models.PriceBreakdown) error {
+						assert.Equal(t, 1, breakdown.OrderID)
+						assert.Equal(t, 300, breakdown.PenaltyCents)
+						assert.Equal(t, 1800, breakdown.TotalCents)
+						assert.Equal(t, 450, breakdown.CommissionCents)
+						assert.Equal(t, 1350, breakdown.SupplierCents)
+						return nil
+					}).Times(1)
+				mock.RepoTrans.EXPECT().GetAccountTransactionsByOrder(gomock.Any(), gomock.Any()).
+					Return(&models.AccountTransactionList{}, nil).Times(1)
+				mock.RepoAccount.EXPECT().GetAccountsByOwner(gomock.Any(), models.User{ID: 5}).
+					Return(&models.AccountList{Accounts: []models.Account{{ID: 3}}}, nil).Times(1)
+				mock.RepoAccount.EXPECT().GetAccountsByOwner(gomock.Any(), models.User{ID: 9}).
+					Return(&models.AccountList{Accounts: []models.Account{{ID: 1}}}, nil).Times(1)
+				mock.RepoPayment.EXPECT().GetPaymentTypeById(gomock.Any(), 4).Return(models.PaymentType{ID: 4}, nil).Times(1)
+				mock.Clock.EXPECT().Now().Return(endStatus.DateTime).Times(1)
+				mock.RepoAccount.EXPECT().GetAccountByNumber(gomock.Any(), "000000000001").
+					Return(models.Account{ID: 10}, nil).Times(1)
+				mock.RepoPayment.EXPECT().GetPaymentTypeById(gomock.Any(), PayCommissionTypeID).
+					Return(models.PaymentType{ID: PayCommissionTypeID}, nil).Times(1)
+				mock.RepoTrans.EXPECT().AddAccountTransactions(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).Times(1)
+
+				err := mock.OrderService.CompleteOrder(context.Background(), &order, 300)
+				assert.Equal(t, nil, err)
+				assert.Equal(t, 1800, order.Amount)
+			},
+		}, {
+			name: "Incorrect",
+			test: func(t *testing.T, mock *OrderMock) {
+				order := models.Order{ID: 1, ScooterID: 4, StatusStartID: 2, StatusEndID: 3}
+				expectedError := errors.New("expectedError")
+				mock.RepoOrder.EXPECT().GetStatusInRentByID(gomock.Any(), 2).Return(startStatus, nil).Times(1)
+				mock.RepoOrder.EXPECT().GetStatusInRentByID(gomock.Any(), 3).Return(endStatus, nil).Times(1)
+				mock.RepoPrice.EXPECT().GetTariffByScooterID(gomock.Any(), 4).Return(models.Tariff{}, expectedError).Times(1)
+
+				err := mock.OrderService.CompleteOrder(context.Background(), &order, 0)
+				assert.Error(t, err)
+				assert.Equal(t, expectedError, err)
+			},
+		},
+	})
+}
+
+func TestOrderService_CountTripAmountMoney(t *testing.T) {
+	start := time.Date(2021, 12, 1, 10, 0, 0, 0, time.UTC)
+
+	runOrderTestCases(t, []orderTestCase{
+		{
+			name: "Correct",
+			test: func(t *testing.T, mock *OrderMock) {
+				order := models.Order{ID: 1, ScooterID: 4, StatusStartID: 2, StatusEndID: 3}
+				mock.RepoOrder.EXPECT().GetStatusInRentByID(gomock.Any(), 2).
+					Return(models.ScooterStatusInRent{DateTime: start}, nil).Times(1)
+				mock.RepoOrder.EXPECT().GetStatusInRentByID(gomock.Any(), 3).
+					Return(models.ScooterStatusInRent{DateTime: start.Add(90 * time.Second)}, nil).Times(1)
+				mock.RepoPrice.EXPECT().GetTariffByScooterID(gomock.Any(), 4).
+					Return(models.Tariff{PricePerMinute: 100}, nil).Times(1)
+
+				amount, err := mock.OrderService.CountTripAmountMoney(context.Background(), order)
+				assert.Equal(t, nil, err)
+				assert.Equal(t, 200, amount)
+			},
+		}, {
+			name: "Incorrect",
+			test: func(t *testing.T, mock *OrderMock) {
+				order := models.Order{ID: 1, ScooterID: 4, StatusStartID: 2, StatusEndID: 3}
+				expectedError := errors.New("expectedError")
+				mock.RepoOrder.EXPECT().GetStatusInRentByID(gomock.Any(), 2).
+					Return(models.ScooterStatusInRent{}, expectedError).Times(1)
+
+				_, err := mock.OrderService.CountTripAmountMoney(context.Background(), order)
 				assert.Error(t, err)
 				assert.Equal(t, expectedError, err)
 			},
@@ -0,0 +1,121 @@
+package services
+
+import (
+	"Dp218GO/models"
+	"Dp218GO/repositories"
+	"Dp218GO/utils"
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+//defaultPasswordResetTTL is the lifetime of the password reset link if it isn't configured.
+const defaultPasswordResetTTL = time.Hour
+
+var ErrWrongPassword = errors.New("current password is wrong")
+
+//PasswordService resets forgotten passwords by the emailed links and changes passwords of the users.
+//Every password change invalidates the sessions and api tokens issued before it.
+type PasswordService struct {
+	repoUser    repositories.UserRepo
+	repoReset   repositories.PasswordResetRepo
+	repoToken   repositories.TokenRepo
+	repoContact repositories.ContactRepo
+	mailer      Mailer
+	clock       Clock
+	ttl         time.Duration
+	baseURL     string
+}
+
+//NewPasswordService creates the new PasswordService. baseURL is the address of the application
+//which is used for the reset links.
+func NewPasswordService(userRepo repositories.UserRepo, resetRepo repositories.PasswordResetRepo,
+	tokenRepo repositories.TokenRepo, contactRepo repositories.ContactRepo, mailer Mailer, clock Clock,
+	ttl time.Duration, baseURL string) *PasswordService {
+	if ttl <= 0 {
+		ttl = defaultPasswordResetTTL
+	}
+	return &PasswordService{repoUser: userRepo, repoReset: resetRepo, repoToken: tokenRepo, repoContact: contactRepo,
+		mailer: mailer, clock: clock, ttl: ttl, baseURL: strings.TrimRight(baseURL, "/")}
+}
+
+//RequestReset sends the password reset link to the login email of the user. The primary secondary email
+//of the user is only notified about the request, it doesn't get the link.
+func (ps *PasswordService) RequestReset(ctx context.Context, email string) error {
+	user, err := ps.repoUser.GetUserByEmail(ctx, email)
+	if err != nil {
+		return err
+	}
+
+	token, err := generateToken()
+	if err != nil {
+		return err
+	}
+
+	now := ps.clock.Now()
+	reset := &models.PasswordReset{
+		UserID:    user.ID,
+		TokenHash: hashToken(token),
+		CreatedAt: now,
+		ExpiresAt: now.Add(ps.ttl),
+	}
+	if err = ps.repoReset.AddPasswordReset(ctx, reset); err != nil {
+		return err
+	}
+
+	link := ps.baseURL + "/password/reset?token=" + url.QueryEscape(token)
+	body := fmt.Sprintf("Hello, %s!\n\nTo set the new password open the link below:\n%s\n\n"+
+		"The link is valid for %s. Ignore this email if you didn't ask for the password reset.",
+		user.UserName, link, ps.ttl)
+
+	if err = ps.mailer.Send(ctx, user.LoginEmail, "Password reset", body); err != nil {
+		return err
+	}
+
+	secondary, ok, err := secondaryEmail(ctx, ps.repoContact, user)
+	if err != nil || !ok {
+		return err
+	}
+	notice := fmt.Sprintf("Hello, %s!\n\nThe password reset was requested for your account. "+
+		"The link was sent to your login email. Ignore this email if it was you.", user.UserName)
+	return ps.mailer.Send(ctx, secondary, "Password reset", notice)
+}
+
+//Reset sets the new password of the user whom the reset token was sent to. The token can be used only once.
+func (ps *PasswordService) Reset(ctx context.Context, token, newPassword string) error {
+	now := ps.clock.Now()
+	reset, err := ps.repoReset.UsePasswordReset(ctx, hashToken(token), now)
+	if err != nil {
+		return err
+	}
+
+	return ps.setPassword(ctx, reset.UserID, newPassword, now)
+}
+
+//Change sets the new password of the user if the current one is right.
+func (ps *PasswordService) Change(ctx context.Context, email, oldPassword, newPassword string) error {
+	user, err := ps.repoUser.GetUserByEmail(ctx, email)
+	if err != nil {
+		return err
+	}
+	if err = utils.CheckPassword(user.Password, oldPassword); err != nil {
+		return ErrWrongPassword
+	}
+
+	return ps.setPassword(ctx, user.ID, newPassword, ps.clock.Now())
+}
+
+func (ps *PasswordService) setPassword(ctx context.Context, userID int, password string, now time.Time) error {
+	hash, err := utils.HashPassword(password)
+	if err != nil {
+		return err
+	}
+	if err = ps.repoUser.UpdatePassword(ctx, userID, hash, now); err != nil {
+		return err
+	}
+
+	return ps.repoToken.RevokeUserTokens(ctx, userID, now)
+}
@@ -0,0 +1,182 @@
+package services
+
+import (
+	"Dp218GO/models"
+	"Dp218GO/repositories"
+	repomock "Dp218GO/repositories/mock"
+	"Dp218GO/services/mock"
+	"Dp218GO/utils"
+	"context"
+	"github.com/golang/mock/gomock"
+	assert "github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+const testPasswordResetTTL = 30 * time.Minute
+
+var passwordTime = time.Date(2021, 12, 20, 10, 0, 0, 0, time.UTC)
+
+type passwordUseCasesMock struct {
+	PasswordServiceUC *PasswordService
+	RepoUser          *repomock.MockUserRepo
+	RepoReset         *repomock.MockPasswordResetRepo
+	RepoToken         *repomock.MockTokenRepo
+	RepoContact       *repomock.MockContactRepo
+	Clock             *mock.MockClock
+	Mailer            *testMailer
+}
+
+type passwordTestCase struct {
+	name string
+	test func(t *testing.T, mock *passwordUseCasesMock)
+}
+
+func runPasswordTestCases(t *testing.T, testCases []passwordTestCase) {
+	for _, tc := range testCases {
+		t.Run(tc.name, func(tt *testing.T) {
+			defer func() {
+				if err := recover(); err != nil {
+					tt.Error(err)
+				}
+			}()
+
+			ctrl := gomock.NewController(tt)
+			defer ctrl.Finish()
+
+			mock := newPasswordUseCasesMock(ctrl)
+
+			tc.test(tt, mock)
+		})
+	}
+}
+
+func newPasswordUseCasesMock(ctrl *gomock.Controller) *passwordUseCasesMock {
+	repoUser := repomock.NewMockUserRepo(ctrl)
+	repoReset := repomock.NewMockPasswordResetRepo(ctrl)
+	repoToken := repomock.NewMockTokenRepo(ctrl)
+	repoContact := repomock.NewMockContactRepo(ctrl)
+	clock := mock.NewMockClock(ctrl)
+	mailer := &testMailer{}
+
+	return &passwordUseCasesMock{
+		PasswordServiceUC: NewPasswordService(repoUser, repoReset, repoToken, repoContact, mailer, clock, testPasswordResetTTL,
+			"http://localhost:8080"),
+		RepoUser:  repoUser,
+		RepoReset: repoReset,
+		RepoToken:   repoToken,
+		RepoContact: repoContact,
+		Clock:       clock,
+		Mailer:      mailer,
+	}
+}
+
+func Test_Password_RequestReset(t *testing.T) {
+	runPasswordTestCases(t, []passwordTestCase{
+		{
+			name: "Correct",
+			test: func(t *testing.T, mock *passwordUseCasesMock) {
+				var stored models.PasswordReset
+				mock.Clock.EXPECT().Now().Return(passwordTime).Times(1)
+				mock.RepoUser.EXPECT().GetUserByEmail(gomock.Any(), "user@mail.com").
+					Return(models.User{ID: 5, LoginEmail: "user@mail.com"}, nil).Times(1)
+				mock.RepoReset.EXPECT().AddPasswordReset(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, reset *models.PasswordReset) error {
+						stored = *reset
+						return nil
+					}).Times(1)
+				mock.RepoContact.EXPECT().GetPrimaryContact(gomock.Any(), 5, models.ContactTypeEmail).
+					Return(models.Contact{}, repositories.ErrNoContact).Times(1)
+
+				err := mock.PasswordServiceUC.RequestReset(context.Background(), "user@mail.com")
+				assert.Nil(t, err)
+				assert.Equal(t, "user@mail.com", mock.Mailer.to)
+				assert.Contains(t, mock.Mailer.body, "http://localhost:8080/password/reset?token=")
+				assert.Equal(t, 5, stored.UserID)
+				assert.Equal(t, hashToken(mock.Mailer.token()), stored.TokenHash)
+				assert.Equal(t, passwordTime.Add(testPasswordResetTTL), stored.ExpiresAt)
+			},
+		},
+		{
+			name: "SecondaryEmail",
+			test: func(t *testing.T, mock *passwordUseCasesMock) {
+				mock.Clock.EXPECT().Now().Return(passwordTime).Times(1)
+				mock.RepoUser.EXPECT().GetUserByEmail(gomock.Any(), "user@mail.com").
+					Return(models.User{ID: 5, LoginEmail: "user@mail.com"}, nil).Times(1)
+				mock.RepoReset.EXPECT().AddPasswordReset(gomock.Any(), gomock.Any()).Return(nil).Times(1)
+				mock.RepoContact.EXPECT().GetPrimaryContact(gomock.Any(), 5, models.ContactTypeEmail).
+					Return(models.Contact{ID: 3, UserID: 5, ContactInfo: "backup@mail.com", IsPrimary: true}, nil).Times(1)
+
+				err := mock.PasswordServiceUC.RequestReset(context.Background(), "user@mail.com")
+				assert.Nil(t, err)
+				assert.Len(t, mock.Mailer.sent, 2)
+				assert.Contains(t, mock.Mailer.sent["user@mail.com"], "http://localhost:8080/password/reset?token=")
+				assert.Contains(t, mock.Mailer.sent["backup@mail.com"], "password reset was requested")
+				assert.NotContains(t, mock.Mailer.sent["backup@mail.com"], "token=")
+			},
+		},
+	})
+}
+
+func Test_Password_Reset(t *testing.T) {
+	runPasswordTestCases(t, []passwordTestCase{
+		{
+			name: "Correct",
+			test: func(t *testing.T, mock *passwordUseCasesMock) {
+				mock.Clock.EXPECT().Now().Return(passwordTime).Times(1)
+				mock.RepoReset.EXPECT().UsePasswordReset(gomock.Any(), hashToken("token"), passwordTime).
+					Return(models.PasswordReset{ID: 2, UserID: 5}, nil).Times(1)
+				mock.RepoUser.EXPECT().UpdatePassword(gomock.Any(), 5, gomock.Any(), passwordTime).
+					DoAndReturn(func(ctx context.Context, userID int, hash string, changedAt time.Time) error {
+						assert.Nil(t, utils.CheckPassword(hash, "NewPassw0rd"))
+						return nil
+					}).Times(1)
+				mock.RepoToken.EXPECT().RevokeUserTokens(gomock.Any(), 5, passwordTime).Return(nil).Times(1)
+
+				err := mock.PasswordServiceUC.Reset(context.Background(), "token", "NewPassw0rd")
+				assert.Nil(t, err)
+			},
+		},
+		{
+			name: "UsedOrExpired",
+			test: func(t *testing.T, mock *passwordUseCasesMock) {
+				mock.Clock.EXPECT().Now().Return(passwordTime).Times(1)
+				mock.RepoReset.EXPECT().UsePasswordReset(gomock.Any(), hashToken("token"), passwordTime).
+					Return(models.PasswordReset{}, repositories.ErrNoPasswordReset).Times(1)
+
+				err := mock.PasswordServiceUC.Reset(context.Background(), "token", "NewPassw0rd")
+				assert.ErrorIs(t, err, repositories.ErrNoPasswordReset)
+			},
+		},
+	})
+}
+
+func Test_Password_Change(t *testing.T) {
+	hash, _ := utils.HashPassword("OldPassw0rd")
+	user := models.User{ID: 5, LoginEmail: "user@mail.com", Password: hash}
+
+	runPasswordTestCases(t, []passwordTestCase{
+		{
+			name: "Correct",
+			test: func(t *testing.T, mock *passwordUseCasesMock) {
+				mock.Clock.EXPECT().Now().Return(passwordTime).Times(1)
+				mock.RepoUser.EXPECT().GetUserByEmail(gomock.Any(), "user@mail.com").Return(user, nil).Times(1)
+				mock.RepoUser.EXPECT().UpdatePassword(gomock.Any(), 5, gomock.Any(), passwordTime).
+					Return(nil).Times(1)
+				mock.RepoToken.EXPECT().RevokeUserTokens(gomock.Any(), 5, passwordTime).Return(nil).Times(1)
+
+				err := mock.PasswordServiceUC.Change(context.Background(), "user@mail.com", "OldPassw0rd", "NewPassw0rd")
+				assert.Nil(t, err)
+			},
+		},
+		{
+			name: "WrongPassword",
+			test: func(t *testing.T, mock *passwordUseCasesMock) {
+				mock.RepoUser.EXPECT().GetUserByEmail(gomock.Any(), "user@mail.com").Return(user, nil).Times(1)
+
+				err := mock.PasswordServiceUC.Change(context.Background(), "user@mail.com", "Wrong", "NewPassw0rd")
+				assert.ErrorIs(t, err, ErrWrongPassword)
+			},
+		},
+	})
+}
@@ -0,0 +1,323 @@
+package services
+
+import (
+	"Dp218GO/models"
+	"Dp218GO/repositories"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+//importReason is the reason of the station status changed by the import.
+const importReason = "bulk import"
+
+//PlaceService is the service which imports and exports stations and locations in bulk.
+type PlaceService struct {
+	stations     *StationService
+	repoLocation repositories.LocationRepo
+	tx           repositories.TxManager
+}
+
+//NewPlaceService creates the new PlaceService.
+func NewPlaceService(stations *StationService, repoLocation repositories.LocationRepo,
+	tx repositories.TxManager) *PlaceService {
+	return &PlaceService{stations: stations, repoLocation: repoLocation, tx: tx}
+}
+
+//StationsFeed returns all the stations as GeoJSON points with their occupancy.
+func (ps *PlaceService) StationsFeed(ctx context.Context) (models.FeatureCollection, error) {
+	stations, err := ps.stations.GetAllStations(ctx)
+	if err != nil {
+		return models.FeatureCollection{}, err
+	}
+
+	places := make([]models.Place, 0, len(stations.Station))
+	for _, station := range stations.Station {
+		place := stationPlace(station)
+		occupied, freeSlots := station.Occupied, station.FreeSlots
+		place.Occupied, place.FreeSlots = &occupied, &freeSlots
+		places = append(places, place)
+	}
+	return ToFeatureCollection(places), nil
+}
+
+//ExportPlaces writes all the stations followed by all the locations to w in the given format.
+func (ps *PlaceService) ExportPlaces(ctx context.Context, format string, w io.Writer) error {
+	if format != PlacesGeoJSON && format != PlacesCSV {
+		return ErrPlacesFormat
+	}
+
+	stations, locations, err := ps.getPlaces(ctx)
+	if err != nil {
+		return err
+	}
+
+	places := make([]models.Place, 0, len(stations)+len(locations))
+	for _, station := range stations {
+		places = append(places, stationPlace(station))
+	}
+	for _, location := range locations {
+		places = append(places, locationPlace(location))
+	}
+	return encodePlaces(format, w, places)
+}
+
+//ImportPlaces reads the stations and locations from r and compares them with the stored ones.
+//The place with ID updates the stored place, the place without ID updates the one with the same name
+//(label of the location) or is created. Nothing is stored if any record is wrong or it is the dry run,
+//otherwise all the changes are stored at once. The report lists the changes anyway.
+//The changed activity of the station is recorded in its status history as made by actorID.
+func (ps *PlaceService) ImportPlaces(ctx context.Context, format string, r io.Reader,
+	dryRun bool, actorID int) (models.ImportReport, error) {
+	report := models.ImportReport{DryRun: dryRun}
+
+	records, importErrors, err := decodePlaces(format, r)
+	if err != nil {
+		return report, err
+	}
+	report.Errors = importErrors
+
+	stations, locations, err := ps.getPlaces(ctx)
+	if err != nil {
+		return report, err
+	}
+	planImport(&report, records, stations, locations)
+
+	if dryRun || len(report.Errors) > 0 {
+		return report, nil
+	}
+
+	err = ps.tx.WithTx(ctx, func(ctx context.Context) error {
+		for i := range report.Created {
+			if err := ps.createPlace(ctx, &report.Created[i].Place); err != nil {
+				return fmt.Errorf("record %d: %w", report.Created[i].Record, err)
+			}
+		}
+		for _, change := range report.Updated {
+			if err := ps.updatePlace(ctx, change, actorID); err != nil {
+				return fmt.Errorf("record %d: %w", change.Record, err)
+			}
+		}
+		return nil
+	})
+	report.Applied = err == nil
+
+	return report, err
+}
+
+func (ps *PlaceService) getPlaces(ctx context.Context) ([]models.Station, []models.Location, error) {
+	stations, err := ps.stations.GetAllStations(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	locations, err := ps.repoLocation.GetAllLocations(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	return stations.Station, locations.Location, nil
+}
+
+func (ps *PlaceService) createPlace(ctx context.Context, place *models.Place) error {
+	if place.Kind == models.PlaceLocation {
+		location := placeLocation(*place)
+		err := ps.repoLocation.AddLocation(ctx, &location)
+		place.ID = location.ID
+		return err
+	}
+
+	station := placeStation(*place)
+	err := ps.stations.AddStation(ctx, &station)
+	place.ID, place.Capacity = station.ID, station.Capacity
+	return err
+}
+
+func (ps *PlaceService) updatePlace(ctx context.Context, change models.PlaceChange, actorID int) error {
+	place := change.Place
+	if place.Kind == models.PlaceLocation {
+		return ps.repoLocation.UpdateLocation(ctx, placeLocation(place))
+	}
+
+	station := placeStation(place)
+	if _, err := ps.stations.UpdateStation(ctx, place.ID, station); err != nil {
+		return err
+	}
+	for _, field := range change.Changes {
+		if field.Field == "is_active" {
+			return ps.stations.ChangeStatus(ctx, &models.StationStatusChange{StationID: place.ID,
+				Status: station.Status, Reason: importReason, ChangedBy: actorID})
+		}
+	}
+	return nil
+}
+
+//planImport sorts the valid records into created, updated and unchanged places of the report.
+//The records which are wrong or point to the same place as an earlier record are reported as errors.
+func planImport(report *models.ImportReport, records []placeRecord, stations []models.Station,
+	locations []models.Location) {
+	stored := make(map[string]models.Place, len(stations)+len(locations))
+	byName := make(map[string][]models.Place)
+	for _, station := range stations {
+		place := stationPlace(station)
+		stored[placeKey(place)] = place
+		byName[nameKey(place)] = append(byName[nameKey(place)], place)
+	}
+	for _, location := range locations {
+		place := locationPlace(location)
+		stored[placeKey(place)] = place
+		byName[nameKey(place)] = append(byName[nameKey(place)], place)
+	}
+
+	seen := make(map[string]int)
+	for _, record := range records {
+		place, err := normalizePlace(record.place)
+		if err == nil {
+			var existing models.Place
+			existing, err = matchPlace(place, stored, byName)
+			if err == nil {
+				key := placeKey(existing)
+				if existing.ID == 0 {
+					key = nameKey(place)
+				}
+				if first, ok := seen[key]; ok {
+					err = fmt.Errorf("the %s is already imported by record %d", place.Kind, first)
+				} else {
+					seen[key] = record.number
+					addChange(report, record.number, place, existing)
+				}
+			}
+		}
+		if err != nil {
+			report.Errors = append(report.Errors, models.ImportError{Record: record.number, Message: err.Error()})
+		}
+	}
+}
+
+//matchPlace returns the stored place which is changed by the imported one, an empty place means a new one.
+func matchPlace(place models.Place, stored map[string]models.Place, byName map[string][]models.Place) (models.Place, error) {
+	if place.ID != 0 {
+		existing, ok := stored[placeKey(place)]
+		if !ok {
+			return existing, fmt.Errorf("%s %d is not found", place.Kind, place.ID)
+		}
+		return existing, nil
+	}
+
+	switch matches := byName[nameKey(place)]; len(matches) {
+	case 0:
+		return models.Place{}, nil
+	case 1:
+		return matches[0], nil
+	default:
+		return models.Place{}, fmt.Errorf("there are %d %ss named %q, set the id", len(matches), place.Kind, place.Name)
+	}
+}
+
+//addChange adds the place to the created ones or, with the unset fields taken from the stored place,
+//to the updated ones if anything is changed. The new station gets the defaults for the unset fields.
+func addChange(report *models.ImportReport, number int, place, existing models.Place) {
+	if existing.ID == 0 {
+		if place.Kind == models.PlaceStation {
+			station := placeStation(place)
+			place.IsActive = &station.IsActive
+			if place.Capacity == 0 {
+				place.Capacity = DefaultStationCapacity
+			}
+		}
+		report.Created = append(report.Created, models.PlaceChange{Record: number, Place: place})
+		return
+	}
+
+	place.ID = existing.ID
+	if place.IsActive == nil {
+		place.IsActive = existing.IsActive
+	}
+	if place.Capacity == 0 {
+		place.Capacity = existing.Capacity
+	}
+
+	changes := diffPlaces(existing, place)
+	if len(changes) == 0 {
+		report.Unchanged++
+		return
+	}
+	report.Updated = append(report.Updated, models.PlaceChange{Record: number, Place: place, Changes: changes})
+}
+
+func diffPlaces(stored, imported models.Place) []models.FieldChange {
+	var changes []models.FieldChange
+	compare := func(field, oldValue, newValue string) {
+		if oldValue != newValue {
+			changes = append(changes, models.FieldChange{Field: field, Old: oldValue, New: newValue})
+		}
+	}
+
+	compare("name", stored.Name, imported.Name)
+	compare("latitude", formatCoordinate(stored.Latitude), formatCoordinate(imported.Latitude))
+	compare("longitude", formatCoordinate(stored.Longitude), formatCoordinate(imported.Longitude))
+	if imported.Kind == models.PlaceStation {
+		compare("is_active", strconv.FormatBool(*stored.IsActive), strconv.FormatBool(*imported.IsActive))
+		compare("capacity", strconv.Itoa(stored.Capacity), strconv.Itoa(imported.Capacity))
+	}
+	return changes
+}
+
+//normalizePlace trims the imported place and checks its values. The location has no activity and capacity.
+func normalizePlace(place models.Place) (models.Place, error) {
+	place.Kind = strings.ToLower(strings.TrimSpace(place.Kind))
+	place.Name = strings.TrimSpace(place.Name)
+
+	switch {
+	case place.Kind != models.PlaceStation && place.Kind != models.PlaceLocation:
+		return place, fmt.Errorf("kind must be %s or %s", models.PlaceStation, models.PlaceLocation)
+	case place.Name == "":
+		return place, errors.New("name is required")
+	case place.ID < 0:
+		return place, errors.New("id can't be negative")
+	case place.Latitude < -90 || place.Latitude > 90 || place.Longitude < -180 || place.Longitude > 180:
+		return place, fmt.Errorf("point (%v, %v) is out of the map", place.Latitude, place.Longitude)
+	case place.Capacity < 0:
+		return place, ErrInvalidCapacity
+	}
+
+	if place.Kind == models.PlaceLocation {
+		place.IsActive, place.Capacity = nil, 0
+	}
+	return place, nil
+}
+
+func placeKey(place models.Place) string {
+	return place.Kind + "#" + strconv.Itoa(place.ID)
+}
+
+func nameKey(place models.Place) string {
+	return place.Kind + ":" + strings.ToLower(place.Name)
+}
+
+func stationPlace(station models.Station) models.Place {
+	isActive := station.IsActive
+	return models.Place{Kind: models.PlaceStation, ID: station.ID, Name: station.Name, Latitude: station.Latitude,
+		Longitude: station.Longitude, IsActive: &isActive, Capacity: station.Capacity}
+}
+
+func locationPlace(location models.Location) models.Place {
+	return models.Place{Kind: models.PlaceLocation, ID: location.ID, Name: location.Label,
+		Latitude: location.Latitude, Longitude: location.Longitude}
+}
+
+//placeStation converts the place to the station, the new station is active unless it's set otherwise.
+//The inactive station is under maintenance.
+func placeStation(place models.Place) models.Station {
+	station := models.Station{ID: place.ID, Name: place.Name, IsActive: true, Status: models.StationActive,
+		Latitude: place.Latitude, Longitude: place.Longitude, Capacity: place.Capacity}
+	if place.IsActive != nil && !*place.IsActive {
+		station.IsActive, station.Status = false, models.StationMaintenance
+	}
+	return station
+}
+
+func placeLocation(place models.Place) models.Location {
+	return models.Location{ID: place.ID, Label: place.Name, Latitude: place.Latitude, Longitude: place.Longitude}
+}
@@ -0,0 +1,203 @@
+package services
+
+import (
+	"Dp218GO/models"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+//formats of the places import & export files
+const (
+	PlacesGeoJSON = "geojson"
+	PlacesCSV     = "csv"
+)
+
+var (
+	//ErrPlacesFormat is returned for the import or export format which isn't supported.
+	ErrPlacesFormat = errors.New("unsupported places format, use geojson or csv")
+	//ErrPlacesFile is returned when the imported file can't be read at all.
+	ErrPlacesFile = errors.New("malformed places file")
+)
+
+//placeColumns are the columns of the places CSV file, the imported file may have them in any order.
+var placeColumns = []string{"kind", "id", "name", "latitude", "longitude", "is_active", "capacity"}
+
+//placeRecord is the imported place with its number in the file.
+type placeRecord struct {
+	number int
+	place  models.Place
+}
+
+//ToFeatureCollection converts the places to GeoJSON points.
+func ToFeatureCollection(places []models.Place) models.FeatureCollection {
+	collection := models.FeatureCollection{Type: "FeatureCollection", Features: make([]models.Feature, 0, len(places))}
+	for _, place := range places {
+		collection.Features = append(collection.Features, models.Feature{
+			Type:       "Feature",
+			Geometry:   models.Geometry{Type: "Point", Coordinates: []float64{place.Longitude, place.Latitude}},
+			Properties: place,
+		})
+	}
+	return collection
+}
+
+//encodePlaces writes the places to w in the given format.
+func encodePlaces(format string, w io.Writer, places []models.Place) error {
+	switch format {
+	case PlacesGeoJSON:
+		return json.NewEncoder(w).Encode(ToFeatureCollection(places))
+	case PlacesCSV:
+		return writeCSV(w, places)
+	}
+	return ErrPlacesFormat
+}
+
+//decodePlaces reads the places from r in the given format. The records which can't be parsed are reported
+//as errors, the error is returned only if the file can't be read at all.
+func decodePlaces(format string, r io.Reader) ([]placeRecord, []models.ImportError, error) {
+	switch format {
+	case PlacesGeoJSON:
+		return readGeoJSON(r)
+	case PlacesCSV:
+		return readCSV(r)
+	}
+	return nil, nil, ErrPlacesFormat
+}
+
+func readGeoJSON(r io.Reader) ([]placeRecord, []models.ImportError, error) {
+	var collection models.FeatureCollection
+	if err := json.NewDecoder(r).Decode(&collection); err != nil {
+		return nil, nil, fmt.Errorf("%w: %v", ErrPlacesFile, err)
+	}
+	if collection.Type != "FeatureCollection" {
+		return nil, nil, fmt.Errorf("%w: FeatureCollection is expected, got %q", ErrPlacesFile, collection.Type)
+	}
+
+	var records []placeRecord
+	var importErrors []models.ImportError
+	for i, feature := range collection.Features {
+		if feature.Geometry.Type != "Point" || len(feature.Geometry.Coordinates) < 2 {
+			importErrors = append(importErrors, models.ImportError{Record: i + 1,
+				Message: "geometry must be a point with longitude and latitude"})
+			continue
+		}
+
+		place := feature.Properties
+		place.Longitude, place.Latitude = feature.Geometry.Coordinates[0], feature.Geometry.Coordinates[1]
+		place.Occupied, place.FreeSlots = nil, nil
+		records = append(records, placeRecord{number: i + 1, place: place})
+	}
+	return records, importErrors, nil
+}
+
+func writeCSV(w io.Writer, places []models.Place) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(placeColumns); err != nil {
+		return err
+	}
+
+	for _, place := range places {
+		row := []string{place.Kind, strconv.Itoa(place.ID), place.Name, formatCoordinate(place.Latitude),
+			formatCoordinate(place.Longitude), "", ""}
+		if place.IsActive != nil {
+			row[5] = strconv.FormatBool(*place.IsActive)
+		}
+		if place.Capacity > 0 {
+			row[6] = strconv.Itoa(place.Capacity)
+		}
+		if err := writer.Write(row); err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+//readCSV reads the places by the header of the file. The record number is the line of the row.
+func readCSV(r io.Reader) ([]placeRecord, []models.ImportError, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: %v", ErrPlacesFile, err)
+	}
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, name := range []string{"kind", "name", "latitude", "longitude"} {
+		if _, ok := columns[name]; !ok {
+			return nil, nil, fmt.Errorf("%w: column %q is missing", ErrPlacesFile, name)
+		}
+	}
+
+	var records []placeRecord
+	var importErrors []models.ImportError
+	for {
+		row, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, nil, fmt.Errorf("%w: %v", ErrPlacesFile, err)
+		}
+		line, _ := reader.FieldPos(0)
+
+		place, err := placeFromRow(row, columns)
+		if err != nil {
+			importErrors = append(importErrors, models.ImportError{Record: line, Message: err.Error()})
+			continue
+		}
+		records = append(records, placeRecord{number: line, place: place})
+	}
+	return records, importErrors, nil
+}
+
+//placeFromRow parses the CSV row, empty id, is_active and capacity are left unset.
+func placeFromRow(row []string, columns map[string]int) (models.Place, error) {
+	value := func(name string) string {
+		if i, ok := columns[name]; ok && i < len(row) {
+			return strings.TrimSpace(row[i])
+		}
+		return ""
+	}
+
+	place := models.Place{Kind: value("kind"), Name: value("name")}
+	var err error
+	if place.Latitude, err = strconv.ParseFloat(value("latitude"), 64); err != nil {
+		return place, fmt.Errorf("latitude: %w", err)
+	}
+	if place.Longitude, err = strconv.ParseFloat(value("longitude"), 64); err != nil {
+		return place, fmt.Errorf("longitude: %w", err)
+	}
+	if id := value("id"); id != "" {
+		if place.ID, err = strconv.Atoi(id); err != nil {
+			return place, fmt.Errorf("id: %w", err)
+		}
+	}
+	if active := value("is_active"); active != "" {
+		isActive, err := strconv.ParseBool(active)
+		if err != nil {
+			return place, fmt.Errorf("is_active: %w", err)
+		}
+		place.IsActive = &isActive
+	}
+	if capacity := value("capacity"); capacity != "" {
+		if place.Capacity, err = strconv.Atoi(capacity); err != nil {
+			return place, fmt.Errorf("capacity: %w", err)
+		}
+	}
+	return place, nil
+}
+
+func formatCoordinate(value float64) string {
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
@@ -0,0 +1,243 @@
+package services
+
+import (
+	"Dp218GO/models"
+	repomock "Dp218GO/repositories/mock"
+	"Dp218GO/services/mock"
+	"bytes"
+	"context"
+	"github.com/golang/mock/gomock"
+	assert "github.com/stretchr/testify/require"
+	"strings"
+	"testing"
+	"time"
+)
+
+var (
+	storedStations = &models.StationList{Station: []models.Station{
+		{ID: 1, Name: "Central", IsActive: true, Latitude: 48.4647, Longitude: 35.0462, Capacity: 10, Occupied: 3, FreeSlots: 7},
+		{ID: 2, Name: "Dafi Mall", IsActive: false, Latitude: 48.4221, Longitude: 35.0196, Capacity: 6},
+	}}
+	storedLocations = &models.LocationList{Location: []models.Location{
+		{ID: 1, Label: "Pobeda", Latitude: 48, Longitude: 35},
+	}}
+	importTime = time.Date(2021, 12, 14, 9, 0, 0, 0, time.UTC)
+)
+
+type placeUseCasesMock struct {
+	PlaceServiceUC *PlaceService
+	RepoStation    *repomock.MockStationRepo
+	RepoLocation   *repomock.MockLocationRepo
+	Tx             *repomock.MockTxManager
+	Clock          *mock.MockClock
+}
+
+type placeTestCase struct {
+	name string
+	test func(t *testing.T, mock *placeUseCasesMock)
+}
+
+func runPlaceTestCases(t *testing.T, testCases []placeTestCase) {
+	for _, tc := range testCases {
+		t.Run(tc.name, func(tt *testing.T) {
+			defer func() {
+				if err := recover(); err != nil {
+					tt.Error(err)
+				}
+			}()
+
+			ctrl := gomock.NewController(tt)
+			defer ctrl.Finish()
+
+			mock := newPlaceUseCasesMock(ctrl)
+
+			tc.test(tt, mock)
+		})
+	}
+}
+
+func newPlaceUseCasesMock(ctrl *gomock.Controller) *placeUseCasesMock {
+	repoStation := repomock.NewMockStationRepo(ctrl)
+	repoLocation := repomock.NewMockLocationRepo(ctrl)
+	tx := repomock.NewMockTxManager(ctrl)
+	clock := mock.NewMockClock(ctrl)
+
+	return &placeUseCasesMock{
+		PlaceServiceUC: NewPlaceService(NewStationService(repoStation, clock), repoLocation, tx),
+		RepoStation:    repoStation,
+		RepoLocation:   repoLocation,
+		Tx:             tx,
+		Clock:          clock,
+	}
+}
+
+func (mock *placeUseCasesMock) expectStored() {
+	mock.RepoStation.EXPECT().GetAllStations(gomock.Any()).Return(storedStations, nil).Times(1)
+	mock.RepoLocation.EXPECT().GetAllLocations(gomock.Any()).Return(storedLocations, nil).Times(1)
+}
+
+func Test_Place_ImportPlaces(t *testing.T) {
+	runPlaceTestCases(t, []placeTestCase{
+		{
+			name: "GeoJSONDryRun",
+			test: func(t *testing.T, mock *placeUseCasesMock) {
+				mock.expectStored()
+				geoJSON := `{"type": "FeatureCollection", "features": [
+					{"type": "Feature", "geometry": {"type": "Point", "coordinates": [35.05, 48.4647]},
+						"properties": {"kind": "station", "name": " central "}},
+					{"type": "Feature", "geometry": {"type": "Point", "coordinates": [35.0234, 48.4223]},
+						"properties": {"kind": "station", "name": "Park"}},
+					{"type": "Feature", "geometry": {"type": "Point", "coordinates": [35, 48]},
+						"properties": {"kind": "location", "id": 1, "name": "Pobeda"}}]}`
+
+				report, err := mock.PlaceServiceUC.ImportPlaces(context.Background(), PlacesGeoJSON,
+					strings.NewReader(geoJSON), true, 1)
+				assert.Nil(t, err)
+				assert.False(t, report.Applied)
+				assert.Empty(t, report.Errors)
+				assert.Equal(t, 1, report.Unchanged)
+
+				assert.Len(t, report.Created, 1)
+				assert.Equal(t, 2, report.Created[0].Record)
+				assert.Equal(t, DefaultStationCapacity, report.Created[0].Place.Capacity)
+				assert.True(t, *report.Created[0].Place.IsActive)
+
+				assert.Len(t, report.Updated, 1)
+				assert.Equal(t, 1, report.Updated[0].Place.ID)
+				assert.Equal(t, []models.FieldChange{
+					{Field: "name", Old: "Central", New: "central"},
+					{Field: "longitude", Old: "35.0462", New: "35.05"},
+				}, report.Updated[0].Changes)
+			},
+		},
+		{
+			name: "CSVApplied",
+			test: func(t *testing.T, mock *placeUseCasesMock) {
+				mock.expectStored()
+				mock.Tx.EXPECT().WithTx(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).Times(1)
+				mock.RepoStation.EXPECT().AddStation(gomock.Any(), &models.Station{Name: "Park", IsActive: false,
+					Status: models.StationMaintenance, Latitude: 48.4223, Longitude: 35.0234, Capacity: 4}).
+					DoAndReturn(func(ctx context.Context, station *models.Station) error {
+						station.ID = 3
+						return nil
+					}).Times(1)
+				mock.RepoStation.EXPECT().UpdateStation(gomock.Any(), 2, models.Station{ID: 2, Name: "Dafi Mall",
+					IsActive: true, Status: models.StationActive, Latitude: 48.4221, Longitude: 35.0196, Capacity: 6}).
+					Return(models.Station{}, nil).Times(1)
+				mock.Clock.EXPECT().Now().Return(importTime).Times(1)
+				mock.RepoStation.EXPECT().AddStatusChange(gomock.Any(), &models.StationStatusChange{StationID: 2,
+					Status: models.StationActive, Reason: importReason, ChangedBy: 5, EffectiveAt: importTime},
+					importTime).Return(nil).Times(1)
+				mock.RepoLocation.EXPECT().UpdateLocation(gomock.Any(),
+					models.Location{ID: 1, Label: "Pobeda square", Latitude: 48, Longitude: 35}).Return(nil).Times(1)
+
+				csv := "name,kind,latitude,longitude,is_active,capacity,id\n" +
+					"Park,station,48.4223,35.0234,false,4,\n" +
+					"Dafi Mall,station,48.4221,35.0196,true,,2\n" +
+					"Pobeda square,location,48,35,,,1\n"
+				report, err := mock.PlaceServiceUC.ImportPlaces(context.Background(), PlacesCSV,
+					strings.NewReader(csv), false, 5)
+				assert.Nil(t, err)
+				assert.True(t, report.Applied)
+				assert.Equal(t, 3, report.Created[0].Place.ID)
+				assert.Len(t, report.Updated, 2)
+			},
+		},
+		{
+			name: "InvalidRecords",
+			test: func(t *testing.T, mock *placeUseCasesMock) {
+				mock.expectStored()
+
+				csv := "kind,id,name,latitude,longitude\n" +
+					"station,7,Lost,48,35\n" +
+					"station,,Central,48.46,35.04\n" +
+					"station,1,Main,48.46,35.04\n" +
+					"shop,,Corner,48,35\n" +
+					"location,,Far,95,35\n" +
+					"station,,Park,north,35\n"
+				report, err := mock.PlaceServiceUC.ImportPlaces(context.Background(), PlacesCSV,
+					strings.NewReader(csv), false, 1)
+				assert.Nil(t, err)
+				assert.False(t, report.Applied)
+
+				var lines []int
+				for _, importErr := range report.Errors {
+					lines = append(lines, importErr.Record)
+				}
+				assert.ElementsMatch(t, []int{2, 4, 5, 6, 7}, lines)
+			},
+		},
+		{
+			name: "MalformedFile",
+			test: func(t *testing.T, mock *placeUseCasesMock) {
+				_, err := mock.PlaceServiceUC.ImportPlaces(context.Background(), PlacesGeoJSON,
+					strings.NewReader(`{"type": "Feature"}`), true, 1)
+				assert.ErrorIs(t, err, ErrPlacesFile)
+
+				_, err = mock.PlaceServiceUC.ImportPlaces(context.Background(), PlacesCSV,
+					strings.NewReader("id,name\n1,Central\n"), true, 1)
+				assert.ErrorIs(t, err, ErrPlacesFile)
+
+				_, err = mock.PlaceServiceUC.ImportPlaces(context.Background(), "kml", strings.NewReader(""), true, 1)
+				assert.ErrorIs(t, err, ErrPlacesFormat)
+			},
+		},
+	})
+}
+
+func Test_Place_ExportPlaces(t *testing.T) {
+	runPlaceTestCases(t, []placeTestCase{
+		{
+			name: "CSV",
+			test: func(t *testing.T, mock *placeUseCasesMock) {
+				mock.expectStored()
+
+				var out bytes.Buffer
+				err := mock.PlaceServiceUC.ExportPlaces(context.Background(), PlacesCSV, &out)
+				assert.Nil(t, err)
+				assert.Equal(t, "kind,id,name,latitude,longitude,is_active,capacity\n"+
+					"station,1,Central,48.4647,35.0462,true,10\n"+
+					"station,2,Dafi Mall,48.4221,35.0196,false,6\n"+
+					"location,1,Pobeda,48,35,,\n", out.String())
+			},
+		},
+		{
+			name: "RoundTrip",
+			test: func(t *testing.T, mock *placeUseCasesMock) {
+				mock.expectStored()
+				var out bytes.Buffer
+				assert.Nil(t, mock.PlaceServiceUC.ExportPlaces(context.Background(), PlacesGeoJSON, &out))
+
+				mock.expectStored()
+				report, err := mock.PlaceServiceUC.ImportPlaces(context.Background(), PlacesGeoJSON, &out, true, 1)
+				assert.Nil(t, err)
+				assert.Empty(t, report.Errors)
+				assert.Empty(t, report.Created)
+				assert.Empty(t, report.Updated)
+				assert.Equal(t, 3, report.Unchanged)
+			},
+		},
+	})
+}
+
+func Test_Place_StationsFeed(t *testing.T) {
+	runPlaceTestCases(t, []placeTestCase{
+		{
+			name: "Success",
+			test: func(t *testing.T, mock *placeUseCasesMock) {
+				mock.RepoStation.EXPECT().GetAllStations(gomock.Any()).Return(storedStations, nil).Times(1)
+
+				feed, err := mock.PlaceServiceUC.StationsFeed(context.Background())
+				assert.Nil(t, err)
+				assert.Equal(t, "FeatureCollection", feed.Type)
+				assert.Len(t, feed.Features, 2)
+				assert.Equal(t, []float64{35.0462, 48.4647}, feed.Features[0].Geometry.Coordinates)
+				assert.Equal(t, 7, *feed.Features[0].Properties.FreeSlots)
+				assert.Equal(t, 0, *feed.Features[1].Properties.Occupied)
+			},
+		},
+	})
+}
@@ -0,0 +1,70 @@
+package services
+
+import (
+	"Dp218GO/models"
+	"Dp218GO/repositories"
+	"context"
+	"shared.micro/pricing"
+	"time"
+)
+
+//PricingService is the service which counts the trip price by the tariff of the scooter's supplier.
+type PricingService struct {
+	repoPrice repositories.PriceRepo
+}
+
+//NewPricingService creates the new PricingService.
+func NewPricingService(priceRepo repositories.PriceRepo) *PricingService {
+	return &PricingService{repoPrice: priceRepo}
+}
+
+//CountTripPrice returns the itemised price of the order's trip which lasted for the given duration.
+func (ps *PricingService) CountTripPrice(ctx context.Context, order models.Order, duration time.Duration) (models.PriceBreakdown, error) {
+	tariff, err := ps.repoPrice.GetTariffByScooterID(ctx, order.ScooterID)
+	if err != nil {
+		return models.PriceBreakdown{}, err
+	}
+
+	breakdown := calculatePrice(tariff, duration, order.Distance)
+	breakdown.OrderID = order.ID
+
+	return breakdown, nil
+}
+
+//SavePriceBreakdown gives the access to the PriceRepo.AddPriceBreakdown function.
+func (ps *PricingService) SavePriceBreakdown(ctx context.Context, breakdown *models.PriceBreakdown) error {
+	return ps.repoPrice.AddPriceBreakdown(ctx, breakdown)
+}
+
+//GetPriceBreakdown gives the access to the PriceRepo.GetPriceBreakdownByOrderID function.
+func (ps *PricingService) GetPriceBreakdown(ctx context.Context, orderID int) (models.PriceBreakdown, error) {
+	return ps.repoPrice.GetPriceBreakdownByOrderID(ctx, orderID)
+}
+
+//calculatePrice counts the trip price by the shared pricing rules: every started minute and every kilometer
+//(distance is given in meters) are charged by the tariff, the unlock fee is added once, the minimum charge is
+//reached and the supplier's commission is taken from the total.
+func calculatePrice(tariff models.Tariff, duration time.Duration, distance float64) models.PriceBreakdown {
+	price := pricing.Calculate(pricing.Tariff{
+		PricePerMinute:    tariff.PricePerMinute,
+		PricePerKm:        tariff.PricePerKm,
+		UnlockFee:         tariff.UnlockFee,
+		MinimumCharge:     tariff.MinimumCharge,
+		CommissionPercent: tariff.CommissionPercent,
+	}, duration, distance)
+
+	return models.PriceBreakdown{
+		SupplierID:         tariff.SupplierID,
+		PaymentTypeID:      tariff.PaymentTypeID,
+		Minutes:            price.Minutes,
+		Kilometers:         price.Kilometers,
+		TimeCents:          price.TimeCents,
+		DistanceCents:      price.DistanceCents,
+		UnlockFeeCents:     price.UnlockFeeCents,
+		MinimumChargeCents: price.MinimumChargeCents,
+		PenaltyCents:       price.PenaltyCents,
+		TotalCents:         price.TotalCents,
+		CommissionCents:    price.CommissionCents,
+		SupplierCents:      price.SupplierCents,
+	}
+}
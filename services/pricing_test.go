@@ -0,0 +1,55 @@
+package services
+
+import (
+	"Dp218GO/models"
+	assert "github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestPricing_CalculatePrice(t *testing.T) {
+	testCases := []struct {
+		name     string
+		tariff   models.Tariff
+		duration time.Duration
+		distance float64
+		expected models.PriceBreakdown
+	}{
+		{
+			name:     "PerMinute",
+			tariff:   models.Tariff{PricePerMinute: 150},
+			duration: 4*time.Minute + time.Second,
+			distance: 1200,
+			expected: models.PriceBreakdown{Minutes: 5, Kilometers: 1.2, TimeCents: 750, TotalCents: 750,
+				SupplierCents: 750},
+		},
+		{
+			name:     "PerKmWithUnlockFee",
+			tariff:   models.Tariff{PricePerKm: 1000, UnlockFee: 300},
+			duration: 3 * time.Minute,
+			distance: 2500,
+			expected: models.PriceBreakdown{Minutes: 3, Kilometers: 2.5, DistanceCents: 2500, UnlockFeeCents: 300,
+				TotalCents: 2800, SupplierCents: 2800},
+		},
+		{
+			name:     "MinimumCharge",
+			tariff:   models.Tariff{PricePerMinute: 100, MinimumCharge: 1000},
+			duration: 2 * time.Minute,
+			expected: models.PriceBreakdown{Minutes: 2, TimeCents: 200, MinimumChargeCents: 800, TotalCents: 1000,
+				SupplierCents: 1000},
+		},
+		{
+			name:     "Commission",
+			tariff:   models.Tariff{PricePerMinute: 333, CommissionPercent: 12.5},
+			duration: 3 * time.Minute,
+			expected: models.PriceBreakdown{Minutes: 3, TimeCents: 999, TotalCents: 999, CommissionCents: 125,
+				SupplierCents: 874},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(tt *testing.T) {
+			assert.Equal(tt, tc.expected, calculatePrice(tc.tariff, tc.duration, tc.distance))
+		})
+	}
+}
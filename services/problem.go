@@ -14,14 +14,14 @@ type ProblemService struct {
 	userService  *UserService
 }
 
-func (problserv *ProblemService) unmarshallProblem(problemGRPC *proto2.Problem) models.Problem {
+func (problserv *ProblemService) unmarshallProblem(ctx context.Context, problemGRPC *proto2.Problem) models.Problem {
 	problem := models.Problem{
 		ID:           int(problemGRPC.Id),
 		DateReported: time.Unix(problemGRPC.ReportedAt.Seconds, 0),
 		Description:  problemGRPC.Description,
 		IsSolved:     problemGRPC.IsSolved,
 	}
-	problserv.AddProblemComplexFields(&problem, int(problemGRPC.Type.Id), int(problemGRPC.UserId))
+	problserv.AddProblemComplexFields(ctx, &problem, int(problemGRPC.Type.Id), int(problemGRPC.UserId))
 	return problem
 }
 
@@ -32,9 +32,9 @@ func (problserv *ProblemService) unmarshallProblemType(problemTypeGRPC *proto2.P
 	}
 }
 
-func (problserv *ProblemService) unmarshallSolution(solutionGRPC *proto2.Solution) models.Solution {
+func (problserv *ProblemService) unmarshallSolution(ctx context.Context, solutionGRPC *proto2.Solution) models.Solution {
 	solution := models.Solution{
-		Problem:     problserv.unmarshallProblem(solutionGRPC.Problem),
+		Problem:     problserv.unmarshallProblem(ctx, solutionGRPC.Problem),
 		DateSolved:  time.Unix(solutionGRPC.SolvedAt.Seconds, 0),
 		Description: solutionGRPC.Description,
 	}
@@ -70,7 +70,7 @@ func NewProblemService(grpcConn grpc.ClientConnInterface, userServ *UserService)
 }
 
 // AddNewProblem - add new user problem record
-func (problserv *ProblemService) AddNewProblem(problem *models.Problem) error {
+func (problserv *ProblemService) AddNewProblem(ctx context.Context, problem *models.Problem) error {
 	problemType := &proto2.ProblemType{
 		Id: int32(problem.Type.ID),
 	}
@@ -80,36 +80,36 @@ func (problserv *ProblemService) AddNewProblem(problem *models.Problem) error {
 		Type:        problemType,
 		IsSolved:    problem.IsSolved,
 	}
-	_, err := problserv.microservice.AddNewProblem(context.Background(), problemToAdd)
+	_, err := problserv.microservice.AddNewProblem(ctx, problemToAdd)
 	return err
 }
 
 // GetProblemByID - get problem information by its ID
-func (problserv *ProblemService) GetProblemByID(problemID int) (models.Problem, error) {
+func (problserv *ProblemService) GetProblemByID(ctx context.Context, problemID int) (models.Problem, error) {
 	request := &proto2.ProblemRequest{Id: int64(problemID)}
-	response, err := problserv.microservice.GetProblemByID(context.Background(), request)
+	response, err := problserv.microservice.GetProblemByID(ctx, request)
 
-	return problserv.unmarshallProblem(response.Problem), err
+	return problserv.unmarshallProblem(ctx, response.Problem), err
 }
 
 // MarkProblemAsSolved - update problem record to make problem solved
-func (problserv *ProblemService) MarkProblemAsSolved(problem *models.Problem) (models.Problem, error) {
+func (problserv *ProblemService) MarkProblemAsSolved(ctx context.Context, problem *models.Problem) (models.Problem, error) {
 	problem.IsSolved = true
-	response, err := problserv.microservice.UpdateProblem(context.Background(), problserv.marshallProblem(problem))
+	response, err := problserv.microservice.UpdateProblem(ctx, problserv.marshallProblem(problem))
 
-	return problserv.unmarshallProblem(response.Problem), err
+	return problserv.unmarshallProblem(ctx, response.Problem), err
 }
 
 // GetProblemTypeByID - get problem type record by its ID
-func (problserv *ProblemService) GetProblemTypeByID(typeID int) (models.ProblemType, error) {
+func (problserv *ProblemService) GetProblemTypeByID(ctx context.Context, typeID int) (models.ProblemType, error) {
 	request := &proto2.ProblemRequest{TypeId: int32(typeID)}
-	response, err := problserv.microservice.GetProblemTypeByID(context.Background(), request)
+	response, err := problserv.microservice.GetProblemTypeByID(ctx, request)
 	return problserv.unmarshallProblemType(response.ProblemType), err
 }
 
-func (problserv *ProblemService) GetAllProblemTypes() ([]models.ProblemType, error) {
+func (problserv *ProblemService) GetAllProblemTypes(ctx context.Context) ([]models.ProblemType, error) {
 	request := &proto2.ProblemRequest{}
-	response, err := problserv.microservice.GetAllProblemTypes(context.Background(), request)
+	response, err := problserv.microservice.GetAllProblemTypes(ctx, request)
 	var resultingList []models.ProblemType
 	if err != nil {
 		return resultingList, err
@@ -121,74 +121,74 @@ func (problserv *ProblemService) GetAllProblemTypes() ([]models.ProblemType, err
 }
 
 // GetProblemsByUserID - get problem list for given user (by user ID)
-func (problserv *ProblemService) GetProblemsByUserID(userID int) (*models.ProblemList, error) {
+func (problserv *ProblemService) GetProblemsByUserID(ctx context.Context, userID int) (*models.ProblemList, error) {
 	request := &proto2.ProblemRequest{UserId: int64(userID)}
-	response, err := problserv.microservice.GetProblemsByUserID(context.Background(), request)
+	response, err := problserv.microservice.GetProblemsByUserID(ctx, request)
 	resultingList := &models.ProblemList{}
 	if err != nil {
 		return resultingList, err
 	}
 	for _, val := range response.Problems {
-		resultingList.Problems = append(resultingList.Problems, problserv.unmarshallProblem(val))
+		resultingList.Problems = append(resultingList.Problems, problserv.unmarshallProblem(ctx, val))
 	}
 	return resultingList, err
 }
 
 // GetProblemsByTypeID - get problem list by given problem type ID
-func (problserv *ProblemService) GetProblemsByTypeID(typeID int) (*models.ProblemList, error) {
+func (problserv *ProblemService) GetProblemsByTypeID(ctx context.Context, typeID int) (*models.ProblemList, error) {
 	request := &proto2.ProblemRequest{TypeId: int32(typeID)}
-	response, err := problserv.microservice.GetProblemsByTypeID(context.Background(), request)
+	response, err := problserv.microservice.GetProblemsByTypeID(ctx, request)
 	resultingList := &models.ProblemList{}
 	if err != nil {
 		return resultingList, err
 	}
 	for _, val := range response.Problems {
-		resultingList.Problems = append(resultingList.Problems, problserv.unmarshallProblem(val))
+		resultingList.Problems = append(resultingList.Problems, problserv.unmarshallProblem(ctx, val))
 	}
 	return resultingList, err
 }
 
 // GetProblemsByBeingSolved - get problem list by is_solved field value
-func (problserv *ProblemService) GetProblemsByBeingSolved(solved bool) (*models.ProblemList, error) {
+func (problserv *ProblemService) GetProblemsByBeingSolved(ctx context.Context, solved bool) (*models.ProblemList, error) {
 	request := &proto2.ProblemRequest{IsSolved: solved}
-	response, err := problserv.microservice.GetProblemsBySolved(context.Background(), request)
+	response, err := problserv.microservice.GetProblemsBySolved(ctx, request)
 	resultingList := &models.ProblemList{}
 	if err != nil {
 		return resultingList, err
 	}
 	for _, val := range response.Problems {
-		resultingList.Problems = append(resultingList.Problems, problserv.unmarshallProblem(val))
+		resultingList.Problems = append(resultingList.Problems, problserv.unmarshallProblem(ctx, val))
 	}
 	return resultingList, err
 }
 
 // GetProblemsByTimePeriod - get problem list from time start to time end
-func (problserv *ProblemService) GetProblemsByTimePeriod(start, end time.Time) (*models.ProblemList, error) {
+func (problserv *ProblemService) GetProblemsByTimePeriod(ctx context.Context, start, end time.Time) (*models.ProblemList, error) {
 	request := &proto2.ProblemRequest{
 		StartTime: &proto2.DateTime{Seconds: start.Unix()},
 		EndTime:   &proto2.DateTime{Seconds: end.Unix()},
 	}
-	response, err := problserv.microservice.GetProblemsByTimePeriod(context.Background(), request)
+	response, err := problserv.microservice.GetProblemsByTimePeriod(ctx, request)
 	resultingList := &models.ProblemList{}
 	if err != nil {
 		return resultingList, err
 	}
 	for _, val := range response.Problems {
-		resultingList.Problems = append(resultingList.Problems, problserv.unmarshallProblem(val))
+		resultingList.Problems = append(resultingList.Problems, problserv.unmarshallProblem(ctx, val))
 	}
 	return resultingList, err
 }
 
 // AddProblemComplexFields - fulfill problem model with problem type, scooter, user (by their IDs)
-func (problserv *ProblemService) AddProblemComplexFields(problem *models.Problem, typeID, userID int) {
+func (problserv *ProblemService) AddProblemComplexFields(ctx context.Context, problem *models.Problem, typeID, userID int) {
 	if typeID != 0 {
-		problemType, eType := problserv.GetProblemTypeByID(typeID)
+		problemType, eType := problserv.GetProblemTypeByID(ctx, typeID)
 		if eType == nil {
 			problem.Type = problemType
 		}
 	}
 	if userID != 0 {
-		user, eUser := problserv.userService.GetUserByID(userID)
+		user, eUser := problserv.userService.GetUserByID(ctx, userID)
 		if eUser == nil {
 			problem.User = user
 		}
@@ -196,18 +196,18 @@ func (problserv *ProblemService) AddProblemComplexFields(problem *models.Problem
 }
 
 // AddProblemSolution - make solution record for given problem (by ID)
-func (problserv *ProblemService) AddProblemSolution(problemID int, solution *models.Solution) error {
+func (problserv *ProblemService) AddProblemSolution(ctx context.Context, problemID int, solution *models.Solution) error {
 	request := &proto2.ProblemSolution{
 		Problem:  &proto2.Problem{Id: int64(problemID)},
 		Solution: problserv.marshallSolution(solution),
 	}
-	_, err := problserv.microservice.AddProblemSolution(context.Background(), request)
+	_, err := problserv.microservice.AddProblemSolution(ctx, request)
 	return err
 }
 
 // GetSolutionByProblem - get solution for given problem
-func (problserv *ProblemService) GetSolutionByProblem(problem models.Problem) (models.Solution, error) {
-	response, err := problserv.microservice.GetSolutionByProblem(context.Background(), problserv.marshallProblem(&problem))
+func (problserv *ProblemService) GetSolutionByProblem(ctx context.Context, problem models.Problem) (models.Solution, error) {
+	response, err := problserv.microservice.GetSolutionByProblem(ctx, problserv.marshallProblem(&problem))
 
-	return problserv.unmarshallSolution(response.Solution), err
+	return problserv.unmarshallSolution(ctx, response.Solution), err
 }
@@ -0,0 +1,144 @@
+package services
+
+import (
+	"Dp218GO/models"
+	"Dp218GO/repositories"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+//defaultReservationHold is the time the scooter is held for the user if the hold isn't configured.
+const defaultReservationHold = 10 * time.Minute
+
+var ErrReservationExists = errors.New("user already has an active reservation")
+
+//ReservationService is the service which holds the scooters for the users until they start the trip.
+type ReservationService struct {
+	repoReservation repositories.ReservationRepo
+	clock           Clock
+	hold            time.Duration
+}
+
+//NewReservationService creates the new ReservationService. Every reservation expires after the hold period.
+func NewReservationService(reservationRepo repositories.ReservationRepo, clock Clock,
+	hold time.Duration) *ReservationService {
+	if hold <= 0 {
+		hold = defaultReservationHold
+	}
+	return &ReservationService{repoReservation: reservationRepo, clock: clock, hold: hold}
+}
+
+//Reserve holds the scooter for the user. The expired reservations are released first,
+//so the scooters which they held can be reserved again.
+func (rs *ReservationService) Reserve(ctx context.Context, userID, scooterID int) (models.Reservation, error) {
+	now := rs.clock.Now()
+	if _, err := rs.repoReservation.ExpireReservations(ctx, now); err != nil {
+		return models.Reservation{}, err
+	}
+
+	_, err := rs.repoReservation.GetActiveReservationByUserID(ctx, userID)
+	if err == nil {
+		return models.Reservation{}, ErrReservationExists
+	}
+	if !errors.Is(err, repositories.ErrNoReservation) {
+		return models.Reservation{}, err
+	}
+
+	reservation := models.Reservation{
+		UserID:    userID,
+		ScooterID: scooterID,
+		CreatedAt: now,
+		ExpiresAt: now.Add(rs.hold),
+	}
+	err = rs.repoReservation.AddReservation(ctx, &reservation)
+
+	return reservation, err
+}
+
+//GetReservation returns the active reservation of the user. The expired reservation is not returned.
+func (rs *ReservationService) GetReservation(ctx context.Context, userID int) (models.Reservation, error) {
+	reservation, err := rs.repoReservation.GetActiveReservationByUserID(ctx, userID)
+	if err != nil {
+		return reservation, err
+	}
+	if !reservation.ExpiresAt.After(rs.clock.Now()) {
+		return models.Reservation{}, repositories.ErrNoReservation
+	}
+
+	return reservation, nil
+}
+
+//Extend holds the reserved scooter for one more hold period starting from now.
+func (rs *ReservationService) Extend(ctx context.Context, userID int) (models.Reservation, error) {
+	reservation, err := rs.GetReservation(ctx, userID)
+	if err != nil {
+		return reservation, err
+	}
+
+	reservation.ExpiresAt = rs.clock.Now().Add(rs.hold)
+	err = rs.repoReservation.ExtendReservation(ctx, reservation.ID, reservation.ExpiresAt)
+
+	return reservation, err
+}
+
+//ChooseDestination remembers where the user is going to finish the trip on the reserved scooter.
+func (rs *ReservationService) ChooseDestination(ctx context.Context, userID int,
+	destination models.TripDestination) (models.Reservation, error) {
+	reservation, err := rs.GetReservation(ctx, userID)
+	if err != nil {
+		return reservation, err
+	}
+
+	if err = rs.repoReservation.SetDestination(ctx, reservation.ID, destination); err != nil {
+		return reservation, err
+	}
+	reservation.Destination = &destination
+	return reservation, nil
+}
+
+//Release cancels the reservation of the user and makes the scooter available for others.
+func (rs *ReservationService) Release(ctx context.Context, userID int) error {
+	reservation, err := rs.repoReservation.GetActiveReservationByUserID(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	return rs.repoReservation.ReleaseReservation(ctx, reservation.ID, rs.clock.Now())
+}
+
+//UseForTrip closes the user's reservation of the scooter because the trip on it starts.
+//It returns false if the user holds no valid reservation of the scooter.
+func (rs *ReservationService) UseForTrip(ctx context.Context, userID, scooterID int) (bool, error) {
+	reservation, err := rs.GetReservation(ctx, userID)
+	if errors.Is(err, repositories.ErrNoReservation) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	if reservation.ScooterID != scooterID {
+		return false, nil
+	}
+
+	err = rs.repoReservation.UseReservation(ctx, reservation.ID, rs.clock.Now())
+	return err == nil, err
+}
+
+//ExpireEvery releases the expired reservations every interval until the context is done.
+func (rs *ReservationService) ExpireEvery(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := rs.repoReservation.ExpireReservations(ctx, rs.clock.Now()); err != nil {
+				fmt.Println(err)
+			}
+		}
+	}
+}
@@ -0,0 +1,221 @@
+package services
+
+import (
+	"Dp218GO/models"
+	"Dp218GO/repositories"
+	repomock "Dp218GO/repositories/mock"
+	"Dp218GO/services/mock"
+	"context"
+	"github.com/golang/mock/gomock"
+	assert "github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+const testReservationHold = 5 * time.Minute
+
+var reservationTime = time.Date(2021, 12, 20, 10, 0, 0, 0, time.UTC)
+
+type reservationUseCasesMock struct {
+	ReservationServiceUC *ReservationService
+	RepoReservation      *repomock.MockReservationRepo
+	Clock                *mock.MockClock
+}
+
+type reservationTestCase struct {
+	name string
+	test func(t *testing.T, mock *reservationUseCasesMock)
+}
+
+func runReservationTestCases(t *testing.T, testCases []reservationTestCase) {
+	for _, tc := range testCases {
+		t.Run(tc.name, func(tt *testing.T) {
+			defer func() {
+				if err := recover(); err != nil {
+					tt.Error(err)
+				}
+			}()
+
+			ctrl := gomock.NewController(tt)
+			defer ctrl.Finish()
+
+			mock := newReservationUseCasesMock(ctrl)
+
+			tc.test(tt, mock)
+		})
+	}
+}
+
+func newReservationUseCasesMock(ctrl *gomock.Controller) *reservationUseCasesMock {
+	repoReservation := repomock.NewMockReservationRepo(ctrl)
+	clock := mock.NewMockClock(ctrl)
+
+	return &reservationUseCasesMock{
+		ReservationServiceUC: NewReservationService(repoReservation, clock, testReservationHold),
+		RepoReservation:      repoReservation,
+		Clock:                clock,
+	}
+}
+
+func Test_Reservation_Reserve(t *testing.T) {
+	runReservationTestCases(t, []reservationTestCase{
+		{
+			name: "Correct",
+			test: func(t *testing.T, mock *reservationUseCasesMock) {
+				mock.Clock.EXPECT().Now().Return(reservationTime).Times(1)
+				mock.RepoReservation.EXPECT().ExpireReservations(gomock.Any(), reservationTime).
+					Return(int64(0), nil).Times(1)
+				mock.RepoReservation.EXPECT().GetActiveReservationByUserID(gomock.Any(), 1).
+					Return(models.Reservation{}, repositories.ErrNoReservation).Times(1)
+				mock.RepoReservation.EXPECT().AddReservation(gomock.Any(), &models.Reservation{UserID: 1,
+					ScooterID: 2, CreatedAt: reservationTime, ExpiresAt: reservationTime.Add(testReservationHold)}).
+					DoAndReturn(func(ctx context.Context, reservation *models.Reservation) error {
+						reservation.ID = 7
+						return nil
+					}).Times(1)
+
+				reservation, err := mock.ReservationServiceUC.Reserve(context.Background(), 1, 2)
+				assert.Nil(t, err)
+				assert.Equal(t, 7, reservation.ID)
+				assert.Equal(t, reservationTime.Add(testReservationHold), reservation.ExpiresAt)
+			},
+		},
+		{
+			name: "AlreadyReserved",
+			test: func(t *testing.T, mock *reservationUseCasesMock) {
+				mock.Clock.EXPECT().Now().Return(reservationTime).Times(1)
+				mock.RepoReservation.EXPECT().ExpireReservations(gomock.Any(), reservationTime).
+					Return(int64(1), nil).Times(1)
+				mock.RepoReservation.EXPECT().GetActiveReservationByUserID(gomock.Any(), 1).
+					Return(models.Reservation{ID: 3, UserID: 1, ScooterID: 5}, nil).Times(1)
+
+				_, err := mock.ReservationServiceUC.Reserve(context.Background(), 1, 2)
+				assert.ErrorIs(t, err, ErrReservationExists)
+			},
+		},
+		{
+			name: "ScooterNotAvailable",
+			test: func(t *testing.T, mock *reservationUseCasesMock) {
+				mock.Clock.EXPECT().Now().Return(reservationTime).Times(1)
+				mock.RepoReservation.EXPECT().ExpireReservations(gomock.Any(), reservationTime).
+					Return(int64(0), nil).Times(1)
+				mock.RepoReservation.EXPECT().GetActiveReservationByUserID(gomock.Any(), 1).
+					Return(models.Reservation{}, repositories.ErrNoReservation).Times(1)
+				mock.RepoReservation.EXPECT().AddReservation(gomock.Any(), gomock.Any()).
+					Return(repositories.ErrScooterNotAvailable).Times(1)
+
+				_, err := mock.ReservationServiceUC.Reserve(context.Background(), 1, 2)
+				assert.ErrorIs(t, err, repositories.ErrScooterNotAvailable)
+			},
+		},
+	})
+}
+
+func Test_Reservation_ChooseDestination(t *testing.T) {
+	runReservationTestCases(t, []reservationTestCase{
+		{
+			name: "Station",
+			test: func(t *testing.T, mock *reservationUseCasesMock) {
+				destination := models.TripDestination{StationID: 4}
+				mock.Clock.EXPECT().Now().Return(reservationTime).Times(1)
+				mock.RepoReservation.EXPECT().GetActiveReservationByUserID(gomock.Any(), 1).
+					Return(models.Reservation{ID: 3, UserID: 1, ScooterID: 2, CreatedAt: reservationTime,
+						ExpiresAt: reservationTime.Add(testReservationHold)}, nil).Times(1)
+				mock.RepoReservation.EXPECT().SetDestination(gomock.Any(), 3, destination).Return(nil).Times(1)
+
+				reservation, err := mock.ReservationServiceUC.ChooseDestination(context.Background(), 1, destination)
+				assert.Nil(t, err)
+				assert.Equal(t, &destination, reservation.Destination)
+			},
+		},
+		{
+			name: "NoReservation",
+			test: func(t *testing.T, mock *reservationUseCasesMock) {
+				mock.RepoReservation.EXPECT().GetActiveReservationByUserID(gomock.Any(), 1).
+					Return(models.Reservation{}, repositories.ErrNoReservation).Times(1)
+
+				_, err := mock.ReservationServiceUC.ChooseDestination(context.Background(), 1,
+					models.TripDestination{Location: models.Coordinate{Latitude: 48.46, Longitude: 35.04}})
+				assert.ErrorIs(t, err, repositories.ErrNoReservation)
+			},
+		},
+	})
+}
+
+func Test_Reservation_Extend(t *testing.T) {
+	runReservationTestCases(t, []reservationTestCase{
+		{
+			name: "Correct",
+			test: func(t *testing.T, mock *reservationUseCasesMock) {
+				now := reservationTime.Add(3 * time.Minute)
+				mock.Clock.EXPECT().Now().Return(now).Times(2)
+				mock.RepoReservation.EXPECT().GetActiveReservationByUserID(gomock.Any(), 1).
+					Return(models.Reservation{ID: 3, UserID: 1, ScooterID: 2, CreatedAt: reservationTime,
+						ExpiresAt: reservationTime.Add(testReservationHold)}, nil).Times(1)
+				mock.RepoReservation.EXPECT().ExtendReservation(gomock.Any(), 3, now.Add(testReservationHold)).
+					Return(nil).Times(1)
+
+				reservation, err := mock.ReservationServiceUC.Extend(context.Background(), 1)
+				assert.Nil(t, err)
+				assert.Equal(t, now.Add(testReservationHold), reservation.ExpiresAt)
+			},
+		},
+		{
+			name: "Expired",
+			test: func(t *testing.T, mock *reservationUseCasesMock) {
+				mock.Clock.EXPECT().Now().Return(reservationTime.Add(testReservationHold)).Times(1)
+				mock.RepoReservation.EXPECT().GetActiveReservationByUserID(gomock.Any(), 1).
+					Return(models.Reservation{ID: 3, UserID: 1, ScooterID: 2, CreatedAt: reservationTime,
+						ExpiresAt: reservationTime.Add(testReservationHold)}, nil).Times(1)
+
+				_, err := mock.ReservationServiceUC.Extend(context.Background(), 1)
+				assert.ErrorIs(t, err, repositories.ErrNoReservation)
+			},
+		},
+	})
+}
+
+func Test_Reservation_UseForTrip(t *testing.T) {
+	active := models.Reservation{ID: 3, UserID: 1, ScooterID: 2, CreatedAt: reservationTime,
+		ExpiresAt: reservationTime.Add(testReservationHold)}
+
+	runReservationTestCases(t, []reservationTestCase{
+		{
+			name: "Reserved",
+			test: func(t *testing.T, mock *reservationUseCasesMock) {
+				now := reservationTime.Add(time.Minute)
+				mock.Clock.EXPECT().Now().Return(now).Times(2)
+				mock.RepoReservation.EXPECT().GetActiveReservationByUserID(gomock.Any(), 1).
+					Return(active, nil).Times(1)
+				mock.RepoReservation.EXPECT().UseReservation(gomock.Any(), 3, now).Return(nil).Times(1)
+
+				reserved, err := mock.ReservationServiceUC.UseForTrip(context.Background(), 1, 2)
+				assert.Nil(t, err)
+				assert.True(t, reserved)
+			},
+		},
+		{
+			name: "AnotherScooter",
+			test: func(t *testing.T, mock *reservationUseCasesMock) {
+				mock.Clock.EXPECT().Now().Return(reservationTime.Add(time.Minute)).Times(1)
+				mock.RepoReservation.EXPECT().GetActiveReservationByUserID(gomock.Any(), 1).
+					Return(active, nil).Times(1)
+
+				reserved, err := mock.ReservationServiceUC.UseForTrip(context.Background(), 1, 4)
+				assert.Nil(t, err)
+				assert.False(t, reserved)
+			},
+		},
+		{
+			name: "NoReservation",
+			test: func(t *testing.T, mock *reservationUseCasesMock) {
+				mock.RepoReservation.EXPECT().GetActiveReservationByUserID(gomock.Any(), 1).
+					Return(models.Reservation{}, repositories.ErrNoReservation).Times(1)
+
+				reserved, err := mock.ReservationServiceUC.UseForTrip(context.Background(), 1, 2)
+				assert.Nil(t, err)
+				assert.False(t, reserved)
+			},
+		},
+	})
+}
@@ -3,6 +3,7 @@ package services
 import (
 	"Dp218GO/models"
 	"Dp218GO/repositories"
+	"context"
 )
 
 //ScooterService is the service which gives access to the ScooterRepo repository.
@@ -16,29 +17,29 @@ func NewScooterService(repoScooter repositories.ScooterRepo) *ScooterService {
 }
 
 //GetAllScooters gives the access to the ScooterRepo.GetAllScooters function.
-func (ser *ScooterService) GetAllScooters() (*models.ScooterListDTO, error) {
-	return ser.repoScooter.GetAllScooters()
+func (ser *ScooterService) GetAllScooters(ctx context.Context) (*models.ScooterListDTO, error) {
+	return ser.repoScooter.GetAllScooters(ctx)
 }
-func (ser *ScooterService) GetAllScootersByStationID(stationID int) (*models.ScooterListDTO, error) {
-	return ser.repoScooter.GetAllScootersByStationID(stationID)
+func (ser *ScooterService) GetAllScootersByStationID(ctx context.Context, stationID int) (*models.ScooterListDTO, error) {
+	return ser.repoScooter.GetAllScootersByStationID(ctx, stationID)
 }
 
 //GetScooterById gives the access to the ScooterRepo.GetScooterById function.
-func (ser *ScooterService) GetScooterById(uid int) (models.ScooterDTO, error) {
-	return ser.repoScooter.GetScooterById(uid)
+func (ser *ScooterService) GetScooterById(ctx context.Context, uid int) (models.ScooterDTO, error) {
+	return ser.repoScooter.GetScooterById(ctx, uid)
 }
 
 //GetScooterStatus gives the access to the ScooterRepo.GetScooterStatus function.
-func (ser *ScooterService) GetScooterStatus(scooterID int) (models.ScooterStatus, error) {
-	return ser.repoScooter.GetScooterStatus(scooterID)
+func (ser *ScooterService) GetScooterStatus(ctx context.Context, scooterID int) (models.ScooterStatus, error) {
+	return ser.repoScooter.GetScooterStatus(ctx, scooterID)
 }
 
 //SendCurrentStatus gives the access to the ScooterRepo.SendCurrentStatus function.
-func (ser *ScooterService) SendCurrentStatus(id, stationID int, lat, lon, battery float64) error {
-	return ser.repoScooter.SendCurrentStatus(id, stationID, lat, lon, battery)
+func (ser *ScooterService) SendCurrentStatus(ctx context.Context, id, stationID int, lat, lon, battery float64) error {
+	return ser.repoScooter.SendCurrentStatus(ctx, id, stationID, lat, lon, battery)
 }
 
 //CreateScooterStatusInRent gives the access to the ScooterRepo.CreateScooterStatusInRent function.
-func (ser *ScooterService) CreateScooterStatusInRent(scooterID int) (models.ScooterStatusInRent, error) {
-	return ser.repoScooter.CreateScooterStatusInRent(scooterID)
+func (ser *ScooterService) CreateScooterStatusInRent(ctx context.Context, scooterID int) (models.ScooterStatusInRent, error) {
+	return ser.repoScooter.CreateScooterStatusInRent(ctx, scooterID)
 }
@@ -3,6 +3,7 @@ package services
 import (
 	"Dp218GO/models"
 	"Dp218GO/repositories"
+	"context"
 )
 
 // ScooterInitService - structure for implementing ScooterInitService
@@ -15,30 +16,30 @@ func NewScooterInitService(scooterInitRepo repositories.ScooterInitRepoI) *Scoot
 	return &ScooterInitService{scooterInitRepo}
 }
 
-// GetOwnersScooters - get all scooters that related to current user
-func (si *ScooterInitService) GetOwnersScooters() (*models.SuppliersScooterList, error) {
-	return si.scooterInitRepo.GetOwnersScooters()
+// GetOwnersScooters - get all scooters that related to given owner
+func (si *ScooterInitService) GetOwnersScooters(ctx context.Context, owner models.User) (*models.SuppliersScooterList, error) {
+	return si.scooterInitRepo.GetOwnersScooters(ctx, owner)
 }
 
 // GetActiveStations - get all active system stations
-func (si *ScooterInitService) GetActiveStations() (*models.StationList, error) {
-	return si.scooterInitRepo.GetActiveStations()
+func (si *ScooterInitService) GetActiveStations(ctx context.Context) (*models.StationList, error) {
+	return si.scooterInitRepo.GetActiveStations(ctx)
 }
 
 // AddStatusesToScooters - add statuses to scooter statuses
-func (si *ScooterInitService) AddStatusesToScooters(scooterIds []int, station models.Station) error {
-	return si.scooterInitRepo.AddStatusesToScooters(scooterIds, station)
+func (si *ScooterInitService) AddStatusesToScooters(ctx context.Context, owner models.User, scooterIds []int, station models.Station) error {
+	return si.scooterInitRepo.AddStatusesToScooters(ctx, owner, scooterIds, station)
 }
 
-// ConvertForTemplateStruct - create struct for template rendering
-func (si *ScooterInitService) ConvertForTemplateStruct() *models.ScootersStationsAllocation {
+// ConvertForTemplateStruct - create struct with owner scooters for template rendering
+func (si *ScooterInitService) ConvertForTemplateStruct(ctx context.Context, owner models.User) *models.ScootersStationsAllocation {
 	list := &models.ScootersStationsAllocation{}
 
-	scooters, err := si.scooterInitRepo.GetOwnersScooters()
+	scooters, err := si.scooterInitRepo.GetOwnersScooters(ctx, owner)
 	if err != nil {
 		return nil
 	}
-	stations, err := si.scooterInitRepo.GetActiveStations()
+	stations, err := si.scooterInitRepo.GetActiveStations(ctx)
 	if err != nil {
 		return nil
 	}
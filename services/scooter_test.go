@@ -3,6 +3,7 @@ package services
 import (
 	"Dp218GO/models"
 	"Dp218GO/repositories/mock"
+	"context"
 	"errors"
 	"github.com/golang/mock/gomock"
 	assert "github.com/stretchr/testify/require"
@@ -54,18 +55,18 @@ func TestScooterService_GetScooterById(t *testing.T) {
 		{
 			name: "Correct",
 			test: func(t *testing.T, mock *ScooterMock) {
-				mock.RepoScooter.EXPECT().GetScooterById(1).Return(models.ScooterDTO{}, nil).Times(1)
+				mock.RepoScooter.EXPECT().GetScooterById(gomock.Any(), 1).Return(models.ScooterDTO{}, nil).Times(1)
 
-				_, err := mock.ScooterService.GetScooterById(1)
+				_, err := mock.ScooterService.GetScooterById(context.Background(), 1)
 				assert.Equal(t, nil, err)
 			},
 		}, {
 			name: "Incorrect",
 			test: func(t *testing.T, mock *ScooterMock) {
 				expectedError := errors.New("expectedError")
-				mock.RepoScooter.EXPECT().GetScooterById(-1).Return(models.ScooterDTO{}, expectedError).Times(1)
+				mock.RepoScooter.EXPECT().GetScooterById(gomock.Any(), -1).Return(models.ScooterDTO{}, expectedError).Times(1)
 
-				_, err := mock.ScooterService.GetScooterById(-1)
+				_, err := mock.ScooterService.GetScooterById(context.Background(), -1)
 				assert.Error(t, err)
 				assert.Equal(t, expectedError, err)
 			},
@@ -78,9 +79,9 @@ func TestScooterService_CreateScooterStatusInRent(t *testing.T) {
 		{
 			name: "Correct",
 			test: func(t *testing.T, mock *ScooterMock) {
-				mock.RepoScooter.EXPECT().CreateScooterStatusInRent(1).Return(models.ScooterStatusInRent{}, nil).Times(1)
+				mock.RepoScooter.EXPECT().CreateScooterStatusInRent(gomock.Any(), 1).Return(models.ScooterStatusInRent{}, nil).Times(1)
 
-				_, err := mock.RepoScooter.CreateScooterStatusInRent(1)
+				_, err := mock.RepoScooter.CreateScooterStatusInRent(context.Background(), 1)
 
 				assert.Equal(t, nil, err)
 			},
@@ -88,9 +89,9 @@ func TestScooterService_CreateScooterStatusInRent(t *testing.T) {
 			name: "Incorrect",
 			test: func(t *testing.T, mock *ScooterMock) {
 				expectedError := errors.New("expectedError")
-				mock.RepoScooter.EXPECT().CreateScooterStatusInRent(1).Return(models.ScooterStatusInRent{}, expectedError).Times(1)
+				mock.RepoScooter.EXPECT().CreateScooterStatusInRent(gomock.Any(), 1).Return(models.ScooterStatusInRent{}, expectedError).Times(1)
 
-				_, err := mock.ScooterService.CreateScooterStatusInRent(1)
+				_, err := mock.ScooterService.CreateScooterStatusInRent(context.Background(), 1)
 
 				assert.Error(t, err)
 				assert.Equal(t, expectedError, err)
@@ -104,9 +105,9 @@ func TestScooterService_GetAllScooters(t *testing.T) {
 		{
 			name: "Correct",
 			test: func(t *testing.T, mock *ScooterMock) {
-				mock.RepoScooter.EXPECT().GetAllScooters().Return(&models.ScooterListDTO{}, nil).Times(1)
+				mock.RepoScooter.EXPECT().GetAllScooters(gomock.Any()).Return(&models.ScooterListDTO{}, nil).Times(1)
 
-				_, err := mock.RepoScooter.GetAllScooters()
+				_, err := mock.RepoScooter.GetAllScooters(context.Background())
 
 				assert.Equal(t, nil, err)
 			},
@@ -114,9 +115,9 @@ func TestScooterService_GetAllScooters(t *testing.T) {
 			name: "Incorrect",
 			test: func(t *testing.T, mock *ScooterMock) {
 				expectedError := errors.New("expectedError")
-				mock.RepoScooter.EXPECT().GetAllScooters().Return(&models.ScooterListDTO{}, expectedError).Times(1)
+				mock.RepoScooter.EXPECT().GetAllScooters(gomock.Any()).Return(&models.ScooterListDTO{}, expectedError).Times(1)
 
-				_, err := mock.ScooterService.GetAllScooters()
+				_, err := mock.ScooterService.GetAllScooters(context.Background())
 
 				assert.Error(t, err)
 				assert.Equal(t, expectedError, err)
@@ -130,9 +131,9 @@ func TestScooterService_GetAllScootersByStationID(t *testing.T) {
 		{
 			name: "Correct",
 			test: func(t *testing.T, mock *ScooterMock) {
-				mock.RepoScooter.EXPECT().GetAllScootersByStationID(1).Return(&models.ScooterListDTO{}, nil).Times(1)
+				mock.RepoScooter.EXPECT().GetAllScootersByStationID(gomock.Any(), 1).Return(&models.ScooterListDTO{}, nil).Times(1)
 
-				_, err := mock.RepoScooter.GetAllScootersByStationID(1)
+				_, err := mock.RepoScooter.GetAllScootersByStationID(context.Background(), 1)
 
 				assert.Equal(t, nil, err)
 			},
@@ -140,9 +141,9 @@ func TestScooterService_GetAllScootersByStationID(t *testing.T) {
 			name: "Incorrect",
 			test: func(t *testing.T, mock *ScooterMock) {
 				expectedError := errors.New("expectedError")
-				mock.RepoScooter.EXPECT().GetAllScootersByStationID(1).Return(&models.ScooterListDTO{}, expectedError).Times(1)
+				mock.RepoScooter.EXPECT().GetAllScootersByStationID(gomock.Any(), 1).Return(&models.ScooterListDTO{}, expectedError).Times(1)
 
-				_, err := mock.ScooterService.GetAllScootersByStationID(1)
+				_, err := mock.ScooterService.GetAllScootersByStationID(context.Background(), 1)
 
 				assert.Error(t, err)
 				assert.Equal(t, expectedError, err)
@@ -156,9 +157,9 @@ func TestScooterService_GetScooterStatus(t *testing.T) {
 		{
 			name: "Correct",
 			test: func(t *testing.T, mock *ScooterMock) {
-				mock.RepoScooter.EXPECT().GetScooterStatus(1).Return(models.ScooterStatus{}, nil).Times(1)
+				mock.RepoScooter.EXPECT().GetScooterStatus(gomock.Any(), 1).Return(models.ScooterStatus{}, nil).Times(1)
 
-				_, err := mock.RepoScooter.GetScooterStatus(1)
+				_, err := mock.RepoScooter.GetScooterStatus(context.Background(), 1)
 
 				assert.Equal(t, nil, err)
 			},
@@ -166,9 +167,9 @@ func TestScooterService_GetScooterStatus(t *testing.T) {
 			name: "Incorrect",
 			test: func(t *testing.T, mock *ScooterMock) {
 				expectedError := errors.New("expectedError")
-				mock.RepoScooter.EXPECT().GetScooterStatus(1).Return(models.ScooterStatus{}, expectedError).Times(1)
+				mock.RepoScooter.EXPECT().GetScooterStatus(gomock.Any(), 1).Return(models.ScooterStatus{}, expectedError).Times(1)
 
-				_, err := mock.ScooterService.GetScooterStatus(1)
+				_, err := mock.ScooterService.GetScooterStatus(context.Background(), 1)
 
 				assert.Error(t, err)
 				assert.Equal(t, expectedError, err)
@@ -182,10 +183,10 @@ func TestScooterService_SendCurrentStatus(t *testing.T) {
 		{
 			name: "Correct",
 			test: func(t *testing.T, mock *ScooterMock) {
-				mock.RepoScooter.EXPECT().SendCurrentStatus(1, 1, 40.5, 50.5, 70.0).Return(
+				mock.RepoScooter.EXPECT().SendCurrentStatus(gomock.Any(), 1, 1, 40.5, 50.5, 70.0).Return(
 					nil).Times(1)
 
-				err := mock.RepoScooter.SendCurrentStatus(1, 1, 40.5, 50.5, 70.0)
+				err := mock.RepoScooter.SendCurrentStatus(context.Background(), 1, 1, 40.5, 50.5, 70.0)
 
 				assert.Equal(t, nil, err)
 			},
@@ -193,9 +194,9 @@ func TestScooterService_SendCurrentStatus(t *testing.T) {
 			name: "Incorrect",
 			test: func(t *testing.T, mock *ScooterMock) {
 				expectedError := errors.New("expectedError")
-				mock.RepoScooter.EXPECT().SendCurrentStatus(1, 1, 40.5, 50.5, 70.0).Return(expectedError).Times(1)
+				mock.RepoScooter.EXPECT().SendCurrentStatus(gomock.Any(), 1, 1, 40.5, 50.5, 70.0).Return(expectedError).Times(1)
 
-				err := mock.ScooterService.SendCurrentStatus(1, 1, 40.5, 50.5, 70.0)
+				err := mock.ScooterService.SendCurrentStatus(context.Background(), 1, 1, 40.5, 50.5, 70.0)
 
 				assert.Error(t, err)
 				assert.Equal(t, expectedError, err)
@@ -3,42 +3,152 @@ package services
 import (
 	"Dp218GO/models"
 	"Dp218GO/repositories"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
 )
 
+//DefaultStationCapacity is the number of slots of the station which is added without capacity.
+const DefaultStationCapacity = 10
+
+//ErrInvalidCapacity is returned when the station capacity is negative.
+var ErrInvalidCapacity = errors.New("station capacity can't be negative")
+
+//ErrInvalidStatus is returned when the station status is not active, maintenance or closed.
+var ErrInvalidStatus = errors.New("invalid station status")
+
+//ErrReasonRequired is returned when the station status is changed without a reason.
+var ErrReasonRequired = errors.New("reason of the status change is required")
+
+//ErrInvalidMove is returned when the scooters of the deleted station can't be moved to the chosen one.
+var ErrInvalidMove = errors.New("scooters can be moved only to another active station")
+
 type StationService struct {
 	repoStation repositories.StationRepo
+	clock       Clock
 }
 
-func NewStationService(repoStation repositories.StationRepo) *StationService {
-	return &StationService{repoStation: repoStation}
+func NewStationService(repoStation repositories.StationRepo, clock Clock) *StationService {
+	return &StationService{repoStation: repoStation, clock: clock}
 }
 
-func (db *StationService) GetAllStations() (*models.StationList, error) {
-	return db.repoStation.GetAllStations()
+func (ser *StationService) GetAllStations(ctx context.Context) (*models.StationList, error) {
+	return ser.repoStation.GetAllStations(ctx)
 }
 
-func (db *StationService) AddStation(station *models.Station) error {
-	return db.repoStation.AddStation(station)
+func (ser *StationService) AddStation(ctx context.Context, station *models.Station) error {
+	if station.Capacity < 0 {
+		return ErrInvalidCapacity
+	}
+	if station.Capacity == 0 {
+		station.Capacity = DefaultStationCapacity
+	}
+	if station.Status == "" {
+		station.Status = models.StationMaintenance
+		if station.IsActive {
+			station.Status = models.StationActive
+		}
+	}
+	if !validStationStatus(station.Status) {
+		return ErrInvalidStatus
+	}
+	station.IsActive = station.Status == models.StationActive
+	return ser.repoStation.AddStation(ctx, station)
 }
 
-func (db *StationService) GetStationById(stationId int) (models.Station, error) {
-	return db.repoStation.GetStationById(stationId)
+func (ser *StationService) GetStationById(ctx context.Context, stationId int) (models.Station, error) {
+	return ser.repoStation.GetStationById(ctx, stationId)
 }
 
-func (db *StationService) DeleteStation(stationId int) error {
-	return db.repoStation.DeleteStation(stationId)
+//DeleteStation deletes the station. The station with scooters is deleted only if moveTo is another active station,
+//the scooters are docked there.
+func (ser *StationService) DeleteStation(ctx context.Context, stationId, moveTo int) error {
+	if moveTo != 0 {
+		if moveTo == stationId {
+			return ErrInvalidMove
+		}
+		target, err := ser.repoStation.GetStationById(ctx, moveTo)
+		if err != nil {
+			return err
+		}
+		if !target.IsActive {
+			return ErrInvalidMove
+		}
+	}
+	return ser.repoStation.DeleteStation(ctx, stationId, moveTo)
 }
 
-func (ser *StationService) ChangeStationBlockStatus(stationId int) error {
-	station, err := ser.repoStation.GetStationById(stationId)
-	if err != nil {
-		return err
+//ChangeStatus records the status change of the station made by change.ChangedBy.
+//The change without EffectiveAt or with EffectiveAt in the past is applied at once, otherwise it's scheduled.
+func (ser *StationService) ChangeStatus(ctx context.Context, change *models.StationStatusChange) error {
+	if !validStationStatus(change.Status) {
+		return ErrInvalidStatus
+	}
+	change.Reason = strings.TrimSpace(change.Reason)
+	if change.Reason == "" {
+		return ErrReasonRequired
 	}
-	station.IsActive = !station.IsActive
-	_, err = ser.repoStation.UpdateStation(stationId, station)
-	return err
+
+	now := ser.clock.Now()
+	if change.EffectiveAt.IsZero() || change.EffectiveAt.Before(now) {
+		change.EffectiveAt = now
+	}
+	return ser.repoStation.AddStatusChange(ctx, change, now)
+}
+
+//StatusHistory returns the status changes of the station, the latest first.
+func (ser *StationService) StatusHistory(ctx context.Context, stationId int) (*models.StationStatusHistory, error) {
+	return ser.repoStation.GetStatusHistory(ctx, stationId)
 }
 
-func (ser *StationService) UpdateStation(stationId int, stationData models.Station) (models.Station, error) {
-	return ser.repoStation.UpdateStation(stationId, stationData)
+//CancelStatusChange cancels the scheduled status change which isn't applied yet.
+func (ser *StationService) CancelStatusChange(ctx context.Context, stationId, changeId int) error {
+	return ser.repoStation.CancelStatusChange(ctx, stationId, changeId, ser.clock.Now())
+}
+
+//ApplyScheduledEvery applies the scheduled status changes every interval until the context is done.
+func (ser *StationService) ApplyScheduledEvery(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := ser.repoStation.ApplyStatusChanges(ctx, ser.clock.Now()); err != nil {
+				fmt.Println(err)
+			}
+		}
+	}
+}
+
+func validStationStatus(status string) bool {
+	switch status {
+	case models.StationActive, models.StationMaintenance, models.StationClosed:
+		return true
+	}
+	return false
+}
+
+func (ser *StationService) UpdateStation(ctx context.Context, stationId int, stationData models.Station) (models.Station, error) {
+	if stationData.Capacity < 0 {
+		return models.Station{}, ErrInvalidCapacity
+	}
+	return ser.repoStation.UpdateStation(ctx, stationId, stationData)
+}
+
+//CheckRoom returns repositories.ErrStationFull if the scooter can't be docked at the station.
+//The scooter which is already docked there keeps its slot.
+func (ser *StationService) CheckRoom(ctx context.Context, stationId, scooterId int) error {
+	hasRoom, err := ser.repoStation.HasRoom(ctx, stationId, scooterId)
+	if err != nil {
+		return err
+	}
+	if !hasRoom {
+		return repositories.ErrStationFull
+	}
+	return nil
 }
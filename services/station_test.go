@@ -0,0 +1,280 @@
+package services
+
+import (
+	"Dp218GO/models"
+	"Dp218GO/repositories"
+	repomock "Dp218GO/repositories/mock"
+	"Dp218GO/services/mock"
+	"context"
+	"github.com/golang/mock/gomock"
+	assert "github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+var statusChangeTime = time.Date(2021, 12, 14, 10, 0, 0, 0, time.UTC)
+
+type stationUseCasesMock struct {
+	StationServiceUC *StationService
+	RepoStation      *repomock.MockStationRepo
+	Clock            *mock.MockClock
+}
+
+type stationTestCase struct {
+	name string
+	test func(t *testing.T, mock *stationUseCasesMock)
+}
+
+func runStationTestCases(t *testing.T, testCases []stationTestCase) {
+	for _, tc := range testCases {
+		t.Run(tc.name, func(tt *testing.T) {
+			defer func() {
+				if err := recover(); err != nil {
+					tt.Error(err)
+				}
+			}()
+
+			ctrl := gomock.NewController(tt)
+			defer ctrl.Finish()
+
+			mock := newStationUseCasesMock(ctrl)
+
+			tc.test(tt, mock)
+		})
+	}
+}
+
+func newStationUseCasesMock(ctrl *gomock.Controller) *stationUseCasesMock {
+	repoStation := repomock.NewMockStationRepo(ctrl)
+	clock := mock.NewMockClock(ctrl)
+
+	return &stationUseCasesMock{
+		StationServiceUC: NewStationService(repoStation, clock),
+		RepoStation:      repoStation,
+		Clock:            clock,
+	}
+}
+
+func Test_Station_AddStation(t *testing.T) {
+	runStationTestCases(t, []stationTestCase{
+		{
+			name: "DefaultCapacity",
+			test: func(t *testing.T, mock *stationUseCasesMock) {
+				mock.RepoStation.EXPECT().AddStation(gomock.Any(),
+					&models.Station{Name: "Central", Status: models.StationMaintenance, Capacity: DefaultStationCapacity}).
+					Return(nil).Times(1)
+
+				err := mock.StationServiceUC.AddStation(context.Background(), &models.Station{Name: "Central"})
+				assert.Nil(t, err)
+			},
+		},
+		{
+			name: "GivenCapacity",
+			test: func(t *testing.T, mock *stationUseCasesMock) {
+				mock.RepoStation.EXPECT().AddStation(gomock.Any(),
+					&models.Station{Name: "Central", Status: models.StationMaintenance, Capacity: 4}).Return(nil).Times(1)
+
+				err := mock.StationServiceUC.AddStation(context.Background(), &models.Station{Name: "Central", Capacity: 4})
+				assert.Nil(t, err)
+			},
+		},
+		{
+			name: "ActiveStatus",
+			test: func(t *testing.T, mock *stationUseCasesMock) {
+				mock.RepoStation.EXPECT().AddStation(gomock.Any(), &models.Station{Name: "Central", IsActive: true,
+					Status: models.StationActive, Capacity: 4}).Return(nil).Times(1)
+
+				err := mock.StationServiceUC.AddStation(context.Background(),
+					&models.Station{Name: "Central", IsActive: true, Capacity: 4})
+				assert.Nil(t, err)
+			},
+		},
+		{
+			name: "InvalidStatus",
+			test: func(t *testing.T, mock *stationUseCasesMock) {
+				err := mock.StationServiceUC.AddStation(context.Background(),
+					&models.Station{Name: "Central", Status: "blocked"})
+				assert.ErrorIs(t, err, ErrInvalidStatus)
+			},
+		},
+		{
+			name: "NegativeCapacity",
+			test: func(t *testing.T, mock *stationUseCasesMock) {
+				err := mock.StationServiceUC.AddStation(context.Background(), &models.Station{Name: "Central", Capacity: -1})
+				assert.ErrorIs(t, err, ErrInvalidCapacity)
+			},
+		},
+	})
+}
+
+func Test_Station_UpdateStation(t *testing.T) {
+	runStationTestCases(t, []stationTestCase{
+		{
+			name: "Success",
+			test: func(t *testing.T, mock *stationUseCasesMock) {
+				station := models.Station{ID: 2, Name: "Central", Capacity: 6}
+				mock.RepoStation.EXPECT().UpdateStation(gomock.Any(), 2, station).
+					Return(models.Station{ID: 2, Name: "Central", Capacity: 6, Occupied: 2, FreeSlots: 4}, nil).Times(1)
+
+				updated, err := mock.StationServiceUC.UpdateStation(context.Background(), 2, station)
+				assert.Nil(t, err)
+				assert.Equal(t, 4, updated.FreeSlots)
+			},
+		},
+		{
+			name: "NegativeCapacity",
+			test: func(t *testing.T, mock *stationUseCasesMock) {
+				_, err := mock.StationServiceUC.UpdateStation(context.Background(), 2, models.Station{Capacity: -3})
+				assert.ErrorIs(t, err, ErrInvalidCapacity)
+			},
+		},
+	})
+}
+
+func Test_Station_CheckRoom(t *testing.T) {
+	runStationTestCases(t, []stationTestCase{
+		{
+			name: "HasRoom",
+			test: func(t *testing.T, mock *stationUseCasesMock) {
+				mock.RepoStation.EXPECT().HasRoom(gomock.Any(), 2, 7).Return(true, nil).Times(1)
+
+				assert.Nil(t, mock.StationServiceUC.CheckRoom(context.Background(), 2, 7))
+			},
+		},
+		{
+			name: "Full",
+			test: func(t *testing.T, mock *stationUseCasesMock) {
+				mock.RepoStation.EXPECT().HasRoom(gomock.Any(), 2, 7).Return(false, nil).Times(1)
+
+				err := mock.StationServiceUC.CheckRoom(context.Background(), 2, 7)
+				assert.ErrorIs(t, err, repositories.ErrStationFull)
+			},
+		},
+	})
+}
+
+func Test_Station_ChangeStatus(t *testing.T) {
+	runStationTestCases(t, []stationTestCase{
+		{
+			name: "Now",
+			test: func(t *testing.T, mock *stationUseCasesMock) {
+				mock.Clock.EXPECT().Now().Return(statusChangeTime).Times(1)
+				mock.RepoStation.EXPECT().AddStatusChange(gomock.Any(), &models.StationStatusChange{StationID: 2,
+					Status: models.StationMaintenance, Reason: "broken dock", ChangedBy: 5, EffectiveAt: statusChangeTime},
+					statusChangeTime).Return(nil).Times(1)
+
+				err := mock.StationServiceUC.ChangeStatus(context.Background(), &models.StationStatusChange{StationID: 2,
+					Status: models.StationMaintenance, Reason: " broken dock ", ChangedBy: 5})
+				assert.Nil(t, err)
+			},
+		},
+		{
+			name: "PastIsNow",
+			test: func(t *testing.T, mock *stationUseCasesMock) {
+				mock.Clock.EXPECT().Now().Return(statusChangeTime).Times(1)
+				mock.RepoStation.EXPECT().AddStatusChange(gomock.Any(), gomock.Any(), statusChangeTime).Return(nil).Times(1)
+
+				change := &models.StationStatusChange{StationID: 2, Status: models.StationActive, Reason: "repaired",
+					EffectiveAt: statusChangeTime.Add(-time.Hour)}
+				assert.Nil(t, mock.StationServiceUC.ChangeStatus(context.Background(), change))
+				assert.Equal(t, statusChangeTime, change.EffectiveAt)
+			},
+		},
+		{
+			name: "Scheduled",
+			test: func(t *testing.T, mock *stationUseCasesMock) {
+				closure := statusChangeTime.Add(48 * time.Hour)
+				mock.Clock.EXPECT().Now().Return(statusChangeTime).Times(1)
+				mock.RepoStation.EXPECT().AddStatusChange(gomock.Any(), &models.StationStatusChange{StationID: 2,
+					Status: models.StationClosed, Reason: "road works", EffectiveAt: closure},
+					statusChangeTime).Return(nil).Times(1)
+
+				err := mock.StationServiceUC.ChangeStatus(context.Background(), &models.StationStatusChange{StationID: 2,
+					Status: models.StationClosed, Reason: "road works", EffectiveAt: closure})
+				assert.Nil(t, err)
+			},
+		},
+		{
+			name: "InvalidStatus",
+			test: func(t *testing.T, mock *stationUseCasesMock) {
+				err := mock.StationServiceUC.ChangeStatus(context.Background(),
+					&models.StationStatusChange{StationID: 2, Status: "blocked", Reason: "broken dock"})
+				assert.ErrorIs(t, err, ErrInvalidStatus)
+			},
+		},
+		{
+			name: "NoReason",
+			test: func(t *testing.T, mock *stationUseCasesMock) {
+				err := mock.StationServiceUC.ChangeStatus(context.Background(),
+					&models.StationStatusChange{StationID: 2, Status: models.StationClosed, Reason: "  "})
+				assert.ErrorIs(t, err, ErrReasonRequired)
+			},
+		},
+	})
+}
+
+func Test_Station_CancelStatusChange(t *testing.T) {
+	runStationTestCases(t, []stationTestCase{
+		{
+			name: "Success",
+			test: func(t *testing.T, mock *stationUseCasesMock) {
+				mock.Clock.EXPECT().Now().Return(statusChangeTime).Times(1)
+				mock.RepoStation.EXPECT().CancelStatusChange(gomock.Any(), 2, 9, statusChangeTime).Return(nil).Times(1)
+
+				assert.Nil(t, mock.StationServiceUC.CancelStatusChange(context.Background(), 2, 9))
+			},
+		},
+		{
+			name: "AlreadyApplied",
+			test: func(t *testing.T, mock *stationUseCasesMock) {
+				mock.Clock.EXPECT().Now().Return(statusChangeTime).Times(1)
+				mock.RepoStation.EXPECT().CancelStatusChange(gomock.Any(), 2, 9, statusChangeTime).
+					Return(repositories.ErrNoStatusChange).Times(1)
+
+				err := mock.StationServiceUC.CancelStatusChange(context.Background(), 2, 9)
+				assert.ErrorIs(t, err, repositories.ErrNoStatusChange)
+			},
+		},
+	})
+}
+
+func Test_Station_DeleteStation(t *testing.T) {
+	runStationTestCases(t, []stationTestCase{
+		{
+			name: "InUse",
+			test: func(t *testing.T, mock *stationUseCasesMock) {
+				mock.RepoStation.EXPECT().DeleteStation(gomock.Any(), 2, 0).Return(repositories.ErrStationInUse).Times(1)
+
+				err := mock.StationServiceUC.DeleteStation(context.Background(), 2, 0)
+				assert.ErrorIs(t, err, repositories.ErrStationInUse)
+			},
+		},
+		{
+			name: "MoveScooters",
+			test: func(t *testing.T, mock *stationUseCasesMock) {
+				mock.RepoStation.EXPECT().GetStationById(gomock.Any(), 3).
+					Return(models.Station{ID: 3, IsActive: true, Status: models.StationActive}, nil).Times(1)
+				mock.RepoStation.EXPECT().DeleteStation(gomock.Any(), 2, 3).Return(nil).Times(1)
+
+				assert.Nil(t, mock.StationServiceUC.DeleteStation(context.Background(), 2, 3))
+			},
+		},
+		{
+			name: "MoveToInactive",
+			test: func(t *testing.T, mock *stationUseCasesMock) {
+				mock.RepoStation.EXPECT().GetStationById(gomock.Any(), 3).
+					Return(models.Station{ID: 3, Status: models.StationClosed}, nil).Times(1)
+
+				err := mock.StationServiceUC.DeleteStation(context.Background(), 2, 3)
+				assert.ErrorIs(t, err, ErrInvalidMove)
+			},
+		},
+		{
+			name: "MoveToItself",
+			test: func(t *testing.T, mock *stationUseCasesMock) {
+				err := mock.StationServiceUC.DeleteStation(context.Background(), 2, 2)
+				assert.ErrorIs(t, err, ErrInvalidMove)
+			},
+		},
+	})
+}
@@ -3,6 +3,7 @@ package services
 import (
 	"Dp218GO/models"
 	"Dp218GO/repositories"
+	"context"
 )
 
 // ScooterInitService - structure for implementing ScooterInitService
@@ -16,20 +17,20 @@ func NewSupMicroService(supMicroRepo repositories.SupMicroRepoI) *SupMicroServic
 }
 
 // GetOwnersScooters - get all scooters that related to current user
-func (si *SupMicroService) GetStations() (*models.StationList, error) {
-	return si.supMicroRepo.GetStations()
+func (si *SupMicroService) GetStations(ctx context.Context) (*models.StationList, error) {
+	return si.supMicroRepo.GetStations(ctx)
 }
 
 // GetActiveStations - get all active system stations
-func (si *SupMicroService) GetLocations() (*models.LocationList, error) {
-	return si.supMicroRepo.GetLocations()
+func (si *SupMicroService) GetLocations(ctx context.Context) (*models.LocationList, error) {
+	return si.supMicroRepo.GetLocations(ctx)
 }
 
 // AddStatusesToScooters - add statuses to scooter statuses
-func (si *SupMicroService) AddStation(station *models.Station) error {
-	return si.supMicroRepo.CreateStation(station)
+func (si *SupMicroService) AddStation(ctx context.Context, station *models.Station) error {
+	return si.supMicroRepo.CreateStation(ctx, station)
 }
 
-func (si *SupMicroService) AddStationInLocation(station *models.Station, location *models.Location) error {
-	return si.supMicroRepo.CreateStationInLocation(station, location)
+func (si *SupMicroService) AddStationInLocation(ctx context.Context, station *models.Station, location *models.Location) error {
+	return si.supMicroRepo.CreateStationInLocation(ctx, station, location)
 }
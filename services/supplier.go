@@ -3,6 +3,7 @@ package services
 import (
 	"Dp218GO/models"
 	"Dp218GO/repositories"
+	"context"
 )
 
 // SupplierService - structure for implementing supplier service
@@ -16,37 +17,37 @@ func NewSupplierService(SupplierRepo repositories.SupplierRepoI) *SupplierServic
 }
 
 //AddSuppliersScooter - adds suppliers scooter to scooter model
-func (s *SupplierService) AddSuppliersScooter(modelId int, scooter string) error {
-	return s.SupplierRepo.AddSuppliersScooter(modelId, scooter)
+func (s *SupplierService) AddSuppliersScooter(ctx context.Context, owner models.User, modelId int, scooter string) error {
+	return s.SupplierRepo.AddSuppliersScooter(ctx, owner, modelId, scooter)
 }
 
 // DeleteSuppliersScooter - delete suppliers scooter
-func (s *SupplierService) DeleteSuppliersScooter(id int) error {
-	return s.SupplierRepo.DeleteSuppliersScooter(id)
+func (s *SupplierService) DeleteSuppliersScooter(ctx context.Context, owner models.User, id int) error {
+	return s.SupplierRepo.DeleteSuppliersScooter(ctx, owner, id)
 }
 
 // InsertScootersToDb - adding scooters from .csv to Db
-func (s *SupplierService) InsertScootersToDb(modelId int, path string) {
+func (s *SupplierService) InsertScootersToDb(ctx context.Context, owner models.User, modelId int, path string) {
 	scooterUploaded := s.SupplierRepo.ConvertToStruct(path)
-	s.SupplierRepo.InsertToDb(modelId, scooterUploaded)
+	s.SupplierRepo.InsertToDb(ctx, owner, modelId, scooterUploaded)
 }
 
-// GetModels - getting all scooter models
-func (s *SupplierService) GetModels() (*models.ScooterModelDTOList, error) {
-	return s.SupplierRepo.GetModels()
+// GetModels - getting all scooter models with prices and scooters of the owner
+func (s *SupplierService) GetModels(ctx context.Context, owner models.User) (*models.ScooterModelDTOList, error) {
+	return s.SupplierRepo.GetModels(ctx, owner)
 }
 
 // SelectModel - get selected model data
-func (s *SupplierService) SelectModel(id int) (*models.ScooterModelDTO, error) {
-	return s.SupplierRepo.SelectModel(id)
+func (s *SupplierService) SelectModel(ctx context.Context, owner models.User, id int) (*models.ScooterModelDTO, error) {
+	return s.SupplierRepo.SelectModel(ctx, owner, id)
 }
 
 // AddModel - adding of model to Db
-func (s *SupplierService) AddModel(modelData *models.ScooterModelDTO) error {
-	return s.SupplierRepo.AddModel(modelData)
+func (s *SupplierService) AddModel(ctx context.Context, owner models.User, modelData *models.ScooterModelDTO) error {
+	return s.SupplierRepo.AddModel(ctx, owner, modelData)
 }
 
 // ChangePrice - change payment price that related to scooter model
-func (s *SupplierService) ChangePrice(modelData *models.ScooterModelDTO) error {
-	return s.SupplierRepo.EditPrice(modelData)
+func (s *SupplierService) ChangePrice(ctx context.Context, owner models.User, modelData *models.ScooterModelDTO) error {
+	return s.SupplierRepo.EditPrice(ctx, owner, modelData)
 }
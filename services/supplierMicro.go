@@ -47,9 +47,9 @@ func (supserv *SupplierMicroService) marshallStation(station *models.Station) *p
 	}
 }
 
-func (supserv *SupplierMicroService) GetAllLStations() (models.StationList, error) {
+func (supserv *SupplierMicroService) GetAllLStations(ctx context.Context) (models.StationList, error) {
 	request := &proto.Request{}
-	response, err := supserv.microservice.GetStations(context.Background(), request)
+	response, err := supserv.microservice.GetStations(ctx, request)
 	var stationList models.StationList
 	if err != nil {
 		return stationList, err
@@ -60,9 +60,9 @@ func (supserv *SupplierMicroService) GetAllLStations() (models.StationList, erro
 	return stationList, err
 }
 
-func (supserv *SupplierMicroService) GetAllLocations() (*models.LocationList, error) {
+func (supserv *SupplierMicroService) GetAllLocations(ctx context.Context) (*models.LocationList, error) {
 	request := &proto.Request{}
-	response, err := supserv.microservice.GetLocations(context.Background(), request)
+	response, err := supserv.microservice.GetLocations(ctx, request)
 	var locationList *models.LocationList
 	if err != nil {
 		return locationList, err
@@ -73,7 +73,7 @@ func (supserv *SupplierMicroService) GetAllLocations() (*models.LocationList, er
 	return locationList, err
 }
 
-func (supserv *SupplierMicroService) AddNewStation(station *models.Station) error {
+func (supserv *SupplierMicroService) AddNewStation(ctx context.Context, station *models.Station) error {
 	stationToAdd := &proto.Station{
 		Id:        int32(station.ID),
 		Name:      station.Name,
@@ -81,12 +81,12 @@ func (supserv *SupplierMicroService) AddNewStation(station *models.Station) erro
 		Latitude:  float32(station.Latitude),
 		Longitude: float32(station.Longitude),
 	}
-	_, err := supserv.microservice.CreateStation(context.Background(), stationToAdd)
+	_, err := supserv.microservice.CreateStation(ctx, stationToAdd)
 	return err
 }
 
 // AddProblemSolution - make solution record for given problem (by ID)
-func (supserv *SupplierMicroService) CreateStationInLocation(location *models.Location, station *models.Station) error {
+func (supserv *SupplierMicroService) CreateStationInLocation(ctx context.Context, location *models.Location, station *models.Station) error {
 	request := &proto.StationLocation{
 		Location: &proto.Location{
 			Longitude: float32(location.Longitude),
@@ -94,6 +94,6 @@ func (supserv *SupplierMicroService) CreateStationInLocation(location *models.Lo
 		},
 		ScooterStation: supserv.marshallStation(station),
 	}
-	_, err := supserv.microservice.CreateStationInLocation(context.Background(), request)
+	_, err := supserv.microservice.CreateStationInLocation(ctx, request)
 	return err
 }
@@ -0,0 +1,150 @@
+package services
+
+import (
+	"Dp218GO/models"
+	"Dp218GO/repositories"
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"time"
+)
+
+//default lifetimes of api tokens if they aren't configured.
+const (
+	defaultAccessTokenTTL  = 15 * time.Minute
+	defaultRefreshTokenTTL = 30 * 24 * time.Hour
+)
+
+const (
+	tokenBytes = 32
+	tokenType  = "Bearer"
+)
+
+//TokenService issues short-lived access tokens with refresh tokens for the api clients.
+//Only the hashes of the tokens are kept in the repository.
+type TokenService struct {
+	repoToken  repositories.TokenRepo
+	repoUser   repositories.UserRepo
+	clock      Clock
+	accessTTL  time.Duration
+	refreshTTL time.Duration
+}
+
+//NewTokenService creates the new TokenService with given lifetimes of access and refresh tokens.
+func NewTokenService(tokenRepo repositories.TokenRepo, userRepo repositories.UserRepo, clock Clock,
+	accessTTL, refreshTTL time.Duration) *TokenService {
+	if accessTTL <= 0 {
+		accessTTL = defaultAccessTokenTTL
+	}
+	if refreshTTL <= 0 {
+		refreshTTL = defaultRefreshTokenTTL
+	}
+	return &TokenService{repoToken: tokenRepo, repoUser: userRepo, clock: clock,
+		accessTTL: accessTTL, refreshTTL: refreshTTL}
+}
+
+//Issue creates the new pair of tokens for the user.
+func (ts *TokenService) Issue(ctx context.Context, userID int) (models.TokenPair, error) {
+	access, err := generateToken()
+	if err != nil {
+		return models.TokenPair{}, err
+	}
+	refresh, err := generateToken()
+	if err != nil {
+		return models.TokenPair{}, err
+	}
+
+	now := ts.clock.Now()
+	token := &models.AuthToken{
+		UserID:           userID,
+		AccessHash:       hashToken(access),
+		RefreshHash:      hashToken(refresh),
+		AccessExpiresAt:  now.Add(ts.accessTTL),
+		RefreshExpiresAt: now.Add(ts.refreshTTL),
+	}
+	if err = ts.repoToken.AddToken(ctx, token); err != nil {
+		return models.TokenPair{}, err
+	}
+
+	return models.TokenPair{
+		AccessToken:      access,
+		RefreshToken:     refresh,
+		TokenType:        tokenType,
+		AccessExpiresAt:  token.AccessExpiresAt,
+		RefreshExpiresAt: token.RefreshExpiresAt,
+	}, nil
+}
+
+//Refresh exchanges the refresh token for the new pair of tokens. The old pair is revoked,
+//so every refresh token can be used only once. The blocked user gets no new tokens.
+func (ts *TokenService) Refresh(ctx context.Context, refreshToken string) (models.TokenPair, error) {
+	token, err := ts.repoToken.GetTokenByRefreshHash(ctx, hashToken(refreshToken))
+	if err != nil {
+		return models.TokenPair{}, err
+	}
+
+	now := ts.clock.Now()
+	if !now.Before(token.RefreshExpiresAt) {
+		return models.TokenPair{}, repositories.ErrNoToken
+	}
+	if err = ts.repoToken.RevokeToken(ctx, token.ID, now); err != nil {
+		return models.TokenPair{}, err
+	}
+
+	user, err := ts.repoUser.GetUserByID(ctx, token.UserID)
+	if err != nil {
+		return models.TokenPair{}, err
+	}
+	if user.IsBlocked {
+		return models.TokenPair{}, ErrUserBlocked
+	}
+
+	return ts.Issue(ctx, token.UserID)
+}
+
+//Authenticate returns the user whom the access token was issued to. The tokens of the blocked user
+//are rejected, so blocking takes effect without waiting for the tokens to expire.
+func (ts *TokenService) Authenticate(ctx context.Context, accessToken string) (*models.User, error) {
+	token, err := ts.repoToken.GetTokenByAccessHash(ctx, hashToken(accessToken))
+	if err != nil {
+		return nil, err
+	}
+	if !ts.clock.Now().Before(token.AccessExpiresAt) {
+		return nil, repositories.ErrNoToken
+	}
+
+	user, err := ts.repoUser.GetUserByID(ctx, token.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if user.IsBlocked {
+		return nil, ErrUserBlocked
+	}
+
+	return sanitize(&user), nil
+}
+
+//Revoke makes both tokens of the pair, which the access token belongs to, invalid.
+func (ts *TokenService) Revoke(ctx context.Context, accessToken string) error {
+	token, err := ts.repoToken.GetTokenByAccessHash(ctx, hashToken(accessToken))
+	if err != nil {
+		return err
+	}
+
+	return ts.repoToken.RevokeToken(ctx, token.ID, ts.clock.Now())
+}
+
+func generateToken() (string, error) {
+	buf := make([]byte, tokenBytes)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
+
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,200 @@
+package services
+
+import (
+	"Dp218GO/models"
+	"Dp218GO/repositories"
+	repomock "Dp218GO/repositories/mock"
+	"Dp218GO/services/mock"
+	"context"
+	"github.com/golang/mock/gomock"
+	assert "github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+const (
+	testAccessTTL  = 10 * time.Minute
+	testRefreshTTL = 24 * time.Hour
+)
+
+var tokenTime = time.Date(2021, 12, 20, 10, 0, 0, 0, time.UTC)
+
+type tokenUseCasesMock struct {
+	TokenServiceUC *TokenService
+	RepoToken      *repomock.MockTokenRepo
+	RepoUser       *repomock.MockUserRepo
+	Clock          *mock.MockClock
+}
+
+type tokenTestCase struct {
+	name string
+	test func(t *testing.T, mock *tokenUseCasesMock)
+}
+
+func runTokenTestCases(t *testing.T, testCases []tokenTestCase) {
+	for _, tc := range testCases {
+		t.Run(tc.name, func(tt *testing.T) {
+			defer func() {
+				if err := recover(); err != nil {
+					tt.Error(err)
+				}
+			}()
+
+			ctrl := gomock.NewController(tt)
+			defer ctrl.Finish()
+
+			mock := newTokenUseCasesMock(ctrl)
+
+			tc.test(tt, mock)
+		})
+	}
+}
+
+func newTokenUseCasesMock(ctrl *gomock.Controller) *tokenUseCasesMock {
+	repoToken := repomock.NewMockTokenRepo(ctrl)
+	repoUser := repomock.NewMockUserRepo(ctrl)
+	clock := mock.NewMockClock(ctrl)
+
+	return &tokenUseCasesMock{
+		TokenServiceUC: NewTokenService(repoToken, repoUser, clock, testAccessTTL, testRefreshTTL),
+		RepoToken:      repoToken,
+		RepoUser:       repoUser,
+		Clock:          clock,
+	}
+}
+
+func Test_Token_Issue(t *testing.T) {
+	runTokenTestCases(t, []tokenTestCase{
+		{
+			name: "Correct",
+			test: func(t *testing.T, mock *tokenUseCasesMock) {
+				var stored models.AuthToken
+				mock.Clock.EXPECT().Now().Return(tokenTime).Times(1)
+				mock.RepoToken.EXPECT().AddToken(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, token *models.AuthToken) error {
+						stored = *token
+						return nil
+					}).Times(1)
+
+				pair, err := mock.TokenServiceUC.Issue(context.Background(), 5)
+				assert.Nil(t, err)
+				assert.NotEqual(t, pair.AccessToken, pair.RefreshToken)
+				assert.Equal(t, 5, stored.UserID)
+				assert.Equal(t, hashToken(pair.AccessToken), stored.AccessHash)
+				assert.Equal(t, hashToken(pair.RefreshToken), stored.RefreshHash)
+				assert.Equal(t, tokenTime.Add(testAccessTTL), pair.AccessExpiresAt)
+				assert.Equal(t, tokenTime.Add(testRefreshTTL), pair.RefreshExpiresAt)
+			},
+		},
+	})
+}
+
+func Test_Token_Refresh(t *testing.T) {
+	token := models.AuthToken{ID: 3, UserID: 5, AccessExpiresAt: tokenTime.Add(testAccessTTL),
+		RefreshExpiresAt: tokenTime.Add(testRefreshTTL)}
+
+	runTokenTestCases(t, []tokenTestCase{
+		{
+			name: "Rotates",
+			test: func(t *testing.T, mock *tokenUseCasesMock) {
+				now := tokenTime.Add(time.Hour)
+				mock.Clock.EXPECT().Now().Return(now).Times(2)
+				mock.RepoToken.EXPECT().GetTokenByRefreshHash(gomock.Any(), hashToken("refresh")).
+					Return(token, nil).Times(1)
+				mock.RepoToken.EXPECT().RevokeToken(gomock.Any(), 3, now).Return(nil).Times(1)
+				mock.RepoUser.EXPECT().GetUserByID(gomock.Any(), 5).Return(models.User{ID: 5}, nil).Times(1)
+				mock.RepoToken.EXPECT().AddToken(gomock.Any(), gomock.Any()).Return(nil).Times(1)
+
+				pair, err := mock.TokenServiceUC.Refresh(context.Background(), "refresh")
+				assert.Nil(t, err)
+				assert.Equal(t, now.Add(testAccessTTL), pair.AccessExpiresAt)
+			},
+		},
+		{
+			name: "Blocked",
+			test: func(t *testing.T, mock *tokenUseCasesMock) {
+				now := tokenTime.Add(time.Hour)
+				mock.Clock.EXPECT().Now().Return(now).Times(1)
+				mock.RepoToken.EXPECT().GetTokenByRefreshHash(gomock.Any(), hashToken("refresh")).
+					Return(token, nil).Times(1)
+				mock.RepoToken.EXPECT().RevokeToken(gomock.Any(), 3, now).Return(nil).Times(1)
+				mock.RepoUser.EXPECT().GetUserByID(gomock.Any(), 5).
+					Return(models.User{ID: 5, IsBlocked: true}, nil).Times(1)
+				mock.RepoToken.EXPECT().AddToken(gomock.Any(), gomock.Any()).Times(0)
+
+				_, err := mock.TokenServiceUC.Refresh(context.Background(), "refresh")
+				assert.ErrorIs(t, err, ErrUserBlocked)
+			},
+		},
+		{
+			name: "Expired",
+			test: func(t *testing.T, mock *tokenUseCasesMock) {
+				mock.Clock.EXPECT().Now().Return(tokenTime.Add(testRefreshTTL)).Times(1)
+				mock.RepoToken.EXPECT().GetTokenByRefreshHash(gomock.Any(), hashToken("refresh")).
+					Return(token, nil).Times(1)
+
+				_, err := mock.TokenServiceUC.Refresh(context.Background(), "refresh")
+				assert.ErrorIs(t, err, repositories.ErrNoToken)
+			},
+		},
+	})
+}
+
+func Test_Token_Authenticate(t *testing.T) {
+	token := models.AuthToken{ID: 3, UserID: 5, AccessExpiresAt: tokenTime.Add(testAccessTTL),
+		RefreshExpiresAt: tokenTime.Add(testRefreshTTL)}
+
+	runTokenTestCases(t, []tokenTestCase{
+		{
+			name: "Correct",
+			test: func(t *testing.T, mock *tokenUseCasesMock) {
+				mock.Clock.EXPECT().Now().Return(tokenTime.Add(time.Minute)).Times(1)
+				mock.RepoToken.EXPECT().GetTokenByAccessHash(gomock.Any(), hashToken("access")).
+					Return(token, nil).Times(1)
+				mock.RepoUser.EXPECT().GetUserByID(gomock.Any(), 5).
+					Return(models.User{ID: 5, LoginEmail: "test@mail.com", Password: "hash",
+						Role: models.Role{ID: 2, IsUser: true}}, nil).Times(1)
+
+				user, err := mock.TokenServiceUC.Authenticate(context.Background(), "access")
+				assert.Nil(t, err)
+				assert.Equal(t, 5, user.ID)
+				assert.True(t, user.Role.IsUser)
+				assert.Empty(t, user.Password)
+			},
+		},
+		{
+			name: "Expired",
+			test: func(t *testing.T, mock *tokenUseCasesMock) {
+				mock.Clock.EXPECT().Now().Return(tokenTime.Add(testAccessTTL)).Times(1)
+				mock.RepoToken.EXPECT().GetTokenByAccessHash(gomock.Any(), hashToken("access")).
+					Return(token, nil).Times(1)
+
+				_, err := mock.TokenServiceUC.Authenticate(context.Background(), "access")
+				assert.ErrorIs(t, err, repositories.ErrNoToken)
+			},
+		},
+		{
+			name: "Blocked",
+			test: func(t *testing.T, mock *tokenUseCasesMock) {
+				mock.Clock.EXPECT().Now().Return(tokenTime.Add(time.Minute)).Times(1)
+				mock.RepoToken.EXPECT().GetTokenByAccessHash(gomock.Any(), hashToken("access")).
+					Return(token, nil).Times(1)
+				mock.RepoUser.EXPECT().GetUserByID(gomock.Any(), 5).
+					Return(models.User{ID: 5, IsBlocked: true}, nil).Times(1)
+
+				_, err := mock.TokenServiceUC.Authenticate(context.Background(), "access")
+				assert.ErrorIs(t, err, ErrUserBlocked)
+			},
+		},
+		{
+			name: "Revoked",
+			test: func(t *testing.T, mock *tokenUseCasesMock) {
+				mock.RepoToken.EXPECT().GetTokenByAccessHash(gomock.Any(), hashToken("access")).
+					Return(models.AuthToken{}, repositories.ErrNoToken).Times(1)
+
+				_, err := mock.TokenServiceUC.Authenticate(context.Background(), "access")
+				assert.ErrorIs(t, err, repositories.ErrNoToken)
+			},
+		},
+	})
+}
@@ -3,6 +3,7 @@ package services
 import (
 	"Dp218GO/models"
 	"Dp218GO/repositories"
+	"context"
 )
 
 // UserService - structure for implementing user service
@@ -17,52 +18,57 @@ func NewUserService(repoUser repositories.UserRepo, repoRole repositories.RoleRe
 }
 
 // GetAllUsers - get all system users
-func (ser *UserService) GetAllUsers() (*models.UserList, error) {
-	return ser.repoUser.GetAllUsers()
+func (ser *UserService) GetAllUsers(ctx context.Context) (*models.UserList, error) {
+	return ser.repoUser.GetAllUsers(ctx)
 }
 
 // AddUser - create new system user
-func (ser *UserService) AddUser(user *models.User) error {
-	return ser.repoUser.AddUser(user)
+func (ser *UserService) AddUser(ctx context.Context, user *models.User) error {
+	return ser.repoUser.AddUser(ctx, user)
 }
 
 // GetUserByID - get user information by its ID
-func (ser *UserService) GetUserByID(userID int) (models.User, error) {
-	return ser.repoUser.GetUserByID(userID)
+func (ser *UserService) GetUserByID(ctx context.Context, userID int) (models.User, error) {
+	return ser.repoUser.GetUserByID(ctx, userID)
 }
 
 // DeleteUser - delete given user by its ID
-func (ser *UserService) DeleteUser(userID int) error {
-	return ser.repoUser.DeleteUser(userID)
+func (ser *UserService) DeleteUser(ctx context.Context, userID int) error {
+	return ser.repoUser.DeleteUser(ctx, userID)
 }
 
 // UpdateUser - update user information by its ID
-func (ser *UserService) UpdateUser(userID int, userData models.User) (models.User, error) {
-	return ser.repoUser.UpdateUser(userID, userData)
+func (ser *UserService) UpdateUser(ctx context.Context, userID int, userData models.User) (models.User, error) {
+	return ser.repoUser.UpdateUser(ctx, userID, userData)
 }
 
 // FindUsersByLoginNameSurname - find system user by given login(email), name or surname
-func (ser *UserService) FindUsersByLoginNameSurname(whatToFind string) (*models.UserList, error) {
-	return ser.repoUser.FindUsersByLoginNameSurname(whatToFind)
+func (ser *UserService) FindUsersByLoginNameSurname(ctx context.Context, whatToFind string) (*models.UserList, error) {
+	return ser.repoUser.FindUsersByLoginNameSurname(ctx, whatToFind)
 }
 
 // GetAllRoles - get all roles information
-func (ser *UserService) GetAllRoles() (*models.RoleList, error) {
-	return ser.repoRole.GetAllRoles()
+func (ser *UserService) GetAllRoles(ctx context.Context) (*models.RoleList, error) {
+	return ser.repoRole.GetAllRoles(ctx)
 }
 
 // GetRoleByID - get role information by role ID
-func (ser *UserService) GetRoleByID(roleID int) (models.Role, error) {
-	return ser.repoRole.GetRoleByID(roleID)
+func (ser *UserService) GetRoleByID(ctx context.Context, roleID int) (models.Role, error) {
+	return ser.repoRole.GetRoleByID(ctx, roleID)
+}
+
+// UnlockUser - lets the user locked after failed sign in attempts sign in again
+func (ser *UserService) UnlockUser(ctx context.Context, userID int) error {
+	return ser.repoUser.UnlockUser(ctx, userID)
 }
 
 // ChangeUsersBlockStatus - change user blocked status to the opposite (true->false, false->true)
-func (ser *UserService) ChangeUsersBlockStatus(userID int) error {
-	user, err := ser.repoUser.GetUserByID(userID)
+func (ser *UserService) ChangeUsersBlockStatus(ctx context.Context, userID int) error {
+	user, err := ser.repoUser.GetUserByID(ctx, userID)
 	if err != nil {
 		return err
 	}
 	user.IsBlocked = !user.IsBlocked
-	_, err = ser.repoUser.UpdateUser(userID, user)
+	_, err = ser.repoUser.UpdateUser(ctx, userID, user)
 	return err
 }
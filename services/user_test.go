@@ -3,6 +3,7 @@ package services
 import (
 	"Dp218GO/models"
 	mock "Dp218GO/repositories/mock"
+	"context"
 	"errors"
 	"github.com/golang/mock/gomock"
 	assert "github.com/stretchr/testify/require"
@@ -57,13 +58,13 @@ func Test_User_ChangeUsersBlockStatus(t *testing.T) {
 			name: "correct",
 			test: func(t *testing.T, mock *userUseCasesMock) {
 
-				mock.repoUser.EXPECT().GetUserByID(1).
+				mock.repoUser.EXPECT().GetUserByID(gomock.Any(), 1).
 					Return(models.User{IsBlocked: false}, nil).Times(1)
 
-				mock.repoUser.EXPECT().UpdateUser(1, models.User{IsBlocked: true}).
+				mock.repoUser.EXPECT().UpdateUser(gomock.Any(), 1, models.User{IsBlocked: true}).
 					Return(models.User{}, nil).Times(1)
 
-				err := mock.userUC.ChangeUsersBlockStatus(1)
+				err := mock.userUC.ChangeUsersBlockStatus(context.Background(), 1)
 				assert.Equal(t, nil, err)
 			},
 		},
@@ -73,10 +74,10 @@ func Test_User_ChangeUsersBlockStatus(t *testing.T) {
 
 				var someError = errors.New("error get by ID")
 
-				mock.repoUser.EXPECT().GetUserByID(2).
+				mock.repoUser.EXPECT().GetUserByID(gomock.Any(), 2).
 					Return(models.User{IsBlocked: false}, someError).Times(1)
 
-				err := mock.userUC.ChangeUsersBlockStatus(2)
+				err := mock.userUC.ChangeUsersBlockStatus(context.Background(), 2)
 				assert.Error(t, err)
 				assert.Equal(t, someError, err)
 			},
@@ -87,13 +88,13 @@ func Test_User_ChangeUsersBlockStatus(t *testing.T) {
 
 				var someError = errors.New("error update user")
 
-				mock.repoUser.EXPECT().GetUserByID(3).
+				mock.repoUser.EXPECT().GetUserByID(gomock.Any(), 3).
 					Return(models.User{IsBlocked: false}, nil).Times(1)
 
-				mock.repoUser.EXPECT().UpdateUser(3, models.User{IsBlocked: true}).
+				mock.repoUser.EXPECT().UpdateUser(gomock.Any(), 3, models.User{IsBlocked: true}).
 					Return(models.User{}, someError).Times(1)
 
-				err := mock.userUC.ChangeUsersBlockStatus(3)
+				err := mock.userUC.ChangeUsersBlockStatus(context.Background(), 3)
 				assert.Error(t, err)
 				assert.Equal(t, someError, err)
 			},
@@ -107,10 +108,10 @@ func Test_User_GetUserByID(t *testing.T) {
 			name: "correct",
 			test: func(t *testing.T, mock *userUseCasesMock) {
 
-				mock.repoUser.EXPECT().GetUserByID(1).
+				mock.repoUser.EXPECT().GetUserByID(gomock.Any(), 1).
 					Return(models.User{}, nil).Times(1)
 
-				_, err := mock.userUC.GetUserByID(1)
+				_, err := mock.userUC.GetUserByID(context.Background(), 1)
 				assert.Equal(t, nil, err)
 			},
 		},
@@ -123,10 +124,10 @@ func Test_User_UpdateUser(t *testing.T) {
 			name: "correct",
 			test: func(t *testing.T, mock *userUseCasesMock) {
 
-				mock.repoUser.EXPECT().UpdateUser(1, models.User{UserName: "Test"}).
+				mock.repoUser.EXPECT().UpdateUser(gomock.Any(), 1, models.User{UserName: "Test"}).
 					Return(models.User{UserName: "Test"}, nil).Times(1)
 
-				result, err := mock.userUC.UpdateUser(1, models.User{UserName: "Test"})
+				result, err := mock.userUC.UpdateUser(context.Background(), 1, models.User{UserName: "Test"})
 				assert.Equal(t, nil, err)
 				assert.Equal(t, "Test", result.UserName)
 			},
@@ -140,10 +141,10 @@ func Test_User_DeleteUser(t *testing.T) {
 			name: "correct",
 			test: func(t *testing.T, mock *userUseCasesMock) {
 
-				mock.repoUser.EXPECT().DeleteUser(1).
+				mock.repoUser.EXPECT().DeleteUser(gomock.Any(), 1).
 					Return(nil).Times(1)
 
-				err := mock.userUC.DeleteUser(1)
+				err := mock.userUC.DeleteUser(context.Background(), 1)
 				assert.Equal(t, nil, err)
 			},
 		},
@@ -153,10 +154,10 @@ func Test_User_DeleteUser(t *testing.T) {
 
 				someError := errors.New("deletion error")
 
-				mock.repoUser.EXPECT().DeleteUser(1).
+				mock.repoUser.EXPECT().DeleteUser(gomock.Any(), 1).
 					Return(someError).Times(1)
 
-				err := mock.userUC.DeleteUser(1)
+				err := mock.userUC.DeleteUser(context.Background(), 1)
 				assert.Error(t, err)
 				assert.Equal(t, someError, err)
 			},
@@ -164,6 +165,22 @@ func Test_User_DeleteUser(t *testing.T) {
 	})
 }
 
+func Test_User_UnlockUser(t *testing.T) {
+	runUserTestCases(t, []userTestCase{
+		{
+			name: "correct",
+			test: func(t *testing.T, mock *userUseCasesMock) {
+
+				mock.repoUser.EXPECT().UnlockUser(gomock.Any(), 1).
+					Return(nil).Times(1)
+
+				err := mock.userUC.UnlockUser(context.Background(), 1)
+				assert.Equal(t, nil, err)
+			},
+		},
+	})
+}
+
 func Test_User_AddUser(t *testing.T) {
 	modelToReturn := &models.User{ID: 1, UserName: "Test"}
 
@@ -172,10 +189,10 @@ func Test_User_AddUser(t *testing.T) {
 			name: "correct",
 			test: func(t *testing.T, mock *userUseCasesMock) {
 
-				mock.repoUser.EXPECT().AddUser(modelToReturn).
+				mock.repoUser.EXPECT().AddUser(gomock.Any(), modelToReturn).
 					Return(nil).Times(1)
 
-				err := mock.userUC.AddUser(modelToReturn)
+				err := mock.userUC.AddUser(context.Background(), modelToReturn)
 				assert.Equal(t, nil, err)
 				assert.Equal(t, 1, modelToReturn.ID)
 				assert.Equal(t, "Test", modelToReturn.UserName)
@@ -187,10 +204,10 @@ func Test_User_AddUser(t *testing.T) {
 
 				someError := errors.New("addition error")
 
-				mock.repoUser.EXPECT().AddUser(modelToReturn).
+				mock.repoUser.EXPECT().AddUser(gomock.Any(), modelToReturn).
 					Return(someError).Times(1)
 
-				err := mock.userUC.AddUser(modelToReturn)
+				err := mock.userUC.AddUser(context.Background(), modelToReturn)
 				assert.Error(t, err)
 				assert.Equal(t, someError, err)
 			},
@@ -206,10 +223,10 @@ func Test_User_GetAllUsers(t *testing.T) {
 			name: "correct",
 			test: func(t *testing.T, mock *userUseCasesMock) {
 
-				mock.repoUser.EXPECT().GetAllUsers().
+				mock.repoUser.EXPECT().GetAllUsers(gomock.Any()).
 					Return(modelsToReturn, nil).Times(1)
 
-				result, err := mock.userUC.GetAllUsers()
+				result, err := mock.userUC.GetAllUsers(context.Background())
 				assert.Equal(t, nil, err)
 				assert.Equal(t, 2, len(result.Users))
 				assert.Contains(t, result.Users[0].UserName, "Test")
@@ -226,10 +243,10 @@ func Test_User_FindUsersByLoginNameSurname(t *testing.T) {
 			name: "correct",
 			test: func(t *testing.T, mock *userUseCasesMock) {
 
-				mock.repoUser.EXPECT().FindUsersByLoginNameSurname("Test").
+				mock.repoUser.EXPECT().FindUsersByLoginNameSurname(gomock.Any(), "Test").
 					Return(modelsToReturn, nil).Times(1)
 
-				result, err := mock.userUC.FindUsersByLoginNameSurname("Test")
+				result, err := mock.userUC.FindUsersByLoginNameSurname(context.Background(), "Test")
 				assert.Equal(t, nil, err)
 				assert.Equal(t, 2, len(result.Users))
 				assert.Contains(t, result.Users[0].UserName, "Test")
@@ -246,10 +263,10 @@ func Test_User_GetAllRoles(t *testing.T) {
 			name: "correct",
 			test: func(t *testing.T, mock *userUseCasesMock) {
 
-				mock.repoRole.EXPECT().GetAllRoles().
+				mock.repoRole.EXPECT().GetAllRoles(gomock.Any()).
 					Return(rolesToReturn, nil).Times(1)
 
-				result, err := mock.userUC.GetAllRoles()
+				result, err := mock.userUC.GetAllRoles(context.Background())
 				assert.Equal(t, nil, err)
 				assert.Equal(t, 2, len(result.Roles))
 				assert.Contains(t, result.Roles[0].Name, "Test")
@@ -264,10 +281,10 @@ func Test_User_GetRoleByID(t *testing.T) {
 			name: "correct",
 			test: func(t *testing.T, mock *userUseCasesMock) {
 
-				mock.repoRole.EXPECT().GetRoleByID(1).
+				mock.repoRole.EXPECT().GetRoleByID(gomock.Any(), 1).
 					Return(models.Role{}, nil).Times(1)
 
-				_, err := mock.userUC.GetRoleByID(1)
+				_, err := mock.userUC.GetRoleByID(context.Background(), 1)
 				assert.Equal(t, nil, err)
 			},
 		},
@@ -4,9 +4,12 @@ import (
 	"Dp218GO/models"
 	"Dp218GO/repositories"
 	"Dp218GO/utils"
+	"context"
 	"encoding/gob"
+	"errors"
 	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/gorilla/sessions"
 )
@@ -16,20 +19,35 @@ import (
 type AuthService struct {
 	DB        repositories.UserRepo
 	sessStore sessions.Store
+	audit     *LoginAuditService
+	lockout   *LockoutService
 }
 
 const (
-	sessionName = "login"
-	sessionVal  = "user"
+	sessionName     = "login"
+	sessionVal      = "user"
+	sessionSignedAt = "signed_at"
+	sessionIDVal    = "session_id"
+)
+
+var (
+	// ErrUserBlocked is returned on sign in of the blocked or not activated user
+	ErrUserBlocked = errors.New("user is blocked or not activated")
+	// ErrSessionExpired is returned for the session which was created before password change
+	ErrSessionExpired = errors.New("session expired, sign in again")
 )
 
 // NewAuthService returns new AuthService
-func NewAuthService(db repositories.UserRepo, store sessions.Store) *AuthService {
+func NewAuthService(db repositories.UserRepo, store sessions.Store, audit *LoginAuditService,
+	lockout *LockoutService) *AuthService {
 
 	gob.Register(&models.User{})
+	gob.Register(time.Time{})
 	return &AuthService{
 		DB:        db,
 		sessStore: store,
+		audit:     audit,
+		lockout:   lockout,
 	}
 }
 
@@ -40,14 +58,14 @@ type AuthRequest struct {
 }
 
 // SignUp method registeres new user in system, returns error if it's failed
-func (sv *AuthService) SignUp(user *models.User) error {
+func (sv *AuthService) SignUp(ctx context.Context, user *models.User) error {
 	pass, err := utils.HashPassword(user.Password)
 	if err != nil {
 		return err
 	}
 	user.Password = pass
 
-	err = sv.DB.AddUser(user)
+	err = sv.DB.AddUser(ctx, user)
 
 	if err != nil {
 		return err
@@ -55,16 +73,16 @@ func (sv *AuthService) SignUp(user *models.User) error {
 	return nil
 }
 
-// SignIn method takes user from db, checks password, writes it to session and
+// SignIn method takes user from db, checks password, starts new user session, writes it to session and
 // writes session id to cookie, returns error if it's failed
 func (sv *AuthService) SignIn(w http.ResponseWriter, r *http.Request, authreq *AuthRequest) error {
-	user, err := sv.DB.GetUserByEmail(authreq.Email)
-
+	user, err := sv.checkRecorded(r, authreq)
 	if err != nil {
 		return err
 	}
 
-	if err := utils.CheckPassword(user.Password, authreq.Password); err != nil {
+	sessionID, err := sv.audit.StartSession(r.Context(), user, r, true)
+	if err != nil {
 		return err
 	}
 
@@ -72,25 +90,82 @@ func (sv *AuthService) SignIn(w http.ResponseWriter, r *http.Request, authreq *A
 	if err != nil {
 		return err
 	}
-	user = *sanitize(&user)
+	session.Values[sessionIDVal] = sessionID
+	return sv.saveUser(w, r, session, &user)
+}
 
-	session.Values[sessionVal] = user
-	err = session.Save(r, w)
+// Authenticate checks credentials of user signing in without session (api tokens),
+// both successful and failed attempts are written to login history
+func (sv *AuthService) Authenticate(r *http.Request, authreq *AuthRequest) (models.User, error) {
+	user, err := sv.checkRecorded(r, authreq)
+	if err != nil {
+		return models.User{}, err
+	}
+
+	if _, err = sv.audit.StartSession(r.Context(), user, r, false); err != nil {
+		return models.User{}, err
+	}
+	return user, nil
+}
+
+// RefreshSession writes user to session with current sign in time, so the session
+// stays valid after the password change made by this user
+func (sv *AuthService) RefreshSession(w http.ResponseWriter, r *http.Request, user *models.User) error {
+	session, err := sv.getSessionStore().Get(r, sessionName)
 	if err != nil {
 		return err
 	}
 
-	return nil
+	return sv.saveUser(w, r, session, user)
+}
+
+// CurrentSessionID returns id of user session which the request belongs to
+// returns zero if request has no session (e.g. authenticated with bearer token)
+func (sv *AuthService) CurrentSessionID(r *http.Request) int {
+	session, err := sv.getSessionStore().Get(r, sessionName)
+	if err != nil {
+		return 0
+	}
+
+	sessionID, _ := session.Values[sessionIDVal].(int)
+	return sessionID
+}
+
+// CheckCredentials takes user from db and checks password, returns user without password
+// or error if credentials are wrong or user is blocked
+func (sv *AuthService) CheckCredentials(ctx context.Context, authreq *AuthRequest) (models.User, error) {
+	user, err := sv.DB.GetUserByEmail(ctx, authreq.Email)
+	if err != nil {
+		return models.User{}, err
+	}
+
+	if err := utils.CheckPassword(user.Password, authreq.Password); err != nil {
+		return models.User{}, err
+	}
+
+	if user.IsBlocked {
+		return models.User{}, ErrUserBlocked
+	}
+
+	return *sanitize(&user), nil
 }
 
-// SignOut deletes user from session, removes cookies, returns error if it's failed
+// SignOut revokes user session, deletes user from session, removes cookies, returns error if it's failed
 func (sv *AuthService) SignOut(w http.ResponseWriter, r *http.Request) error {
 	session, err := sv.getSessionStore().Get(r, sessionName)
 	if err != nil {
 		return err
 	}
 
+	if user, ok := session.Values[sessionVal].(*models.User); ok {
+		sessionID, _ := session.Values[sessionIDVal].(int)
+		if err = sv.audit.EndSession(r.Context(), *user, sessionID, r); err != nil {
+			return err
+		}
+	}
+
 	session.Values[sessionVal] = nil
+	session.Values[sessionIDVal] = nil
 	session.Options.MaxAge = -1
 
 	err = session.Save(r, w)
@@ -102,7 +177,7 @@ func (sv *AuthService) SignOut(w http.ResponseWriter, r *http.Request) error {
 }
 
 // GetUserFromRequest retrieves user data from session
-// returns error if is no user in session
+// returns error if is no user in session, the session was revoked or created before password change
 func (sv *AuthService) GetUserFromRequest(r *http.Request) (*models.User, error) {
 	sess, err := sv.sessStore.Get(r, sessionName)
 	if err != nil {
@@ -120,8 +195,70 @@ func (sv *AuthService) GetUserFromRequest(r *http.Request) (*models.User, error)
 
 	}
 
+	signedAt, _ := sess.Values[sessionSignedAt].(time.Time)
+	changedAt, err := sv.DB.GetPasswordChangedAt(r.Context(), user.ID)
+	if err != nil {
+		return nil, err
+	}
+	if signedAt.Before(changedAt) {
+		return nil, ErrSessionExpired
+	}
+
+	sessionID, ok := sess.Values[sessionIDVal].(int)
+	if !ok {
+		return nil, ErrSessionExpired
+	}
+	active, err := sv.audit.IsSessionActive(r.Context(), sessionID)
+	if err != nil {
+		return nil, err
+	}
+	if !active {
+		return nil, ErrSessionExpired
+	}
+
+	return user, nil
+
+}
+
+// checkRecorded checks credentials unless too many attempts were failed already,
+// writes failed attempt to login history
+func (sv *AuthService) checkRecorded(r *http.Request, authreq *AuthRequest) (models.User, error) {
+	ctx := r.Context()
+	if err := sv.lockout.Check(ctx, authreq.Email, requestIP(r)); err != nil {
+		return models.User{}, sv.recordFailed(r, authreq.Email, err)
+	}
+
+	user, err := sv.CheckCredentials(ctx, authreq)
+	if errors.Is(err, ErrUserBlocked) {
+		return models.User{}, sv.recordFailed(r, authreq.Email, err)
+	}
+	if err != nil {
+		if lockErr := sv.lockout.Failed(ctx, authreq.Email); lockErr != nil {
+			return models.User{}, lockErr
+		}
+		return models.User{}, sv.recordFailed(r, authreq.Email, err)
+	}
+
+	if err = sv.lockout.Succeeded(ctx, authreq.Email); err != nil {
+		return models.User{}, err
+	}
 	return user, nil
+}
+
+// recordFailed writes failed attempt to login history, returns the reason of failure
+// or error of writing
+func (sv *AuthService) recordFailed(r *http.Request, email string, reason error) error {
+	if err := sv.audit.RecordFailed(r.Context(), email, r); err != nil {
+		return err
+	}
+	return reason
+}
 
+// saveUser writes user to session with current sign in time
+func (sv *AuthService) saveUser(w http.ResponseWriter, r *http.Request, session *sessions.Session, user *models.User) error {
+	session.Values[sessionVal] = user
+	session.Values[sessionSignedAt] = time.Now()
+	return session.Save(r, w)
 }
 
 func (sv *AuthService) getSessionStore() sessions.Store {
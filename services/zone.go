@@ -0,0 +1,184 @@
+package services
+
+import (
+	"Dp218GO/models"
+	"Dp218GO/repositories"
+	"context"
+	"errors"
+	"fmt"
+)
+
+var (
+	//ErrInvalidZone is returned when the zone can't be saved: it has no name, unknown kind, less than three
+	//vertices or a vertex out of the map, a slow zone has no speed limit or the penalty is negative.
+	ErrInvalidZone = errors.New("invalid zone")
+	//ErrParkingNotAllowed is returned when the trip can't be finished at the chosen point.
+	ErrParkingNotAllowed = errors.New("parking is not allowed here")
+)
+
+//ZoneService is the service which manages the operator's zones and applies their rules to the trips.
+type ZoneService struct {
+	repoZone repositories.ZoneRepo
+}
+
+//NewZoneService creates the new ZoneService.
+func NewZoneService(repoZone repositories.ZoneRepo) *ZoneService {
+	return &ZoneService{repoZone: repoZone}
+}
+
+//GetAllZones gives the access to the ZoneRepo.GetAllZones function.
+func (zs *ZoneService) GetAllZones(ctx context.Context) (*models.ZoneList, error) {
+	return zs.repoZone.GetAllZones(ctx)
+}
+
+//GetZoneByID gives the access to the ZoneRepo.GetZoneByID function.
+func (zs *ZoneService) GetZoneByID(ctx context.Context, zoneID int) (models.Zone, error) {
+	return zs.repoZone.GetZoneByID(ctx, zoneID)
+}
+
+//AddZone validates the zone and stores it.
+func (zs *ZoneService) AddZone(ctx context.Context, zone *models.Zone) error {
+	if err := validateZone(*zone); err != nil {
+		return err
+	}
+	return zs.repoZone.AddZone(ctx, zone)
+}
+
+//UpdateZone validates the new state of the zone and stores it.
+func (zs *ZoneService) UpdateZone(ctx context.Context, zoneID int, zone models.Zone) (models.Zone, error) {
+	if err := validateZone(zone); err != nil {
+		return models.Zone{}, err
+	}
+	return zs.repoZone.UpdateZone(ctx, zoneID, zone)
+}
+
+//DeleteZone gives the access to the ZoneRepo.DeleteZone function.
+func (zs *ZoneService) DeleteZone(ctx context.Context, zoneID int) error {
+	return zs.repoZone.DeleteZone(ctx, zoneID)
+}
+
+//ZonesAt returns the active zones which contain the point.
+func (zs *ZoneService) ZonesAt(ctx context.Context, point models.Coordinate) ([]models.Zone, error) {
+	return zs.repoZone.FindActiveAt(ctx, point)
+}
+
+//ActiveZones returns all the zones whose rules are applied now.
+func (zs *ZoneService) ActiveZones(ctx context.Context) ([]models.Zone, error) {
+	list, err := zs.repoZone.GetAllZones(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var active []models.Zone
+	for _, zone := range list.Zones {
+		if zone.IsActive {
+			active = append(active, zone)
+		}
+	}
+	return active, nil
+}
+
+//CheckParking returns ErrParkingNotAllowed if the trip can't be finished at the point out of the stations.
+func (zs *ZoneService) CheckParking(ctx context.Context, point models.Coordinate) error {
+	zones, err := zs.repoZone.FindActiveAt(ctx, point)
+	if err != nil {
+		return err
+	}
+	return canPark(zones)
+}
+
+//canPark decides by the zones which contain the point: the parking is allowed inside a parking zone
+//unless the point is also in a no-parking or out-of-service zone.
+func canPark(zones []models.Zone) error {
+	allowed := false
+	for _, zone := range zones {
+		switch zone.Kind {
+		case models.ZoneNoParking, models.ZoneOutOfService:
+			return fmt.Errorf("%w: %s", ErrParkingNotAllowed, zone.Name)
+		case models.ZoneParking:
+			allowed = true
+		}
+	}
+	if !allowed {
+		return fmt.Errorf("%w: out of the parking zones", ErrParkingNotAllowed)
+	}
+	return nil
+}
+
+//zonesAt returns the zones which contain the point.
+func zonesAt(zones []models.Zone, point models.Coordinate) []models.Zone {
+	var found []models.Zone
+	for _, zone := range zones {
+		if zone.Contains(point) {
+			found = append(found, zone)
+		}
+	}
+	return found
+}
+
+//zoneOfKind returns the first zone of the kind.
+func zoneOfKind(zones []models.Zone, kind string) (models.Zone, bool) {
+	for _, zone := range zones {
+		if zone.Kind == kind {
+			return zone, true
+		}
+	}
+	return models.Zone{}, false
+}
+
+//speedLimit returns the speed (km/h) which is allowed at the point for the scooter with the given max speed.
+func speedLimit(zones []models.Zone, point models.Coordinate, speed float64) float64 {
+	for _, zone := range zonesAt(zones, point) {
+		if zone.Kind == models.ZoneSlow && zone.SpeedLimit > 0 && zone.SpeedLimit < speed {
+			speed = zone.SpeedLimit
+		}
+	}
+	return speed
+}
+
+//finishTrip counts the penalty of the trip. The scooter left out of the stations in a no-parking zone
+//breaks its rule as well as the zones which were violated on the way. Every zone is charged once.
+func finishTrip(zones []models.Zone, end models.TripEnd) models.TripEnd {
+	if end.StationID == 0 {
+		if zone, ok := zoneOfKind(zonesAt(zones, end.Location), models.ZoneNoParking); ok {
+			end.Violations = append(end.Violations, zone)
+		}
+	}
+
+	charged := make(map[int]bool)
+	var violations []models.Zone
+	for _, zone := range end.Violations {
+		if charged[zone.ID] {
+			continue
+		}
+		charged[zone.ID] = true
+		violations = append(violations, zone)
+		end.PenaltyCents += zone.PenaltyCents
+	}
+	end.Violations = violations
+
+	return end
+}
+
+func validateZone(zone models.Zone) error {
+	switch {
+	case zone.Name == "":
+		return fmt.Errorf("%w: name is required", ErrInvalidZone)
+	case zone.Kind != models.ZoneParking && zone.Kind != models.ZoneNoParking &&
+		zone.Kind != models.ZoneSlow && zone.Kind != models.ZoneOutOfService:
+		return fmt.Errorf("%w: unknown kind %q", ErrInvalidZone, zone.Kind)
+	case len(zone.Area) < 3:
+		return fmt.Errorf("%w: area needs at least 3 vertices", ErrInvalidZone)
+	case zone.Kind == models.ZoneSlow && zone.SpeedLimit <= 0:
+		return fmt.Errorf("%w: slow zone needs a speed limit", ErrInvalidZone)
+	case zone.SpeedLimit < 0 || zone.PenaltyCents < 0:
+		return fmt.Errorf("%w: speed limit and penalty can't be negative", ErrInvalidZone)
+	}
+
+	for _, vertex := range zone.Area {
+		if vertex.Latitude < -90 || vertex.Latitude > 90 || vertex.Longitude < -180 || vertex.Longitude > 180 {
+			return fmt.Errorf("%w: vertex %v is out of the map", ErrInvalidZone, vertex)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,207 @@
+package services
+
+import (
+	"Dp218GO/models"
+	"Dp218GO/repositories"
+	repomock "Dp218GO/repositories/mock"
+	"context"
+	"github.com/golang/mock/gomock"
+	assert "github.com/stretchr/testify/require"
+	"testing"
+)
+
+// the park lies between Pobeda3 and Dafi Mall, the square in its middle is closed for parking
+var (
+	parkZone = models.Zone{ID: 1, Name: "Park", Kind: models.ZoneParking, IsActive: true, Area: []models.Coordinate{
+		{Latitude: 48.420, Longitude: 35.020}, {Latitude: 48.420, Longitude: 35.040},
+		{Latitude: 48.426, Longitude: 35.040}, {Latitude: 48.426, Longitude: 35.020}}}
+	squareZone = models.Zone{ID: 2, Name: "Square", Kind: models.ZoneNoParking, PenaltyCents: 300, IsActive: true,
+		Area: []models.Coordinate{
+			{Latitude: 48.422, Longitude: 35.028}, {Latitude: 48.422, Longitude: 35.032},
+			{Latitude: 48.424, Longitude: 35.032}, {Latitude: 48.424, Longitude: 35.028}}}
+	alleyZone = models.Zone{ID: 3, Name: "Alley", Kind: models.ZoneSlow, SpeedLimit: 10, IsActive: true,
+		Area: []models.Coordinate{
+			{Latitude: 48.420, Longitude: 35.020}, {Latitude: 48.426, Longitude: 35.020},
+			{Latitude: 48.423, Longitude: 35.026}}}
+	depotZone = models.Zone{ID: 4, Name: "Depot", Kind: models.ZoneOutOfService, PenaltyCents: 1000,
+		Area: []models.Coordinate{
+			{Latitude: 48.430, Longitude: 35.050}, {Latitude: 48.430, Longitude: 35.060},
+			{Latitude: 48.435, Longitude: 35.055}}}
+
+	parkPoint   = models.Coordinate{Latitude: 48.421, Longitude: 35.035}
+	squarePoint = models.Coordinate{Latitude: 48.423, Longitude: 35.030}
+)
+
+type zoneUseCasesMock struct {
+	ZoneServiceUC *ZoneService
+	RepoZone      *repomock.MockZoneRepo
+}
+
+type zoneTestCase struct {
+	name string
+	test func(t *testing.T, mock *zoneUseCasesMock)
+}
+
+func runZoneTestCases(t *testing.T, testCases []zoneTestCase) {
+	for _, tc := range testCases {
+		t.Run(tc.name, func(tt *testing.T) {
+			defer func() {
+				if err := recover(); err != nil {
+					tt.Error(err)
+				}
+			}()
+
+			ctrl := gomock.NewController(tt)
+			defer ctrl.Finish()
+
+			mock := newZoneUseCasesMock(ctrl)
+
+			tc.test(tt, mock)
+		})
+	}
+}
+
+func newZoneUseCasesMock(ctrl *gomock.Controller) *zoneUseCasesMock {
+	repoZone := repomock.NewMockZoneRepo(ctrl)
+
+	return &zoneUseCasesMock{
+		ZoneServiceUC: NewZoneService(repoZone),
+		RepoZone:      repoZone,
+	}
+}
+
+func Test_Zone_AddZone(t *testing.T) {
+	runZoneTestCases(t, []zoneTestCase{
+		{
+			name: "Success",
+			test: func(t *testing.T, mock *zoneUseCasesMock) {
+				zone := alleyZone
+				mock.RepoZone.EXPECT().AddZone(gomock.Any(), &zone).Return(nil).Times(1)
+
+				assert.Nil(t, mock.ZoneServiceUC.AddZone(context.Background(), &zone))
+			},
+		},
+		{
+			name: "Invalid",
+			test: func(t *testing.T, mock *zoneUseCasesMock) {
+				invalid := []models.Zone{
+					{Kind: models.ZoneParking, Area: parkZone.Area},
+					{Name: "Lake", Kind: "lake", Area: parkZone.Area},
+					{Name: "Line", Kind: models.ZoneParking, Area: parkZone.Area[:2]},
+					{Name: "Alley", Kind: models.ZoneSlow, Area: alleyZone.Area},
+					{Name: "Park", Kind: models.ZoneParking, Area: parkZone.Area, PenaltyCents: -1},
+					{Name: "Pole", Kind: models.ZoneParking, Area: []models.Coordinate{
+						{Latitude: 91, Longitude: 0}, {Latitude: 0, Longitude: 1}, {Latitude: 1, Longitude: 1}}},
+				}
+				for _, zone := range invalid {
+					err := mock.ZoneServiceUC.AddZone(context.Background(), &zone)
+					assert.ErrorIs(t, err, ErrInvalidZone)
+				}
+			},
+		},
+	})
+}
+
+func Test_Zone_UpdateZone(t *testing.T) {
+	runZoneTestCases(t, []zoneTestCase{
+		{
+			name: "NotFound",
+			test: func(t *testing.T, mock *zoneUseCasesMock) {
+				mock.RepoZone.EXPECT().UpdateZone(gomock.Any(), 7, parkZone).
+					Return(models.Zone{}, repositories.ErrNoZone).Times(1)
+
+				_, err := mock.ZoneServiceUC.UpdateZone(context.Background(), 7, parkZone)
+				assert.ErrorIs(t, err, repositories.ErrNoZone)
+			},
+		},
+		{
+			name: "Invalid",
+			test: func(t *testing.T, mock *zoneUseCasesMock) {
+				_, err := mock.ZoneServiceUC.UpdateZone(context.Background(), 1, models.Zone{Name: "Park"})
+				assert.ErrorIs(t, err, ErrInvalidZone)
+			},
+		},
+	})
+}
+
+func Test_Zone_ActiveZones(t *testing.T) {
+	runZoneTestCases(t, []zoneTestCase{
+		{
+			name: "SkipsInactive",
+			test: func(t *testing.T, mock *zoneUseCasesMock) {
+				mock.RepoZone.EXPECT().GetAllZones(gomock.Any()).
+					Return(&models.ZoneList{Zones: []models.Zone{parkZone, depotZone, squareZone}}, nil).Times(1)
+
+				zones, err := mock.ZoneServiceUC.ActiveZones(context.Background())
+				assert.Nil(t, err)
+				assert.Equal(t, []models.Zone{parkZone, squareZone}, zones)
+			},
+		},
+	})
+}
+
+func Test_Zone_CheckParking(t *testing.T) {
+	runZoneTestCases(t, []zoneTestCase{
+		{
+			name: "ParkingZone",
+			test: func(t *testing.T, mock *zoneUseCasesMock) {
+				mock.RepoZone.EXPECT().FindActiveAt(gomock.Any(), parkPoint).
+					Return([]models.Zone{parkZone}, nil).Times(1)
+
+				assert.Nil(t, mock.ZoneServiceUC.CheckParking(context.Background(), parkPoint))
+			},
+		},
+		{
+			name: "NoParkingInsideParking",
+			test: func(t *testing.T, mock *zoneUseCasesMock) {
+				mock.RepoZone.EXPECT().FindActiveAt(gomock.Any(), squarePoint).
+					Return([]models.Zone{parkZone, squareZone}, nil).Times(1)
+
+				err := mock.ZoneServiceUC.CheckParking(context.Background(), squarePoint)
+				assert.ErrorIs(t, err, ErrParkingNotAllowed)
+			},
+		},
+		{
+			name: "OutOfZones",
+			test: func(t *testing.T, mock *zoneUseCasesMock) {
+				mock.RepoZone.EXPECT().FindActiveAt(gomock.Any(), gomock.Any()).Return(nil, nil).Times(1)
+
+				err := mock.ZoneServiceUC.CheckParking(context.Background(), models.Coordinate{Latitude: 50, Longitude: 30})
+				assert.ErrorIs(t, err, ErrParkingNotAllowed)
+			},
+		},
+	})
+}
+
+func Test_Zone_Contains(t *testing.T) {
+	assert.True(t, parkZone.Contains(parkPoint))
+	assert.True(t, parkZone.Contains(squarePoint))
+	assert.True(t, squareZone.Contains(squarePoint))
+	assert.False(t, squareZone.Contains(parkPoint))
+	assert.True(t, alleyZone.Contains(models.Coordinate{Latitude: 48.423, Longitude: 35.022}))
+	assert.False(t, alleyZone.Contains(models.Coordinate{Latitude: 48.4205, Longitude: 35.025}))
+}
+
+func Test_Zone_SpeedLimit(t *testing.T) {
+	zones := []models.Zone{parkZone, alleyZone}
+
+	assert.Equal(t, 10.0, speedLimit(zones, models.Coordinate{Latitude: 48.423, Longitude: 35.022}, 25))
+	assert.Equal(t, 25.0, speedLimit(zones, parkPoint, 25))
+	assert.Equal(t, 8.0, speedLimit(zones, models.Coordinate{Latitude: 48.423, Longitude: 35.022}, 8))
+}
+
+func Test_Zone_FinishTrip(t *testing.T) {
+	zones := []models.Zone{parkZone, squareZone, depotZone}
+
+	end := finishTrip(zones, models.TripEnd{Location: parkPoint})
+	assert.Empty(t, end.Violations)
+	assert.Equal(t, 0, end.PenaltyCents)
+
+	end = finishTrip(zones, models.TripEnd{Location: squarePoint, Violations: []models.Zone{depotZone, depotZone}})
+	assert.Equal(t, []models.Zone{depotZone, squareZone}, end.Violations)
+	assert.Equal(t, 1300, end.PenaltyCents)
+
+	end = finishTrip(zones, models.TripEnd{StationID: 2, Location: squarePoint})
+	assert.Empty(t, end.Violations)
+	assert.Equal(t, 0, end.PenaltyCents)
+}
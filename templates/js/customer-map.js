@@ -7,10 +7,10 @@
         const getNearestButton = document.getElementById('getNearestButton');
         const clearAll = document.getElementById('clearAll');
         const stationSubUrl = '/customer/station';
-        const stationListUrl = location.origin + stationSubUrl;
         const stationNearestUrl = location.origin + stationSubUrl + '/nearest';
         const stationInfoUrl = location.origin + stationSubUrl;
         const stationScooter = location.origin + '/start-trip';
+        const stationFeedUrl = location.origin + '/api/v1/stations.geojson';
 
 
         var map, marker, stations;
@@ -73,12 +73,21 @@
                 "<p>station id=" + data.id + "</p>" +
                 "<p>station name=" + data.name + "</p>" +
                 "<p>station is active=" + data.is_active + "</p>" +
+                "<p>free slots=" + data.free_slots + " of " + data.capacity + "</p>" +
                 "<a href='/'>show station<" + stationScooter + "/" + data.id + "a>";
         }
 
         async function getAllStations() {
-            let response = await fetch(stationListUrl);
-            let data = await response.json();
+            let response = await fetch(stationFeedUrl);
+            let feed = await response.json();
+
+            let data = feed.features
+                .filter((feature) => feature.properties.is_active)
+                .map((feature) => ({
+                    id: feature.properties.id,
+                    latitude: feature.geometry.coordinates[1],
+                    longitude: feature.geometry.coordinates[0]
+                }));
 
             await clearStations();
             await showStations(data);
@@ -91,6 +100,11 @@
             let data = await response.json();
             await clearStations();
             await showStations(data);
+
+            stationInfo.innerHTML = "<h4>nearest stations:</h4>" + data.map((item) =>
+                "<p>station " + item.name + ": " + Math.round(item.distance) + " m, " + item.walking_minutes +
+                " min walk, " + item.rentable_scooters + " scooters, " + item.free_slots + " free slots</p>"
+            ).join("");
         }
 
         DG.then(initMap);
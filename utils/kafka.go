@@ -1,7 +1,6 @@
 package utils
 
 import (
-	"Dp218GO/configs"
 	"context"
 	"fmt"
 	"github.com/Shopify/sarama"
@@ -17,26 +16,26 @@ const GroupConsumer = "some_group"
 
 var kafkaVersion = sarama.V3_0_0_0
 
-func CheckKafka() {
+func CheckKafka(brokerAddr string) {
 
-	broker, err := connectToBroker(configs.KAFKA_BROKER)
+	broker, err := connectToBroker(brokerAddr)
 	if err != nil {
 		log.Fatalln("Failed to connect to kafka broker:", err)
 		return
 	}
 
-	err = createTopic([]string{configs.KAFKA_BROKER}, TopicName, 1, 1)
+	err = createTopic([]string{brokerAddr}, TopicName, 1, 1)
 	if err != nil && err.(*sarama.TopicError).Err != sarama.ErrTopicAlreadyExists {
 		log.Fatalln("Failed to create kafka topic:", err)
 		return
 	}
 
-	producer := createProducer([]string{configs.KAFKA_BROKER}, ClientID)
+	producer := createProducer([]string{brokerAddr}, ClientID)
 	for i := 0; i < 10; i++ {
 		_ = sendMessage(producer, TopicName, "Hello there"+strconv.Itoa(i))
 	}
 
-	group := createConsumerGroup([]string{configs.KAFKA_BROKER}, ClientID, GroupConsumer)
+	group := createConsumerGroup([]string{brokerAddr}, ClientID, GroupConsumer)
 	ctx, cancel := context.WithCancel(context.Background())
 	consumeMessages(ctx, group, TopicName)
 	cancel()